@@ -8,7 +8,6 @@ import (
 
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/state"
-	"github.com/diamondburned/arikawa/v3/utils/handler"
 )
 
 // To run, do `BOT_TOKEN="TOKEN HERE" go run .`
@@ -20,9 +19,9 @@ func main() {
 	}
 
 	s := state.New("Bot " + token)
-	// Make a pre-handler
-	s.PreHandler = handler.New()
-	s.PreHandler.AddSyncHandler(func(c *gateway.MessageDeleteEvent) {
+	// Add a synchronous pre-handler, which runs before the state deletes the
+	// message from its cache.
+	s.AddSyncPreHandler(func(c *gateway.MessageDeleteEvent) {
 		// Grab from the state
 		m, err := s.Message(c.ChannelID, c.ID)
 		if err != nil {
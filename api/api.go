@@ -5,11 +5,16 @@ package api
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/api/rate"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+	"github.com/diamondburned/arikawa/v3/utils/telemetry"
 )
 
 var (
@@ -28,6 +33,10 @@ type Client struct {
 	*httputil.Client
 	*Session
 	AcquireOptions rate.AcquireOptions
+
+	// onRequest and onResponse are the indices of InjectRequest and
+	// OnResponse in the hooks of Client, which are bound to the Session.
+	onRequest, onResponse int
 }
 
 func NewClient(token string) *Client {
@@ -37,16 +46,23 @@ func NewClient(token string) *Client {
 func NewCustomClient(token string, httpClient *httputil.Client) *Client {
 	c := &Client{
 		Session: &Session{
-			Limiter:   rate.NewLimiter(Path),
-			Token:     token,
-			UserAgent: UserAgent,
+			Limiter:    rate.NewLimiter(Path),
+			Token:      token,
+			UserAgent:  UserAgent,
+			dmChannels: newDMChannelCache(),
 		},
 		Client: httpClient.Copy(),
 	}
 
+	c.onRequest = len(c.Client.OnRequest)
+	c.onResponse = len(c.Client.OnResponse)
 	c.Client.OnRequest = append(c.Client.OnRequest, c.InjectRequest)
 	c.Client.OnResponse = append(c.Client.OnResponse, c.OnResponse)
 
+	if c.Client.TelemetryRoute == nil {
+		c.Client.TelemetryRoute = c.telemetryRoute
+	}
+
 	return c
 }
 
@@ -63,17 +79,230 @@ func (c *Client) WithLocale(language discord.Language) *Client {
 		Client:         client,
 		Session:        c.Session,
 		AcquireOptions: c.AcquireOptions,
+		onRequest:      c.onRequest,
+		onResponse:     c.onResponse,
+	}
+}
+
+// WithReason creates a copy of Client that sends the given audit log reason
+// with every request, including requests made by methods that don't take a
+// reason. A reason given to a method directly takes precedence.
+//
+//	client.WithReason("spamming").DeleteWebhook(webhookID)
+func (c *Client) WithReason(reason AuditLogReason) *Client {
+	header := reason.Header()
+	if header == nil {
+		return c
+	}
+
+	client := c.Client.Copy()
+	client.OnRequest = append(client.OnRequest, func(r httpdriver.Request) error {
+		r.AddHeader(header)
+		return nil
+	})
+
+	return &Client{
+		Client:         client,
+		Session:        c.Session,
+		AcquireOptions: c.AcquireOptions,
+		onRequest:      c.onRequest,
+		onResponse:     c.onResponse,
+	}
+}
+
+// WithoutRateLimiter creates a copy of Client with a copy of its session that
+// has no rate limiter, so requests made with it are not rate limited. This is
+// useful when they go through a proxy that handles rate limits itself. c and
+// its session are not changed.
+func (c *Client) WithoutRateLimiter() *Client {
+	session := *c.Session
+	session.Limiter = nil
+
+	client := c.Client.Copy()
+	cpy := &Client{
+		Client:         client,
+		Session:        &session,
+		AcquireOptions: c.AcquireOptions,
+		onRequest:      c.onRequest,
+		onResponse:     c.onResponse,
+	}
+
+	// The hooks of c use its session, so they're replaced with the hooks of
+	// the copy in new slices.
+	client.OnRequest = append([]httputil.RequestOption(nil), client.OnRequest...)
+	client.OnRequest[c.onRequest] = cpy.InjectRequest
+	client.OnResponse = append([]httputil.ResponseFunc(nil), client.OnResponse...)
+	client.OnResponse[c.onResponse] = cpy.OnResponse
+
+	return cpy
+}
+
+// WithBaseURL creates a copy of Client that sends requests to baseURL instead
+// of BaseEndpoint, such as "http://localhost:8080" for a REST proxy. Only the
+// scheme and host of API requests are replaced, so the path, including the API
+// version, stays the same.
+//
+// The copy shares the session and its rate limiter with c. If the proxy
+// handles rate limits itself, then the rate limiter can be disabled using
+// WithoutRateLimiter:
+//
+//	client := api.NewClient(token).WithBaseURL("http://localhost:8080").WithoutRateLimiter()
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	return c.withURLRewrite(func(url string) string {
+		if strings.HasPrefix(url, BaseEndpoint+"/") {
+			return baseURL + url[len(BaseEndpoint):]
+		}
+		return url
+	})
+}
+
+// WithVersion creates a copy of Client that uses the given version of the API,
+// such as "10", instead of Version. The methods of Client are written for
+// Version, so other versions may behave differently. Rate limits are shared
+// between all versions.
+func (c *Client) WithVersion(version string) *Client {
+	from := Path + "/"
+	to := "/api/v" + version + "/"
+
+	return c.withURLRewrite(func(url string) string {
+		// The API path comes right after the host, which may have been
+		// changed using WithBaseURL.
+		if i := strings.Index(url, from); i >= 0 && !strings.Contains(url[:i], "?") {
+			return url[:i] + to + url[i+len(from):]
+		}
+		return url
+	})
+}
+
+// WithCDNBaseURL creates a copy of Client that fetches assets from baseURL
+// instead of discord.CDNBaseURL, such as when assets are downloaded through a
+// caching proxy. It only affects requests made by the client, not the URLs
+// returned by discord.CDN.
+func (c *Client) WithCDNBaseURL(baseURL string) *Client {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	return c.withURLRewrite(func(url string) string {
+		if strings.HasPrefix(url, discord.CDNBaseURL) {
+			return baseURL + url[len(discord.CDNBaseURL):]
+		}
+		return url
+	})
+}
+
+func (c *Client) withURLRewrite(rewrite func(string) string) *Client {
+	client := c.Client.Copy()
+	client.Client = urlRewriter{client.Client, rewrite}
+
+	return &Client{
+		Client:         client,
+		Session:        c.Session,
+		AcquireOptions: c.AcquireOptions,
+		onRequest:      c.onRequest,
+		onResponse:     c.onResponse,
 	}
 }
 
+// urlRewriter rewrites the URL of every request before it's made.
+type urlRewriter struct {
+	httpdriver.Client
+	rewrite func(string) string
+}
+
+func (d urlRewriter) NewRequest(
+	ctx context.Context, method, url string) (httpdriver.Request, error) {
+
+	return d.Client.NewRequest(ctx, method, d.rewrite(url))
+}
+
 // WithContext returns a shallow copy of Client with the given context. It's
 // used for method timeouts and such. This method is thread-safe.
+//
+// To give a single call its own context, use WithCtx instead, which doesn't
+// copy the client.
 func (c *Client) WithContext(ctx context.Context) *Client {
 	return &Client{
 		Client:         c.Client.WithContext(ctx),
 		Session:        c.Session,
 		AcquireOptions: c.AcquireOptions,
+		onRequest:      c.onRequest,
+		onResponse:     c.onResponse,
+	}
+}
+
+// WithCtx returns a view of Client that makes its requests with the given
+// context instead of the client's. Unlike WithContext, it doesn't copy the
+// client, so it can be used for every call that needs its own context:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+//	defer cancel()
+//
+//	msg, err := client.WithCtx(ctx).SendMessage(channelID, "Hello!")
+func (c *Client) WithCtx(ctx context.Context) CtxClient {
+	return CtxClient{c, ctx}
+}
+
+// view returns a view of Client that uses the client's context. It's used by
+// the methods of Client.
+func (c *Client) view() CtxClient {
+	return CtxClient{Client: c}
+}
+
+//go:generate go run ../utils/cmd/genctxclient -o client_methods.go
+
+// CtxClient is a view of Client that makes its requests with its own context.
+// It has all the API methods of Client, which are implemented on CtxClient;
+// the methods of Client call them with the client's context. It is created
+// using WithCtx.
+//
+// CtxClient is a small value that is cheap to create and pass around. It
+// shares everything else, such as the session and the rate limiter, with the
+// client. Methods of types that wrap Client, such as the cached methods of
+// state.State, aren't used by the view.
+type CtxClient struct {
+	*Client
+	ctx context.Context // nil to use the client's
+}
+
+// Context returns the context of the view.
+func (c CtxClient) Context() context.Context {
+	if c.ctx == nil {
+		return c.Client.Context()
 	}
+	return c.ctx
+}
+
+// Request performs a request with the context of the view. Refer to
+// httputil.Client.Request.
+func (c CtxClient) Request(
+	method, url string, opts ...httputil.RequestOption) (httpdriver.Response, error) {
+
+	return c.Client.Client.RequestCtx(c.Context(), method, url, opts...)
+}
+
+// FastRequest performs a request with the context of the view without waiting
+// for the body. Refer to httputil.Client.FastRequest.
+func (c CtxClient) FastRequest(method, url string, opts ...httputil.RequestOption) error {
+	return c.Client.Client.FastRequestCtx(c.Context(), method, url, opts...)
+}
+
+// RequestJSON performs a request with the context of the view and unmarshals
+// the JSON body into to. Refer to httputil.Client.RequestJSON.
+func (c CtxClient) RequestJSON(
+	to interface{}, method, url string, opts ...httputil.RequestOption) error {
+
+	return c.Client.Client.RequestJSONCtx(c.Context(), to, method, url, opts...)
+}
+
+// sendpart sends data using sendpart.DoCtx with the context of the view.
+func (c CtxClient) sendpart(
+	method string, data sendpart.DataMultipartWriter,
+	v interface{}, url string, opts ...httputil.RequestOption) error {
+
+	return sendpart.DoCtx(c.Context(), c.Client.Client, method, data, v, url, opts...)
 }
 
 func (c *Client) InjectRequest(r httpdriver.Request) error {
@@ -82,20 +311,86 @@ func (c *Client) InjectRequest(r httpdriver.Request) error {
 		"User-Agent":    {c.Session.UserAgent},
 	})
 
+	if c.Session.Limiter == nil {
+		return nil
+	}
+
 	ctx := c.AcquireOptions.Context(r.GetContext())
-	return c.Session.Limiter.Acquire(ctx, r.GetPath())
+
+	if !c.Client.Telemetry.Enabled() {
+		return c.Session.Limiter.Acquire(ctx, r.GetPath())
+	}
+
+	start := time.Now()
+	err := c.Session.Limiter.Acquire(ctx, r.GetPath())
+
+	wait := time.Since(start).Seconds()
+	route := c.bucketKey(r.GetPath())
+
+	telemetry.SpanFromContext(r.GetContext()).SetAttributes(
+		telemetry.Float(telemetry.KeyRateLimitWait, wait),
+	)
+	c.Client.Telemetry.Record(ctx, telemetry.MetricRateLimitWait, wait,
+		telemetry.String(telemetry.KeyRoute, route),
+	)
+
+	return err
+}
+
+// RateLimits returns a snapshot of the client's rate limits. Refer to
+// rate.Limiter's Snapshot for more information. It is empty if the client has
+// no rate limiter.
+func (c *Client) RateLimits() rate.LimiterState {
+	if c.Session.Limiter == nil {
+		return rate.LimiterState{}
+	}
+	return c.Session.Limiter.Snapshot()
+}
+
+// telemetryRoute returns the rate limit bucket key of the given URL, which has
+// all minor IDs removed.
+func (c *Client) telemetryRoute(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return c.bucketKey(u.Path)
+}
+
+func (c *Client) bucketKey(path string) string {
+	limiter := c.Session.Limiter
+	if limiter == nil {
+		limiter = &rate.Limiter{Prefix: Path}
+	}
+	return limiter.BucketKey(path)
 }
 
 func (c *Client) OnResponse(r httpdriver.Request, resp httpdriver.Response) error {
+	if c.Session.Limiter == nil {
+		return nil
+	}
 	return c.Session.Limiter.Release(r.GetPath(), httpdriver.OptHeader(resp))
 }
 
 // Session keeps a single session. This is typically wrapped around Client.
 type Session struct {
+	// Limiter is the rate limiter of the session. If it's nil, then requests
+	// are not rate limited, which is useful when they go through a proxy that
+	// handles rate limits itself. It must not be changed once the session is
+	// used; use WithoutRateLimiter instead.
 	Limiter *rate.Limiter
 
 	Token     string
 	UserAgent string
+
+	// CachedUploadLimit, if not nil, returns the upload limit of a channel
+	// from a cache, which UploadLimit uses before making any requests. It
+	// returns false if the channel or its guild isn't cached.
+	CachedUploadLimit func(discord.ChannelID) (int64, bool)
+
+	// dmChannels caches the DM channel IDs for SendDM. It is nil, so nothing
+	// is cached, if the Session wasn't created by NewCustomClient.
+	dmChannels *dmChannelCache
 }
 
 // AuditLogReason is the type embedded in data structs when the action
@@ -110,5 +405,7 @@ func (r AuditLogReason) Header() http.Header {
 		return nil
 	}
 
-	return http.Header{"X-Audit-Log-Reason": []string{string(r)}}
+	// The reason must be URL-encoded, since headers can only contain ASCII
+	// characters. Spaces must be encoded as %20 rather than +.
+	return http.Header{"X-Audit-Log-Reason": []string{url.PathEscape(string(r))}}
 }
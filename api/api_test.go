@@ -3,7 +3,15 @@ package api
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api/rate"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
 )
 
 func TestContext(t *testing.T) {
@@ -18,3 +26,182 @@ func TestContext(t *testing.T) {
 		t.Fatal("Unexpected error:", err)
 	}
 }
+
+func TestWithReason(t *testing.T) {
+	var reasons []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reasons = append(reasons, r.Header.Get("X-Audit-Log-Reason"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithReason("spam bot, über annoying")
+
+	if err := client.FastRequest("DELETE", srv.URL+Path+"/webhooks/1"); err != nil {
+		t.Fatal("failed to make request:", err)
+	}
+
+	reason := AuditLogReason("explicit")
+	if err := client.FastRequest("DELETE", srv.URL+Path+"/webhooks/1", httputil.WithHeaders(reason.Header())); err != nil {
+		t.Fatal("failed to make request:", err)
+	}
+
+	expect := []string{"spam%20bot%2C%20%C3%BCber%20annoying", "explicit"}
+	if len(reasons) != len(expect) || reasons[0] != expect[0] || reasons[1] != expect[1] {
+		t.Fatalf("expected reasons %q, got %q", expect, reasons)
+	}
+}
+
+func TestWithoutRateLimiter(t *testing.T) {
+	var mu sync.Mutex
+	var tokens []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		mu.Unlock()
+
+		// Every response exhausts the bucket for an hour.
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer srv.Close()
+
+	base := NewClient("no. 3-chan")
+	base.AcquireOptions.DontWait = true
+
+	client := base.WithBaseURL(srv.URL)
+	unlimited := client.WithoutRateLimiter()
+
+	if unlimited.Limiter != nil || unlimited.Token != client.Token {
+		t.Fatal("unexpected session of the copy")
+	}
+	if client.Limiter == nil {
+		t.Fatal("the client's rate limiter was removed")
+	}
+
+	if _, err := client.Me(); err != nil {
+		t.Fatal("failed to make the first request:", err)
+	}
+	if _, err := client.Me(); !errors.Is(err, rate.ErrTimedOutEarly) {
+		t.Fatal("expected the client to be rate limited, got", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := unlimited.Me(); err != nil {
+			t.Fatal("unexpected error from the copy:", err)
+		}
+	}
+
+	// The copy still authorizes its requests.
+	expectStrings(t, "tokens", tokens, []string{"no. 3-chan", "no. 3-chan", "no. 3-chan"})
+}
+
+// failingStore is a rate.Store that fails to be updated.
+type failingStore struct{ *rate.MemoryStore }
+
+func (failingStore) Update(ctx context.Context, state rate.BucketState) error {
+	return errors.New("store is down")
+}
+
+func TestRateLimitStoreError(t *testing.T) {
+	var mu sync.Mutex
+	var sent int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sent++
+		mu.Unlock()
+
+		w.Header().Set("X-RateLimit-Remaining", "4")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.Write([]byte(`{"id":"1","channel_id":"1"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+	client.Limiter.Store = failingStore{rate.NewMemoryStore()}
+
+	var storeErrs []error
+	client.Limiter.OnStoreError = func(err error) { storeErrs = append(storeErrs, err) }
+
+	// The message was sent, so it must not be sent again just because the
+	// store couldn't be updated.
+	if _, err := client.SendMessage(1, "hello"); err != nil {
+		t.Fatal("failed to send message:", err)
+	}
+
+	if sent != 1 {
+		t.Fatalf("expected the message to be sent once, got %d times", sent)
+	}
+	if len(storeErrs) != 1 {
+		t.Fatalf("expected 1 store error, got %v", storeErrs)
+	}
+}
+
+func TestWithCtx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.WithCtx(ctx).Me(); !errors.Is(err, context.Canceled) {
+		t.Fatal("expected the view's context to be used, got", err)
+	}
+
+	// The client's own context is unaffected.
+	if _, err := client.Me(); err != nil {
+		t.Fatal("unexpected error from the client:", err)
+	}
+	if ctx := client.WithCtx(context.Background()).Context(); ctx != context.Background() {
+		t.Fatal("unexpected view context", ctx)
+	}
+}
+
+func TestWithCtxNoCopy(t *testing.T) {
+	client := NewClient("no. 3-chan")
+	ctx := context.Background()
+
+	var view CtxClient
+	allocs := testing.AllocsPerRun(100, func() {
+		view = client.WithCtx(ctx)
+	})
+	if allocs != 0 {
+		t.Fatalf("WithCtx allocated %v times", allocs)
+	}
+	if view.Client != client {
+		t.Fatal("WithCtx copied the client")
+	}
+}
+
+// Sinks for BenchmarkWithCtx, so that the results escape like they would
+// when used.
+var (
+	ctxClientSink CtxClient
+	clientSink    *Client
+)
+
+func BenchmarkWithCtx(b *testing.B) {
+	client := NewClient("no. 3-chan")
+	ctx := context.Background()
+
+	b.Run("WithCtx", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ctxClientSink = client.WithCtx(ctx)
+		}
+	})
+
+	b.Run("WithContext", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			clientSink = client.WithContext(ctx)
+		}
+	})
+}
@@ -2,23 +2,119 @@ package api
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
 )
 
-var EndpointApplications = Endpoint + "applications/"
+var (
+	EndpointApplications = Endpoint + "applications/"
+	EndpointOAuth2       = Endpoint + "oauth2/"
+)
 
 // CurrentApplication returns the current bot account's Discord application. It
 // can be used to get the application ID.
-func (c *Client) CurrentApplication() (*discord.Application, error) {
+func (c CtxClient) CurrentApplication() (*discord.Application, error) {
 	var app *discord.Application
 	return app, c.RequestJSON(
 		&app, "GET",
-		Endpoint+"/oauth2/applications/@me",
+		EndpointApplications+"@me",
+	)
+}
+
+// https://discord.com/developers/docs/resources/application#edit-current-application-json-params
+type ModifyCurrentApplicationData struct {
+	// Description is the description of the application.
+	Description option.String `json:"description,omitempty"`
+	// Icon is the icon of the application. Use NullImage to remove it.
+	Icon *Image `json:"icon,omitempty"`
+	// CoverImage is the default rich presence invite cover image of the
+	// application. Use NullImage to remove it.
+	CoverImage *Image `json:"cover_image,omitempty"`
+	// Tags are up to 5 tags describing the content and functionality of the
+	// application.
+	Tags []string `json:"tags,omitempty"`
+	// Flags are the application's flags. Only the limited gateway intent
+	// flags, such as AppFlagGatewayPresenceLimited, can be changed.
+	Flags *discord.ApplicationFlags `json:"flags,omitempty"`
+
+	// InteractionsEndpointURL is the URL that interactions are sent to
+	// instead of the gateway. An empty string sends them through the gateway
+	// again.
+	InteractionsEndpointURL option.NullableString `json:"interactions_endpoint_url,omitempty"`
+	// RoleConnectionsVerificationURL is the role connection verification URL
+	// of the application.
+	RoleConnectionsVerificationURL option.NullableString `json:"role_connections_verification_url,omitempty"`
+	// CustomInstallURL is the default custom authorization URL of the
+	// application. It can't be set along with InstallParams.
+	CustomInstallURL option.NullableString `json:"custom_install_url,omitempty"`
+	// InstallParams are the settings of the default in-app authorization link
+	// of the application.
+	InstallParams *discord.InstallParams `json:"install_params,omitempty"`
+	// IntegrationTypesConfig are the default install settings of each
+	// installation context that the application supports.
+	IntegrationTypesConfig map[discord.ApplicationIntegrationType]discord.ApplicationIntegrationTypeConfig `json:"integration_types_config,omitempty"`
+}
+
+// ModifyCurrentApplication edits the current bot account's Discord
+// application and returns the updated application. Only the given fields are
+// changed.
+//
+// Discord checks the new interactions endpoint URL by sending it a PING
+// interaction, so the server behind it must already be running.
+func (c CtxClient) ModifyCurrentApplication(
+	data ModifyCurrentApplicationData) (*discord.Application, error) {
+
+	var app *discord.Application
+	return app, c.RequestJSON(
+		&app, "PATCH",
+		EndpointApplications+"@me",
+		httputil.WithJSONBody(data),
 	)
 }
 
+// CurrentAuthorization is the authorization information of the token used by
+// the client.
+//
+// https://discord.com/developers/docs/topics/oauth2#get-current-authorization-information-response-structure
+type CurrentAuthorization struct {
+	// Application is the current application.
+	Application discord.Application `json:"application"`
+	// Scopes is the scopes the user has authorized the application for.
+	Scopes []string `json:"scopes"`
+	// Expires is when the access token expires.
+	Expires discord.Timestamp `json:"expires"`
+	// User is the user who has authorized, if the user has authorized with the
+	// identify scope.
+	User *discord.User `json:"user,omitempty"`
+}
+
+// HasScope returns true if the authorization was granted the given scope.
+func (a CurrentAuthorization) HasScope(scope string) bool {
+	for _, s := range a.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Expired returns true if the access token has already expired. An
+// authorization without an expiry never expires.
+func (a CurrentAuthorization) Expired() bool {
+	return a.Expires.IsValid() && a.Expires.Time().Before(time.Now())
+}
+
+// CurrentAuthorization returns info about the current authorization. It
+// requires authentication with a bearer token, and is useful for validating
+// tokens given by users, for example from a web dashboard.
+func (c CtxClient) CurrentAuthorization() (*CurrentAuthorization, error) {
+	var auth *CurrentAuthorization
+	return auth, c.RequestJSON(&auth, "GET", EndpointOAuth2+"@me")
+}
+
 // https://discord.com/developers/docs/interactions/application-commands#create-global-application-command
 // https://discord.com/developers/docs/interactions/application-commands#bulk-overwrite-guild-application-commands
 type CreateCommandData struct {
@@ -76,7 +172,7 @@ func (c *CreateCommandData) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func (c *Client) Commands(appID discord.AppID) ([]discord.Command, error) {
+func (c CtxClient) Commands(appID discord.AppID) ([]discord.Command, error) {
 	var cmds []discord.Command
 	return cmds, c.RequestJSON(
 		&cmds, "GET",
@@ -84,7 +180,7 @@ func (c *Client) Commands(appID discord.AppID) ([]discord.Command, error) {
 	)
 }
 
-func (c *Client) Command(
+func (c CtxClient) Command(
 	appID discord.AppID, commandID discord.CommandID) (*discord.Command, error) {
 
 	var cmd *discord.Command
@@ -94,7 +190,7 @@ func (c *Client) Command(
 	)
 }
 
-func (c *Client) CreateCommand(
+func (c CtxClient) CreateCommand(
 	appID discord.AppID, data CreateCommandData) (*discord.Command, error) {
 
 	var cmd *discord.Command
@@ -105,7 +201,7 @@ func (c *Client) CreateCommand(
 	)
 }
 
-func (c *Client) EditCommand(
+func (c CtxClient) EditCommand(
 	appID discord.AppID,
 	commandID discord.CommandID, data CreateCommandData) (*discord.Command, error) {
 
@@ -117,7 +213,7 @@ func (c *Client) EditCommand(
 	)
 }
 
-func (c *Client) DeleteCommand(appID discord.AppID, commandID discord.CommandID) error {
+func (c CtxClient) DeleteCommand(appID discord.AppID, commandID discord.CommandID) error {
 	return c.FastRequest(
 		"DELETE",
 		EndpointApplications+appID.String()+"/commands/"+commandID.String(),
@@ -130,7 +226,7 @@ func (c *Client) DeleteCommand(appID discord.AppID, commandID discord.CommandID)
 //
 // Commands that do not already exist will count toward daily application
 // command create limits.
-func (c *Client) BulkOverwriteCommands(
+func (c CtxClient) BulkOverwriteCommands(
 	appID discord.AppID, commands []CreateCommandData) ([]discord.Command, error) {
 
 	var cmds []discord.Command
@@ -140,7 +236,7 @@ func (c *Client) BulkOverwriteCommands(
 		httputil.WithJSONBody(commands))
 }
 
-func (c *Client) GuildCommands(
+func (c CtxClient) GuildCommands(
 	appID discord.AppID, guildID discord.GuildID) ([]discord.Command, error) {
 
 	var cmds []discord.Command
@@ -150,7 +246,7 @@ func (c *Client) GuildCommands(
 	)
 }
 
-func (c *Client) GuildCommand(
+func (c CtxClient) GuildCommand(
 	appID discord.AppID,
 	guildID discord.GuildID, commandID discord.CommandID) (*discord.Command, error) {
 
@@ -163,7 +259,7 @@ func (c *Client) GuildCommand(
 	)
 }
 
-func (c *Client) CreateGuildCommand(
+func (c CtxClient) CreateGuildCommand(
 	appID discord.AppID,
 	guildID discord.GuildID, data CreateCommandData) (*discord.Command, error) {
 
@@ -175,7 +271,7 @@ func (c *Client) CreateGuildCommand(
 	)
 }
 
-func (c *Client) EditGuildCommand(
+func (c CtxClient) EditGuildCommand(
 	appID discord.AppID, guildID discord.GuildID,
 	commandID discord.CommandID, data CreateCommandData) (*discord.Command, error) {
 
@@ -189,7 +285,7 @@ func (c *Client) EditGuildCommand(
 	)
 }
 
-func (c *Client) DeleteGuildCommand(
+func (c CtxClient) DeleteGuildCommand(
 	appID discord.AppID, guildID discord.GuildID, commandID discord.CommandID) error {
 
 	return c.FastRequest(
@@ -202,7 +298,7 @@ func (c *Client) DeleteGuildCommand(
 
 // BulkOverwriteGuildCommands takes a slice of application commands,
 // overwriting existing commands that are registered for the guild.
-func (c *Client) BulkOverwriteGuildCommands(
+func (c CtxClient) BulkOverwriteGuildCommands(
 	appID discord.AppID,
 	guildID discord.GuildID, commands []CreateCommandData) ([]discord.Command, error) {
 
@@ -214,8 +310,10 @@ func (c *Client) BulkOverwriteGuildCommands(
 }
 
 // GuildCommandPermissions fetches command permissions for all commands for the
-// application in a guild.
-func (c *Client) GuildCommandPermissions(
+// application in a guild. Commands without any overwrites aren't included. The
+// permissions that apply to all of the application's commands have the
+// application's ID as their command ID.
+func (c CtxClient) GuildCommandPermissions(
 	appID discord.AppID, guildID discord.GuildID) ([]discord.GuildCommandPermissions, error) {
 
 	var perms []discord.GuildCommandPermissions
@@ -226,8 +324,9 @@ func (c *Client) GuildCommandPermissions(
 }
 
 // CommandPermissions fetches command permissions for a specific command for
-// the application in a guild.
-func (c *Client) CommandPermissions(
+// the application in a guild. Pass the application's ID as the command ID to
+// fetch the permissions that apply to all of its commands.
+func (c CtxClient) CommandPermissions(
 	appID discord.AppID, guildID discord.GuildID,
 	commandID discord.CommandID) (*discord.GuildCommandPermissions, error) {
 
@@ -250,7 +349,11 @@ type editCommandPermissionsData struct {
 // Existing permissions for the command will be overwritten in that guild.
 // Deleting or renaming a command will permanently delete all permissions for
 // that command.
-func (c *Client) EditCommandPermissions(
+//
+// This endpoint requires a Bearer token with the
+// applications.commands.permissions.update scope, so it cannot be used with a
+// bot token.
+func (c CtxClient) EditCommandPermissions(
 	appID discord.AppID, guildID discord.GuildID, commandID discord.CommandID,
 	permissions []discord.CommandPermissions) (*discord.GuildCommandPermissions, error) {
 
@@ -277,7 +380,7 @@ type BatchEditCommandPermissionsData struct {
 // Existing permissions for the command will be overwritten in that guild.
 // Deleting or renaming a command will permanently delete all permissions for
 // that command.
-func (c *Client) BatchEditCommandPermissions(
+func (c CtxClient) BatchEditCommandPermissions(
 	appID discord.AppID, guildID discord.GuildID,
 	data []BatchEditCommandPermissionsData) ([]discord.GuildCommandPermissions, error) {
 
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+)
+
+var EndpointAttachments = Endpoint + "attachments/"
+
+// MaxRefreshAttachmentURLs is the maximum number of URLs that can be
+// refreshed in a single RefreshAttachmentURLs call.
+const MaxRefreshAttachmentURLs = 50
+
+// AttachmentURLExpiryMargin is the margin used by RefreshExpiredAttachments
+// to refresh URLs that are about to expire.
+var AttachmentURLExpiryMargin = time.Minute
+
+// RefreshedURL is a single refreshed attachment URL.
+type RefreshedURL struct {
+	// Original is the URL that was given.
+	Original discord.URL `json:"original"`
+	// Refreshed is the newly signed URL.
+	Refreshed discord.URL `json:"refreshed"`
+}
+
+// RefreshAttachmentURLs refreshes the signatures of the given attachment CDN
+// URLs, which stop working once they expire. At most
+// MaxRefreshAttachmentURLs URLs can be refreshed at once.
+func (c CtxClient) RefreshAttachmentURLs(urls ...discord.URL) ([]RefreshedURL, error) {
+	var param struct {
+		AttachmentURLs []discord.URL `json:"attachment_urls"`
+	}
+
+	param.AttachmentURLs = urls
+
+	var resp struct {
+		RefreshedURLs []RefreshedURL `json:"refreshed_urls"`
+	}
+
+	return resp.RefreshedURLs, c.RequestJSON(
+		&resp, "POST", EndpointAttachments+"refresh-urls",
+		httputil.WithJSONBody(param),
+	)
+}
+
+// FreshAttachmentURL returns the given attachment URL as-is if it hasn't
+// expired, or a refreshed URL if it has. It should be called before
+// downloading from a URL that might have been cached for a while.
+func (c CtxClient) FreshAttachmentURL(url discord.URL) (discord.URL, error) {
+	if !discord.URLExpired(url, AttachmentURLExpiryMargin) {
+		return url, nil
+	}
+
+	refreshed, err := c.RefreshAttachmentURLs(url)
+	if err != nil {
+		return "", err
+	}
+
+	if len(refreshed) == 0 {
+		return url, nil
+	}
+
+	return refreshed[0].Refreshed, nil
+}
+
+// RefreshExpiredAttachments refreshes the URLs and proxy URLs of the given
+// attachments that have expired or are about to, replacing them in place.
+// Fresh URLs are left untouched, and no request is made if all of them are
+// fresh.
+func (c CtxClient) RefreshExpiredAttachments(attachments []discord.Attachment) error {
+	expired := make(map[discord.URL][]*discord.URL)
+	var urls []discord.URL
+
+	addURL := func(u *discord.URL) {
+		if !discord.URLExpired(*u, AttachmentURLExpiryMargin) {
+			return
+		}
+		if _, ok := expired[*u]; !ok {
+			urls = append(urls, *u)
+		}
+		expired[*u] = append(expired[*u], u)
+	}
+
+	for i := range attachments {
+		addURL(&attachments[i].URL)
+		addURL(&attachments[i].Proxy)
+	}
+
+	for len(urls) > 0 {
+		batch := urls
+		if len(batch) > MaxRefreshAttachmentURLs {
+			batch = batch[:MaxRefreshAttachmentURLs]
+		}
+		urls = urls[len(batch):]
+
+		refreshed, err := c.RefreshAttachmentURLs(batch...)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range refreshed {
+			for _, u := range expired[r.Original] {
+				*u = r.Refreshed
+			}
+		}
+	}
+
+	return nil
+}
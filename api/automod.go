@@ -0,0 +1,129 @@
+package api
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// AutoModerationRules returns a list of all rules currently configured for the
+// guild.
+//
+// Requires the MANAGE_GUILD permission.
+func (c CtxClient) AutoModerationRules(guildID discord.GuildID) ([]discord.AutoModerationRule, error) {
+	var rules []discord.AutoModerationRule
+	return rules, c.RequestJSON(
+		&rules, "GET",
+		EndpointGuilds+guildID.String()+"/auto-moderation/rules",
+	)
+}
+
+// AutoModerationRule returns a single rule of the guild.
+//
+// Requires the MANAGE_GUILD permission.
+func (c CtxClient) AutoModerationRule(
+	guildID discord.GuildID, ruleID discord.AutoModerationRuleID) (*discord.AutoModerationRule, error) {
+
+	var rule *discord.AutoModerationRule
+	return rule, c.RequestJSON(
+		&rule, "GET",
+		EndpointGuilds+guildID.String()+"/auto-moderation/rules/"+ruleID.String(),
+	)
+}
+
+// https://discord.com/developers/docs/resources/auto-moderation#create-auto-moderation-rule-json-params
+type CreateAutoModerationRuleData struct {
+	// Name is the name of the rule.
+	Name string `json:"name"`
+	// EventType is the type of event that the rule is checked on.
+	EventType discord.AutoModerationEventType `json:"event_type"`
+	// TriggerType is the type of content that triggers the rule.
+	TriggerType discord.AutoModerationTriggerType `json:"trigger_type"`
+	// TriggerMetadata is the additional data used to determine whether the
+	// rule should be triggered. It is required depending on TriggerType.
+	TriggerMetadata *discord.AutoModerationTriggerMetadata `json:"trigger_metadata,omitempty"`
+	// Actions are the actions that are executed when the rule is triggered.
+	Actions []discord.AutoModerationAction `json:"actions"`
+	// Enabled is whether the rule is enabled. It defaults to false.
+	Enabled bool `json:"enabled,omitempty"`
+	// ExemptRoles are the roles that are not affected by the rule. There can
+	// be at most 20.
+	ExemptRoles []discord.RoleID `json:"exempt_roles,omitempty"`
+	// ExemptChannels are the channels that are not affected by the rule.
+	// There can be at most 50.
+	ExemptChannels []discord.ChannelID `json:"exempt_channels,omitempty"`
+
+	AuditLogReason `json:"-"`
+}
+
+// CreateAutoModerationRule creates a new rule in the guild.
+//
+// Requires the MANAGE_GUILD permission.
+//
+// Fires an Auto Moderation Rule Create Gateway event.
+func (c CtxClient) CreateAutoModerationRule(
+	guildID discord.GuildID, data CreateAutoModerationRuleData) (*discord.AutoModerationRule, error) {
+
+	var rule *discord.AutoModerationRule
+	return rule, c.RequestJSON(
+		&rule, "POST",
+		EndpointGuilds+guildID.String()+"/auto-moderation/rules",
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
+	)
+}
+
+// https://discord.com/developers/docs/resources/auto-moderation#modify-auto-moderation-rule-json-params
+type ModifyAutoModerationRuleData struct {
+	// Name is the name of the rule.
+	Name option.String `json:"name,omitempty"`
+	// EventType is the type of event that the rule is checked on.
+	EventType discord.AutoModerationEventType `json:"event_type,omitempty"`
+	// TriggerMetadata is the additional data used to determine whether the
+	// rule should be triggered.
+	TriggerMetadata *discord.AutoModerationTriggerMetadata `json:"trigger_metadata,omitempty"`
+	// Actions are the actions that are executed when the rule is triggered.
+	Actions *[]discord.AutoModerationAction `json:"actions,omitempty"`
+	// Enabled is whether the rule is enabled.
+	Enabled option.Bool `json:"enabled,omitempty"`
+	// ExemptRoles are the roles that are not affected by the rule. There can
+	// be at most 20.
+	ExemptRoles *[]discord.RoleID `json:"exempt_roles,omitempty"`
+	// ExemptChannels are the channels that are not affected by the rule.
+	// There can be at most 50.
+	ExemptChannels *[]discord.ChannelID `json:"exempt_channels,omitempty"`
+
+	AuditLogReason `json:"-"`
+}
+
+// ModifyAutoModerationRule modifies an existing rule in the guild. The trigger
+// type of a rule cannot be changed.
+//
+// Requires the MANAGE_GUILD permission.
+//
+// Fires an Auto Moderation Rule Update Gateway event.
+func (c CtxClient) ModifyAutoModerationRule(
+	guildID discord.GuildID, ruleID discord.AutoModerationRuleID,
+	data ModifyAutoModerationRuleData) (*discord.AutoModerationRule, error) {
+
+	var rule *discord.AutoModerationRule
+	return rule, c.RequestJSON(
+		&rule, "PATCH",
+		EndpointGuilds+guildID.String()+"/auto-moderation/rules/"+ruleID.String(),
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
+	)
+}
+
+// DeleteAutoModerationRule deletes a rule from the guild.
+//
+// Requires the MANAGE_GUILD permission.
+//
+// Fires an Auto Moderation Rule Delete Gateway event.
+func (c CtxClient) DeleteAutoModerationRule(
+	guildID discord.GuildID, ruleID discord.AutoModerationRuleID, reason AuditLogReason) error {
+
+	return c.FastRequest(
+		"DELETE",
+		EndpointGuilds+guildID.String()+"/auto-moderation/rules/"+ruleID.String(),
+		httputil.WithHeaders(reason.Header()),
+	)
+}
@@ -26,7 +26,7 @@ type SessionStartLimit struct {
 
 // BotURL fetches the Gateway URL along with extra metadata. The token
 // passed in will NOT be prefixed with Bot.
-func (c *Client) BotURL() (*BotData, error) {
+func (c CtxClient) BotURL() (*BotData, error) {
 	var g *BotData
 	return g, c.RequestJSON(&g, "GET", EndpointGatewayBot)
 }
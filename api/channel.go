@@ -1,15 +1,19 @@
 package api
 
 import (
+	"mime/multipart"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
 )
 
 var EndpointChannels = Endpoint + "channels/"
 
 // Channels returns a list of guild channel objects.
-func (c *Client) Channels(guildID discord.GuildID) ([]discord.Channel, error) {
+func (c CtxClient) Channels(guildID discord.GuildID) ([]discord.Channel, error) {
 	var chs []discord.Channel
 	return chs, c.RequestJSON(&chs, "GET", EndpointGuilds+guildID.String()+"/channels")
 }
@@ -74,8 +78,30 @@ type CreateChannelData struct {
 	// ChannelTypes: Voice
 	VoiceQualityMode discord.VideoQualityMode `json:"voice_quality_mode,omitempty"`
 
-	AvailableTags        []discord.Tag          `json:"available_tags,omitempty"`
+	// AvailableTags is the set of tags that can be used in the channel.
+	//
+	// Channel Types: Forum, Media
+	AvailableTags []discord.Tag `json:"available_tags,omitempty"`
+	// DefaultReactionEmoji is the emoji to show in the add reaction button on
+	// threads in the channel.
+	//
+	// Channel Types: Forum, Media
 	DefaultReactionEmoji *discord.ForumReaction `json:"default_reaction_emoji,omitempty"`
+	// DefaultThreadRateLimitPerUser is the initial UserRateLimit to set on
+	// newly created threads in the channel.
+	//
+	// Channel Types: Text, Forum, Media
+	DefaultThreadRateLimitPerUser discord.Seconds `json:"default_thread_rate_limit_per_user,omitempty"`
+	// DefaultSortOrder is the default sort order used to order posts in the
+	// channel.
+	//
+	// Channel Types: Forum, Media
+	DefaultSortOrder *discord.SortOrderType `json:"default_sort_order,omitempty"`
+	// DefaultForumLayout is the default layout used to display posts in the
+	// channel.
+	//
+	// Channel Types: Forum
+	DefaultForumLayout discord.ForumLayoutType `json:"default_forum_layout,omitempty"`
 
 	AuditLogReason `json:"-"`
 }
@@ -88,7 +114,7 @@ type CreateChannelData struct {
 // administrators. Returns the new channel object on success.
 //
 // Fires a ChannelCreate Gateway event.
-func (c *Client) CreateChannel(
+func (c CtxClient) CreateChannel(
 	guildID discord.GuildID, data CreateChannelData) (*discord.Channel, error) {
 
 	var ch *discord.Channel
@@ -125,7 +151,7 @@ type (
 // Requires MANAGE_CHANNELS.
 //
 // Fires multiple Channel Update Gateway events.
-func (c *Client) MoveChannels(guildID discord.GuildID, data MoveChannelsData) error {
+func (c CtxClient) MoveChannels(guildID discord.GuildID, data MoveChannelsData) error {
 	return c.FastRequest(
 		"PATCH",
 		EndpointGuilds+guildID.String()+"/channels",
@@ -134,7 +160,7 @@ func (c *Client) MoveChannels(guildID discord.GuildID, data MoveChannelsData) er
 }
 
 // Channel gets a channel by ID. Returns a channel object.
-func (c *Client) Channel(channelID discord.ChannelID) (*discord.Channel, error) {
+func (c CtxClient) Channel(channelID discord.ChannelID) (*discord.Channel, error) {
 	var channel *discord.Channel
 	return channel, c.RequestJSON(&channel, "GET", EndpointChannels+channelID.String())
 }
@@ -159,7 +185,12 @@ type ModifyChannelData struct {
 	//
 	// Channel Types: Text, News
 	Topic option.NullableString `json:"topic,omitempty"`
-	// Flags is a bitmask that contains if a thread is pinned for example
+	// Flags are the channel's flags. Only PinnedThread can be set on threads
+	// in Forum and Media channels, and only ThreadRequireTag and
+	// HideMediaDownloadOptions can be set on those channels themselves. Use
+	// PinThread to pin a thread.
+	//
+	// Channel Types: Forum, Media, Public Thread
 	Flags *discord.ChannelFlags `json:"flags,omitempty"`
 	// NSFW specifies whether the channel is nsfw.
 	//
@@ -220,6 +251,22 @@ type ModifyChannelData struct {
 	AppliedTags          *[]discord.TagID        `json:"applied_tags,omitempty"`
 	DefaultReactionEmoji **discord.ForumReaction `json:"default_reaction_emoji,omitempty"`
 
+	// DefaultThreadRateLimitPerUser is the initial UserRateLimit to set on
+	// newly created threads in the channel.
+	//
+	// Channel Types: Text, Forum, Media
+	DefaultThreadRateLimitPerUser option.NullableUint `json:"default_thread_rate_limit_per_user,omitempty"`
+	// DefaultSortOrder is the default sort order used to order posts in the
+	// channel.
+	//
+	// Channel Types: Forum, Media
+	DefaultSortOrder *discord.SortOrderType `json:"default_sort_order,omitempty"`
+	// DefaultForumLayout is the default layout used to display posts in the
+	// channel.
+	//
+	// Channel Types: Forum
+	DefaultForumLayout *discord.ForumLayoutType `json:"default_forum_layout,omitempty"`
+
 	AuditLogReason `json:"-"`
 }
 
@@ -234,20 +281,39 @@ type ModifyChannelData struct {
 //
 // Fires a Channel Update event when modifying a guild channel, and a Thread
 // Update event when modifying a thread.
-func (c *Client) ModifyChannel(channelID discord.ChannelID, data ModifyChannelData) error {
+func (c CtxClient) ModifyChannel(channelID discord.ChannelID, data ModifyChannelData) error {
 	return c.FastRequest(
 		"PATCH", EndpointChannels+channelID.String(),
 		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
 	)
 }
 
+// PinThread pins or unpins a thread in a Forum or Media channel. A pinned
+// thread is shown at the top of its parent channel, and only one thread can be
+// pinned at a time.
+//
+// Requires the MANAGE_THREADS permission.
+//
+// Fires a Thread Update Gateway event.
+func (c CtxClient) PinThread(threadID discord.ChannelID, pinned bool, reason AuditLogReason) error {
+	var flags discord.ChannelFlags
+	if pinned {
+		flags = discord.PinnedThread
+	}
+
+	return c.ModifyChannel(threadID, ModifyChannelData{
+		Flags:          &flags,
+		AuditLogReason: reason,
+	})
+}
+
 // DeleteChannel deletes a channel, or closes a private message. Requires the
 // MANAGE_CHANNELS permission for the guild. Deleting a category does not
 // delete its child channels: they will have their parent_id removed and a
 // Channel Update Gateway event will fire for each of them.
 //
 // Fires a Channel Delete Gateway event.
-func (c *Client) DeleteChannel(
+func (c CtxClient) DeleteChannel(
 	channelID discord.ChannelID, reason AuditLogReason) error {
 
 	return c.FastRequest(
@@ -272,7 +338,7 @@ type EditChannelPermissionData struct {
 // or role in a channel. Only usable for guild channels.
 //
 // Requires the MANAGE_ROLES permission.
-func (c *Client) EditChannelPermission(
+func (c CtxClient) EditChannelPermission(
 	channelID discord.ChannelID,
 	overwriteID discord.Snowflake, data EditChannelPermissionData) error {
 
@@ -286,7 +352,7 @@ func (c *Client) EditChannelPermission(
 // role in a channel. Only usable for guild channels.
 //
 // Requires the MANAGE_ROLES permission.
-func (c *Client) DeleteChannelPermission(
+func (c CtxClient) DeleteChannelPermission(
 	channelID discord.ChannelID, overwriteID discord.Snowflake, reason AuditLogReason) error {
 
 	return c.FastRequest(
@@ -297,13 +363,13 @@ func (c *Client) DeleteChannelPermission(
 
 // Typing posts a typing indicator to the channel. Undocumented, but the client
 // usually clears the typing indicator after 8-10 seconds (or after a message).
-func (c *Client) Typing(channelID discord.ChannelID) error {
+func (c CtxClient) Typing(channelID discord.ChannelID) error {
 	return c.FastRequest("POST", EndpointChannels+channelID.String()+"/typing")
 }
 
 // PinnedMessages returns all pinned messages in the channel as an array of
 // message objects.
-func (c *Client) PinnedMessages(channelID discord.ChannelID) ([]discord.Message, error) {
+func (c CtxClient) PinnedMessages(channelID discord.ChannelID) ([]discord.Message, error) {
 	var pinned []discord.Message
 	return pinned, c.RequestJSON(&pinned, "GET", EndpointChannels+channelID.String()+"/pins")
 }
@@ -311,7 +377,7 @@ func (c *Client) PinnedMessages(channelID discord.ChannelID) ([]discord.Message,
 // PinMessage pins a message in a channel.
 //
 // Requires the MANAGE_MESSAGES permission.
-func (c *Client) PinMessage(
+func (c CtxClient) PinMessage(
 	channelID discord.ChannelID, messageID discord.MessageID, reason AuditLogReason) error {
 
 	return c.FastRequest(
@@ -323,7 +389,7 @@ func (c *Client) PinMessage(
 // UnpinMessage deletes a pinned message in a channel.
 //
 // Requires the MANAGE_MESSAGES permission.
-func (c *Client) UnpinMessage(
+func (c CtxClient) UnpinMessage(
 	channelID discord.ChannelID, messageID discord.MessageID, reason AuditLogReason) error {
 
 	return c.FastRequest(
@@ -335,7 +401,7 @@ func (c *Client) UnpinMessage(
 // AddRecipient adds a user to a group direct message. As accessToken is
 // needed, clearly this endpoint should only be used for OAuth. AccessToken can
 // be obtained with the "gdm.join" scope.
-func (c *Client) AddRecipient(
+func (c CtxClient) AddRecipient(
 	channelID discord.ChannelID, userID discord.UserID, accessToken, nickname string) error {
 
 	var params struct {
@@ -354,7 +420,7 @@ func (c *Client) AddRecipient(
 }
 
 // RemoveRecipient removes a user from a group direct message.
-func (c *Client) RemoveRecipient(channelID discord.ChannelID, userID discord.UserID) error {
+func (c CtxClient) RemoveRecipient(channelID discord.ChannelID, userID discord.UserID) error {
 	return c.FastRequest(
 		"DELETE",
 		EndpointChannels+channelID.String()+"/recipients/"+userID.String(),
@@ -369,7 +435,7 @@ type Ack struct {
 // Ack marks the read state of a channel. This is undocumented. The method will
 // write to the ack variable passed in. If this method is called asynchronously,
 // then ack should be mutex guarded.
-func (c *Client) Ack(channelID discord.ChannelID, messageID discord.MessageID, ack *Ack) error {
+func (c CtxClient) Ack(channelID discord.ChannelID, messageID discord.MessageID, ack *Ack) error {
 	return c.RequestJSON(
 		ack, "POST",
 		EndpointChannels+channelID.String()+"/messages/"+messageID.String()+"/ack",
@@ -410,7 +476,7 @@ type StartThreadData struct {
 // message can only have a single thread created from it.
 //
 // Fires a Thread Create Gateway event.
-func (c *Client) StartThreadWithMessage(
+func (c CtxClient) StartThreadWithMessage(
 	channelID discord.ChannelID,
 	messageID discord.MessageID, data StartThreadData) (*discord.Channel, error) {
 
@@ -428,7 +494,7 @@ func (c *Client) StartThreadWithMessage(
 // existing message.
 //
 // Fires a Thread Create Gateway event.
-func (c *Client) StartThreadWithoutMessage(
+func (c CtxClient) StartThreadWithoutMessage(
 	channelID discord.ChannelID, data StartThreadData) (*discord.Channel, error) {
 
 	var ch *discord.Channel
@@ -439,11 +505,108 @@ func (c *Client) StartThreadWithoutMessage(
 	)
 }
 
+// StartForumThreadData is the data used to start a thread in a GuildForum or
+// GuildMedia channel.
+//
+// https://discord.com/developers/docs/resources/channel#start-thread-in-forum-or-media-channel-jsonform-params
+type StartForumThreadData struct {
+	// Name is the 1-100 character channel name.
+	Name string `json:"name"`
+	// AutoArchiveDuration is the duration in minutes to automatically archive
+	// the thread after recent activity.
+	AutoArchiveDuration discord.ArchiveDuration `json:"auto_archive_duration,omitempty"`
+	// UserRateLimit is the amount of seconds a user has to wait before sending
+	// another message (0-21600).
+	UserRateLimit discord.Seconds `json:"rate_limit_per_user,omitempty"`
+	// Message is the first message in the thread.
+	Message ForumThreadMessageData `json:"message"`
+	// AppliedTags are the IDs of the tags to apply to the thread. It is
+	// required if the channel has the ThreadRequireTag flag.
+	AppliedTags []discord.TagID `json:"applied_tags,omitempty"`
+
+	AuditLogReason `json:"-"`
+}
+
+// ForumThreadMessageData is the first message of a thread started in a
+// GuildForum or GuildMedia channel. At least one of Content, Embeds,
+// Components, Files or StickerIDs must be set.
+//
+// https://discord.com/developers/docs/resources/channel#start-thread-in-forum-or-media-channel-forum-and-media-thread-message-params-object
+type ForumThreadMessageData struct {
+	// Content are the message contents (up to 2000 characters).
+	Content string `json:"content,omitempty"`
+	// Embeds contains embedded rich content.
+	Embeds []discord.Embed `json:"embeds,omitempty"`
+	// AllowedMentions are the allowed mentions for the message.
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	// Components is the list of components (such as buttons) to be attached to
+	// the message.
+	Components discord.ContainerComponents `json:"components,omitempty"`
+	// StickerIDs are the IDs of up to 3 stickers to send in the message.
+	StickerIDs []discord.StickerID `json:"sticker_ids,omitempty"`
+	// Files is the list of file attachments to be uploaded.
+	Files []sendpart.File `json:"-"`
+	// Flags specifies the message flags to set. Only SuppressEmbeds and
+	// SuppressNotifications can be set.
+	Flags discord.MessageFlags `json:"flags,omitempty"`
+}
+
+// NeedsMultipart returns true if the StartForumThreadData has files.
+func (data StartForumThreadData) NeedsMultipart() bool {
+	return len(data.Message.Files) > 0
+}
+
+// WriteMultipart writes the data and its files into the multipart body.
+func (data StartForumThreadData) WriteMultipart(body *multipart.Writer) error {
+	return sendpart.WriteInto(body, data, "message", data.Message.Files)
+}
+
+// ForumThread is a thread started in a GuildForum or GuildMedia channel along
+// with its first message.
+type ForumThread struct {
+	discord.Channel
+	// Message is the first message in the thread.
+	Message *discord.Message `json:"message"`
+}
+
+// UnmarshalJSON unmarshals the thread. It is needed because Channel has its
+// own UnmarshalJSON method, which would otherwise skip Message.
+func (t *ForumThread) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &t.Channel); err != nil {
+		return err
+	}
+
+	var message struct {
+		Message *discord.Message `json:"message"`
+	}
+	if err := json.Unmarshal(data, &message); err != nil {
+		return err
+	}
+
+	t.Message = message.Message
+	return nil
+}
+
+// StartThreadInForum starts a new thread in a GuildForum or GuildMedia channel
+// along with its first message. The thread is returned with the message.
+//
+// Requires the SEND_MESSAGES permission.
+//
+// Fires a Thread Create and a Message Create Gateway event.
+func (c CtxClient) StartThreadInForum(
+	channelID discord.ChannelID, data StartForumThreadData) (*ForumThread, error) {
+
+	var thread *ForumThread
+	return thread, c.sendpart("POST", data, &thread,
+		EndpointChannels+channelID.String()+"/threads",
+		httputil.WithHeaders(data.Header()))
+}
+
 // JoinThread adds the current user to a thread. Also requires the thread is
 // not archived.
 //
 // Fires a Thread Members Update Gateway event.
-func (c *Client) JoinThread(threadID discord.ChannelID) error {
+func (c CtxClient) JoinThread(threadID discord.ChannelID) error {
 	return c.FastRequest("PUT", EndpointChannels+threadID.String()+"/thread-members/@me")
 }
 
@@ -451,7 +614,7 @@ func (c *Client) JoinThread(threadID discord.ChannelID) error {
 // send messages in the thread. Also requires the thread is not archived.
 //
 // Fires a Thread Members Update Gateway event.
-func (c *Client) AddThreadMember(threadID discord.ChannelID, userID discord.UserID) error {
+func (c CtxClient) AddThreadMember(threadID discord.ChannelID, userID discord.UserID) error {
 	return c.FastRequest(
 		"PUT",
 		EndpointChannels+threadID.String()+"/thread-members/"+userID.String(),
@@ -462,7 +625,7 @@ func (c *Client) AddThreadMember(threadID discord.ChannelID, userID discord.User
 // is not archived.
 //
 // Fires a Thread Members Update Gateway event.
-func (c *Client) LeaveThread(threadID discord.ChannelID) error {
+func (c CtxClient) LeaveThread(threadID discord.ChannelID) error {
 	return c.FastRequest("DELETE", EndpointChannels+threadID.String()+"/thread-members/@me")
 }
 
@@ -471,7 +634,7 @@ func (c *Client) LeaveThread(threadID discord.ChannelID) error {
 // discord.GuildPrivateThread. Also requires the thread is not archived.
 //
 // Fires a Thread Members Update Gateway event.
-func (c *Client) RemoveThreadMember(threadID discord.ChannelID, userID discord.UserID) error {
+func (c CtxClient) RemoveThreadMember(threadID discord.ChannelID, userID discord.UserID) error {
 	return c.FastRequest(
 		"DELETE",
 		EndpointChannels+threadID.String()+"/thread-members/"+userID.String(),
@@ -482,13 +645,13 @@ func (c *Client) RemoveThreadMember(threadID discord.ChannelID, userID discord.U
 //
 // This endpoint is restricted according to whether the GUILD_MEMBERS
 // Privileged Intent is enabled for your application.
-func (c *Client) ThreadMembers(threadID discord.ChannelID) ([]discord.ThreadMember, error) {
+func (c CtxClient) ThreadMembers(threadID discord.ChannelID) ([]discord.ThreadMember, error) {
 	var m []discord.ThreadMember
 	return m, c.RequestJSON(&m, "GET", EndpointChannels+threadID.String()+"/thread-members")
 }
 
 // ThreadMember returns a thread member for the user ID if the user is a member of the thread.
-func (c *Client) ThreadMember(
+func (c CtxClient) ThreadMember(
 	threadID discord.ChannelID, userID discord.UserID) (*discord.ThreadMember, error) {
 
 	var m *discord.ThreadMember
@@ -508,18 +671,33 @@ type ActiveThreads struct {
 	Members []discord.ThreadMember `json:"members"`
 }
 
+// Member returns the current user's thread member for the thread with the
+// given ID, or nil if the current user hasn't joined the thread.
+func (t ActiveThreads) Member(threadID discord.ChannelID) *discord.ThreadMember {
+	for i, m := range t.Members {
+		if m.ID == threadID {
+			return &t.Members[i]
+		}
+	}
+	return nil
+}
+
 // ActiveThreads returns all the active threads in the guild, including public
 // and private threads.
-func (c *Client) ActiveThreads(guildID discord.GuildID) (*ActiveThreads, error) {
+func (c CtxClient) ActiveThreads(guildID discord.GuildID) (*ActiveThreads, error) {
 	var t *ActiveThreads
 	return t, c.RequestJSON(&t, "GET", EndpointGuilds+guildID.String()+"/threads/active")
 }
 
+// MaxArchivedThreadFetchLimit is the maximum number of archived threads that
+// can be returned in a single request.
+const MaxArchivedThreadFetchLimit = 100
+
 // https://discord.com/developers/docs/resources/channel#list-public-archived-threads-response-body
 // and
 // https://discord.com/developers/docs/resources/channel#list-private-archived-threads-response-body
 // and
-// https://discord.com/developers/docs/resources/channel#list-private-archived-threads-response-body
+// https://discord.com/developers/docs/resources/channel#list-joined-private-archived-threads-response-body
 type ArchivedThreads struct {
 	ActiveThreads
 	// More specifies whether there are potentially additional threads that
@@ -534,92 +712,223 @@ type ArchivedThreads struct {
 // GUILD_PUBLIC_THREAD. When called on a GUILD_NEWS channel returns threads of
 // type GUILD_NEWS_THREAD.
 //
-// Threads are ordered by ArchiveTimestamp, in descending order.
+// Threads are ordered by ArchiveTimestamp, in descending order. At most
+// MaxArchivedThreadFetchLimit threads are returned, or 50 if limit is 0.
 //
 // Requires the READ_MESSAGE_HISTORY permission.
-func (c *Client) PublicArchivedThreads(
+func (c CtxClient) PublicArchivedThreads(
 	channelID discord.ChannelID,
 	before discord.Timestamp, limit uint) (*ArchivedThreads, error) {
 
-	var param struct {
-		Before string `schema:"before,omitempty"`
-		Limit  uint   `schema:"limit,omitempty"`
-	}
-
-	if before.IsValid() {
-		param.Before = before.Format(discord.TimestampFormat)
-	}
-	param.Limit = limit
-
-	var t *ArchivedThreads
-	return t, c.RequestJSON(
-		&t, "GET",
+	return c.archivedThreads(
 		EndpointChannels+channelID.String()+"/threads/archived/public",
-		httputil.WithSchema(c, param),
+		archivedThreadsBefore(before), limit,
 	)
 }
 
 // PrivateArchivedThreads returns archived threads in the channel that are of
 // type GUILD_PRIVATE_THREAD.
 //
-// Threads are ordered by ArchiveTimestamp, in descending order.
+// Threads are ordered by ArchiveTimestamp, in descending order. At most
+// MaxArchivedThreadFetchLimit threads are returned, or 50 if limit is 0.
 //
 // Requires both the READ_MESSAGE_HISTORY and MANAGE_THREADS permissions.
-func (c *Client) PrivateArchivedThreads(
+func (c CtxClient) PrivateArchivedThreads(
 	channelID discord.ChannelID,
 	before discord.Timestamp, limit uint) (*ArchivedThreads, error) {
 
-	var param struct {
-		Before string `schema:"before,omitempty"`
-		Limit  uint   `schema:"limit,omitempty"`
-	}
-
-	if before.IsValid() {
-		param.Before = before.Format(discord.TimestampFormat)
-	}
-	param.Limit = limit
-
-	var t *ArchivedThreads
-	return t, c.RequestJSON(
-		&t, "GET",
+	return c.archivedThreads(
 		EndpointChannels+channelID.String()+"/threads/archived/private",
-		httputil.WithSchema(c, param),
+		archivedThreadsBefore(before), limit,
 	)
 }
 
 // JoinedPrivateArchivedThreads returns archived threads in the channel that are
 // of type GUILD_PRIVATE_THREAD, and the user has joined.
 //
-// Threads are ordered by their ID, in descending order.
+// Deprecated: Use JoinedPrivateArchivedThreadsBeforeID instead, since the
+// endpoint doesn't paginate by time. before is converted to the ID of a thread
+// created at that time.
+func (c CtxClient) JoinedPrivateArchivedThreads(
+	channelID discord.ChannelID,
+	before discord.Timestamp, limit uint) (*ArchivedThreads, error) {
+
+	var beforeID discord.ChannelID
+	if before.IsValid() {
+		beforeID = discord.ChannelID(discord.NewSnowflake(before.Time()))
+	}
+
+	return c.JoinedPrivateArchivedThreadsBeforeID(channelID, beforeID, limit)
+}
+
+// JoinedPrivateArchivedThreadsBeforeID returns archived threads in the channel
+// that are of type GUILD_PRIVATE_THREAD, and the user has joined. Only threads
+// with an ID smaller than before are returned, unless before is 0.
+//
+// Threads are ordered by their ID, in descending order. At most
+// MaxArchivedThreadFetchLimit threads are returned, or 50 if limit is 0.
 //
 // Requires the READ_MESSAGE_HISTORY permission
-func (c *Client) JoinedPrivateArchivedThreads(
+func (c CtxClient) JoinedPrivateArchivedThreadsBeforeID(
 	channelID discord.ChannelID,
-	before discord.Timestamp, limit uint) (*ArchivedThreads, error) {
+	before discord.ChannelID, limit uint) (*ArchivedThreads, error) {
+
+	var b string
+	if before.IsValid() {
+		b = before.String()
+	}
+
+	return c.archivedThreads(
+		EndpointChannels+channelID.String()+"/users/@me/threads/archived/private",
+		b, limit,
+	)
+}
 
+func (c CtxClient) archivedThreads(url, before string, limit uint) (*ArchivedThreads, error) {
 	var param struct {
 		Before string `schema:"before,omitempty"`
 		Limit  uint   `schema:"limit,omitempty"`
 	}
 
-	if before.IsValid() {
-		param.Before = before.Format(discord.TimestampFormat)
-	}
+	param.Before = before
 	param.Limit = limit
 
 	var t *ArchivedThreads
-	return t, c.RequestJSON(
-		&t, "GET",
-		EndpointChannels+channelID.String()+"/users/@me/threads/archived/private",
-		httputil.WithSchema(c, param),
-	)
+	return t, c.RequestJSON(&t, "GET", url, httputil.WithSchema(c, param))
+}
+
+func archivedThreadsBefore(before discord.Timestamp) string {
+	if !before.IsValid() {
+		return ""
+	}
+	return before.Format(discord.TimestampFormat)
+}
+
+// ArchivedThreadsIterator iterates over the archived threads in a channel,
+// fetching a page of threads only once the previous page has been consumed.
+// It is created using PublicArchivedThreadsIter, PrivateArchivedThreadsIter
+// or JoinedPrivateArchivedThreadsIter, and yields threads in the same order
+// as the methods that they wrap.
+type ArchivedThreadsIterator struct {
+	fetch func(it *ArchivedThreadsIterator) (*ArchivedThreads, error)
+
+	before   discord.Timestamp
+	beforeID discord.ChannelID
+	page     []discord.Channel
+	members  []discord.ThreadMember
+	thread   discord.Channel
+	done     bool
+	err      error
+}
+
+// PublicArchivedThreadsIter returns an iterator over the public archived
+// threads in the channel. Refer to PublicArchivedThreads for more information.
+func (c CtxClient) PublicArchivedThreadsIter(channelID discord.ChannelID) *ArchivedThreadsIterator {
+	return &ArchivedThreadsIterator{
+		fetch: func(it *ArchivedThreadsIterator) (*ArchivedThreads, error) {
+			return c.PublicArchivedThreads(channelID, it.before, MaxArchivedThreadFetchLimit)
+		},
+	}
+}
+
+// PrivateArchivedThreadsIter returns an iterator over the private archived
+// threads in the channel. Refer to PrivateArchivedThreads for more
+// information.
+func (c CtxClient) PrivateArchivedThreadsIter(channelID discord.ChannelID) *ArchivedThreadsIterator {
+	return &ArchivedThreadsIterator{
+		fetch: func(it *ArchivedThreadsIterator) (*ArchivedThreads, error) {
+			return c.PrivateArchivedThreads(channelID, it.before, MaxArchivedThreadFetchLimit)
+		},
+	}
+}
+
+// JoinedPrivateArchivedThreadsIter returns an iterator over the private
+// archived threads in the channel that the current user has joined. Refer to
+// JoinedPrivateArchivedThreadsBeforeID for more information.
+func (c CtxClient) JoinedPrivateArchivedThreadsIter(
+	channelID discord.ChannelID) *ArchivedThreadsIterator {
+
+	return &ArchivedThreadsIterator{
+		fetch: func(it *ArchivedThreadsIterator) (*ArchivedThreads, error) {
+			return c.JoinedPrivateArchivedThreadsBeforeID(channelID, it.beforeID, MaxArchivedThreadFetchLimit)
+		},
+	}
+}
+
+// Before makes the iterator start with the threads archived before the given
+// time. It must be called before the first call to Next, and is ignored by
+// iterators from JoinedPrivateArchivedThreadsIter, which use BeforeID.
+func (it *ArchivedThreadsIterator) Before(before discord.Timestamp) *ArchivedThreadsIterator {
+	it.before = before
+	return it
+}
+
+// BeforeID makes the iterator start with the threads with an ID smaller than
+// the given one. It must be called before the first call to Next, and is only
+// used by iterators from JoinedPrivateArchivedThreadsIter.
+func (it *ArchivedThreadsIterator) BeforeID(before discord.ChannelID) *ArchivedThreadsIterator {
+	it.beforeID = before
+	return it
+}
+
+// Next advances the iterator to the next thread, fetching the next page if
+// needed. It returns false once there are no more threads or an error occurs,
+// which is then returned by Err.
+func (it *ArchivedThreadsIterator) Next() bool {
+	if len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		threads, err := it.fetch(it)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.page = threads.Threads
+		it.members = threads.Members
+		it.done = !threads.More
+		if len(it.page) == 0 {
+			return false
+		}
+
+		last := it.page[len(it.page)-1]
+		it.beforeID = last.ID
+		if last.ThreadMetadata == nil {
+			it.done = true
+		} else {
+			it.before = last.ThreadMetadata.ArchiveTimestamp
+		}
+	}
+
+	it.thread = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Thread returns the current thread. It is only valid after Next returns
+// true.
+func (it *ArchivedThreadsIterator) Thread() discord.Channel {
+	return it.thread
+}
+
+// Member returns the current user's thread member for the current thread, or
+// nil if the current user hasn't joined it.
+func (it *ArchivedThreadsIterator) Member() *discord.ThreadMember {
+	return ActiveThreads{Members: it.members}.Member(it.thread.ID)
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ArchivedThreadsIterator) Err() error {
+	return it.err
 }
 
 // PublicArchivedThreadsBefore returns archived threads in the channel that are
 // public.
 //
 // Deprecated: Use PublicArchivedThreads instead.
-func (c *Client) PublicArchivedThreadsBefore(
+func (c CtxClient) PublicArchivedThreadsBefore(
 	channelID discord.ChannelID,
 	before discord.Timestamp, limit uint) (*ArchivedThreads, error) {
 	return c.PublicArchivedThreads(channelID, before, limit)
@@ -629,7 +938,7 @@ func (c *Client) PublicArchivedThreadsBefore(
 // are of type GUILD_PRIVATE_THREAD.
 //
 // Deprecated: Use PrivateArchivedThreads instead.
-func (c *Client) PrivateArchivedThreadsBefore(
+func (c CtxClient) PrivateArchivedThreadsBefore(
 	channelID discord.ChannelID,
 	before discord.Timestamp, limit uint) (*ArchivedThreads, error) {
 	return c.PrivateArchivedThreads(channelID, before, limit)
@@ -638,8 +947,8 @@ func (c *Client) PrivateArchivedThreadsBefore(
 // JoinedPrivateArchivedThreadsBefore returns archived threads in the channel
 // that are of type GUILD_PRIVATE_THREAD, and the user has joined.
 //
-// Deprecated: Use JoinedPrivateArchivedThreads instead.
-func (c *Client) JoinedPrivateArchivedThreadsBefore(
+// Deprecated: Use JoinedPrivateArchivedThreadsBeforeID instead.
+func (c CtxClient) JoinedPrivateArchivedThreadsBefore(
 	channelID discord.ChannelID,
 	before discord.Timestamp, limit uint) (*ArchivedThreads, error) {
 	return c.JoinedPrivateArchivedThreads(channelID, before, limit)
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// threadEpoch is the archive time of thread 0. Each thread is archived a
+// second after the previous one.
+var threadEpoch = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newThreadServer serves threads with IDs from 1 to n as the archived threads
+// of any channel, paginated like Discord does. The current user has joined the
+// threads with an even ID. It returns the before parameter of each request it
+// receives.
+func newThreadServer(t *testing.T, n int) func() []string {
+	var mu sync.Mutex
+	var befores []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		mu.Lock()
+		befores = append(befores, q.Get("before"))
+		mu.Unlock()
+
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		// Joined threads are paginated by ID, and the others by archive time.
+		before := n + 1
+		if b := q.Get("before"); b != "" {
+			if strings.HasSuffix(r.URL.Path, "/users/@me/threads/archived/private") {
+				before, _ = strconv.Atoi(b)
+			} else {
+				ts, err := time.Parse(discord.TimestampFormat, b)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				before = int(ts.Sub(threadEpoch) / time.Second)
+			}
+		}
+
+		var threads ArchivedThreads
+		for id := before - 1; id > 0 && len(threads.Threads) < limit; id-- {
+			threads.Threads = append(threads.Threads, discord.Channel{
+				ID: discord.ChannelID(id),
+				ThreadMetadata: &discord.ThreadMetadata{
+					Archived:         true,
+					ArchiveTimestamp: discord.NewTimestamp(threadEpoch.Add(time.Duration(id) * time.Second)),
+				},
+			})
+			if id%2 == 0 {
+				threads.Members = append(threads.Members, discord.ThreadMember{
+					ID:     discord.ChannelID(id),
+					UserID: 1,
+				})
+			}
+		}
+		threads.More = len(threads.Threads) > 0 && threads.Threads[len(threads.Threads)-1].ID > 1
+
+		json.EncodeStream(w, threads)
+	}))
+	t.Cleanup(srv.Close)
+
+	oldEndpoint := EndpointChannels
+	EndpointChannels = srv.URL + Path + "/channels/"
+	t.Cleanup(func() { EndpointChannels = oldEndpoint })
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), befores...)
+	}
+}
+
+func threadTime(id int) string {
+	return threadEpoch.Add(time.Duration(id) * time.Second).Format(discord.TimestampFormat)
+}
+
+func TestArchivedThreadsIter(t *testing.T) {
+	tests := []struct {
+		name    string
+		iter    func(c *Client) *ArchivedThreadsIterator
+		from    int
+		befores []string
+	}{{
+		name: "public",
+		iter: func(c *Client) *ArchivedThreadsIterator {
+			return c.PublicArchivedThreadsIter(1)
+		},
+		from:    250,
+		befores: []string{"", threadTime(151), threadTime(51)},
+	}, {
+		name: "private before",
+		iter: func(c *Client) *ArchivedThreadsIterator {
+			return c.PrivateArchivedThreadsIter(1).Before(discord.NewTimestamp(threadEpoch.Add(201 * time.Second)))
+		},
+		from:    200,
+		befores: []string{threadTime(201), threadTime(101)},
+	}, {
+		name: "joined before",
+		iter: func(c *Client) *ArchivedThreadsIterator {
+			return c.JoinedPrivateArchivedThreadsIter(1).BeforeID(121)
+		},
+		from:    120,
+		befores: []string{"121", "21"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			befores := newThreadServer(t, 250)
+
+			var ids []int
+			it := test.iter(NewClient("no. 3-chan"))
+			for it.Next() {
+				id := int(it.Thread().ID)
+				ids = append(ids, id)
+
+				joined := it.Member() != nil
+				if joined != (id%2 == 0) {
+					t.Fatalf("thread %d: expected joined to be %v", id, !joined)
+				}
+			}
+			if err := it.Err(); err != nil {
+				t.Fatal("failed to iterate:", err)
+			}
+
+			if len(ids) != test.from {
+				t.Fatalf("expected %d threads, got %d", test.from, len(ids))
+			}
+			for i, id := range ids {
+				if id != test.from-i {
+					t.Fatalf("expected thread %d at %d, got %d", test.from-i, i, id)
+				}
+			}
+
+			// The iteration ends once has_more is false, without another
+			// request.
+			expectStrings(t, "befores", befores(), test.befores)
+		})
+	}
+}
+
+func TestArchivedThreadsIterError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	oldEndpoint := EndpointChannels
+	EndpointChannels = srv.URL + Path + "/channels/"
+	defer func() { EndpointChannels = oldEndpoint }()
+
+	client := NewClient("no. 3-chan")
+	client.Retries = 1
+
+	it := client.PublicArchivedThreadsIter(1)
+	if it.Next() {
+		t.Fatal("unexpected thread")
+	}
+	if it.Err() == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestJoinedPrivateArchivedThreads(t *testing.T) {
+	befores := newThreadServer(t, 10)
+	client := NewClient("no. 3-chan")
+
+	if _, err := client.JoinedPrivateArchivedThreadsBeforeID(1, 5, 0); err != nil {
+		t.Fatal("failed to get threads:", err)
+	}
+
+	// The deprecated timestamp variant converts the time to a thread ID.
+	before := discord.NewTimestamp(threadEpoch)
+	if _, err := client.JoinedPrivateArchivedThreads(1, before, 0); err != nil {
+		t.Fatal("failed to get threads:", err)
+	}
+	if _, err := client.JoinedPrivateArchivedThreads(1, discord.Timestamp{}, 0); err != nil {
+		t.Fatal("failed to get threads:", err)
+	}
+
+	expectStrings(t, "befores", befores(), []string{
+		"5",
+		discord.NewSnowflake(threadEpoch).String(),
+		"",
+	})
+}
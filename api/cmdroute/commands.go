@@ -0,0 +1,438 @@
+package cmdroute
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// Limits imposed by Discord on application commands.
+const (
+	MaxChatInputCommands = 100
+	MaxUserCommands      = 5
+	MaxMessageCommands   = 5
+	MaxCommandOptions    = 25
+	MaxCommandChoices    = 25
+)
+
+var commandNameRe = regexp.MustCompile(`^[-_\p{L}\p{N}]{1,32}$`)
+
+// CommandsLister is an interface that allows listing all commands of the
+// current application. Everything *api.Client will implement this interface,
+// including *state.State.
+type CommandsLister interface {
+	CurrentApplication() (*discord.Application, error)
+	Commands(appID discord.AppID) ([]discord.Command, error)
+}
+
+var _ CommandsLister = (*api.Client)(nil)
+
+// ExportCommands writes the given commands to w as indented JSON. The output
+// can be read back using ImportCommands, which makes it suitable for keeping
+// commands in configuration files.
+func ExportCommands(w io.Writer, cmds []api.CreateCommandData) error {
+	if cmds == nil {
+		cmds = []api.CreateCommandData{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(cmds); err != nil {
+		return fmt.Errorf("cannot encode commands: %w", err)
+	}
+
+	return nil
+}
+
+// ExportRegisteredCommands fetches all global commands registered for the
+// current application and writes them to w using ExportCommands.
+func ExportRegisteredCommands(client CommandsLister, w io.Writer) error {
+	app, err := client.CurrentApplication()
+	if err != nil {
+		return fmt.Errorf("cannot get current app ID: %w", err)
+	}
+
+	registered, err := client.Commands(app.ID)
+	if err != nil {
+		return fmt.Errorf("cannot get commands: %w", err)
+	}
+
+	return ExportCommands(w, CommandsData(registered))
+}
+
+// CommandsData converts the given commands into their creation data. Fields
+// that are only ever set by Discord, such as IDs and versions, are dropped.
+func CommandsData(cmds []discord.Command) []api.CreateCommandData {
+	data := make([]api.CreateCommandData, len(cmds))
+	for i, cmd := range cmds {
+		data[i] = api.CreateCommandData{
+			Name:                     cmd.Name,
+			NameLocalizations:        cmd.NameLocalizations,
+			Description:              cmd.Description,
+			DescriptionLocalizations: cmd.DescriptionLocalizations,
+			Options:                  cmd.Options,
+			DefaultMemberPermissions: cmd.DefaultMemberPermissions,
+			NoDMPermission:           cmd.NoDMPermission,
+			NoDefaultPermission:      cmd.NoDefaultPermission,
+			Type:                     cmd.Type,
+		}
+	}
+	return data
+}
+
+// ImportCommands reads commands written by ExportCommands from r. The commands
+// are validated using ValidateCommands before they are returned.
+func ImportCommands(r io.Reader) ([]api.CreateCommandData, error) {
+	var cmds []api.CreateCommandData
+
+	if err := json.NewDecoder(r).Decode(&cmds); err != nil {
+		return nil, fmt.Errorf("cannot decode commands: %w", err)
+	}
+
+	if err := ValidateCommands(cmds); err != nil {
+		return nil, err
+	}
+
+	return cmds, nil
+}
+
+// CommandError is returned by ValidateCommands when a command is invalid.
+type CommandError struct {
+	// Path is the path to the invalid command or option, with each name
+	// separated by a space, such as "settings set language".
+	Path string
+	Err  error
+}
+
+// Error implements error.
+func (err *CommandError) Error() string {
+	return fmt.Sprintf("command %q: %v", err.Path, err.Err)
+}
+
+// Unwrap returns the underlying error.
+func (err *CommandError) Unwrap() error {
+	return err.Err
+}
+
+// ValidateCommands checks the given commands against the limits documented by
+// Discord, so that invalid commands can be caught before they are sent. It
+// returns a *CommandError for the first invalid command found.
+func ValidateCommands(cmds []api.CreateCommandData) error {
+	names := make(map[discord.CommandType]map[string]struct{}, 3)
+	count := make(map[discord.CommandType]int, 3)
+
+	for _, cmd := range cmds {
+		typ := cmd.Type
+		if typ == 0 {
+			typ = discord.ChatInputCommand
+		}
+
+		if names[typ] == nil {
+			names[typ] = make(map[string]struct{})
+		}
+		if _, ok := names[typ][cmd.Name]; ok {
+			return &CommandError{cmd.Name, errors.New("duplicate command name")}
+		}
+		names[typ][cmd.Name] = struct{}{}
+		count[typ]++
+
+		if err := validateCommand(typ, cmd); err != nil {
+			return err
+		}
+	}
+
+	limits := map[discord.CommandType]int{
+		discord.ChatInputCommand: MaxChatInputCommands,
+		discord.UserCommand:      MaxUserCommands,
+		discord.MessageCommand:   MaxMessageCommands,
+	}
+
+	for typ, max := range limits {
+		if count[typ] > max {
+			return fmt.Errorf("too many commands of type %d: %d > %d", typ, count[typ], max)
+		}
+	}
+
+	return nil
+}
+
+func validateCommand(typ discord.CommandType, cmd api.CreateCommandData) error {
+	switch typ {
+	case discord.ChatInputCommand:
+		if err := validateName(cmd.Name); err != nil {
+			return &CommandError{cmd.Name, err}
+		}
+		if err := validateDescription(cmd.Description); err != nil {
+			return &CommandError{cmd.Name, err}
+		}
+		return validateOptions(cmd.Name, cmd.Options, true)
+
+	case discord.UserCommand, discord.MessageCommand:
+		if n := utf8.RuneCountInString(cmd.Name); n < 1 || n > 32 {
+			return &CommandError{cmd.Name, errors.New("name must be 1-32 characters")}
+		}
+		if cmd.Description != "" {
+			return &CommandError{cmd.Name, errors.New("description must be empty")}
+		}
+		if len(cmd.Options) > 0 {
+			return &CommandError{cmd.Name, errors.New("options are not allowed")}
+		}
+		return nil
+
+	default:
+		return &CommandError{cmd.Name, fmt.Errorf("unknown command type %d", typ)}
+	}
+}
+
+func validateName(name string) error {
+	if !commandNameRe.MatchString(name) {
+		return errors.New("name must be 1-32 word characters or dashes")
+	}
+	if strings.ToLower(name) != name {
+		return errors.New("name must be lowercase")
+	}
+	return nil
+}
+
+func validateDescription(desc string) error {
+	if n := utf8.RuneCountInString(desc); n < 1 || n > 100 {
+		return errors.New("description must be 1-100 characters")
+	}
+	return nil
+}
+
+// validateOptions validates the given options. subcommands is true if the
+// options may contain subcommands or subcommand groups.
+func validateOptions(path string, opts []discord.CommandOption, subcommands bool) error {
+	if len(opts) > MaxCommandOptions {
+		return &CommandError{path, fmt.Errorf("too many options: %d > %d", len(opts), MaxCommandOptions)}
+	}
+
+	var hasSub, hasValue, optional bool
+	names := make(map[string]struct{}, len(opts))
+
+	for _, opt := range opts {
+		optPath := path + " " + opt.Name()
+
+		if _, ok := names[opt.Name()]; ok {
+			return &CommandError{optPath, errors.New("duplicate option name")}
+		}
+		names[opt.Name()] = struct{}{}
+
+		if err := validateName(opt.Name()); err != nil {
+			return &CommandError{optPath, err}
+		}
+
+		switch opt := opt.(type) {
+		case *discord.SubcommandGroupOption:
+			if !subcommands {
+				return &CommandError{optPath, errors.New("subcommand group is not allowed here")}
+			}
+			hasSub = true
+
+			if err := validateDescription(opt.Description); err != nil {
+				return &CommandError{optPath, err}
+			}
+
+			subs := make([]discord.CommandOption, len(opt.Subcommands))
+			for i, sub := range opt.Subcommands {
+				subs[i] = sub
+			}
+
+			if len(subs) == 0 {
+				return &CommandError{optPath, errors.New("subcommand group has no subcommands")}
+			}
+			if err := validateOptions(optPath, subs, false); err != nil {
+				return err
+			}
+
+		case *discord.SubcommandOption:
+			hasSub = true
+
+			if err := validateDescription(opt.Description); err != nil {
+				return &CommandError{optPath, err}
+			}
+
+			values := make([]discord.CommandOption, len(opt.Options))
+			for i, value := range opt.Options {
+				values[i] = value
+			}
+
+			if err := validateOptions(optPath, values, false); err != nil {
+				return err
+			}
+
+		case discord.CommandOptionValue:
+			hasValue = true
+
+			if err := validateValue(optPath, opt); err != nil {
+				return err
+			}
+
+			required := optionRequired(opt)
+			if required && optional {
+				return &CommandError{optPath, errors.New("required option after optional option")}
+			}
+			if !required {
+				optional = true
+			}
+
+		default:
+			return &CommandError{optPath, fmt.Errorf("unknown option type %d", opt.Type())}
+		}
+	}
+
+	if hasSub && hasValue {
+		return &CommandError{path, errors.New("subcommands cannot be mixed with other options")}
+	}
+
+	return nil
+}
+
+func validateValue(path string, opt discord.CommandOptionValue) error {
+	var desc string
+	var choices int
+	var autocomplete bool
+
+	switch opt := opt.(type) {
+	case *discord.StringOption:
+		desc, choices, autocomplete = opt.Description, len(opt.Choices), opt.Autocomplete
+	case *discord.IntegerOption:
+		desc, choices, autocomplete = opt.Description, len(opt.Choices), opt.Autocomplete
+	case *discord.NumberOption:
+		desc, choices, autocomplete = opt.Description, len(opt.Choices), opt.Autocomplete
+	case *discord.BooleanOption:
+		desc = opt.Description
+	case *discord.UserOption:
+		desc = opt.Description
+	case *discord.ChannelOption:
+		desc = opt.Description
+	case *discord.RoleOption:
+		desc = opt.Description
+	case *discord.MentionableOption:
+		desc = opt.Description
+	case *discord.AttachmentOption:
+		desc = opt.Description
+	default:
+		return &CommandError{path, fmt.Errorf("unknown option type %d", opt.Type())}
+	}
+
+	if err := validateDescription(desc); err != nil {
+		return &CommandError{path, err}
+	}
+	if choices > MaxCommandChoices {
+		return &CommandError{path, fmt.Errorf("too many choices: %d > %d", choices, MaxCommandChoices)}
+	}
+	if choices > 0 && autocomplete {
+		return &CommandError{path, errors.New("autocomplete cannot be used with choices")}
+	}
+
+	return nil
+}
+
+func optionRequired(opt discord.CommandOptionValue) bool {
+	switch opt := opt.(type) {
+	case *discord.StringOption:
+		return opt.Required
+	case *discord.IntegerOption:
+		return opt.Required
+	case *discord.NumberOption:
+		return opt.Required
+	case *discord.BooleanOption:
+		return opt.Required
+	case *discord.UserOption:
+		return opt.Required
+	case *discord.ChannelOption:
+		return opt.Required
+	case *discord.RoleOption:
+		return opt.Required
+	case *discord.MentionableOption:
+		return opt.Required
+	case *discord.AttachmentOption:
+		return opt.Required
+	default:
+		return false
+	}
+}
+
+// UnhandledCommands returns the paths of all chat input commands and
+// subcommands in cmds that have no handler in the router. Each path is the
+// command name followed by its subcommand group and subcommand names, if any,
+// separated by spaces. It is useful for checking that a command file matches
+// the router before it is synced.
+func (r *Router) UnhandledCommands(cmds []api.CreateCommandData) []string {
+	var unhandled []string
+	for _, cmd := range cmds {
+		if cmd.Type != 0 && cmd.Type != discord.ChatInputCommand {
+			continue
+		}
+		unhandled = r.unhandledCommands(unhandled, nil, cmd.Name, cmd.Options)
+	}
+	return unhandled
+}
+
+func (r *Router) unhandledCommands(
+	unhandled, path []string, name string, opts []discord.CommandOption) []string {
+
+	path = append(path[:len(path):len(path)], name)
+
+	var subs []discord.CommandOption
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case *discord.SubcommandGroupOption:
+			subs = append(subs, opt)
+		case *discord.SubcommandOption:
+			subs = append(subs, opt)
+		}
+	}
+
+	node, ok := r.findNode(name)
+	if !ok {
+		return append(unhandled, strings.Join(path, " "))
+	}
+
+	switch node := node.(type) {
+	case routeNodeSub:
+		if len(subs) == 0 {
+			return append(unhandled, strings.Join(path, " "))
+		}
+		for _, sub := range subs {
+			var children []discord.CommandOption
+			if group, ok := sub.(*discord.SubcommandGroupOption); ok {
+				for _, child := range group.Subcommands {
+					children = append(children, child)
+				}
+			}
+			unhandled = node.unhandledCommands(unhandled, path, sub.Name(), children)
+		}
+	case routeNodeCommand:
+		if len(subs) > 0 || node.command == nil {
+			return append(unhandled, strings.Join(path, " "))
+		}
+	default:
+		return append(unhandled, strings.Join(path, " "))
+	}
+
+	return unhandled
+}
+
+// findNode finds the node with the given name in the router or its groups.
+func (r *Router) findNode(name string) (routeNode, bool) {
+	if node, ok := r.nodes[name]; ok {
+		return node, true
+	}
+	for _, group := range r.groups {
+		if node, ok := group.findNode(name); ok {
+			return node, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,172 @@
+package cmdroute
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+var testCommands = []api.CreateCommandData{
+	{
+		Name:        "ping",
+		Description: "Ping the bot.",
+	},
+	{
+		Name:        "settings",
+		Description: "Manage settings.",
+		Options: []discord.CommandOption{
+			discord.NewSubcommandOption("get", "Get a setting.",
+				discord.NewStringOption("key", "The setting key.", true),
+			),
+			discord.NewSubcommandGroupOption("set", "Set a setting.",
+				discord.NewSubcommandOption("language", "Set the language.",
+					discord.NewStringOption("value", "The language.", true),
+					discord.NewBooleanOption("global", "Apply globally.", false),
+				),
+			),
+		},
+	},
+	{
+		Name: "Report Message",
+		Type: discord.MessageCommand,
+	},
+}
+
+func TestCommandsExportImport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportCommands(&buf, testCommands); err != nil {
+		t.Fatal("failed to export:", err)
+	}
+
+	cmds, err := ImportCommands(&buf)
+	if err != nil {
+		t.Fatal("failed to import:", err)
+	}
+
+	if len(cmds) != len(testCommands) {
+		t.Fatalf("expected %d commands, got %d", len(testCommands), len(cmds))
+	}
+
+	settings := cmds[1]
+	if len(settings.Options) != 2 {
+		t.Fatalf("expected 2 options, got %d", len(settings.Options))
+	}
+
+	group, ok := settings.Options[1].(*discord.SubcommandGroupOption)
+	if !ok {
+		t.Fatalf("expected subcommand group, got %T", settings.Options[1])
+	}
+
+	value, ok := group.Subcommands[0].Options[0].(*discord.StringOption)
+	if !ok || value.OptionName != "value" || !value.Required {
+		t.Fatalf("unexpected option %#v", group.Subcommands[0].Options[0])
+	}
+}
+
+func TestValidateCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  api.CreateCommandData
+		path string
+	}{
+		{
+			name: "uppercase",
+			cmd:  api.CreateCommandData{Name: "Ping", Description: "Ping."},
+			path: "Ping",
+		},
+		{
+			name: "no description",
+			cmd:  api.CreateCommandData{Name: "ping"},
+			path: "ping",
+		},
+		{
+			name: "user command description",
+			cmd: api.CreateCommandData{
+				Name:        "Profile",
+				Description: "Show profile.",
+				Type:        discord.UserCommand,
+			},
+			path: "Profile",
+		},
+		{
+			name: "required after optional",
+			cmd: api.CreateCommandData{
+				Name:        "echo",
+				Description: "Echo.",
+				Options: []discord.CommandOption{
+					discord.NewBooleanOption("loud", "Be loud.", false),
+					discord.NewStringOption("text", "The text.", true),
+				},
+			},
+			path: "echo text",
+		},
+		{
+			name: "mixed subcommands",
+			cmd: api.CreateCommandData{
+				Name:        "echo",
+				Description: "Echo.",
+				Options: []discord.CommandOption{
+					discord.NewSubcommandOption("loud", "Be loud."),
+					discord.NewStringOption("text", "The text.", true),
+				},
+			},
+			path: "echo",
+		},
+		{
+			name: "duplicate option",
+			cmd: api.CreateCommandData{
+				Name:        "echo",
+				Description: "Echo.",
+				Options: []discord.CommandOption{
+					discord.NewStringOption("text", "The text.", true),
+					discord.NewStringOption("text", "The text.", true),
+				},
+			},
+			path: "echo text",
+		},
+	}
+
+	if err := ValidateCommands(testCommands); err != nil {
+		t.Fatal("unexpected error validating valid commands:", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateCommands([]api.CreateCommandData{test.cmd})
+
+			var cmdErr *CommandError
+			if !errors.As(err, &cmdErr) {
+				t.Fatalf("expected CommandError, got %v", err)
+			}
+
+			if cmdErr.Path != test.path {
+				t.Fatalf("expected path %q, got %q (%v)", test.path, cmdErr.Path, err)
+			}
+		})
+	}
+}
+
+func TestRouterUnhandledCommands(t *testing.T) {
+	noop := func(ctx context.Context, data CommandData) *api.InteractionResponseData {
+		return nil
+	}
+
+	r := NewRouter()
+	r.AddFunc("ping", noop)
+	r.Sub("settings", func(r *Router) {
+		r.AddFunc("get", noop)
+		r.Sub("set", func(r *Router) {})
+	})
+
+	got := r.UnhandledCommands(testCommands)
+	want := []string{"settings set language"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,133 @@
+package cmdroute
+
+import (
+	"context"
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// CommandHandlerErrFunc is a command handler function that may return an
+// error. It implements CommandHandler by turning a returned error into a
+// response using the ErrorResponder in the context, so it can be given to
+// Router.Add:
+//
+//	r.Use(cmdroute.UseErrorResponder(&cmdroute.ErrorResponder{}))
+//	r.Add("ping", cmdroute.CommandHandlerErrFunc(func(ctx context.Context, data cmdroute.CommandData) (*api.InteractionResponseData, error) {
+//		if err := doThing(); err != nil {
+//			return nil, fmt.Errorf("cannot do thing: %w", err)
+//		}
+//		return &api.InteractionResponseData{Content: option.NewNullableString("Pong!")}, nil
+//	}))
+type CommandHandlerErrFunc func(ctx context.Context, data CommandData) (*api.InteractionResponseData, error)
+
+var _ CommandHandler = CommandHandlerErrFunc(nil)
+
+// HandleCommand implements CommandHandler.
+func (f CommandHandlerErrFunc) HandleCommand(ctx context.Context, data CommandData) *api.InteractionResponseData {
+	resp, err := f(ctx, data)
+	if err != nil {
+		return ErrorResponderFromContext(ctx).Respond(ctx, data, err)
+	}
+	return resp
+}
+
+// AddErrFunc is a convenience function that calls Add with a
+// CommandHandlerErrFunc.
+func (r *Router) AddErrFunc(name string, f CommandHandlerErrFunc) {
+	r.Add(name, f)
+}
+
+// ErrorResponder turns errors returned by command handlers into responses. The
+// full error is logged along with a correlation ID, while the user is only
+// shown the correlation ID, so that internal details are not leaked but the
+// error can still be found in the logs. A zero-value ErrorResponder is valid.
+type ErrorResponder struct {
+	// Title is the title of the embed shown to the user. It defaults to
+	// "Something went wrong".
+	Title string
+	// Color is the color of the embed shown to the user. It defaults to red.
+	Color discord.Color
+	// Public, if true, makes the response visible to everyone instead of
+	// only the user.
+	Public bool
+
+	// CorrelationID returns the ID used to correlate the response with the
+	// logged error. It defaults to the interaction ID, which is unique.
+	CorrelationID func(data CommandData) string
+	// Log logs the error. It defaults to logging using the log package.
+	Log func(ctx context.Context, data CommandData, id string, err error)
+	// Response, if not nil, overrides the response data shown to the user.
+	// The embed created by the other fields is not used.
+	Response func(ctx context.Context, data CommandData, id string, err error) *api.InteractionResponseData
+}
+
+// DefaultErrorResponder is the ErrorResponder used when there is none in the
+// context.
+var DefaultErrorResponder = &ErrorResponder{}
+
+// UseErrorResponder returns a middleware that makes command handlers use the
+// given ErrorResponder. It can then be obtained using
+// ErrorResponderFromContext.
+func UseErrorResponder(er *ErrorResponder) Middleware {
+	return func(next InteractionHandler) InteractionHandler {
+		return InteractionHandlerFunc(func(ctx context.Context, ev *discord.InteractionEvent) *api.InteractionResponse {
+			ctx = context.WithValue(ctx, errorResponderCtx, er)
+			return next.HandleInteraction(ctx, ev)
+		})
+	}
+}
+
+// ErrorResponderFromContext returns the ErrorResponder from the context. If
+// there is none, DefaultErrorResponder is returned.
+func ErrorResponderFromContext(ctx context.Context) *ErrorResponder {
+	if er, ok := ctx.Value(errorResponderCtx).(*ErrorResponder); ok && er != nil {
+		return er
+	}
+	return DefaultErrorResponder
+}
+
+// Respond logs the error and returns the response data to show to the user.
+func (er *ErrorResponder) Respond(ctx context.Context, data CommandData, err error) *api.InteractionResponseData {
+	var id string
+	if er.CorrelationID != nil {
+		id = er.CorrelationID(data)
+	} else if data.Event != nil {
+		id = data.Event.ID.String()
+	}
+
+	if er.Log != nil {
+		er.Log(ctx, data, id, err)
+	} else {
+		log.Printf("cmdroute: command %q failed (error ID %s): %v", data.Name, id, err)
+	}
+
+	if er.Response != nil {
+		return er.Response(ctx, data, id, err)
+	}
+
+	title := er.Title
+	if title == "" {
+		title = "Something went wrong"
+	}
+
+	color := er.Color
+	if color == 0 {
+		color = 0xED4245
+	}
+
+	resp := &api.InteractionResponseData{
+		Embeds: &[]discord.Embed{{
+			Title:       title,
+			Description: "If this keeps happening, please report it with the error ID below.",
+			Color:       color,
+			Footer:      &discord.EmbedFooter{Text: "Error ID: " + id},
+		}},
+	}
+	if !er.Public {
+		resp.Flags = discord.EphemeralMessage
+	}
+
+	return resp
+}
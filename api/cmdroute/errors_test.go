@@ -0,0 +1,55 @@
+package cmdroute
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestErrorResponder(t *testing.T) {
+	var logged error
+	var loggedID string
+
+	r := NewRouter()
+	r.Use(UseErrorResponder(&ErrorResponder{
+		Log: func(ctx context.Context, data CommandData, id string, err error) {
+			logged = err
+			loggedID = id
+		},
+	}))
+
+	handlerErr := errors.New("database is on fire")
+	r.AddErrFunc("test", func(ctx context.Context, data CommandData) (*api.InteractionResponseData, error) {
+		return nil, handlerErr
+	})
+
+	resp := r.HandleInteraction(newInteractionEvent(&discord.CommandInteraction{
+		ID:   4,
+		Name: "test",
+	}))
+	if resp == nil || resp.Data == nil {
+		t.Fatal("expected response, got nil")
+	}
+
+	if logged != handlerErr {
+		t.Fatalf("expected error to be logged, got %v", logged)
+	}
+	if loggedID != "100" {
+		t.Fatalf("expected interaction ID as correlation ID, got %q", loggedID)
+	}
+
+	if resp.Data.Flags != discord.EphemeralMessage {
+		t.Fatalf("expected ephemeral response, got flags %d", resp.Data.Flags)
+	}
+
+	if resp.Data.Embeds == nil || len(*resp.Data.Embeds) != 1 {
+		t.Fatal("expected 1 embed")
+	}
+	embed := (*resp.Data.Embeds)[0]
+	if embed.Footer == nil || embed.Footer.Text != "Error ID: 100" {
+		t.Fatalf("unexpected embed footer %+v", embed.Footer)
+	}
+}
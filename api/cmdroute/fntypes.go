@@ -121,3 +121,31 @@ var _ ComponentHandler = (ComponentHandlerFunc)(nil)
 func (f ComponentHandlerFunc) HandleComponent(ctx context.Context, data ComponentData) *api.InteractionResponse {
 	return f(ctx, data)
 }
+
+/*
+ * Modal
+ */
+
+// ModalData is passed to a ModalHandler's HandleModal method.
+type ModalData struct {
+	*discord.ModalInteraction
+	Event *discord.InteractionEvent
+}
+
+// ModalHandler is a type for a modal submission handler.
+type ModalHandler interface {
+	// HandleModal is expected to return a response synchronously, either to be
+	// followed-up later by deferring the response or to be responded
+	// immediately.
+	HandleModal(ctx context.Context, data ModalData) *api.InteractionResponse
+}
+
+// ModalHandlerFunc is a function that implements the ModalHandler interface.
+type ModalHandlerFunc func(ctx context.Context, data ModalData) *api.InteractionResponse
+
+var _ ModalHandler = (ModalHandlerFunc)(nil)
+
+// HandleModal implements ModalHandler.
+func (f ModalHandlerFunc) HandleModal(ctx context.Context, data ModalData) *api.InteractionResponse {
+	return f(ctx, data)
+}
@@ -0,0 +1,281 @@
+package cmdroute
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// Catalog is a message catalog that maps message keys to strings for each
+// locale. A Catalog must not be modified once it's used.
+type Catalog struct {
+	// Fallback is the locale used when a message is not available in any of
+	// the interaction's locales.
+	Fallback discord.Language
+	// Messages maps each locale to its messages, which map a message key to
+	// a string. The string may contain fmt verbs.
+	Messages map[discord.Language]map[string]string
+}
+
+// LoadCatalog loads a Catalog from the given file system. Each JSON file in
+// the root of fsys is a flat object that maps message keys to strings, and
+// its name is the locale, such as "en-US.json" or "fr.json".
+func LoadCatalog(fsys fs.FS, fallback discord.Language) (*Catalog, error) {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Catalog{
+		Fallback: fallback,
+		Messages: make(map[discord.Language]map[string]string, len(files)),
+	}
+
+	for _, file := range files {
+		b, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(b, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		lang := discord.Language(strings.TrimSuffix(path.Base(file), ".json"))
+		c.Messages[lang] = messages
+	}
+
+	if _, ok := c.Messages[fallback]; !ok {
+		return nil, fmt.Errorf("missing messages for fallback locale %q", fallback)
+	}
+
+	return c, nil
+}
+
+// Lookup looks up the message with the given key in the given locale. If the
+// locale is regional, such as "es-ES", and the message is missing, then the
+// base language "es" is tried. The fallback locale is not tried.
+func (c *Catalog) Lookup(lang discord.Language, key string) (string, bool) {
+	if lang == "" {
+		return "", false
+	}
+
+	if msg, ok := c.Messages[lang][key]; ok {
+		return msg, true
+	}
+
+	if i := strings.IndexByte(string(lang), '-'); i > 0 {
+		msg, ok := c.Messages[lang[:i]][key]
+		return msg, ok
+	}
+
+	return "", false
+}
+
+// discordLanguages are the locales that Discord accepts in localizations.
+var discordLanguages = []discord.Language{
+	discord.Danish, discord.German, discord.EnglishUK, discord.EnglishUS,
+	discord.Spanish, discord.SpanishLATAM, discord.French, discord.Croatian,
+	discord.Indonesian, discord.Italian, discord.Lithuanian, discord.Hungarian,
+	discord.Dutch, discord.Norwegian, discord.Polish, discord.PortugueseBR,
+	discord.Romanian, discord.Finnish, discord.Swedish, discord.Vietnamese,
+	discord.Turkish, discord.Czech, discord.Greek, discord.Bulgarian,
+	discord.Russian, discord.Ukrainian, discord.Hindi, discord.Thai,
+	discord.ChineseChina, discord.Japanese, discord.ChineseTaiwan, discord.Korean,
+}
+
+// Locales returns the message with the given key in every locale that Discord
+// accepts, as found by Lookup. A base language, such as "es", is used for all
+// of its regional locales, such as "es-ES" and "es-419", and locales unknown
+// to Discord are skipped. It is useful for localizing command names and
+// descriptions.
+func (c *Catalog) Locales(key string) discord.StringLocales {
+	locales := make(discord.StringLocales)
+	for _, lang := range discordLanguages {
+		if msg, ok := c.Lookup(lang, key); ok {
+			locales[lang] = msg
+		}
+	}
+	return locales
+}
+
+// LocalizeCommands fills in the name and description localizations of the
+// given commands, their options and their choices from the catalog, using the
+// same locales as Locales. Existing localizations are kept. The message keys are the names along the path to
+// each command or option joined by dots, followed by "name" or "description":
+//
+//	{
+//	  "tag.name": "etiqueta",
+//	  "tag.description": "Gestiona las etiquetas",
+//	  "tag.get.description": "Muestra una etiqueta",
+//	  "tag.get.name.description": "El nombre de la etiqueta",
+//	  "tag.get.sort.choices.newest": "Más reciente"
+//	}
+//
+// Choices use the key of their option followed by "choices" and the name of
+// the choice.
+func (c *Catalog) LocalizeCommands(cmds []api.CreateCommandData) {
+	for i := range cmds {
+		cmd := &cmds[i]
+		c.localize(&cmd.NameLocalizations, cmd.Name+".name")
+		c.localize(&cmd.DescriptionLocalizations, cmd.Name+".description")
+		for _, opt := range cmd.Options {
+			c.localizeOption(cmd.Name, opt)
+		}
+	}
+}
+
+func (c *Catalog) localizeOption(prefix string, opt discord.CommandOption) {
+	prefix += "." + opt.Name()
+
+	var name, desc *discord.StringLocales
+
+	switch opt := opt.(type) {
+	case *discord.SubcommandGroupOption:
+		name, desc = &opt.OptionNameLocalizations, &opt.DescriptionLocalizations
+		for _, sub := range opt.Subcommands {
+			c.localizeOption(prefix, sub)
+		}
+	case *discord.SubcommandOption:
+		name, desc = &opt.OptionNameLocalizations, &opt.DescriptionLocalizations
+		for _, sub := range opt.Options {
+			c.localizeOption(prefix, sub)
+		}
+	case *discord.StringOption:
+		name, desc = &opt.OptionNameLocalizations, &opt.DescriptionLocalizations
+		for i := range opt.Choices {
+			choice := &opt.Choices[i]
+			c.localize(&choice.NameLocalizations, prefix+".choices."+choice.Name)
+		}
+	case *discord.IntegerOption:
+		name, desc = &opt.OptionNameLocalizations, &opt.DescriptionLocalizations
+		for i := range opt.Choices {
+			choice := &opt.Choices[i]
+			c.localize(&choice.NameLocalizations, prefix+".choices."+choice.Name)
+		}
+	case *discord.NumberOption:
+		name, desc = &opt.OptionNameLocalizations, &opt.DescriptionLocalizations
+		for i := range opt.Choices {
+			choice := &opt.Choices[i]
+			c.localize(&choice.NameLocalizations, prefix+".choices."+choice.Name)
+		}
+	case *discord.BooleanOption:
+		name, desc = &opt.OptionNameLocalizations, &opt.DescriptionLocalizations
+	case *discord.UserOption:
+		name, desc = &opt.OptionNameLocalizations, &opt.DescriptionLocalizations
+	case *discord.ChannelOption:
+		name, desc = &opt.OptionNameLocalizations, &opt.DescriptionLocalizations
+	case *discord.RoleOption:
+		name, desc = &opt.OptionNameLocalizations, &opt.DescriptionLocalizations
+	case *discord.MentionableOption:
+		name, desc = &opt.OptionNameLocalizations, &opt.DescriptionLocalizations
+	case *discord.AttachmentOption:
+		name, desc = &opt.OptionNameLocalizations, &opt.DescriptionLocalizations
+	default:
+		return
+	}
+
+	c.localize(name, prefix+".name")
+	c.localize(desc, prefix+".description")
+}
+
+// localize adds the messages with the given key to locales without replacing
+// existing ones. Refer to Locales for the locales used.
+func (c *Catalog) localize(locales *discord.StringLocales, key string) {
+	for _, lang := range discordLanguages {
+		msg, ok := c.Lookup(lang, key)
+		if !ok {
+			continue
+		}
+		if _, ok := (*locales)[lang]; ok {
+			continue
+		}
+		if *locales == nil {
+			*locales = make(discord.StringLocales)
+		}
+		(*locales)[lang] = msg
+	}
+}
+
+// Localizer returns a Localizer for the given interaction. The user's locale
+// is preferred over the guild's locale.
+func (c *Catalog) Localizer(ev *discord.InteractionEvent) Localizer {
+	return Localizer{
+		Catalog: c,
+		Locales: []discord.Language{ev.Locale, discord.Language(ev.GuildLocale)},
+	}
+}
+
+// Localizer picks messages from a Catalog for a list of locales.
+type Localizer struct {
+	Catalog *Catalog
+	// Locales is the list of locales to try in order, before the catalog's
+	// fallback locale.
+	Locales []discord.Language
+}
+
+// String returns the message with the given key formatted using fmt.Sprintf
+// if there are any arguments. If the message is missing from all locales,
+// then the key itself is used.
+func (l Localizer) String(key string, args ...interface{}) string {
+	msg, ok := l.lookup(key)
+	if !ok {
+		msg = key
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+
+	return msg
+}
+
+func (l Localizer) lookup(key string) (string, bool) {
+	if l.Catalog == nil {
+		return "", false
+	}
+
+	for _, lang := range l.Locales {
+		if msg, ok := l.Catalog.Lookup(lang, key); ok {
+			return msg, true
+		}
+	}
+
+	return l.Catalog.Lookup(l.Catalog.Fallback, key)
+}
+
+// Response returns a response with its content set to the localized message.
+// Refer to String for more information.
+func (l Localizer) Response(key string, args ...interface{}) *api.InteractionResponseData {
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(l.String(key, args...)),
+	}
+}
+
+// UseLocalizer returns a middleware that makes a Localizer for each
+// interaction using the given catalog. The Localizer can then be obtained
+// using LocalizerFromContext.
+func UseLocalizer(c *Catalog) Middleware {
+	return func(next InteractionHandler) InteractionHandler {
+		return InteractionHandlerFunc(func(ctx context.Context, ev *discord.InteractionEvent) *api.InteractionResponse {
+			ctx = context.WithValue(ctx, localizerCtx, c.Localizer(ev))
+			return next.HandleInteraction(ctx, ev)
+		})
+	}
+}
+
+// LocalizerFromContext returns the Localizer from the context. If no
+// Localizer is found, it returns a zero-value Localizer, which always uses
+// the message keys as-is.
+func LocalizerFromContext(ctx context.Context) Localizer {
+	l, _ := ctx.Value(localizerCtx).(Localizer)
+	return l
+}
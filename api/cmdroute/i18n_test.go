@@ -0,0 +1,164 @@
+package cmdroute
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestCatalog(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en-US.json": {Data: []byte(`{"hello": "Hello, %s!", "bye": "Goodbye!"}`)},
+		"es.json":    {Data: []byte(`{"hello": "¡Hola, %s!"}`)},
+		"fr.json":    {Data: []byte(`{"hello": "Bonjour, %s !"}`)},
+		"README.md":  {Data: []byte(`not a catalog`)},
+	}
+
+	c, err := LoadCatalog(fsys, discord.EnglishUS)
+	if err != nil {
+		t.Fatal("failed to load catalog:", err)
+	}
+
+	tests := []struct {
+		name   string
+		locale discord.Language
+		guild  string
+		key    string
+		expect string
+	}{
+		{"user locale", discord.French, "es-ES", "hello", "Bonjour, arikawa !"},
+		{"base language", discord.Spanish, "", "hello", "¡Hola, arikawa!"},
+		{"guild locale", discord.German, "fr", "hello", "Bonjour, arikawa !"},
+		{"fallback", discord.German, "", "hello", "Hello, arikawa!"},
+		{"fallback missing key", discord.French, "", "bye", "Goodbye!"},
+		{"missing", discord.French, "", "missing", "missing"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ev := &discord.InteractionEvent{
+				Locale:      test.locale,
+				GuildLocale: test.guild,
+			}
+
+			var got string
+			h := UseLocalizer(c)(InteractionHandlerFunc(
+				func(ctx context.Context, ev *discord.InteractionEvent) *api.InteractionResponse {
+					l := LocalizerFromContext(ctx)
+					if test.key == "hello" {
+						got = l.Response(test.key, "arikawa").Content.Val
+					} else {
+						got = l.String(test.key)
+					}
+					return nil
+				},
+			))
+			h.HandleInteraction(context.Background(), ev)
+
+			if got != test.expect {
+				t.Fatalf("expected %q, got %q", test.expect, got)
+			}
+		})
+	}
+
+	locales := c.Locales("hello")
+	if len(locales) != 4 || locales[discord.French] != "Bonjour, %s !" ||
+		locales[discord.SpanishLATAM] != "¡Hola, %s!" {
+		t.Fatalf("unexpected locales: %v", locales)
+	}
+
+	if _, err := LoadCatalog(fsys, discord.German); err == nil {
+		t.Fatal("expected error for missing fallback locale")
+	}
+}
+
+func TestCatalogLocalizeCommands(t *testing.T) {
+	c := &Catalog{
+		Fallback: discord.EnglishUS,
+		Messages: map[discord.Language]map[string]string{
+			discord.Spanish: {
+				"tag.name":                    "etiqueta",
+				"tag.description":             "Gestiona las etiquetas",
+				"tag.get.description":         "Muestra una etiqueta",
+				"tag.get.sort.name":           "orden",
+				"tag.get.sort.choices.newest": "Más reciente",
+			},
+			discord.French: {
+				"tag.name": "étiquette",
+			},
+		},
+	}
+
+	sort := &discord.StringOption{
+		OptionName:  "sort",
+		Description: "The sort order",
+		Choices:     []discord.StringChoice{{Name: "newest", Value: "newest"}},
+	}
+	get := &discord.SubcommandOption{
+		OptionName:  "get",
+		Description: "Shows a tag",
+		Options:     []discord.CommandOptionValue{sort},
+	}
+	cmds := []api.CreateCommandData{{
+		Name:              "tag",
+		NameLocalizations: discord.StringLocales{discord.French: "tag"},
+		Description:       "Manages tags",
+		Options:           []discord.CommandOption{get},
+	}}
+
+	c.LocalizeCommands(cmds)
+
+	if got := cmds[0].NameLocalizations; len(got) != 2 || got[discord.Spanish] != "etiqueta" || got[discord.French] != "tag" {
+		t.Errorf("unexpected command name localizations: %v", got)
+	}
+	if got := cmds[0].DescriptionLocalizations[discord.Spanish]; got != "Gestiona las etiquetas" {
+		t.Errorf("unexpected command description localization: %q", got)
+	}
+	if got := get.DescriptionLocalizations[discord.Spanish]; got != "Muestra una etiqueta" {
+		t.Errorf("unexpected subcommand description localization: %q", got)
+	}
+	if got := sort.OptionNameLocalizations[discord.Spanish]; got != "orden" {
+		t.Errorf("unexpected option name localization: %q", got)
+	}
+	if got := sort.Choices[0].NameLocalizations[discord.Spanish]; got != "Más reciente" {
+		t.Errorf("unexpected choice localization: %q", got)
+	}
+	if sort.DescriptionLocalizations != nil {
+		t.Errorf("unexpected option description localizations: %v", sort.DescriptionLocalizations)
+	}
+}
+
+func TestCatalogBaseLanguage(t *testing.T) {
+	c := &Catalog{
+		Fallback: discord.EnglishUS,
+		Messages: map[discord.Language]map[string]string{
+			"en-US": {"tag.name": "tag"},
+			"es":    {"tag.name": "etiqueta"},
+			"es-ES": {"tag.name": "marca"},
+			"xx":    {"tag.name": "unknown"},
+		},
+	}
+
+	cmds := []api.CreateCommandData{{Name: "tag", Description: "Manages tags"}}
+	c.LocalizeCommands(cmds)
+
+	expect := discord.StringLocales{
+		discord.EnglishUS:    "tag",
+		discord.Spanish:      "marca",
+		discord.SpanishLATAM: "etiqueta",
+	}
+
+	for _, got := range []discord.StringLocales{cmds[0].NameLocalizations, c.Locales("tag.name")} {
+		if len(got) != len(expect) {
+			t.Fatalf("expected localizations %v, got %v", expect, got)
+		}
+		for lang, msg := range expect {
+			if got[lang] != msg {
+				t.Fatalf("expected localizations %v, got %v", expect, got)
+			}
+		}
+	}
+}
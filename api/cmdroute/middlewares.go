@@ -14,6 +14,8 @@ const (
 	_ ctxKey = iota
 	ctxCtx
 	deferTicketCtx
+	localizerCtx
+	errorResponderCtx
 )
 
 // UseContext returns a middleware that override the handler context to the
@@ -11,6 +11,7 @@ import (
 // Router is a router for slash commands. A zero-value Router is a valid router.
 type Router struct {
 	nodes  map[string]routeNode
+	modals map[string]ModalHandler // separate from nodes, see AddModal
 	mws    []Middleware
 	parent *Router  // parent router, if any
 	groups []Router // next routers to check, if any
@@ -123,8 +124,8 @@ func (r *Router) With(mws ...Middleware) *Router {
 	return sub
 }
 
-// HandleInteraction implements webhook.InteractionHandler. It only handles
-// events of type CommandInteraction, otherwise nil is returned.
+// HandleInteraction implements webhook.InteractionHandler. It handles command,
+// autocomplete, component and modal interactions; otherwise, nil is returned.
 func (r *Router) HandleInteraction(ev *discord.InteractionEvent) *api.InteractionResponse {
 	switch data := ev.Data.(type) {
 	case *discord.CommandInteraction:
@@ -133,6 +134,8 @@ func (r *Router) HandleInteraction(ev *discord.InteractionEvent) *api.Interactio
 		return r.HandleAutocompletion(ev, data)
 	case discord.ComponentInteraction:
 		return r.handleComponent(ev, data)
+	case *discord.ModalInteraction:
+		return r.handleModal(ev, data)
 	default:
 		return nil
 	}
@@ -419,3 +422,44 @@ func (r *Router) callComponentHandler(ev *discord.InteractionEvent, handler Comp
 		},
 	)
 }
+
+// AddModal registers a modal submission handler for the given modal custom
+// ID. Modal IDs have their own namespace, so a modal can have the same ID as
+// the button that shows it.
+func (r *Router) AddModal(id string, f ModalHandler) {
+	if r.modals == nil {
+		r.modals = make(map[string]ModalHandler, 4)
+	}
+
+	_, ok := r.modals[id]
+	if ok {
+		panic("cmdroute: modal " + id + " already exists")
+	}
+
+	r.modals[id] = f
+}
+
+// AddModalFunc is a convenience function that calls AddModal with a
+// ModalHandlerFunc.
+func (r *Router) AddModalFunc(id string, f ModalHandlerFunc) {
+	r.AddModal(id, f)
+}
+
+func (r *Router) handleModal(ev *discord.InteractionEvent, modal *discord.ModalInteraction) *api.InteractionResponse {
+	handler, ok := r.modals[string(modal.CustomID)]
+	if ok {
+		return r.callModalHandler(ev, handler)
+	}
+	return nil
+}
+
+func (r *Router) callModalHandler(ev *discord.InteractionEvent, handler ModalHandler) *api.InteractionResponse {
+	return r.callHandler(ev,
+		func(ctx context.Context, ev *discord.InteractionEvent) *api.InteractionResponse {
+			return handler.HandleModal(ctx, ModalData{
+				Event:            ev,
+				ModalInteraction: ev.Data.(*discord.ModalInteraction),
+			})
+		},
+	)
+}
@@ -164,6 +164,59 @@ func TestRouter(t *testing.T) {
 		}
 	})
 
+	t.Run("modal", func(t *testing.T) {
+		r := NewRouter()
+		r.AddModalFunc("feedback", func(ctx context.Context, data ModalData) *api.InteractionResponse {
+			text, _ := data.Value("text")
+			return &api.InteractionResponse{
+				Type: api.MessageInteractionWithSource,
+				Data: &api.InteractionResponseData{
+					Content: option.NewNullableString(text),
+				},
+			}
+		})
+		resp := r.HandleInteraction(newInteractionEvent(&discord.ModalInteraction{
+			CustomID: "feedback",
+			Components: discord.ContainerComponents{
+				&discord.ActionRowComponent{
+					&discord.TextInputComponent{CustomID: "text", Value: "hello"},
+				},
+			},
+		}))
+		if !reflect.DeepEqual(resp, &api.InteractionResponse{
+			Type: api.MessageInteractionWithSource,
+			Data: &api.InteractionResponseData{
+				Content: option.NewNullableString("hello"),
+			},
+		}) {
+			t.Fatal("unexpected response")
+		}
+	})
+
+	t.Run("modal same id as component", func(t *testing.T) {
+		r := NewRouter()
+		r.AddComponentFunc("feedback", func(ctx context.Context, data ComponentData) *api.InteractionResponse {
+			return &api.InteractionResponse{Type: api.ModalResponse}
+		})
+		r.AddModalFunc("feedback", func(ctx context.Context, data ModalData) *api.InteractionResponse {
+			return &api.InteractionResponse{Type: api.DeferredMessageInteractionWithSource}
+		})
+
+		resp := r.HandleInteraction(newInteractionEvent(&discord.ButtonInteraction{
+			CustomID: "feedback",
+		}))
+		if resp == nil || resp.Type != api.ModalResponse {
+			t.Fatalf("unexpected component response %+v", resp)
+		}
+
+		resp = r.HandleInteraction(newInteractionEvent(&discord.ModalInteraction{
+			CustomID: "feedback",
+		}))
+		if resp == nil || resp.Type != api.DeferredMessageInteractionWithSource {
+			t.Fatalf("unexpected modal response %+v", resp)
+		}
+	})
+
 	t.Run("middlewares", func(t *testing.T) {
 		var stack middlewareStacker
 
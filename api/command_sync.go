@@ -0,0 +1,249 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// SyncCommandsResult is the result of SyncCommands. Each command is in exactly
+// one of the lists.
+type SyncCommandsResult struct {
+	// Created contains the commands that didn't exist and were created.
+	Created []discord.Command
+	// Edited contains the commands that existed but differed, and were
+	// edited. Edited commands keep their IDs.
+	Edited []discord.Command
+	// Deleted contains the commands that existed but weren't given, and were
+	// deleted.
+	Deleted []discord.Command
+	// Unchanged contains the commands that already matched and were left
+	// alone.
+	Unchanged []discord.Command
+}
+
+// Changed returns true if any command was created, edited or deleted.
+func (r SyncCommandsResult) Changed() bool {
+	return len(r.Created)+len(r.Edited)+len(r.Deleted) > 0
+}
+
+// SyncCommands makes the application's commands match the given commands. If
+// guildID is 0, then the global commands are synced, otherwise the guild's
+// commands are.
+//
+// Unlike BulkOverwriteCommands, this fetches the existing commands first and
+// only creates, edits or deletes the commands that differ. Commands are matched
+// by their type and name, and compared while ignoring the fields that are
+// populated by Discord, such as IDs and versions. Calling this on every
+// startup therefore makes no requests other than the fetch if nothing changed,
+// which avoids hitting the daily command create limit and keeps command IDs and
+// their permissions stable.
+//
+// If an error occurs, then the returned result contains the changes made so
+// far.
+func (c CtxClient) SyncCommands(
+	appID discord.AppID,
+	guildID discord.GuildID, commands []CreateCommandData) (*SyncCommandsResult, error) {
+
+	var existing []discord.Command
+	var err error
+
+	if guildID.IsValid() {
+		existing, err = c.GuildCommands(appID, guildID)
+	} else {
+		existing, err = c.Commands(appID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing commands: %w", err)
+	}
+
+	type commandKey struct {
+		typ  discord.CommandType
+		name string
+	}
+
+	keyOf := func(typ discord.CommandType, name string) commandKey {
+		if typ == 0 {
+			typ = discord.ChatInputCommand
+		}
+		return commandKey{typ, name}
+	}
+
+	existingByKey := make(map[commandKey]discord.Command, len(existing))
+	for _, cmd := range existing {
+		existingByKey[keyOf(cmd.Type, cmd.Name)] = cmd
+	}
+
+	result := &SyncCommandsResult{}
+
+	for _, data := range commands {
+		key := keyOf(data.Type, data.Name)
+
+		old, ok := existingByKey[key]
+		delete(existingByKey, key)
+
+		if !ok {
+			var cmd *discord.Command
+			if guildID.IsValid() {
+				cmd, err = c.CreateGuildCommand(appID, guildID, data)
+			} else {
+				cmd, err = c.CreateCommand(appID, data)
+			}
+			if err != nil {
+				return result, fmt.Errorf("failed to create command %q: %w", data.Name, err)
+			}
+
+			result.Created = append(result.Created, *cmd)
+			continue
+		}
+
+		same, err := commandsEqual(old, data, guildID.IsValid())
+		if err != nil {
+			return result, fmt.Errorf("failed to compare command %q: %w", data.Name, err)
+		}
+
+		if same {
+			result.Unchanged = append(result.Unchanged, old)
+			continue
+		}
+
+		var cmd *discord.Command
+		if guildID.IsValid() {
+			cmd, err = c.EditGuildCommand(appID, guildID, old.ID, data)
+		} else {
+			cmd, err = c.EditCommand(appID, old.ID, data)
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to edit command %q: %w", data.Name, err)
+		}
+
+		result.Edited = append(result.Edited, *cmd)
+	}
+
+	// Delete in the order that the commands were returned, so that the result
+	// is deterministic.
+	for _, cmd := range existing {
+		if _, ok := existingByKey[keyOf(cmd.Type, cmd.Name)]; !ok {
+			continue
+		}
+
+		if guildID.IsValid() {
+			err = c.DeleteGuildCommand(appID, guildID, cmd.ID)
+		} else {
+			err = c.DeleteCommand(appID, cmd.ID)
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to delete command %q: %w", cmd.Name, err)
+		}
+
+		result.Deleted = append(result.Deleted, cmd)
+	}
+
+	return result, nil
+}
+
+// commandsEqual returns true if the existing command already matches data.
+// Both are marshaled into the same JSON representation, which is normalized
+// before being compared.
+func commandsEqual(cmd discord.Command, data CreateCommandData, guild bool) (bool, error) {
+	have, err := normalizedCommand(CreateCommandData{
+		Name:                     cmd.Name,
+		NameLocalizations:        cmd.NameLocalizations,
+		Description:              cmd.Description,
+		DescriptionLocalizations: cmd.DescriptionLocalizations,
+		Options:                  cmd.Options,
+		DefaultMemberPermissions: cmd.DefaultMemberPermissions,
+		NoDMPermission:           cmd.NoDMPermission,
+		NoDefaultPermission:      cmd.NoDefaultPermission,
+		Type:                     cmd.Type,
+	}, guild)
+	if err != nil {
+		return false, err
+	}
+
+	// The ID is only used for bulk overwrites.
+	data.ID = 0
+
+	want, err := normalizedCommand(data, guild)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(have, want), nil
+}
+
+func normalizedCommand(data CreateCommandData, guild bool) (interface{}, error) {
+	if data.Type == 0 {
+		data.Type = discord.ChatInputCommand
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var v map[string]interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	// DM permissions only apply to global commands, and Discord doesn't
+	// return them for guild commands.
+	if guild {
+		delete(v, "dm_permission")
+	}
+
+	return normalizeJSON(v), nil
+}
+
+// normalizeJSON removes the fields of JSON objects that have zero values, which
+// Discord treats the same as omitted fields, as well as the fields that are
+// only filled in by Discord.
+func normalizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			switch k {
+			case "name_localized", "description_localized":
+				delete(v, k)
+				continue
+			}
+
+			field = normalizeJSON(field)
+			if isZeroJSON(field) {
+				delete(v, k)
+			} else {
+				v[k] = field
+			}
+		}
+		return v
+
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = normalizeJSON(elem)
+		}
+		return v
+
+	default:
+		return v
+	}
+}
+
+func isZeroJSON(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestSyncCommands(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, Path+"/applications/1/guilds/2/commands")
+		requests = append(requests, r.Method+" "+path)
+
+		switch r.Method {
+		case "GET":
+			io.WriteString(w, `[
+				{"id":"10","application_id":"1","guild_id":"2","version":"5","type":1,
+				 "name":"ping","description":"Ping!","default_permission":true,
+				 "options":[{"type":3,"name":"text","description":"Text","required":false}]},
+				{"id":"11","application_id":"1","guild_id":"2","version":"5","type":1,
+				 "name":"echo","description":"Echo","default_permission":true},
+				{"id":"12","application_id":"1","guild_id":"2","version":"5","type":1,
+				 "name":"old","description":"Old","default_permission":true}
+			]`)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			w.Write(body)
+		}
+	}))
+	defer srv.Close()
+
+	oldEndpoint := EndpointApplications
+	EndpointApplications = srv.URL + Path + "/applications/"
+	defer func() { EndpointApplications = oldEndpoint }()
+
+	client := NewClient("no. 3-chan")
+
+	result, err := client.SyncCommands(1, 2, []CreateCommandData{
+		{
+			Name:        "ping",
+			Description: "Ping!",
+			Options: discord.CommandOptions{
+				&discord.StringOption{OptionName: "text", Description: "Text"},
+			},
+		},
+		{Name: "echo", Description: "Echo something"},
+		{Name: "new", Description: "New"},
+	})
+	if err != nil {
+		t.Fatal("failed to sync commands:", err)
+	}
+
+	expected := []string{"GET ", "PATCH /11", "POST ", "DELETE /12"}
+	if strings.Join(requests, ",") != strings.Join(expected, ",") {
+		t.Fatalf("unexpected requests %q, expected %q", requests, expected)
+	}
+
+	if len(result.Unchanged) != 1 || len(result.Edited) != 1 ||
+		len(result.Created) != 1 || len(result.Deleted) != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// ErrDownloadSizeMismatch is returned by Downloader if the downloaded size
+// does not match the size reported by Discord.
+var ErrDownloadSizeMismatch = errors.New("downloaded size does not match the reported size")
+
+// PartialDownloadSuffix is appended to the destination path of DownloadFile
+// while the download is in progress.
+const PartialDownloadSuffix = ".part"
+
+// Downloader downloads attachments and other assets from Discord's CDN.
+// Interrupted downloads are resumed using Range requests instead of starting
+// over, which matters for large media.
+type Downloader struct {
+	// Client is the HTTP client used to download. If nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+	// Retries is the number of times an interrupted download is resumed
+	// before giving up.
+	Retries int
+	// RetryDelay is the delay between each resume attempt.
+	RetryDelay time.Duration
+}
+
+// DefaultDownloader is the Downloader used by DownloadAttachment.
+var DefaultDownloader = &Downloader{
+	Retries:    5,
+	RetryDelay: time.Second,
+}
+
+// Download downloads url into w. The download starts at offset, which should
+// be the number of bytes of the file that w already has. size is the total
+// size reported by Discord, such as Attachment.Size, and is checked against
+// the downloaded size; a size of 0 skips the check. The total number of bytes
+// written into w is returned.
+func (d *Downloader) Download(
+	ctx context.Context, url string, w io.Writer, offset, size int64) (int64, error) {
+
+	if size > 0 && offset > size {
+		return 0, ErrDownloadSizeMismatch
+	}
+
+	var written int64
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		var n int64
+		n, err = d.download(ctx, url, w, offset+written)
+		written += n
+
+		if err == nil || attempt >= d.Retries || ctx.Err() != nil {
+			break
+		}
+
+		var httpErr *downloadStatusError
+		if errors.As(err, &httpErr) && httpErr.status < 500 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return written, ctx.Err()
+		case <-time.After(d.RetryDelay):
+		}
+	}
+
+	if err != nil {
+		return written, err
+	}
+
+	if size > 0 && offset+written != size {
+		return written, ErrDownloadSizeMismatch
+	}
+
+	return written, nil
+}
+
+type downloadStatusError struct {
+	status int
+}
+
+func (err *downloadStatusError) Error() string {
+	return "unexpected status " + strconv.Itoa(err.status)
+}
+
+// download does a single download attempt starting at offset.
+func (d *Downloader) download(
+	ctx context.Context, url string, w io.Writer, offset int64) (int64, error) {
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("cannot create request: %w", err)
+	}
+
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	client := d.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// The server ignored the Range header and sent the whole file, so
+		// skip what we already have.
+		if offset > 0 {
+			if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+				return 0, fmt.Errorf("cannot skip downloaded bytes: %w", err)
+			}
+		}
+	case http.StatusPartialContent:
+		if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); !ok || start != offset {
+			return 0, fmt.Errorf("unexpected Content-Range %q", resp.Header.Get("Content-Range"))
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Nothing left to download. The size check will catch a mismatch.
+		return 0, nil
+	default:
+		return 0, &downloadStatusError{resp.StatusCode}
+	}
+
+	return io.Copy(w, resp.Body)
+}
+
+// contentRangeStart parses the start of a "bytes start-end/size"
+// Content-Range header.
+func contentRangeStart(header string) (int64, bool) {
+	header = strings.TrimPrefix(header, "bytes ")
+
+	dash := strings.IndexByte(header, '-')
+	if dash < 0 {
+		return 0, false
+	}
+
+	start, err := strconv.ParseInt(header[:dash], 10, 64)
+	return start, err == nil
+}
+
+// DownloadFile downloads url into the file at path. While downloading, data is
+// written into path with PartialDownloadSuffix appended, and the file is only
+// renamed to path once it is complete. If a partial file already exists, such
+// as from a previous run that was interrupted, the download resumes from where
+// it stopped. See Download for the meaning of size.
+func (d *Downloader) DownloadFile(ctx context.Context, url, path string, size int64) error {
+	partPath := path + PartialDownloadSuffix
+
+	f, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot open partial file: %w", err)
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("cannot stat partial file: %w", err)
+	}
+
+	_, err = d.Download(ctx, url, f, stat.Size(), size)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		if errors.Is(err, ErrDownloadSizeMismatch) {
+			// The partial file is corrupted, so don't resume from it.
+			os.Remove(partPath)
+		}
+		return err
+	}
+
+	if err := os.Rename(partPath, path); err != nil {
+		return fmt.Errorf("cannot rename partial file: %w", err)
+	}
+
+	return nil
+}
+
+// DownloadAttachment downloads the given attachment into the file at path
+// using DefaultDownloader. The attachment's URL is refreshed first if it has
+// expired, and the downloaded file is checked against the attachment's size.
+func (c CtxClient) DownloadAttachment(
+	ctx context.Context, attachment discord.Attachment, path string) error {
+
+	url, err := c.WithCtx(ctx).FreshAttachmentURL(attachment.URL)
+	if err != nil {
+		return fmt.Errorf("cannot refresh attachment URL: %w", err)
+	}
+
+	return DefaultDownloader.DownloadFile(ctx, url, path, int64(attachment.Size))
+}
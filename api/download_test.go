@@ -0,0 +1,67 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDownloaderResume(t *testing.T) {
+	content := bytes.Repeat([]byte("arikawa"), 1000)
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			// Pretend the connection drops halfway through.
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.Write(content[:len(content)/2])
+			return
+		}
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "file")
+	d := &Downloader{Retries: 2}
+
+	if err := d.DownloadFile(context.Background(), srv.URL, path, int64(len(content))); err != nil {
+		t.Fatal("failed to download:", err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal("failed to read downloaded file:", err)
+	}
+
+	if !bytes.Equal(b, content) {
+		t.Fatal("downloaded content mismatch")
+	}
+
+	if _, err := os.Stat(path + PartialDownloadSuffix); !os.IsNotExist(err) {
+		t.Fatal("partial file was not removed:", err)
+	}
+}
+
+func TestDownloaderSizeMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("short"))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	_, err := DefaultDownloader.Download(context.Background(), srv.URL, &buf, 0, 100)
+	if err != ErrDownloadSizeMismatch {
+		t.Fatal("expected ErrDownloadSizeMismatch, got", err)
+	}
+}
@@ -6,13 +6,13 @@ import (
 )
 
 // Emojis returns a list of emoji objects for the given guild.
-func (c *Client) Emojis(guildID discord.GuildID) ([]discord.Emoji, error) {
+func (c CtxClient) Emojis(guildID discord.GuildID) ([]discord.Emoji, error) {
 	var e []discord.Emoji
 	return e, c.RequestJSON(&e, "GET", EndpointGuilds+guildID.String()+"/emojis")
 }
 
 // Emoji returns an emoji object for the given guild and emoji IDs.
-func (c *Client) Emoji(guildID discord.GuildID, emojiID discord.EmojiID) (*discord.Emoji, error) {
+func (c CtxClient) Emoji(guildID discord.GuildID, emojiID discord.EmojiID) (*discord.Emoji, error) {
 	var emj *discord.Emoji
 	return emj, c.RequestJSON(&emj, "GET",
 		EndpointGuilds+guildID.String()+"/emojis/"+emojiID.String())
@@ -36,7 +36,7 @@ type CreateEmojiData struct {
 // shouldn't be relied on).
 //
 // Emojis and animated emojis have a maximum file size of 256kb.
-func (c *Client) CreateEmoji(
+func (c CtxClient) CreateEmoji(
 	guildID discord.GuildID, data CreateEmojiData) (*discord.Emoji, error) {
 
 	// Max 256KB
@@ -66,7 +66,7 @@ type ModifyEmojiData struct {
 // roles are optional fields (though you'd want to change either though).
 //
 // Fires a Guild Emojis Update Gateway event.
-func (c *Client) ModifyEmoji(
+func (c CtxClient) ModifyEmoji(
 	guildID discord.GuildID, emojiID discord.EmojiID, data ModifyEmojiData) error {
 
 	return c.FastRequest(
@@ -81,7 +81,7 @@ func (c *Client) ModifyEmoji(
 // Requires the MANAGE_EMOJIS permission.
 //
 // Fires a Guild Emojis Update Gateway event.
-func (c *Client) DeleteEmoji(
+func (c CtxClient) DeleteEmoji(
 	guildID discord.GuildID, emojiID discord.EmojiID, reason AuditLogReason) error {
 
 	return c.FastRequest(
@@ -3,6 +3,7 @@ package api
 import (
 	"io"
 	"net/url"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord" // for clarity
 	"github.com/diamondburned/arikawa/v3/internal/intmath"
@@ -73,7 +74,7 @@ type CreateGuildData struct {
 // Fires a Guild Create Gateway event.
 //
 // This endpoint can be used only by bots in less than 10 guilds.
-func (c *Client) CreateGuild(data CreateGuildData) (*discord.Guild, error) {
+func (c CtxClient) CreateGuild(data CreateGuildData) (*discord.Guild, error) {
 	var g *discord.Guild
 	return g, c.RequestJSON(&g, "POST", Endpoint+"guilds", httputil.WithJSONBody(data))
 }
@@ -81,7 +82,7 @@ func (c *Client) CreateGuild(data CreateGuildData) (*discord.Guild, error) {
 // Guild returns the guild object for the given id.
 //
 // ApproximateMembers and ApproximatePresences will not be set.
-func (c *Client) Guild(id discord.GuildID) (*discord.Guild, error) {
+func (c CtxClient) Guild(id discord.GuildID) (*discord.Guild, error) {
 	var g *discord.Guild
 	return g, c.RequestJSON(&g, "GET", EndpointGuilds+id.String())
 }
@@ -90,7 +91,7 @@ func (c *Client) Guild(id discord.GuildID) (*discord.Guild, error) {
 // user is not in the guild.
 //
 // This endpoint is only for public guilds.
-func (c *Client) GuildPreview(id discord.GuildID) (*discord.GuildPreview, error) {
+func (c CtxClient) GuildPreview(id discord.GuildID) (*discord.GuildPreview, error) {
 	var g *discord.GuildPreview
 	return g, c.RequestJSON(&g, "GET", EndpointGuilds+id.String()+"/preview")
 }
@@ -98,7 +99,7 @@ func (c *Client) GuildPreview(id discord.GuildID) (*discord.GuildPreview, error)
 // GuildWithCount returns the guild object for the given id. This will also
 // set the ApproximateMembers and ApproximatePresences fields of the guild
 // struct.
-func (c *Client) GuildWithCount(id discord.GuildID) (*discord.Guild, error) {
+func (c CtxClient) GuildWithCount(id discord.GuildID) (*discord.Guild, error) {
 	var g *discord.Guild
 	return g, c.RequestJSON(
 		&g, "GET",
@@ -124,7 +125,7 @@ func (c *Client) GuildWithCount(id discord.GuildID) (*discord.Guild, error) {
 // of the users' guilds.
 //
 // Requires the guilds OAuth2 scope.
-func (c *Client) Guilds(limit uint) ([]discord.Guild, error) {
+func (c CtxClient) Guilds(limit uint) ([]discord.Guild, error) {
 	return c.GuildsAfter(0, limit)
 }
 
@@ -138,7 +139,7 @@ func (c *Client) Guilds(limit uint) ([]discord.Guild, error) {
 // may be less, if no more guilds are available.
 //
 // Requires the guilds OAuth2 scope.
-func (c *Client) GuildsBefore(before discord.GuildID, limit uint) ([]discord.Guild, error) {
+func (c CtxClient) GuildsBefore(before discord.GuildID, limit uint) ([]discord.Guild, error) {
 	guilds := make([]discord.Guild, 0, limit)
 
 	fetch := uint(MaxGuildFetchLimit)
@@ -183,7 +184,7 @@ func (c *Client) GuildsBefore(before discord.GuildID, limit uint) ([]discord.Gui
 // may be less, if no more guilds are available.
 //
 // Requires the guilds OAuth2 scope.
-func (c *Client) GuildsAfter(after discord.GuildID, limit uint) ([]discord.Guild, error) {
+func (c CtxClient) GuildsAfter(after discord.GuildID, limit uint) ([]discord.Guild, error) {
 	guilds := make([]discord.Guild, 0, limit)
 
 	fetch := uint(MaxGuildFetchLimit)
@@ -218,7 +219,7 @@ func (c *Client) GuildsAfter(after discord.GuildID, limit uint) ([]discord.Guild
 	return guilds, nil
 }
 
-func (c *Client) guildsRange(before, after discord.GuildID, limit uint) ([]discord.Guild, error) {
+func (c CtxClient) guildsRange(before, after discord.GuildID, limit uint) ([]discord.Guild, error) {
 	var param struct {
 		Before discord.GuildID `schema:"before,omitempty"`
 		After  discord.GuildID `schema:"after,omitempty"`
@@ -239,7 +240,7 @@ func (c *Client) guildsRange(before, after discord.GuildID, limit uint) ([]disco
 }
 
 // LeaveGuild leaves a guild.
-func (c *Client) LeaveGuild(id discord.GuildID) error {
+func (c CtxClient) LeaveGuild(id discord.GuildID) error {
 	return c.FastRequest("DELETE", EndpointMe+"/guilds/"+id.String())
 }
 
@@ -297,6 +298,11 @@ type ModifyGuildData struct {
 	//
 	// This field is nullable.
 	PublicUpdatesChannelID discord.ChannelID `json:"public_updates_channel_id,omitempty"`
+	// SafetyAlertsChannelID is the id of the channel where admins and
+	// moderators of community guilds receive safety alerts from Discord.
+	//
+	// This field is nullable.
+	SafetyAlertsChannelID discord.ChannelID `json:"safety_alerts_channel_id,omitempty"`
 
 	// PreferredLocale is the preferred locale of a "PUBLIC" guild used in
 	// server discovery and notices from Discord.
@@ -304,6 +310,11 @@ type ModifyGuildData struct {
 	// This defaults to "en-US".
 	PreferredLocale option.NullableString `json:"preferred_locale,omitempty"`
 
+	// Features are the enabled guild features. Only features that are
+	// mutable, which is reported by GuildFeature's IsMutable, can be enabled
+	// or disabled, and the other features of the guild must be kept as-is.
+	Features []discord.GuildFeature `json:"features,omitempty"`
+
 	AuditLogReason `json:"-"`
 }
 
@@ -312,7 +323,7 @@ type ModifyGuildData struct {
 // Requires the MANAGE_GUILD permission.
 //
 // Fires a Guild Update Gateway event.
-func (c *Client) ModifyGuild(id discord.GuildID, data ModifyGuildData) (*discord.Guild, error) {
+func (c CtxClient) ModifyGuild(id discord.GuildID, data ModifyGuildData) (*discord.Guild, error) {
 	var g *discord.Guild
 	return g, c.RequestJSON(
 		&g, "PATCH",
@@ -322,16 +333,49 @@ func (c *Client) ModifyGuild(id discord.GuildID, data ModifyGuildData) (*discord
 
 }
 
+// MaxIncidentActionDuration is the longest that invites and DMs can be
+// disabled for using ModifyGuildIncidentActions.
+const MaxIncidentActionDuration = 24 * time.Hour
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-incident-actions-json-params
+type ModifyGuildIncidentActionsData struct {
+	// InvitesDisabledUntil is when invites will be enabled again, up to 24
+	// hours in the future. A zero-value Timestamp enables invites now.
+	InvitesDisabledUntil discord.Timestamp `json:"invites_disabled_until"`
+	// DMsDisabledUntil is when direct messages will be enabled again, up to 24
+	// hours in the future. A zero-value Timestamp enables DMs now.
+	DMsDisabledUntil discord.Timestamp `json:"dms_disabled_until"`
+}
+
+// ModifyGuildIncidentActions pauses or resumes invites and DMs in the guild,
+// which can be used to lock a guild down during a raid. Both actions are
+// always set, so the current value of the one that shouldn't change must be
+// given as well.
+//
+// Requires the MANAGE_GUILD permission.
+//
+// Fires a Guild Update Gateway event.
+func (c CtxClient) ModifyGuildIncidentActions(
+	guildID discord.GuildID, data ModifyGuildIncidentActionsData) (*discord.IncidentsData, error) {
+
+	var d *discord.IncidentsData
+	return d, c.RequestJSON(
+		&d, "PUT",
+		EndpointGuilds+guildID.String()+"/incident-actions",
+		httputil.WithJSONBody(data),
+	)
+}
+
 // DeleteGuild deletes a guild permanently. The User must be owner.
 //
 // Fires a Guild Delete Gateway event.
-func (c *Client) DeleteGuild(id discord.GuildID) error {
+func (c CtxClient) DeleteGuild(id discord.GuildID) error {
 	return c.FastRequest("DELETE", EndpointGuilds+id.String())
 }
 
-// VoiceRegionsGuild is the same as /voice, but returns VIP ones as well if
-// available.
-func (c *Client) VoiceRegionsGuild(guildID discord.GuildID) ([]discord.VoiceRegion, error) {
+// VoiceRegionsGuild is the same as VoiceRegions, but returns VIP ones as well
+// if available.
+func (c CtxClient) VoiceRegionsGuild(guildID discord.GuildID) ([]discord.VoiceRegion, error) {
 	var vrs []discord.VoiceRegion
 	return vrs, c.RequestJSON(&vrs, "GET", EndpointGuilds+guildID.String()+"/regions")
 }
@@ -352,7 +396,7 @@ type AuditLogData struct {
 // AuditLog returns an audit log object for the guild.
 //
 // Requires the VIEW_AUDIT_LOG permission.
-func (c *Client) AuditLog(guildID discord.GuildID, data AuditLogData) (*discord.AuditLog, error) {
+func (c CtxClient) AuditLog(guildID discord.GuildID, data AuditLogData) (*discord.AuditLog, error) {
 	switch {
 	case data.Limit == 0:
 		data.Limit = 50
@@ -369,10 +413,85 @@ func (c *Client) AuditLog(guildID discord.GuildID, data AuditLogData) (*discord.
 	)
 }
 
+// AuditLogIterator iterates over the entries of a guild's audit log from the
+// latest to the oldest, fetching a page of entries only once the previous page
+// has been consumed. It is created using AuditLogIter.
+type AuditLogIterator struct {
+	client  CtxClient
+	guildID discord.GuildID
+	data    AuditLogData
+
+	page  *discord.AuditLog
+	next  []discord.AuditLogEntry
+	entry discord.AuditLogEntry
+	done  bool
+	err   error
+}
+
+// AuditLogIter returns an iterator over the entries of the guild's audit log
+// that match the given data. The Limit of data is ignored, and its Before is
+// where the iterator starts.
+//
+// Requires the VIEW_AUDIT_LOG permission.
+func (c CtxClient) AuditLogIter(guildID discord.GuildID, data AuditLogData) *AuditLogIterator {
+	data.Limit = 100
+	return &AuditLogIterator{
+		client:  c,
+		guildID: guildID,
+		data:    data,
+	}
+}
+
+// Next advances the iterator to the next entry, fetching the next page if
+// needed. It returns false once there are no more entries or an error occurs,
+// which is then returned by Err.
+func (it *AuditLogIterator) Next() bool {
+	if len(it.next) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		it.page, it.err = it.client.AuditLog(it.guildID, it.data)
+		if it.err != nil {
+			return false
+		}
+
+		it.next = it.page.Entries
+		if len(it.next) < int(it.data.Limit) {
+			it.done = true
+		}
+		if len(it.next) == 0 {
+			return false
+		}
+
+		it.data.Before = it.next[len(it.next)-1].ID
+	}
+
+	it.entry = it.next[0]
+	it.next = it.next[1:]
+	return true
+}
+
+// Entry returns the current entry. It is only valid after Next returns true.
+func (it *AuditLogIterator) Entry() discord.AuditLogEntry {
+	return it.entry
+}
+
+// AuditLog returns the page of the audit log that the current entry is from,
+// which contains the users, webhooks and integrations that it references.
+func (it *AuditLogIterator) AuditLog() *discord.AuditLog {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *AuditLogIterator) Err() error {
+	return it.err
+}
+
 // Integrations returns a list of integration objects for the guild.
 //
 // Requires the MANAGE_GUILD permission.
-func (c *Client) Integrations(guildID discord.GuildID) ([]discord.Integration, error) {
+func (c CtxClient) Integrations(guildID discord.GuildID) ([]discord.Integration, error) {
 
 	var ints []discord.Integration
 	return ints, c.RequestJSON(&ints, "GET", EndpointGuilds+guildID.String()+"/integrations")
@@ -384,7 +503,7 @@ func (c *Client) Integrations(guildID discord.GuildID) ([]discord.Integration, e
 // Requires the MANAGE_GUILD permission.
 //
 // Fires a Guild Integrations Update Gateway event.
-func (c *Client) AttachIntegration(
+func (c CtxClient) AttachIntegration(
 	guildID discord.GuildID,
 	integrationID discord.IntegrationID, integrationType discord.Service) error {
 
@@ -414,6 +533,8 @@ type ModifyIntegrationData struct {
 	// EnableEmoticons specifies whether emoticons should be synced for this
 	// integration (twitch only currently).
 	EnableEmoticons option.NullableBool `json:"enable_emoticons,omitempty"`
+
+	AuditLogReason `json:"-"`
 }
 
 // ModifyIntegration modifies the behavior and settings of an integration
@@ -421,21 +542,21 @@ type ModifyIntegrationData struct {
 //
 // Requires the MANAGE_GUILD permission.
 // Fires a Guild Integrations Update Gateway event.
-func (c *Client) ModifyIntegration(
+func (c CtxClient) ModifyIntegration(
 	guildID discord.GuildID,
 	integrationID discord.IntegrationID, data ModifyIntegrationData) error {
 
 	return c.FastRequest(
 		"PATCH",
 		EndpointGuilds+guildID.String()+"/integrations/"+integrationID.String(),
-		httputil.WithJSONBody(data),
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
 	)
 }
 
 // SyncIntegration syncs an integration.
 //
 // Requires the MANAGE_GUILD permission.
-func (c *Client) SyncIntegration(
+func (c CtxClient) SyncIntegration(
 	guildID discord.GuildID, integrationID discord.IntegrationID) error {
 
 	return c.FastRequest(
@@ -447,7 +568,7 @@ func (c *Client) SyncIntegration(
 // GuildWidgetSettings returns the guild widget object.
 //
 // Requires the MANAGE_GUILD permission.
-func (c *Client) GuildWidgetSettings(
+func (c CtxClient) GuildWidgetSettings(
 	guildID discord.GuildID) (*discord.GuildWidgetSettings, error) {
 
 	var ge *discord.GuildWidgetSettings
@@ -461,7 +582,8 @@ func (c *Client) GuildWidgetSettings(
 type ModifyGuildWidgetData struct {
 	// Enabled specifies whether the widget is enabled.
 	Enabled option.Bool `json:"enabled,omitempty"`
-	// ChannelID is the widget channel ID.
+	// ChannelID is the widget channel ID, which the widget's invite leads to.
+	// Use discord.ChannelID(discord.NullSnowflake) to remove it.
 	ChannelID discord.ChannelID `json:"channel_id,omitempty"`
 
 	AuditLogReason `json:"-"`
@@ -470,7 +592,7 @@ type ModifyGuildWidgetData struct {
 // ModifyGuildWidget modifies a guild widget object for the guild.
 //
 // Requires the MANAGE_GUILD permission.
-func (c *Client) ModifyGuildWidget(
+func (c CtxClient) ModifyGuildWidget(
 	guildID discord.GuildID, data ModifyGuildWidgetData) (*discord.GuildWidgetSettings, error) {
 
 	var w *discord.GuildWidgetSettings
@@ -481,8 +603,11 @@ func (c *Client) ModifyGuildWidget(
 	)
 }
 
-// GuildWidget returns the widget for the guild.
-func (c *Client) GuildWidget(guildID discord.GuildID) (*discord.GuildWidget, error) {
+// GuildWidget returns the public widget for the guild, which includes its
+// online members and voice channels. Requires no permissions or
+// authentication, but fails with error code 50004 if the widget
+// is not enabled.
+func (c CtxClient) GuildWidget(guildID discord.GuildID) (*discord.GuildWidget, error) {
 	var w *discord.GuildWidget
 	return w, c.RequestJSON(
 		&w, "GET",
@@ -494,7 +619,7 @@ func (c *Client) GuildWidget(guildID discord.GuildID) (*discord.GuildWidget, err
 // url for the guild is not set.
 //
 // Requires MANAGE_GUILD.
-func (c *Client) GuildVanityInvite(guildID discord.GuildID) (*discord.Invite, error) {
+func (c CtxClient) GuildVanityInvite(guildID discord.GuildID) (*discord.Invite, error) {
 	var inv *discord.Invite
 	return inv, c.RequestJSON(&inv, "GET", EndpointGuilds+guildID.String()+"/vanity-url")
 }
@@ -531,16 +656,21 @@ const (
 	GuildBanner4 GuildWidgetImageStyle = "banner4"
 )
 
-// GuildWidgetImageURL returns a link to the PNG image widget for the guild.
+// GuildWidgetImageURL returns a link to the PNG image widget for the guild. If
+// img is empty, then Discord uses GuildShield.
 //
 // Requires no permissions or authentication.
-func (c *Client) GuildWidgetImageURL(guildID discord.GuildID, img GuildWidgetImageStyle) string {
-	return EndpointGuilds + guildID.String() + "/widget.png?style=" + string(img)
+func (c CtxClient) GuildWidgetImageURL(guildID discord.GuildID, img GuildWidgetImageStyle) string {
+	url := EndpointGuilds + guildID.String() + "/widget.png"
+	if img != "" {
+		url += "?style=" + string(img)
+	}
+	return url
 }
 
 // GuildWidgetImage returns a PNG image widget for the guild. Requires no permissions
 // or authentication.
-func (c *Client) GuildWidgetImage(
+func (c CtxClient) GuildWidgetImage(
 	guildID discord.GuildID, img GuildWidgetImageStyle) (io.ReadCloser, error) {
 
 	r, err := c.Request("GET", c.GuildWidgetImageURL(guildID, img))
@@ -550,3 +680,88 @@ func (c *Client) GuildWidgetImage(
 
 	return r.GetBody(), nil
 }
+
+// GuildOnboarding returns the onboarding flow of the guild.
+func (c CtxClient) GuildOnboarding(guildID discord.GuildID) (*discord.GuildOnboarding, error) {
+	var onboarding *discord.GuildOnboarding
+	return onboarding, c.RequestJSON(
+		&onboarding, "GET",
+		EndpointGuilds+guildID.String()+"/onboarding",
+	)
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-onboarding-json-params
+type ModifyGuildOnboardingData struct {
+	// Prompts are the prompts shown during onboarding and in Customize
+	// Community. The prompts replace all existing prompts.
+	Prompts []discord.OnboardingPrompt `json:"prompts"`
+	// DefaultChannelIDs are the channels that members get opted into
+	// automatically.
+	DefaultChannelIDs []discord.ChannelID `json:"default_channel_ids"`
+	// Enabled is whether onboarding is enabled in the guild.
+	Enabled bool `json:"enabled"`
+	// Mode is the criteria that is used to determine whether onboarding can
+	// be enabled.
+	Mode discord.OnboardingMode `json:"mode"`
+
+	AuditLogReason `json:"-"`
+}
+
+// ModifyGuildOnboarding modifies the onboarding flow of the guild. Onboarding
+// enforces constraints when enabled, such as requiring a minimum number of
+// default channels, which Discord will return an error for if unsatisfied.
+//
+// Requires the MANAGE_GUILD and MANAGE_ROLES permissions.
+//
+// Fires a Guild Audit Log Entry Create Gateway event.
+func (c CtxClient) ModifyGuildOnboarding(
+	guildID discord.GuildID, data ModifyGuildOnboardingData) (*discord.GuildOnboarding, error) {
+
+	var onboarding *discord.GuildOnboarding
+	return onboarding, c.RequestJSON(
+		&onboarding, "PUT",
+		EndpointGuilds+guildID.String()+"/onboarding",
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
+	)
+}
+
+// GuildWelcomeScreen returns the welcome screen of the guild.
+//
+// Requires the MANAGE_GUILD permission if the welcome screen is not enabled.
+func (c CtxClient) GuildWelcomeScreen(guildID discord.GuildID) (*discord.WelcomeScreen, error) {
+	var screen *discord.WelcomeScreen
+	return screen, c.RequestJSON(
+		&screen, "GET",
+		EndpointGuilds+guildID.String()+"/welcome-screen",
+	)
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-welcome-screen-json-params
+type ModifyGuildWelcomeScreenData struct {
+	// Enabled is whether the welcome screen is enabled.
+	Enabled option.Bool `json:"enabled,omitempty"`
+	// WelcomeChannels are the channels shown in the welcome screen and their
+	// display options.
+	WelcomeChannels *[]discord.WelcomeScreenChannel `json:"welcome_channels,omitempty"`
+	// Description is the guild description shown in the welcome screen.
+	Description option.NullableString `json:"description,omitempty"`
+
+	AuditLogReason `json:"-"`
+}
+
+// ModifyGuildWelcomeScreen modifies the welcome screen of the guild. All
+// fields are optional.
+//
+// Requires the MANAGE_GUILD permission.
+//
+// Fires a Guild Update Gateway event.
+func (c CtxClient) ModifyGuildWelcomeScreen(
+	guildID discord.GuildID, data ModifyGuildWelcomeScreenData) (*discord.WelcomeScreen, error) {
+
+	var screen *discord.WelcomeScreen
+	return screen, c.RequestJSON(
+		&screen, "PATCH",
+		EndpointGuilds+guildID.String()+"/welcome-screen",
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
+	)
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// EndpointGuildTemplates is the endpoint of templates looked up by their code.
+var EndpointGuildTemplates = EndpointGuilds + "templates/"
+
+// GuildTemplate returns the guild template with the given code.
+func (c CtxClient) GuildTemplate(code string) (*discord.GuildTemplate, error) {
+	var t *discord.GuildTemplate
+	return t, c.RequestJSON(&t, "GET", EndpointGuildTemplates+code)
+}
+
+// https://discord.com/developers/docs/resources/guild-template#create-guild-from-guild-template-json-params
+type CreateGuildFromTemplateData struct {
+	// Name is the name of the guild (2-100 characters).
+	Name string `json:"name"`
+	// Icon is the 128x128 image for the guild icon.
+	Icon *Image `json:"icon,omitempty"`
+}
+
+// CreateGuildFromTemplate creates a new guild based on the template with the
+// given code.
+//
+// This endpoint can be used only by bots in less than 10 guilds.
+func (c CtxClient) CreateGuildFromTemplate(
+	code string, data CreateGuildFromTemplateData) (*discord.Guild, error) {
+
+	var g *discord.Guild
+	return g, c.RequestJSON(
+		&g, "POST", EndpointGuildTemplates+code,
+		httputil.WithJSONBody(data),
+	)
+}
+
+// GuildTemplates returns the templates of the guild.
+//
+// Requires the MANAGE_GUILD permission.
+func (c CtxClient) GuildTemplates(guildID discord.GuildID) ([]discord.GuildTemplate, error) {
+	var t []discord.GuildTemplate
+	return t, c.RequestJSON(&t, "GET", EndpointGuilds+guildID.String()+"/templates")
+}
+
+// https://discord.com/developers/docs/resources/guild-template#create-guild-template-json-params
+type CreateGuildTemplateData struct {
+	// Name is the name of the template (1-100 characters).
+	Name string `json:"name"`
+	// Description is the description of the template (0-120 characters).
+	Description string `json:"description,omitempty"`
+}
+
+// CreateGuildTemplate creates a template for the guild.
+//
+// Requires the MANAGE_GUILD permission.
+func (c CtxClient) CreateGuildTemplate(
+	guildID discord.GuildID, data CreateGuildTemplateData) (*discord.GuildTemplate, error) {
+
+	var t *discord.GuildTemplate
+	return t, c.RequestJSON(
+		&t, "POST", EndpointGuilds+guildID.String()+"/templates",
+		httputil.WithJSONBody(data),
+	)
+}
+
+// SyncGuildTemplate syncs the template to the guild's current state.
+//
+// Requires the MANAGE_GUILD permission.
+func (c CtxClient) SyncGuildTemplate(
+	guildID discord.GuildID, code string) (*discord.GuildTemplate, error) {
+
+	var t *discord.GuildTemplate
+	return t, c.RequestJSON(
+		&t, "PUT", EndpointGuilds+guildID.String()+"/templates/"+code,
+	)
+}
+
+// https://discord.com/developers/docs/resources/guild-template#modify-guild-template-json-params
+type ModifyGuildTemplateData struct {
+	// Name is the name of the template (1-100 characters).
+	Name string `json:"name,omitempty"`
+	// Description is the description of the template (0-120 characters). A
+	// null value removes the description.
+	Description option.NullableString `json:"description,omitempty"`
+}
+
+// ModifyGuildTemplate modifies the template's metadata.
+//
+// Requires the MANAGE_GUILD permission.
+func (c CtxClient) ModifyGuildTemplate(
+	guildID discord.GuildID, code string,
+	data ModifyGuildTemplateData) (*discord.GuildTemplate, error) {
+
+	var t *discord.GuildTemplate
+	return t, c.RequestJSON(
+		&t, "PATCH", EndpointGuilds+guildID.String()+"/templates/"+code,
+		httputil.WithJSONBody(data),
+	)
+}
+
+// DeleteGuildTemplate deletes the template. It returns the deleted template.
+//
+// Requires the MANAGE_GUILD permission.
+func (c CtxClient) DeleteGuildTemplate(
+	guildID discord.GuildID, code string) (*discord.GuildTemplate, error) {
+
+	var t *discord.GuildTemplate
+	return t, c.RequestJSON(
+		&t, "DELETE", EndpointGuilds+guildID.String()+"/templates/"+code,
+	)
+}
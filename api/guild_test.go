@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestAuditLogIter(t *testing.T) {
+	const n = 230
+
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, q)
+
+		before, _ := strconv.Atoi(q.Get("before"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		if before == 0 {
+			before = n + 1
+		}
+
+		log := discord.AuditLog{Users: []discord.User{{ID: discord.UserID(before)}}}
+		for id := before - 1; id > 0 && len(log.Entries) < limit; id-- {
+			log.Entries = append(log.Entries, discord.AuditLogEntry{
+				ID: discord.AuditLogEntryID(id),
+			})
+		}
+
+		json.EncodeStream(w, log)
+	}))
+	defer srv.Close()
+
+	oldEndpoint := EndpointGuilds
+	EndpointGuilds = srv.URL + Path + "/guilds/"
+	defer func() { EndpointGuilds = oldEndpoint }()
+
+	it := NewClient("no. 3-chan").AuditLogIter(1, AuditLogData{
+		UserID: 5,
+		Before: 221,
+		Limit:  10, // ignored
+	})
+
+	var ids []discord.AuditLogEntryID
+	for it.Next() {
+		ids = append(ids, it.Entry().ID)
+
+		// The page of the current entry is kept along with it.
+		before := queries[len(queries)-1].Get("before")
+		if users := it.AuditLog().Users; len(users) != 1 || users[0].ID.String() != before {
+			t.Fatalf("expected the users of the page before %s, got %v", before, users)
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal("failed to iterate:", err)
+	}
+
+	if len(ids) != 220 || ids[0] != 220 || ids[len(ids)-1] != 1 {
+		t.Fatalf("expected entries 220 to 1, got %d entries", len(ids))
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] != ids[i-1]-1 {
+			t.Fatalf("expected entry %d at %d, got %d", ids[i-1]-1, i, ids[i])
+		}
+	}
+
+	// The short third page ends the iteration.
+	befores := make([]string, len(queries))
+	for i, q := range queries {
+		befores[i] = q.Get("before")
+		if q.Get("limit") != "100" || q.Get("user_id") != "5" {
+			t.Fatalf("unexpected query %v", q)
+		}
+	}
+	expectStrings(t, "befores", befores, []string{"221", "121", "21"})
+}
+
+func TestAuditLogIterError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	oldEndpoint := EndpointGuilds
+	EndpointGuilds = srv.URL + Path + "/guilds/"
+	defer func() { EndpointGuilds = oldEndpoint }()
+
+	client := NewClient("no. 3-chan")
+	client.Retries = 1
+
+	it := client.AuditLogIter(1, AuditLogData{})
+	if it.Next() {
+		t.Fatal("unexpected entry")
+	}
+	if it.Err() == nil {
+		t.Fatal("expected an error")
+	}
+}
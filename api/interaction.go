@@ -43,6 +43,29 @@ type InteractionResponse struct {
 	Data *InteractionResponseData `json:"data,omitempty"`
 }
 
+// NewModalResponse creates a response that shows a modal with the given
+// custom ID and title. Each of the given components, usually
+// TextInputComponents, is put in its own row. Modals cannot be shown in
+// response to a modal submission.
+func NewModalResponse(
+	id discord.ComponentID, title string,
+	components ...discord.InteractiveComponent) *InteractionResponse {
+
+	rows := make(discord.ContainerComponents, len(components))
+	for i, component := range components {
+		rows[i] = &discord.ActionRowComponent{component}
+	}
+
+	return &InteractionResponse{
+		Type: ModalResponse,
+		Data: &InteractionResponseData{
+			CustomID:   option.NewNullableString(string(id)),
+			Title:      option.NewNullableString(title),
+			Components: &rows,
+		},
+	}
+}
+
 // NeedsMultipart returns true if the InteractionResponse has files.
 func (resp InteractionResponse) NeedsMultipart() bool {
 	return resp.Data != nil && resp.Data.NeedsMultipart()
@@ -73,6 +96,8 @@ type InteractionResponseData struct {
 	// Flags are the interaction application command callback data flags.
 	// Only SuppressEmbeds and EphemeralMessage may be set.
 	Flags discord.MessageFlags `json:"flags,omitempty"`
+	// Poll is the poll to attach to the message.
+	Poll *CreatePollData `json:"poll,omitempty"`
 
 	// Files represents a list of files to upload. This will not be
 	// JSON-encoded and will only be available through WriteMultipart.
@@ -130,7 +155,7 @@ func (c AutocompleteNumberChoices) choices() {}
 
 // RespondInteraction responds to an incoming interaction. It is also known as
 // an "interaction callback".
-func (c *Client) RespondInteraction(
+func (c CtxClient) RespondInteraction(
 	id discord.InteractionID, token string, resp InteractionResponse) error {
 
 	if resp.Data != nil {
@@ -153,40 +178,21 @@ func (c *Client) RespondInteraction(
 			}
 		}
 
-		if resp.Data.AllowedMentions != nil {
-			if err := resp.Data.AllowedMentions.Verify(); err != nil {
-				return fmt.Errorf("allowedMentions error: %w", err)
-			}
-		}
-
-		if resp.Data.Embeds != nil {
-			sum := 0
-			for i, embed := range *resp.Data.Embeds {
-				if err := embed.Validate(); err != nil {
-					return fmt.Errorf("embed error at %d: %w", i, err)
-				}
-				sum += embed.Length()
-				if sum > 6000 {
-					return &discord.OverboundError{Count: sum, Max: 6000, Thing: "sum of all text in embeds"}
-				}
-
-				(*resp.Data.Embeds)[i] = embed // embed.Validate changes fields
-			}
+		if err := verifyInteractionMessage(resp.Data.AllowedMentions, resp.Data.Embeds); err != nil {
+			return err
 		}
 	}
 
 	URL := EndpointInteractions + id.String() + "/" + token + "/callback"
-	return sendpart.POST(c.Client, resp, nil, URL)
+	return c.sendpart("POST", resp, nil, URL)
 }
 
 // InteractionResponse returns the initial interaction response.
-func (c *Client) InteractionResponse(
+func (c CtxClient) InteractionResponse(
 	appID discord.AppID, token string) (*discord.Message, error) {
 
 	var m *discord.Message
-	return m, c.RequestJSON(
-		&m, "GET",
-		EndpointWebhooks+appID.String()+"/"+token+"/messages/@original")
+	return m, c.RequestJSON(&m, "GET", interactionMessageURL(appID, token, 0))
 }
 
 type EditInteractionResponseData struct {
@@ -216,120 +222,134 @@ func (data EditInteractionResponseData) WriteMultipart(body *multipart.Writer) e
 }
 
 // EditInteractionResponse edits the initial Interaction response.
-func (c *Client) EditInteractionResponse(
+func (c CtxClient) EditInteractionResponse(
 	appID discord.AppID,
 	token string, data EditInteractionResponseData) (*discord.Message, error) {
 
-	if data.AllowedMentions != nil {
-		if err := data.AllowedMentions.Verify(); err != nil {
-			return nil, fmt.Errorf("allowedMentions error: %w", err)
-		}
-	}
-
-	if data.Embeds != nil {
-		sum := 0
-		for i, e := range *data.Embeds {
-			if err := e.Validate(); err != nil {
-				return nil, fmt.Errorf("embed error: %w", err)
-			}
-			sum += e.Length()
-			if sum > 6000 {
-				return nil, &discord.OverboundError{Count: sum, Max: 6000, Thing: "sum of text in embeds"}
-			}
-
-			(*data.Embeds)[i] = e // e.Validate changes fields
-		}
-	}
-
-	var msg *discord.Message
-	return msg, sendpart.PATCH(c.Client, data, &msg,
-		EndpointWebhooks+appID.String()+"/"+token+"/messages/@original")
+	return c.editInteractionMessage(appID, 0, token, data)
 }
 
 // DeleteInteractionResponse deletes the initial interaction response.
-func (c *Client) DeleteInteractionResponse(appID discord.AppID, token string) error {
-	return c.FastRequest("DELETE",
-		EndpointWebhooks+appID.String()+"/"+token+"/messages/@original")
+func (c CtxClient) DeleteInteractionResponse(appID discord.AppID, token string) error {
+	return c.deleteInteractionMessage(appID, 0, token)
 }
 
 // CreateInteractionFollowup creates a followup message for an interaction.
 //
 // Deprecated: use FollowUpInteraction instead.
-func (c *Client) CreateInteractionFollowup(
+func (c CtxClient) CreateInteractionFollowup(
 	appID discord.AppID, token string, data InteractionResponseData) (*discord.Message, error) {
 
 	return c.FollowUpInteraction(appID, token, data)
 }
 
-// FollowUpInteraction creates a followup message for an interaction.
-func (c *Client) FollowUpInteraction(
+// FollowUpInteraction creates a followup message for an interaction. Followup
+// messages can be sent for 15 minutes after the interaction was received, and
+// can be made ephemeral by setting the EphemeralMessage flag. The flags of the
+// first followup to a deferred response are ignored, since it edits the
+// original response instead.
+//
+// Like all followup methods, the message is looked up using the interaction
+// token, which is valid for 15 minutes, rather than the bot's permissions.
+func (c CtxClient) FollowUpInteraction(
 	appID discord.AppID, token string, data InteractionResponseData) (*discord.Message, error) {
 
 	if (data.Content == nil || data.Content.Val == "") &&
-		(data.Embeds == nil || len(*data.Embeds) == 0) && len(data.Files) == 0 {
+		(data.Embeds == nil || len(*data.Embeds) == 0) &&
+		len(data.Files) == 0 && data.Poll == nil &&
+		!data.Flags.Has(discord.ComponentsV2) {
 		return nil, ErrEmptyMessage
 	}
 
-	if data.AllowedMentions != nil {
-		if err := data.AllowedMentions.Verify(); err != nil {
-			return nil, fmt.Errorf("allowedMentions error: %w", err)
-		}
+	if err := verifyInteractionMessage(data.AllowedMentions, data.Embeds); err != nil {
+		return nil, err
 	}
 
-	if data.Embeds != nil {
-		sum := 0
-		for i, embed := range *data.Embeds {
-			if err := embed.Validate(); err != nil {
-				return nil, fmt.Errorf("embed error at %d: %w", i, err)
-			}
-			sum += embed.Length()
-			if sum > 6000 {
-				return nil, &discord.OverboundError{Count: sum, Max: 6000, Thing: "sum of all text in embeds"}
-			}
+	var msg *discord.Message
+	return msg, c.sendpart(
+		"POST", data, &msg, EndpointWebhooks+appID.String()+"/"+token+"?")
+}
 
-			(*data.Embeds)[i] = embed // embed.Validate changes fields
-		}
-	}
+// InteractionFollowup returns a followup message for an interaction.
+func (c CtxClient) InteractionFollowup(
+	appID discord.AppID, messageID discord.MessageID,
+	token string) (*discord.Message, error) {
 
-	var msg *discord.Message
-	return msg, sendpart.POST(
-		c.Client, data, &msg, EndpointWebhooks+appID.String()+"/"+token+"?")
+	var m *discord.Message
+	return m, c.RequestJSON(
+		&m, "GET", interactionMessageURL(appID, token, messageID))
 }
 
-func (c *Client) EditInteractionFollowup(
+// EditInteractionFollowup edits a followup message for an interaction.
+// Ephemeral followups can be edited as well.
+func (c CtxClient) EditInteractionFollowup(
 	appID discord.AppID, messageID discord.MessageID,
 	token string, data EditInteractionResponseData) (*discord.Message, error) {
 
-	if data.AllowedMentions != nil {
-		if err := data.AllowedMentions.Verify(); err != nil {
-			return nil, fmt.Errorf("allowedMentions error: %w", err)
-		}
-	}
+	return c.editInteractionMessage(appID, messageID, token, data)
+}
 
-	if data.Embeds != nil {
-		sum := 0
-		for i, e := range *data.Embeds {
-			if err := e.Validate(); err != nil {
-				return nil, fmt.Errorf("embed error: %w", err)
-			}
-			sum += e.Length()
-			if sum > 6000 {
-				return nil, &discord.OverboundError{Count: sum, Max: 6000, Thing: "sum of text in embeds"}
-			}
+// DeleteInteractionFollowup deletes a followup message for an interaction.
+func (c CtxClient) DeleteInteractionFollowup(
+	appID discord.AppID, messageID discord.MessageID, token string) error {
 
-			(*data.Embeds)[i] = e // e.Validate changes fields
-		}
+	return c.deleteInteractionMessage(appID, messageID, token)
+}
+
+func (c CtxClient) editInteractionMessage(
+	appID discord.AppID, messageID discord.MessageID,
+	token string, data EditInteractionResponseData) (*discord.Message, error) {
+
+	if err := verifyInteractionMessage(data.AllowedMentions, data.Embeds); err != nil {
+		return nil, err
 	}
 
 	var msg *discord.Message
-	return msg, sendpart.PATCH(c.Client, data, &msg,
-		EndpointWebhooks+appID.String()+"/"+token+"/messages/"+messageID.String())
+	return msg, c.sendpart("PATCH", data, &msg,
+		interactionMessageURL(appID, token, messageID))
 }
 
-// DeleteInteractionFollowup deletes a followup message for an interaction.
-func (c *Client) DeleteInteractionFollowup(
+func (c CtxClient) deleteInteractionMessage(
 	appID discord.AppID, messageID discord.MessageID, token string) error {
 
-	return c.FastRequest("DELETE",
-		EndpointWebhooks+appID.String()+"/"+token+"/messages/"+messageID.String())
+	return c.FastRequest("DELETE", interactionMessageURL(appID, token, messageID))
+}
+
+// interactionMessageURL returns the URL of the interaction message with the
+// given ID, or of the initial response if the ID is 0.
+func interactionMessageURL(appID discord.AppID, token string, messageID discord.MessageID) string {
+	id := "@original"
+	if messageID.IsValid() {
+		id = messageID.String()
+	}
+	return EndpointWebhooks + appID.String() + "/" + token + "/messages/" + id
+}
+
+// verifyInteractionMessage verifies the allowed mentions and embeds of an
+// interaction message. The embeds are changed by their Validate method.
+func verifyInteractionMessage(am *AllowedMentions, embeds *[]discord.Embed) error {
+	if am != nil {
+		if err := am.Verify(); err != nil {
+			return fmt.Errorf("allowedMentions error: %w", err)
+		}
+	}
+
+	if embeds == nil {
+		return nil
+	}
+
+	sum := 0
+	for i, embed := range *embeds {
+		if err := embed.Validate(); err != nil {
+			return fmt.Errorf("embed error at %d: %w", i, err)
+		}
+		sum += embed.Length()
+		if sum > discord.MaxEmbedsLength {
+			return &discord.OverboundError{Count: sum, Max: discord.MaxEmbedsLength, Thing: "sum of all text in embeds"}
+		}
+
+		(*embeds)[i] = embed // embed.Validate changes fields
+	}
+
+	return nil
 }
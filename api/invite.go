@@ -11,7 +11,7 @@ var EndpointInvites = Endpoint + "invites/"
 // Invite returns an invite object for the given code.
 //
 // ApproxMembers will not get filled.
-func (c *Client) Invite(code string) (*discord.Invite, error) {
+func (c CtxClient) Invite(code string) (*discord.Invite, error) {
 	var inv *discord.Invite
 	return inv, c.RequestJSON(
 		&inv, "GET",
@@ -21,7 +21,7 @@ func (c *Client) Invite(code string) (*discord.Invite, error) {
 
 // InviteWithCounts returns an invite object for the given code and fills
 // ApproxMembers.
-func (c *Client) InviteWithCounts(code string) (*discord.Invite, error) {
+func (c CtxClient) InviteWithCounts(code string) (*discord.Invite, error) {
 	var params struct {
 		WithCounts bool `schema:"with_counts,omitempty"`
 	}
@@ -40,7 +40,7 @@ func (c *Client) InviteWithCounts(code string) (*discord.Invite, error) {
 // the channel. Only usable for guild channels.
 //
 // Requires the MANAGE_CHANNELS permission.
-func (c *Client) ChannelInvites(channelID discord.ChannelID) ([]discord.Invite, error) {
+func (c CtxClient) ChannelInvites(channelID discord.ChannelID) ([]discord.Invite, error) {
 	var invs []discord.Invite
 	return invs, c.RequestJSON(&invs, "GET",
 		EndpointChannels+channelID.String()+"/invites")
@@ -50,7 +50,7 @@ func (c *Client) ChannelInvites(channelID discord.ChannelID) ([]discord.Invite,
 // guild.
 //
 // Requires the MANAGE_GUILD permission.
-func (c *Client) GuildInvites(guildID discord.GuildID) ([]discord.Invite, error) {
+func (c CtxClient) GuildInvites(guildID discord.GuildID) ([]discord.Invite, error) {
 	var invs []discord.Invite
 	return invs, c.RequestJSON(&invs, "GET",
 		EndpointGuilds+guildID.String()+"/invites")
@@ -77,6 +77,18 @@ type CreateInviteData struct {
 	// Default:	false
 	Unique bool `json:"unique,omitempty"`
 
+	// TargetType is the type of target for this voice channel invite.
+	TargetType discord.InviteTargetType `json:"target_type,omitempty"`
+	// TargetUserID is the ID of the user whose stream to display for this
+	// invite. It is required if TargetType is InviteTargetStream, and the user
+	// must be streaming in the channel.
+	TargetUserID discord.UserID `json:"target_user_id,omitempty"`
+	// TargetApplicationID is the ID of the embedded application to open for
+	// this invite. It is required if TargetType is
+	// InviteTargetEmbeddedApplication, and the application must have the
+	// AppFlagEmbedded flag.
+	TargetApplicationID discord.AppID `json:"target_application_id,omitempty"`
+
 	AuditLogReason `json:"-"`
 }
 
@@ -84,7 +96,7 @@ type CreateInviteData struct {
 // guild channels.
 //
 // Requires the CREATE_INSTANT_INVITE permission.
-func (c *Client) CreateInvite(
+func (c CtxClient) CreateInvite(
 	channelID discord.ChannelID, data CreateInviteData) (*discord.Invite, error) {
 
 	var inv *discord.Invite
@@ -105,7 +117,7 @@ type JoinedInvite struct {
 
 // JoinInvite joins a guild using the given invite code. This endpoint is
 // undocumented.
-func (c *Client) JoinInvite(code string) (*JoinedInvite, error) {
+func (c CtxClient) JoinInvite(code string) (*JoinedInvite, error) {
 	var inv *JoinedInvite
 	return inv, c.RequestJSON(&inv, "POST", EndpointInvites+code)
 }
@@ -116,7 +128,7 @@ func (c *Client) JoinInvite(code string) (*JoinedInvite, error) {
 // to, or MANAGE_GUILD to remove any invite across the guild.
 //
 // Fires an Invite Delete Gateway event.
-func (c *Client) DeleteInvite(code string, reason AuditLogReason) (*discord.Invite, error) {
+func (c CtxClient) DeleteInvite(code string, reason AuditLogReason) (*discord.Invite, error) {
 	var inv *discord.Invite
 	return inv, c.RequestJSON(
 		&inv,
@@ -15,7 +15,7 @@ type LoginResponse struct {
 	Token  string `json:"token"`
 }
 
-func (c *Client) Login(email, password string) (*LoginResponse, error) {
+func (c CtxClient) Login(email, password string) (*LoginResponse, error) {
 	var param struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
@@ -27,7 +27,7 @@ func (c *Client) Login(email, password string) (*LoginResponse, error) {
 	return r, c.RequestJSON(&r, "POST", EndpointLogin, httputil.WithJSONBody(param))
 }
 
-func (c *Client) TOTP(code, ticket string) (*LoginResponse, error) {
+func (c CtxClient) TOTP(code, ticket string) (*LoginResponse, error) {
 	var param struct {
 		Code   string `json:"code"`
 		Ticket string `json:"ticket"`
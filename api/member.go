@@ -1,6 +1,10 @@
 package api
 
 import (
+	"net/url"
+	"strconv"
+	"strings"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/internal/intmath"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
@@ -9,8 +13,11 @@ import (
 
 const MaxMemberFetchLimit = 1000
 
+// MaxBanFetchLimit is the limit of max bans per request, as imposed by Discord.
+const MaxBanFetchLimit = 1000
+
 // Member returns a guild member object for the specified user.
-func (c *Client) Member(guildID discord.GuildID, userID discord.UserID) (*discord.Member, error) {
+func (c CtxClient) Member(guildID discord.GuildID, userID discord.UserID) (*discord.Member, error) {
 	var m *discord.Member
 	return m, c.RequestJSON(&m, "GET", EndpointGuilds+guildID.String()+"/members/"+userID.String())
 }
@@ -24,7 +31,7 @@ func (c *Client) Member(guildID discord.GuildID, userID discord.UserID) (*discor
 // they may be less if no more members are available.
 //
 // When fetching the members, those with the smallest ID will be fetched first.
-func (c *Client) Members(guildID discord.GuildID, limit uint) ([]discord.Member, error) {
+func (c CtxClient) Members(guildID discord.GuildID, limit uint) ([]discord.Member, error) {
 	return c.MembersAfter(guildID, 0, limit)
 }
 
@@ -35,7 +42,7 @@ func (c *Client) Members(guildID discord.GuildID, limit uint) ([]discord.Member,
 // As the underlying endpoint has a maximum of 1000 members per request, at
 // maximum a total of limit/1000 rounded up requests will be made, although
 // they may be less, if no more members are available.
-func (c *Client) MembersAfter(
+func (c CtxClient) MembersAfter(
 	guildID discord.GuildID, after discord.UserID, limit uint) ([]discord.Member, error) {
 
 	mems := make([]discord.Member, 0, limit)
@@ -73,7 +80,7 @@ func (c *Client) MembersAfter(
 	return mems, nil
 }
 
-func (c *Client) membersAfter(
+func (c CtxClient) membersAfter(
 	guildID discord.GuildID, after discord.UserID, limit uint) ([]discord.Member, error) {
 
 	switch {
@@ -99,6 +106,77 @@ func (c *Client) membersAfter(
 	)
 }
 
+// MembersIterator iterates over the members of a guild in ascending order of
+// their IDs, fetching a page of members only once the previous page has been
+// consumed. It is created using MembersIter.
+//
+// Listing members requires the GUILD_MEMBERS intent.
+type MembersIterator struct {
+	client  CtxClient
+	guildID discord.GuildID
+
+	after  discord.UserID
+	page   []discord.Member
+	member discord.Member
+	done   bool
+	err    error
+}
+
+// MembersIter returns an iterator over the members of the guild.
+func (c CtxClient) MembersIter(guildID discord.GuildID) *MembersIterator {
+	return &MembersIterator{
+		client:  c,
+		guildID: guildID,
+	}
+}
+
+// After makes the iterator start after the given user ID. It must be called
+// before the first call to Next.
+func (it *MembersIterator) After(after discord.UserID) *MembersIterator {
+	it.after = after
+	return it
+}
+
+// Next advances the iterator to the next member, fetching the next page if
+// needed. It returns false once there are no more members or an error occurs,
+// which is then returned by Err.
+func (it *MembersIterator) Next() bool {
+	if len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		it.page, it.err = it.client.membersAfter(it.guildID, it.after, MaxMemberFetchLimit)
+		if it.err != nil {
+			return false
+		}
+
+		if len(it.page) < MaxMemberFetchLimit {
+			it.done = true
+		}
+		if len(it.page) == 0 {
+			return false
+		}
+
+		it.after = it.page[len(it.page)-1].User.ID
+	}
+
+	it.member = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Member returns the current member. It is only valid after Next returns
+// true.
+func (it *MembersIterator) Member() discord.Member {
+	return it.member
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *MembersIterator) Err() error {
+	return it.err
+}
+
 // https://discord.com/developers/docs/resources/guild#add-guild-member-json-params
 type AddMemberData struct {
 	// Token is an oauth2 access token granted with the guilds.join to the
@@ -132,7 +210,7 @@ type AddMemberData struct {
 // The Authorization header must be a Bot token (belonging to the same
 // application used for authorization), and the bot must be a member of the
 // guild with CREATE_INSTANT_INVITE permission.
-func (c *Client) AddMember(
+func (c CtxClient) AddMember(
 	guildID discord.GuildID, userID discord.UserID, data AddMemberData) (*discord.Member, error) {
 
 	var mem *discord.Member
@@ -173,6 +251,13 @@ type ModifyMemberData struct {
 	// Requires MODERATE_MEMBERS
 	CommunicationDisabledUntil *discord.Timestamp `json:"communication_disabled_until,omitempty"`
 
+	// Flags are the member's flags. Only MemberFlagsBypassesVerification can
+	// be set, so a pointer to 0 clears it.
+	//
+	// Requires MANAGE_GUILD, MANAGE_ROLES or (MODERATE_MEMBERS and
+	// KICK_MEMBERS and BAN_MEMBERS).
+	Flags *discord.MemberFlags `json:"flags,omitempty"`
+
 	AuditLogReason `json:"-"`
 }
 
@@ -180,7 +265,7 @@ type ModifyMemberData struct {
 // to null, this will force the target user to be disconnected from voice.
 //
 // Fires a Guild Member Update Gateway event.
-func (c *Client) ModifyMember(
+func (c CtxClient) ModifyMember(
 	guildID discord.GuildID, userID discord.UserID, data ModifyMemberData) error {
 
 	return c.FastRequest(
@@ -190,9 +275,66 @@ func (c *Client) ModifyMember(
 	)
 }
 
+// SetMemberBypassesVerification sets whether the member is exempt from the
+// guild's verification requirements. Since it is the only member flag that can
+// be modified, the member's other flags are left as-is.
+//
+// Requires MANAGE_GUILD, MANAGE_ROLES or (MODERATE_MEMBERS and KICK_MEMBERS and
+// BAN_MEMBERS).
+//
+// Fires a Guild Member Update Gateway event.
+func (c CtxClient) SetMemberBypassesVerification(
+	guildID discord.GuildID, userID discord.UserID,
+	bypass bool, reason AuditLogReason) error {
+
+	var flags discord.MemberFlags
+	if bypass {
+		flags = discord.MemberFlagsBypassesVerification
+	}
+
+	return c.ModifyMember(guildID, userID, ModifyMemberData{
+		Flags:          &flags,
+		AuditLogReason: reason,
+	})
+}
+
+// MaxMemberSearchLimit is the maximum number of members that can be returned
+// by SearchGuildMembers.
+const MaxMemberSearchLimit = 1000
+
+// SearchGuildMembers returns the members in the guild whose username or
+// nickname starts with the query, which is case-insensitive. At most limit
+// members are returned, which is capped at MaxMemberSearchLimit. If limit is 0,
+// then only 1 member is returned.
+//
+// Unlike Members, this doesn't require the GUILD_MEMBERS privileged intent,
+// which makes it useful for finding members by name.
+func (c CtxClient) SearchGuildMembers(
+	guildID discord.GuildID, query string, limit uint) ([]discord.Member, error) {
+
+	if limit > MaxMemberSearchLimit {
+		limit = MaxMemberSearchLimit
+	}
+
+	var param struct {
+		Query string `schema:"query"`
+		Limit uint   `schema:"limit,omitempty"`
+	}
+
+	param.Query = query
+	param.Limit = limit
+
+	var mems []discord.Member
+	return mems, c.RequestJSON(
+		&mems, "GET",
+		EndpointGuilds+guildID.String()+"/members/search",
+		httputil.WithSchema(c, param),
+	)
+}
+
 // https://discord.com/developers/docs/resources/guild#get-guild-prune-count-query-string-params
 type PruneCountData struct {
-	// Days is the number of days to count prune for (1 or more, default 7).
+	// Days is the number of days to count prune for (1-30, default 7).
 	Days uint `schema:"days"`
 	// IncludedRoles are the role(s) to include.
 	IncludedRoles []discord.RoleID `schema:"include_roles,omitempty"`
@@ -207,11 +349,24 @@ type PruneCountData struct {
 // will be counted in the prune and users with additional roles will not.
 //
 // Requires KICK_MEMBERS.
-func (c *Client) PruneCount(guildID discord.GuildID, data PruneCountData) (uint, error) {
+func (c CtxClient) PruneCount(guildID discord.GuildID, data PruneCountData) (uint, error) {
 	if data.Days == 0 {
 		data.Days = 7
 	}
 
+	// The roles are sent as a comma-separated list rather than a repeated
+	// parameter.
+	params := url.Values{
+		"days": {strconv.FormatUint(uint64(data.Days), 10)},
+	}
+	if len(data.IncludedRoles) > 0 {
+		roles := make([]string, len(data.IncludedRoles))
+		for i, id := range data.IncludedRoles {
+			roles[i] = id.String()
+		}
+		params.Set("include_roles", strings.Join(roles, ","))
+	}
+
 	var resp struct {
 		Pruned uint `json:"pruned"`
 	}
@@ -219,24 +374,26 @@ func (c *Client) PruneCount(guildID discord.GuildID, data PruneCountData) (uint,
 	return resp.Pruned, c.RequestJSON(
 		&resp, "GET",
 		EndpointGuilds+guildID.String()+"/prune",
-		httputil.WithSchema(c, data),
+		httputil.WithSchema(c, params),
 	)
 }
 
-// https://discord.com/developers/docs/resources/guild#begin-guild-prune-query-string-params
+// https://discord.com/developers/docs/resources/guild#begin-guild-prune-json-params
 type PruneData struct {
-	// Days is the number of days to prune (1 or more, default 7).
-	Days uint `schema:"days"`
+	// Days is the number of days to prune (1-30, default 7).
+	Days uint `json:"days"`
 	// ReturnCount specifies whether 'pruned' is returned. Discouraged for
-	// large guilds.
-	ReturnCount bool `schema:"compute_prune_count"`
+	// large guilds, where the prune is done in the background if this is
+	// false.
+	ReturnCount bool `json:"compute_prune_count"`
 	// IncludedRoles are the role(s) to include.
-	IncludedRoles []discord.RoleID `schema:"include_roles,omitempty"`
+	IncludedRoles []discord.RoleID `json:"include_roles,omitempty"`
 
-	AuditLogReason `schema:"-"`
+	AuditLogReason `json:"-"`
 }
 
-// Prune begins a prune. Days must be 1 or more, default 7.
+// Prune begins a prune. Days must be 1 or more, default 7. The number of
+// pruned members is only returned if ReturnCount is true, and is 0 otherwise.
 //
 // By default, prune will not remove users with roles. You can optionally
 // include specific roles in your prune by providing the IncludedRoles
@@ -246,7 +403,7 @@ type PruneData struct {
 // Requires KICK_MEMBERS.
 //
 // Fires multiple Guild Member Remove Gateway events.
-func (c *Client) Prune(guildID discord.GuildID, data PruneData) (uint, error) {
+func (c CtxClient) Prune(guildID discord.GuildID, data PruneData) (uint, error) {
 	if data.Days == 0 {
 		data.Days = 7
 	}
@@ -258,7 +415,7 @@ func (c *Client) Prune(guildID discord.GuildID, data PruneData) (uint, error) {
 	return resp.Pruned, c.RequestJSON(
 		&resp, "POST",
 		EndpointGuilds+guildID.String()+"/prune",
-		httputil.WithSchema(c, data), httputil.WithHeaders(data.Header()),
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
 	)
 }
 
@@ -267,7 +424,7 @@ func (c *Client) Prune(guildID discord.GuildID, data PruneData) (uint, error) {
 // Requires KICK_MEMBERS permission.
 //
 // Fires a Guild Member Remove Gateway event.
-func (c *Client) Kick(
+func (c CtxClient) Kick(
 	guildID discord.GuildID, userID discord.UserID, reason AuditLogReason) error {
 
 	return c.FastRequest(
@@ -277,10 +434,11 @@ func (c *Client) Kick(
 	)
 }
 
-// Bans returns a list of ban objects for the users banned from this guild.
+// Bans returns a list of ban objects for the users banned from this guild. At
+// most MaxBanFetchLimit bans are returned; use BansIter to get all of them.
 //
 // Requires the BAN_MEMBERS permission.
-func (c *Client) Bans(guildID discord.GuildID) ([]discord.Ban, error) {
+func (c CtxClient) Bans(guildID discord.GuildID) ([]discord.Ban, error) {
 	var bans []discord.Ban
 	return bans, c.RequestJSON(
 		&bans, "GET",
@@ -288,10 +446,100 @@ func (c *Client) Bans(guildID discord.GuildID) ([]discord.Ban, error) {
 	)
 }
 
+// BansIterator iterates over the bans of a guild in ascending order of the
+// banned users' IDs, fetching a page of bans only once the previous page has
+// been consumed. It is created using BansIter.
+type BansIterator struct {
+	client  CtxClient
+	guildID discord.GuildID
+
+	after discord.UserID
+	page  []discord.Ban
+	ban   discord.Ban
+	done  bool
+	err   error
+}
+
+// BansIter returns an iterator over the bans of the guild. Unlike Bans, it
+// returns all bans, even if there are more than MaxBanFetchLimit.
+//
+// Requires the BAN_MEMBERS permission.
+func (c CtxClient) BansIter(guildID discord.GuildID) *BansIterator {
+	return &BansIterator{
+		client:  c,
+		guildID: guildID,
+	}
+}
+
+// After makes the iterator start after the given user ID. It must be called
+// before the first call to Next.
+func (it *BansIterator) After(after discord.UserID) *BansIterator {
+	it.after = after
+	return it
+}
+
+// Next advances the iterator to the next ban, fetching the next page if
+// needed. It returns false once there are no more bans or an error occurs,
+// which is then returned by Err.
+func (it *BansIterator) Next() bool {
+	if len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		it.page, it.err = it.client.bansAfter(it.guildID, it.after, MaxBanFetchLimit)
+		if it.err != nil {
+			return false
+		}
+
+		if len(it.page) < MaxBanFetchLimit {
+			it.done = true
+		}
+		if len(it.page) == 0 {
+			return false
+		}
+
+		it.after = it.page[len(it.page)-1].User.ID
+	}
+
+	it.ban = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Ban returns the current ban. It is only valid after Next returns true.
+func (it *BansIterator) Ban() discord.Ban {
+	return it.ban
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *BansIterator) Err() error {
+	return it.err
+}
+
+func (c CtxClient) bansAfter(
+	guildID discord.GuildID, after discord.UserID, limit uint) ([]discord.Ban, error) {
+
+	var param struct {
+		After discord.UserID `schema:"after,omitempty"`
+		Limit uint           `schema:"limit"`
+	}
+
+	param.After = after
+	param.Limit = limit
+
+	var bans []discord.Ban
+	return bans, c.RequestJSON(
+		&bans, "GET",
+		EndpointGuilds+guildID.String()+"/bans",
+		httputil.WithSchema(c, param),
+	)
+}
+
 // GetBan returns a ban object for the given user.
 //
 // Requires the BAN_MEMBERS permission.
-func (c *Client) GetBan(guildID discord.GuildID, userID discord.UserID) (*discord.Ban, error) {
+func (c CtxClient) GetBan(guildID discord.GuildID, userID discord.UserID) (*discord.Ban, error) {
 	var ban *discord.Ban
 	return ban, c.RequestJSON(
 		&ban, "GET",
@@ -313,7 +561,7 @@ type BanData struct {
 // Requires the BAN_MEMBERS permission.
 //
 // Fires a Guild Ban Add Gateway event.
-func (c *Client) Ban(guildID discord.GuildID, userID discord.UserID, data BanData) error {
+func (c CtxClient) Ban(guildID discord.GuildID, userID discord.UserID, data BanData) error {
 	return c.FastRequest(
 		"PUT",
 		EndpointGuilds+guildID.String()+"/bans/"+userID.String(),
@@ -326,7 +574,7 @@ func (c *Client) Ban(guildID discord.GuildID, userID discord.UserID, data BanDat
 // Requires the BAN_MEMBERS permissions.
 //
 // Fires a Guild Ban Remove Gateway event.
-func (c *Client) Unban(
+func (c CtxClient) Unban(
 	guildID discord.GuildID, userID discord.UserID, reason AuditLogReason) error {
 
 	return c.FastRequest(
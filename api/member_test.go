@@ -0,0 +1,189 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// newGuildUserServer serves users with IDs from 1 to n as both the members
+// and the bans of any guild, paginated like Discord does. It returns the after
+// parameter of each request it receives.
+func newGuildUserServer(t *testing.T, n int) func() []string {
+	var mu sync.Mutex
+	var afters []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		mu.Lock()
+		afters = append(afters, q.Get("after"))
+		mu.Unlock()
+
+		after, _ := strconv.Atoi(q.Get("after"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		var users []discord.User
+		for id := after + 1; id <= n && len(users) < limit; id++ {
+			users = append(users, discord.User{ID: discord.UserID(id)})
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/bans") {
+			bans := make([]discord.Ban, len(users))
+			for i, user := range users {
+				bans[i] = discord.Ban{User: user}
+			}
+			json.EncodeStream(w, bans)
+		} else {
+			members := make([]discord.Member, len(users))
+			for i, user := range users {
+				members[i] = discord.Member{User: user}
+			}
+			json.EncodeStream(w, members)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	oldEndpoint := EndpointGuilds
+	EndpointGuilds = srv.URL + Path + "/guilds/"
+	t.Cleanup(func() { EndpointGuilds = oldEndpoint })
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), afters...)
+	}
+}
+
+func TestMembersAfter(t *testing.T) {
+	newGuildUserServer(t, 2500)
+	client := NewClient("no. 3-chan")
+
+	tests := []struct {
+		name   string
+		after  discord.UserID
+		limit  uint
+		expect []discord.UserID
+	}{
+		{"unlimited", 0, 0, userIDRange(1, 2500)},
+		{"limited", 0, 1500, userIDRange(1, 1500)},
+		{"unlimited after", 2000, 0, userIDRange(2001, 2500)},
+		{"nothing after", 2500, 0, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mems, err := client.MembersAfter(1, test.after, test.limit)
+			if err != nil {
+				t.Fatal("failed to get members:", err)
+			}
+
+			users := make([]discord.User, len(mems))
+			for i, mem := range mems {
+				users[i] = mem.User
+			}
+			expectUserIDs(t, users, test.expect)
+		})
+	}
+}
+
+func TestMembersIter(t *testing.T) {
+	afters := newGuildUserServer(t, 2500)
+
+	var users []discord.User
+	it := NewClient("no. 3-chan").MembersIter(1).After(100)
+	for it.Next() {
+		users = append(users, it.Member().User)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal("failed to iterate:", err)
+	}
+
+	expectUserIDs(t, users, userIDRange(101, 2500))
+	// The short third page ends the iteration.
+	expectStrings(t, "afters", afters(), []string{"100", "1100", "2100"})
+}
+
+func TestBansIter(t *testing.T) {
+	afters := newGuildUserServer(t, 2000)
+
+	var users []discord.User
+	it := NewClient("no. 3-chan").BansIter(1)
+	for it.Next() {
+		users = append(users, it.Ban().User)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal("failed to iterate:", err)
+	}
+
+	expectUserIDs(t, users, userIDRange(1, 2000))
+	// Both pages are full, so an empty page ends the iteration.
+	expectStrings(t, "afters", afters(), []string{"", "1000", "2000"})
+}
+
+func TestGuildUserIterError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	oldEndpoint := EndpointGuilds
+	EndpointGuilds = srv.URL + Path + "/guilds/"
+	defer func() { EndpointGuilds = oldEndpoint }()
+
+	client := NewClient("no. 3-chan")
+	client.Retries = 1
+
+	members := client.MembersIter(1)
+	if members.Next() || members.Err() == nil {
+		t.Fatal("expected the members iterator to fail")
+	}
+
+	bans := client.BansIter(1)
+	if bans.Next() || bans.Err() == nil {
+		t.Fatal("expected the bans iterator to fail")
+	}
+}
+
+func TestSearchGuildMembers(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+		mu.Unlock()
+
+		json.EncodeStream(w, []discord.Member{{User: discord.User{ID: 1, Username: "abc"}}})
+	}))
+	defer srv.Close()
+
+	oldEndpoint := EndpointGuilds
+	EndpointGuilds = srv.URL + Path + "/guilds/"
+	defer func() { EndpointGuilds = oldEndpoint }()
+
+	client := NewClient("no. 3-chan")
+
+	members, err := client.SearchGuildMembers(1, "ab c", 5)
+	if err != nil {
+		t.Fatal("failed to search:", err)
+	}
+	if len(members) != 1 || members[0].User.Username != "abc" {
+		t.Fatalf("unexpected members %+v", members)
+	}
+
+	if _, err := client.SearchGuildMembers(1, "ab", MaxMemberSearchLimit+1); err != nil {
+		t.Fatal("failed to search:", err)
+	}
+
+	expectStrings(t, "queries", queries, []string{
+		Path + "/guilds/1/members/search?limit=5&query=ab+c",
+		Path + "/guilds/1/members/search?limit=1000&query=ab",
+	})
+}
@@ -3,10 +3,12 @@ package api
 import (
 	"fmt"
 	"mime/multipart"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/internal/intmath"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
 	"github.com/diamondburned/arikawa/v3/utils/sendpart"
 )
@@ -31,7 +33,7 @@ const (
 // When fetching the messages, those with the highest ID, will be fetched
 // first.
 // The returned slice will be sorted from latest to oldest.
-func (c *Client) Messages(channelID discord.ChannelID, limit uint) ([]discord.Message, error) {
+func (c CtxClient) Messages(channelID discord.ChannelID, limit uint) ([]discord.Message, error) {
 	// Since before is 0 it will be omitted by the http lib, which in turn
 	// will lead discord to send us the most recent messages without having to
 	// specify a Snowflake.
@@ -39,7 +41,7 @@ func (c *Client) Messages(channelID discord.ChannelID, limit uint) ([]discord.Me
 }
 
 // MessagesAround returns messages around the ID, with a limit of 100.
-func (c *Client) MessagesAround(
+func (c CtxClient) MessagesAround(
 	channelID discord.ChannelID, around discord.MessageID, limit uint) ([]discord.Message, error) {
 
 	return c.messagesRange(channelID, 0, 0, around, limit)
@@ -55,7 +57,7 @@ func (c *Client) MessagesAround(
 // may be less, if no more messages are available.
 //
 // The returned slice will be sorted from latest to oldest.
-func (c *Client) MessagesBefore(
+func (c CtxClient) MessagesBefore(
 	channelID discord.ChannelID, before discord.MessageID, limit uint) ([]discord.Message, error) {
 
 	msgs := make([]discord.Message, 0, limit)
@@ -105,7 +107,7 @@ func (c *Client) MessagesBefore(
 // may be less, if no more messages are available.
 //
 // The returned slice will be sorted from latest to oldest.
-func (c *Client) MessagesAfter(
+func (c CtxClient) MessagesAfter(
 	channelID discord.ChannelID, after discord.MessageID, limit uint) ([]discord.Message, error) {
 
 	// 0 is uint's zero value and will lead to the after param getting omitted,
@@ -152,7 +154,7 @@ func (c *Client) MessagesAfter(
 	return msgs, nil
 }
 
-func (c *Client) messagesRange(
+func (c CtxClient) messagesRange(
 	channelID discord.ChannelID,
 	before, after, around discord.MessageID, limit uint) ([]discord.Message, error) {
 
@@ -184,11 +186,122 @@ func (c *Client) messagesRange(
 	)
 }
 
+// MessagesIterator iterates over the messages in a channel, fetching a page
+// of messages only once the previous page has been consumed. It is created
+// using MessagesIter.
+//
+//	it := client.MessagesIter(channelID)
+//	for it.Next() {
+//		log.Println(it.Message().Content)
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type MessagesIterator struct {
+	client    CtxClient
+	channelID discord.ChannelID
+
+	before    discord.MessageID
+	after     discord.MessageID
+	ascending bool
+
+	page []discord.Message
+	msg  discord.Message
+	done bool
+	err  error
+}
+
+// MessagesIter returns an iterator over the messages in the channel, from the
+// latest to the oldest.
+func (c CtxClient) MessagesIter(channelID discord.ChannelID) *MessagesIterator {
+	return &MessagesIterator{
+		client:    c,
+		channelID: channelID,
+	}
+}
+
+// Before makes the iterator start before the given message ID. It must be
+// called before the first call to Next.
+func (it *MessagesIterator) Before(before discord.MessageID) *MessagesIterator {
+	it.before = before
+	it.ascending = false
+	return it
+}
+
+// After makes the iterator start after the given message ID and iterate from
+// the oldest to the latest message instead. An ID of 0 starts at the first
+// message of the channel. It must be called before the first call to Next.
+func (it *MessagesIterator) After(after discord.MessageID) *MessagesIterator {
+	// An after of 0 would be omitted, which would make Discord return the
+	// latest messages instead.
+	if after == 0 {
+		after = 1
+	}
+
+	it.after = after
+	it.ascending = true
+	return it
+}
+
+// Next advances the iterator to the next message, fetching the next page if
+// needed. It returns false once there are no more messages or an error
+// occurs, which is then returned by Err.
+func (it *MessagesIterator) Next() bool {
+	if len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		if it.ascending {
+			it.page, it.err = it.client.messagesRange(
+				it.channelID, 0, it.after, 0, maxMessageFetchLimit)
+		} else {
+			it.page, it.err = it.client.messagesRange(
+				it.channelID, it.before, 0, 0, maxMessageFetchLimit)
+		}
+		if it.err != nil {
+			return false
+		}
+
+		if len(it.page) < maxMessageFetchLimit {
+			it.done = true
+		}
+		if len(it.page) == 0 {
+			return false
+		}
+
+		// Pages are always sorted from latest to oldest.
+		if it.ascending {
+			for i, j := 0, len(it.page)-1; i < j; i, j = i+1, j-1 {
+				it.page[i], it.page[j] = it.page[j], it.page[i]
+			}
+			it.after = it.page[len(it.page)-1].ID
+		} else {
+			it.before = it.page[len(it.page)-1].ID
+		}
+	}
+
+	it.msg = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Message returns the current message. It is only valid after Next returns
+// true.
+func (it *MessagesIterator) Message() discord.Message {
+	return it.msg
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *MessagesIterator) Err() error {
+	return it.err
+}
+
 // Message returns a specific message in the channel.
 //
 // If operating on a guild channel, this endpoint requires the
 // READ_MESSAGE_HISTORY permission to be present on the current user.
-func (c *Client) Message(
+func (c CtxClient) Message(
 	channelID discord.ChannelID, messageID discord.MessageID) (*discord.Message, error) {
 
 	var msg *discord.Message
@@ -202,7 +315,7 @@ func (c *Client) Message(
 // permission to be present on the current user.
 //
 // Fires a Message Create Gateway event.
-func (c *Client) SendTextReply(
+func (c CtxClient) SendTextReply(
 	channelID discord.ChannelID,
 	content string, referenceID discord.MessageID) (*discord.Message, error) {
 
@@ -212,13 +325,35 @@ func (c *Client) SendTextReply(
 	})
 }
 
+// ForwardMessage forwards the message with the given ID from the given
+// channel into a guild text or DM channel. The forwarded message is sent as a
+// snapshot in the MessageSnapshots field of the new message.
+//
+// This endpoint requires the READ_MESSAGE_HISTORY permission in the channel
+// that the message is forwarded from, and the SEND_MESSAGES permission in the
+// channel that it is forwarded to.
+//
+// Fires a Message Create Gateway event.
+func (c CtxClient) ForwardMessage(
+	channelID discord.ChannelID,
+	fromChannelID discord.ChannelID, messageID discord.MessageID) (*discord.Message, error) {
+
+	return c.SendMessageComplex(channelID, SendMessageData{
+		Reference: &discord.MessageReference{
+			Type:      discord.ForwardMessageReference,
+			MessageID: messageID,
+			ChannelID: fromChannelID,
+		},
+	})
+}
+
 // SendEmbeds sends embeds to a guild text or DM channel.
 //
 // If operating on a guild channel, this endpoint requires the SEND_MESSAGES
 // permission to be present on the current user.
 //
 // Fires a Message Create Gateway event.
-func (c *Client) SendEmbeds(
+func (c CtxClient) SendEmbeds(
 	channelID discord.ChannelID, e ...discord.Embed) (*discord.Message, error) {
 
 	return c.SendMessageComplex(channelID, SendMessageData{
@@ -232,7 +367,7 @@ func (c *Client) SendEmbeds(
 // permission to be present on the current user.
 //
 // Fires a Message Create Gateway event.
-func (c *Client) SendEmbedReply(
+func (c CtxClient) SendEmbedReply(
 	channelID discord.ChannelID,
 	referenceID discord.MessageID, embeds ...discord.Embed) (*discord.Message, error) {
 
@@ -248,7 +383,7 @@ func (c *Client) SendEmbedReply(
 // permission to be present on the current user.
 //
 // Fires a Message Create Gateway event.
-func (c *Client) SendMessage(
+func (c CtxClient) SendMessage(
 	channelID discord.ChannelID,
 	content string, embeds ...discord.Embed) (*discord.Message, error) {
 
@@ -265,7 +400,7 @@ func (c *Client) SendMessage(
 // permission to be present on the current user.
 //
 // Fires a Message Create Gateway event.
-func (c *Client) SendMessageReply(
+func (c CtxClient) SendMessageReply(
 	channelID discord.ChannelID, content string,
 	referenceID discord.MessageID, embeds ...discord.Embed) (*discord.Message, error) {
 
@@ -288,7 +423,10 @@ type EditMessageData struct {
 	Components *discord.ContainerComponents `json:"components,omitempty"`
 	// AllowedMentions are the allowed mentions for a message.
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
-	// Attachments are the attached files to keep
+	// Attachments are the attached files to keep. All other attachments are
+	// removed, while new Files are always added. Only the IDs and
+	// descriptions of the attachments are sent. Use KeepAttachments and
+	// RemoveAllAttachments to set this.
 	Attachments *[]discord.Attachment `json:"attachments,omitempty"`
 	// Flags edits the flags of a message (only SUPPRESS_EMBEDS can currently
 	// be set/unset)
@@ -299,6 +437,55 @@ type EditMessageData struct {
 	Files []sendpart.File `json:"-"`
 }
 
+// KeepAttachments makes the edit keep only the attachments with the given IDs
+// and remove all other attachments of the message.
+func (data *EditMessageData) KeepAttachments(ids ...discord.AttachmentID) {
+	attachments := make([]discord.Attachment, len(ids))
+	for i, id := range ids {
+		attachments[i] = discord.Attachment{ID: id}
+	}
+	data.Attachments = &attachments
+}
+
+// RemoveAllAttachments makes the edit remove all attachments of the message.
+// Files added after calling it are still uploaded.
+func (data *EditMessageData) RemoveAllAttachments() {
+	data.Attachments = &[]discord.Attachment{}
+}
+
+// AddFiles adds files to upload. Unless KeepAttachments or
+// RemoveAllAttachments is used, the existing attachments are kept.
+func (data *EditMessageData) AddFiles(files ...sendpart.File) {
+	data.Files = append(data.Files, files...)
+}
+
+// partialAttachment is an attachment that only has the fields that Discord
+// reads when editing attachments.
+type partialAttachment struct {
+	ID          discord.AttachmentID `json:"id"`
+	Description string               `json:"description,omitempty"`
+}
+
+// MarshalJSON marshals the data, sending only the IDs and descriptions of
+// Attachments.
+func (data EditMessageData) MarshalJSON() ([]byte, error) {
+	type raw EditMessageData
+
+	var attachments *[]partialAttachment
+	if data.Attachments != nil {
+		partial := make([]partialAttachment, len(*data.Attachments))
+		for i, a := range *data.Attachments {
+			partial[i] = partialAttachment{ID: a.ID, Description: a.Description}
+		}
+		attachments = &partial
+	}
+
+	return json.Marshal(struct {
+		raw
+		Attachments *[]partialAttachment `json:"attachments,omitempty"`
+	}{raw(data), attachments})
+}
+
 // NeedsMultipart returns true if the SendMessageData has files.
 func (data EditMessageData) NeedsMultipart() bool {
 	return len(data.Files) > 0
@@ -310,7 +497,7 @@ func (data EditMessageData) WriteMultipart(body *multipart.Writer) error {
 
 // EditText edits the contents of a previously sent message. For more
 // documentation, refer to EditMessageComplex.
-func (c *Client) EditText(
+func (c CtxClient) EditText(
 	channelID discord.ChannelID,
 	messageID discord.MessageID, content string) (*discord.Message, error) {
 
@@ -321,7 +508,7 @@ func (c *Client) EditText(
 
 // EditEmbeds edits the embed of a previously sent message. For more
 // documentation, refer to EditMessageComplex.
-func (c *Client) EditEmbeds(
+func (c CtxClient) EditEmbeds(
 	channelID discord.ChannelID,
 	messageID discord.MessageID, embeds ...discord.Embed) (*discord.Message, error) {
 
@@ -335,7 +522,7 @@ func (c *Client) EditEmbeds(
 // will only update, but not remove parts of the message.
 //
 // For more documentation, refer to EditMessageComplex.
-func (c *Client) EditMessage(
+func (c CtxClient) EditMessage(
 	channelID discord.ChannelID, messageID discord.MessageID,
 	content string, embeds ...discord.Embed) (*discord.Message, error) {
 
@@ -362,7 +549,7 @@ func (c *Client) EditMessage(
 // error).
 //
 // Fires a Message Update Gateway event.
-func (c *Client) EditMessageComplex(
+func (c CtxClient) EditMessageComplex(
 	channelID discord.ChannelID,
 	messageID discord.MessageID, data EditMessageData) (*discord.Message, error) {
 
@@ -379,8 +566,8 @@ func (c *Client) EditMessageComplex(
 				return nil, fmt.Errorf("embed error at %d: %w", i, err)
 			}
 			sum += embed.Length()
-			if sum > 6000 {
-				return nil, &discord.OverboundError{Count: sum, Max: 6000, Thing: "sum of all text in embeds"}
+			if sum > discord.MaxEmbedsLength {
+				return nil, &discord.OverboundError{Count: sum, Max: discord.MaxEmbedsLength, Thing: "sum of all text in embeds"}
 			}
 
 			(*data.Embeds)[i] = embed // embed.Validate changes fields
@@ -388,14 +575,14 @@ func (c *Client) EditMessageComplex(
 	}
 
 	var msg *discord.Message
-	return msg, sendpart.PATCH(c.Client, data, &msg,
+	return msg, c.sendpart("PATCH", data, &msg,
 		EndpointChannels+channelID.String()+"/messages/"+messageID.String())
 }
 
 // CrosspostMessage crossposts a message in a news channel to following channels.
 // This endpoint requires the SEND_MESSAGES permission if the current user sent the message,
 // or additionally the MANAGE_MESSAGES permission for all other messages.
-func (c *Client) CrosspostMessage(
+func (c CtxClient) CrosspostMessage(
 	channelID discord.ChannelID, messageID discord.MessageID) (*discord.Message, error) {
 
 	var msg *discord.Message
@@ -410,7 +597,7 @@ func (c *Client) CrosspostMessage(
 // DeleteMessage delete a message. If operating on a guild channel and trying
 // to delete a message that was not sent by the current user, this endpoint
 // requires the MANAGE_MESSAGES permission.
-func (c *Client) DeleteMessage(
+func (c CtxClient) DeleteMessage(
 	channelID discord.ChannelID, messageID discord.MessageID, reason AuditLogReason) error {
 
 	return c.FastRequest(
@@ -431,7 +618,7 @@ func (c *Client) DeleteMessage(
 // requests.
 //
 // Fires a Message Delete Bulk Gateway event.
-func (c *Client) DeleteMessages(
+func (c CtxClient) DeleteMessages(
 	channelID discord.ChannelID, messageIDs []discord.MessageID, reason AuditLogReason) error {
 
 	switch {
@@ -456,7 +643,116 @@ func (c *Client) DeleteMessages(
 	return nil
 }
 
-func (c *Client) deleteMessages(
+// MaxBulkDeleteAge is the age of the oldest message that can be deleted using
+// DeleteMessages.
+const MaxBulkDeleteAge = 14 * 24 * time.Hour
+
+// PurgeMessagesError is returned by PurgeMessages if any message couldn't be
+// deleted.
+type PurgeMessagesError struct {
+	// Errors maps the ID of each message that couldn't be deleted to the
+	// error that prevented it.
+	Errors map[discord.MessageID]error
+}
+
+// Error implements error.
+func (err *PurgeMessagesError) Error() string {
+	// Report the error of the oldest message, so that the error is stable.
+	var first discord.MessageID
+	for id := range err.Errors {
+		if !first.IsValid() || id < first {
+			first = id
+		}
+	}
+
+	return fmt.Sprintf(
+		"failed to delete %d messages, including %d: %v",
+		len(err.Errors), first, err.Errors[first])
+}
+
+// PurgeMessages deletes any number of messages, using as few requests as
+// possible. Unlike DeleteMessages, it doesn't fail if there are more than 100
+// messages, any of the messages are older than MaxBulkDeleteAge or any
+// message ID is duplicated.
+//
+// Messages that can be bulk deleted are deleted in batches of 100, and older
+// messages are deleted one by one, which is much slower due to rate limits.
+// The context, set using WithContext or WithCtx, can be used to stop early.
+//
+// All messages are attempted even if some fail, and a *PurgeMessagesError is
+// returned with the error of every message that wasn't deleted. Messages that
+// weren't attempted because the context was done have the context's error.
+func (c CtxClient) PurgeMessages(
+	channelID discord.ChannelID, messageIDs []discord.MessageID, reason AuditLogReason) error {
+
+	ctx := c.Context()
+	// Leave a margin so that messages don't become too old in flight.
+	oldest := time.Now().Add(-MaxBulkDeleteAge + time.Minute)
+
+	var bulk, single []discord.MessageID
+	seen := make(map[discord.MessageID]struct{}, len(messageIDs))
+
+	for _, id := range messageIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		if id.Time().Before(oldest) {
+			single = append(single, id)
+		} else {
+			bulk = append(bulk, id)
+		}
+	}
+
+	// A bulk delete needs at least 2 messages.
+	if len(bulk) == 1 {
+		single = append(bulk, single...)
+		bulk = nil
+	}
+
+	errs := make(map[discord.MessageID]error)
+
+	for start := 0; start < len(bulk); start += maxMessageDeleteLimit {
+		end := intmath.Min(len(bulk), start+maxMessageDeleteLimit)
+		batch := bulk[start:end]
+
+		// The last batch may be a single message.
+		var err error
+		if err = ctx.Err(); err == nil {
+			if len(batch) == 1 {
+				err = c.DeleteMessage(channelID, batch[0], reason)
+			} else {
+				err = c.deleteMessages(channelID, batch, reason)
+			}
+		}
+
+		if err != nil {
+			for _, id := range batch {
+				errs[id] = err
+			}
+		}
+	}
+
+	for _, id := range single {
+		err := ctx.Err()
+		if err == nil {
+			err = c.DeleteMessage(channelID, id, reason)
+		}
+
+		if err != nil {
+			errs[id] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return &PurgeMessagesError{Errors: errs}
+	}
+
+	return nil
+}
+
+func (c CtxClient) deleteMessages(
 	channelID discord.ChannelID, messageIDs []discord.MessageID, reason AuditLogReason) error {
 
 	var param struct {
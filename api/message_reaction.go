@@ -14,7 +14,7 @@ const MaxMessageReactionFetchLimit = 100
 // the current user. Additionally, if nobody else has reacted to the message
 // using this emoji, this endpoint requires the 'ADD_REACTIONS' permission to
 // be present on the current user.
-func (c *Client) React(
+func (c CtxClient) React(
 	channelID discord.ChannelID, messageID discord.MessageID, emoji discord.APIEmoji) error {
 
 	return c.FastRequest(
@@ -26,7 +26,7 @@ func (c *Client) React(
 }
 
 // Unreact removes a reaction the current user has made for the message.
-func (c *Client) Unreact(
+func (c CtxClient) Unreact(
 	channelID discord.ChannelID, messageID discord.MessageID, emoji discord.APIEmoji) error {
 
 	return c.DeleteUserReaction(channelID, messageID, 0, emoji)
@@ -41,7 +41,7 @@ func (c *Client) Unreact(
 // may be less, if no more guilds are available.
 //
 // When fetching the users, those with the smallest ID will be fetched first.
-func (c *Client) Reactions(
+func (c CtxClient) Reactions(
 	channelID discord.ChannelID,
 	messageID discord.MessageID, emoji discord.APIEmoji, limit uint) ([]discord.User, error) {
 
@@ -51,47 +51,31 @@ func (c *Client) Reactions(
 // ReactionsBefore returns a list of users that reacted with the passed Emoji.
 // This method automatically paginates until it reaches the passed limit, or,
 // if the limit is set to 0, has fetched all users with an id smaller than
-// before.
+// before. If there are more users than the limit, then the ones closest to
+// before are returned.
 //
-// As the underlying endpoint has a maximum of 100 users per request, at
-// maximum a total of limit/100 rounded up requests will be made, although they
-// may be less, if no more guilds are available.
-func (c *Client) ReactionsBefore(
+// Discord only supports paginating reactions forward, so every user with an id
+// smaller than before is fetched, regardless of the limit.
+func (c CtxClient) ReactionsBefore(
 	channelID discord.ChannelID, messageID discord.MessageID,
 	before discord.UserID, emoji discord.APIEmoji, limit uint) ([]discord.User, error) {
 
-	users := make([]discord.User, 0, limit)
-
-	fetch := uint(MaxMessageReactionFetchLimit)
-
-	unlimited := limit == 0
-
-	for limit > 0 || unlimited {
-		if limit > 0 {
-			// Only fetch as much as we need. Since limit gradually decreases,
-			// we only need to fetch intmath.Min(fetch, limit).
-			fetch = uint(intmath.Min(MaxMessageReactionFetchLimit, int(limit)))
-			limit -= fetch
-		}
-
-		r, err := c.reactionsRange(channelID, messageID, before, 0, emoji, fetch)
-		if err != nil {
-			return users, err
-		}
-		users = append(r, users...)
+	var users []discord.User
 
-		if len(r) < MaxMessageReactionFetchLimit {
+	it := c.ReactionUsers(channelID, messageID, emoji, discord.NormalReaction)
+	for it.Next() {
+		user := it.User()
+		if before.IsValid() && user.ID >= before {
 			break
 		}
 
-		before = r[0].ID
-	}
-
-	if len(users) == 0 {
-		return nil, nil
+		users = append(users, user)
+		if limit > 0 && uint(len(users)) > limit {
+			users = users[1:]
+		}
 	}
 
-	return users, nil
+	return users, it.Err()
 }
 
 // ReactionsAfter returns a list of users that reacted with the passed Emoji.
@@ -102,10 +86,29 @@ func (c *Client) ReactionsBefore(
 // As the underlying endpoint has a maximum of 100 users per request, at
 // maximum a total of limit/100 rounded up requests will be made, although they
 // may be less, if no more guilds are available.
-func (c *Client) ReactionsAfter(
+func (c CtxClient) ReactionsAfter(
 	channelID discord.ChannelID, messageID discord.MessageID,
 	after discord.UserID, emoji discord.APIEmoji, limit uint) ([]discord.User, error) {
 
+	return c.reactionsAfter(channelID, messageID, after, emoji, discord.NormalReaction, limit)
+}
+
+// ReactionsOfType returns a list of users that reacted with the passed Emoji
+// using the given type of reaction, such as discord.BurstReaction for burst
+// (super) reactions. Pagination works the same as Reactions. To start after a
+// user, use ReactionUsers with After instead.
+func (c CtxClient) ReactionsOfType(
+	channelID discord.ChannelID, messageID discord.MessageID,
+	emoji discord.APIEmoji, typ discord.ReactionType, limit uint) ([]discord.User, error) {
+
+	return c.reactionsAfter(channelID, messageID, 0, emoji, typ, limit)
+}
+
+func (c CtxClient) reactionsAfter(
+	channelID discord.ChannelID, messageID discord.MessageID,
+	after discord.UserID, emoji discord.APIEmoji,
+	typ discord.ReactionType, limit uint) ([]discord.User, error) {
+
 	users := make([]discord.User, 0, limit)
 
 	fetch := uint(MaxMessageReactionFetchLimit)
@@ -120,7 +123,7 @@ func (c *Client) ReactionsAfter(
 			limit -= fetch
 		}
 
-		r, err := c.reactionsRange(channelID, messageID, 0, after, emoji, fetch)
+		r, err := c.reactionsRange(channelID, messageID, after, emoji, typ, fetch)
 		if err != nil {
 			return users, err
 		}
@@ -140,11 +143,101 @@ func (c *Client) ReactionsAfter(
 	return users, nil
 }
 
-// reactionsRange get users before and after IDs. Before, after, and limit are
-// optional. A maximum limit of only 100 reactions could be returned.
-func (c *Client) reactionsRange(
+// ReactionUsersIterator iterates over the users that reacted to a message,
+// fetching a page of users only once the previous page has been consumed. It
+// is created using ReactionUsers.
+//
+//	it := client.ReactionUsers(channelID, messageID, emoji, discord.NormalReaction)
+//	for it.Next() {
+//		log.Println(it.User().Username)
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type ReactionUsersIterator struct {
+	client    CtxClient
+	channelID discord.ChannelID
+	messageID discord.MessageID
+	emoji     discord.APIEmoji
+	typ       discord.ReactionType
+
+	after discord.UserID
+	page  []discord.User
+	user  discord.User
+	done  bool
+	err   error
+}
+
+// ReactionUsers returns an iterator over the users that reacted to the message
+// with the passed Emoji and reaction type, in ascending order of their IDs.
+func (c CtxClient) ReactionUsers(
+	channelID discord.ChannelID, messageID discord.MessageID,
+	emoji discord.APIEmoji, typ discord.ReactionType) *ReactionUsersIterator {
+
+	return &ReactionUsersIterator{
+		client:    c,
+		channelID: channelID,
+		messageID: messageID,
+		emoji:     emoji,
+		typ:       typ,
+	}
+}
+
+// After makes the iterator start after the given user ID. It must be called
+// before the first call to Next.
+func (it *ReactionUsersIterator) After(after discord.UserID) *ReactionUsersIterator {
+	it.after = after
+	return it
+}
+
+// Next advances the iterator to the next user, fetching the next page if
+// needed. It returns false once there are no more users or an error occurs,
+// which is then returned by Err.
+func (it *ReactionUsersIterator) Next() bool {
+	if len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		it.page, it.err = it.client.reactionsRange(
+			it.channelID, it.messageID, it.after, it.emoji, it.typ,
+			MaxMessageReactionFetchLimit,
+		)
+		if it.err != nil {
+			return false
+		}
+
+		if len(it.page) < MaxMessageReactionFetchLimit {
+			it.done = true
+		}
+		if len(it.page) == 0 {
+			return false
+		}
+
+		it.after = it.page[len(it.page)-1].ID
+	}
+
+	it.user = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// User returns the current user. It is only valid after Next returns true.
+func (it *ReactionUsersIterator) User() discord.User {
+	return it.user
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ReactionUsersIterator) Err() error {
+	return it.err
+}
+
+// reactionsRange get users after the ID. After and limit are optional. A
+// maximum limit of only 100 reactions could be returned.
+func (c CtxClient) reactionsRange(
 	channelID discord.ChannelID, messageID discord.MessageID,
-	before, after discord.UserID, emoji discord.APIEmoji, limit uint) ([]discord.User, error) {
+	after discord.UserID, emoji discord.APIEmoji,
+	typ discord.ReactionType, limit uint) ([]discord.User, error) {
 
 	switch {
 	case limit == 0:
@@ -154,14 +247,14 @@ func (c *Client) reactionsRange(
 	}
 
 	var param struct {
-		Before discord.UserID `schema:"before,omitempty"`
-		After  discord.UserID `schema:"after,omitempty"`
+		After discord.UserID `schema:"after,omitempty"`
 
-		Limit uint `schema:"limit"`
+		Type  discord.ReactionType `schema:"type,omitempty"`
+		Limit uint                 `schema:"limit"`
 	}
 
-	param.Before = before
 	param.After = after
+	param.Type = typ
 	param.Limit = limit
 
 	var users []discord.User
@@ -177,7 +270,7 @@ func (c *Client) reactionsRange(
 //
 // This endpoint requires the MANAGE_MESSAGES permission to be present on the
 // current user.
-func (c *Client) DeleteUserReaction(
+func (c CtxClient) DeleteUserReaction(
 	channelID discord.ChannelID,
 	messageID discord.MessageID, userID discord.UserID, emoji discord.APIEmoji) error {
 
@@ -200,7 +293,7 @@ func (c *Client) DeleteUserReaction(
 // current user.
 //
 // Fires a Message Reaction Remove Emoji Gateway event.
-func (c *Client) DeleteReactions(
+func (c CtxClient) DeleteReactions(
 	channelID discord.ChannelID, messageID discord.MessageID, emoji discord.APIEmoji) error {
 
 	return c.FastRequest(
@@ -217,7 +310,7 @@ func (c *Client) DeleteReactions(
 // current user.
 //
 // Fires a Message Reaction Remove All Gateway event.
-func (c *Client) DeleteAllReactions(
+func (c CtxClient) DeleteAllReactions(
 	channelID discord.ChannelID, messageID discord.MessageID) error {
 
 	return c.FastRequest(
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// reactionServer serves users with IDs from 1 to n as the reactions of any
+// message, paginated like Discord does, and records the queries it receives.
+type reactionServer struct {
+	mu      sync.Mutex
+	queries []url.Values
+}
+
+func newReactionServer(t *testing.T, n int) *reactionServer {
+	s := &reactionServer{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		s.mu.Lock()
+		s.queries = append(s.queries, q)
+		s.mu.Unlock()
+
+		after, _ := strconv.Atoi(q.Get("after"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		users := []discord.User{}
+		for id := after + 1; id <= n && len(users) < limit; id++ {
+			users = append(users, discord.User{ID: discord.UserID(id)})
+		}
+
+		json.EncodeStream(w, users)
+	}))
+	t.Cleanup(srv.Close)
+
+	oldEndpoint := EndpointChannels
+	EndpointChannels = srv.URL + Path + "/channels/"
+	t.Cleanup(func() { EndpointChannels = oldEndpoint })
+
+	return s
+}
+
+func userIDRange(from, to int) []discord.UserID {
+	ids := make([]discord.UserID, 0, to-from+1)
+	for id := from; id <= to; id++ {
+		ids = append(ids, discord.UserID(id))
+	}
+	return ids
+}
+
+func expectUserIDs(t *testing.T, users []discord.User, expect []discord.UserID) {
+	t.Helper()
+
+	if len(users) != len(expect) {
+		t.Fatalf("expected %d users, got %d", len(expect), len(users))
+	}
+	for i, user := range users {
+		if user.ID != expect[i] {
+			t.Fatalf("expected user %d at %d, got %d", expect[i], i, user.ID)
+		}
+	}
+}
+
+func TestReactionsOfType(t *testing.T) {
+	srv := newReactionServer(t, 150)
+	client := NewClient("no. 3-chan")
+
+	users, err := client.ReactionsOfType(1, 2, "🔥", discord.BurstReaction, 0)
+	if err != nil {
+		t.Fatal("failed to get reactions:", err)
+	}
+	expectUserIDs(t, users, userIDRange(1, 150))
+
+	users, err = client.Reactions(1, 2, "🔥", 10)
+	if err != nil {
+		t.Fatal("failed to get reactions:", err)
+	}
+	expectUserIDs(t, users, userIDRange(1, 10))
+
+	types := make([]string, len(srv.queries))
+	for i, q := range srv.queries {
+		types[i] = q.Get("type")
+	}
+
+	// Normal reactions are the default, so the type is omitted for them.
+	expect := []string{"1", "1", ""}
+	if len(types) != len(expect) {
+		t.Fatalf("expected types %q, got %q", expect, types)
+	}
+	for i := range expect {
+		if types[i] != expect[i] {
+			t.Fatalf("expected types %q, got %q", expect, types)
+		}
+	}
+}
+
+func TestReactionsBefore(t *testing.T) {
+	newReactionServer(t, 250)
+	client := NewClient("no. 3-chan")
+
+	tests := []struct {
+		name   string
+		before discord.UserID
+		limit  uint
+		expect []discord.UserID
+	}{
+		{"limited", 200, 120, userIDRange(80, 199)},
+		{"unlimited", 200, 0, userIDRange(1, 199)},
+		{"limit above available", 50, 100, userIDRange(1, 49)},
+		{"no before", 0, 0, userIDRange(1, 250)},
+		{"nothing before", 1, 10, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			users, err := client.ReactionsBefore(1, 2, test.before, "🔥", test.limit)
+			if err != nil {
+				t.Fatal("failed to get reactions:", err)
+			}
+			expectUserIDs(t, users, test.expect)
+		})
+	}
+}
+
+func TestReactionsAfter(t *testing.T) {
+	srv := newReactionServer(t, 250)
+	client := NewClient("no. 3-chan")
+
+	users, err := client.ReactionsAfter(1, 2, 20, "🔥", 150)
+	if err != nil {
+		t.Fatal("failed to get reactions:", err)
+	}
+	expectUserIDs(t, users, userIDRange(21, 170))
+
+	// Only as many users as needed are fetched, and the cursor advances to
+	// the last user of each page.
+	expect := []struct{ after, limit string }{{"20", "100"}, {"120", "50"}}
+	if len(srv.queries) != len(expect) {
+		t.Fatalf("expected %d requests, got %d", len(expect), len(srv.queries))
+	}
+	for i, q := range srv.queries {
+		if q.Get("after") != expect[i].after || q.Get("limit") != expect[i].limit {
+			t.Fatalf("unexpected query %d: %v", i, q)
+		}
+	}
+}
+
+func TestReactionUsers(t *testing.T) {
+	srv := newReactionServer(t, 250)
+	client := NewClient("no. 3-chan")
+
+	var users []discord.User
+
+	it := client.ReactionUsers(1, 2, "🔥", discord.NormalReaction).After(100)
+	for it.Next() {
+		users = append(users, it.User())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal("failed to iterate:", err)
+	}
+	expectUserIDs(t, users, userIDRange(101, 250))
+
+	// The short second page ends the iteration without another request.
+	afters := []string{"100", "200"}
+	if len(srv.queries) != len(afters) {
+		t.Fatalf("expected %d requests, got %d", len(afters), len(srv.queries))
+	}
+	for i, q := range srv.queries {
+		if q.Get("after") != afters[i] {
+			t.Fatalf("unexpected query %d: %v", i, q)
+		}
+	}
+}
+
+func TestReactionUsersError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	oldEndpoint := EndpointChannels
+	EndpointChannels = srv.URL + Path + "/channels/"
+	defer func() { EndpointChannels = oldEndpoint }()
+
+	client := NewClient("no. 3-chan")
+	client.Retries = 1
+
+	it := client.ReactionUsers(1, 2, "🔥", discord.NormalReaction)
+	if it.Next() {
+		t.Fatal("unexpected user")
+	}
+	if it.Err() == nil {
+		t.Fatal("expected an error")
+	}
+
+	if _, err := client.ReactionsBefore(1, 2, 10, "🔥", 0); err == nil {
+		t.Fatal("expected ReactionsBefore to return the error")
+	}
+}
@@ -0,0 +1,412 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+)
+
+func TestEditMessageDataAttachments(t *testing.T) {
+	tests := []struct {
+		name   string
+		edit   func(*EditMessageData)
+		expect string
+	}{
+		{
+			name: "add files",
+			edit: func(data *EditMessageData) {
+				data.AddFiles(sendpart.File{Name: "a.txt", Reader: strings.NewReader("a")})
+			},
+			expect: `{}`,
+		},
+		{
+			name: "keep attachments",
+			edit: func(data *EditMessageData) {
+				data.KeepAttachments(1, 2)
+			},
+			expect: `{"attachments":[{"id":"1"},{"id":"2"}]}`,
+		},
+		{
+			name: "keep attachments and add files",
+			edit: func(data *EditMessageData) {
+				data.KeepAttachments(1)
+				data.AddFiles(sendpart.File{Name: "a.txt", Reader: strings.NewReader("a")})
+			},
+			expect: `{"attachments":[{"id":"1"},{"content_type":"text/plain; charset=utf-8","filename":"a.txt","id":0}]}`,
+		},
+		{
+			name: "remove all attachments",
+			edit: func(data *EditMessageData) {
+				data.RemoveAllAttachments()
+			},
+			expect: `{"attachments":[]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var data EditMessageData
+			test.edit(&data)
+
+			var buf bytes.Buffer
+			body := multipart.NewWriter(&buf)
+			if err := data.WriteMultipart(body); err != nil {
+				t.Fatal("failed to write:", err)
+			}
+			body.Close()
+
+			part, err := multipart.NewReader(&buf, body.Boundary()).NextPart()
+			if err != nil {
+				t.Fatal("failed to read payload_json:", err)
+			}
+
+			var got, expect interface{}
+			if err := json.DecodeStream(part, &got); err != nil {
+				t.Fatal("failed to decode payload_json:", err)
+			}
+			if err := json.Unmarshal([]byte(test.expect), &expect); err != nil {
+				t.Fatal("failed to decode expected JSON:", err)
+			}
+
+			gotJSON, _ := json.Marshal(got)
+			expectJSON, _ := json.Marshal(expect)
+			if !bytes.Equal(gotJSON, expectJSON) {
+				t.Fatalf("expected %s, got %s", expectJSON, gotJSON)
+			}
+		})
+	}
+
+	// Full attachments, such as ones taken from a message, are sent as partial
+	// attachments.
+	data := EditMessageData{
+		Attachments: &[]discord.Attachment{{ID: 1, Filename: "a.txt", Size: 10, URL: "https://a"}},
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal("failed to marshal:", err)
+	}
+	if string(b) != `{"attachments":[{"id":"1"}]}` {
+		t.Fatalf("unexpected JSON %s", b)
+	}
+}
+
+func TestPurgeMessages(t *testing.T) {
+	var mu sync.Mutex
+	var bulkSizes []int
+	var singles []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if strings.HasSuffix(r.URL.Path, "/bulk-delete") {
+			var body struct {
+				Messages []discord.MessageID `json:"messages"`
+			}
+			json.DecodeStream(r.Body, &body)
+			bulkSizes = append(bulkSizes, len(body.Messages))
+		} else {
+			singles = append(singles, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/404") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	oldEndpoint := EndpointChannels
+	EndpointChannels = srv.URL + Path + "/channels/"
+	defer func() { EndpointChannels = oldEndpoint }()
+
+	var ids []discord.MessageID
+	for i := 0; i < 150; i++ {
+		ids = append(ids, discord.MessageID(discord.NewSnowflake(time.Now().Add(-time.Duration(i)*time.Second))))
+	}
+	// Duplicates are removed, and old messages are deleted one by one.
+	ids = append(ids, ids[0], 404)
+
+	err := NewClient("no. 3-chan").PurgeMessages(1, ids, "")
+
+	var purgeErr *PurgeMessagesError
+	if !errors.As(err, &purgeErr) || len(purgeErr.Errors) != 1 || purgeErr.Errors[404] == nil {
+		t.Fatal("expected only message 404 to fail, got", err)
+	}
+
+	if len(bulkSizes) != 2 || bulkSizes[0] != 100 || bulkSizes[1] != 50 {
+		t.Fatal("unexpected bulk deletes:", bulkSizes)
+	}
+	if len(singles) != 1 || singles[0] != "404" {
+		t.Fatal("unexpected single deletes:", singles)
+	}
+}
+
+// messageServer serves messages with IDs from 1 to n in a channel, paginated
+// like Discord does, and records the queries it receives.
+type messageServer struct {
+	mu      sync.Mutex
+	queries []url.Values
+}
+
+func newMessageServer(t *testing.T, n int) *messageServer {
+	s := &messageServer{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		s.mu.Lock()
+		s.queries = append(s.queries, q)
+		s.mu.Unlock()
+
+		before, _ := strconv.Atoi(q.Get("before"))
+		after, _ := strconv.Atoi(q.Get("after"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		from, to := 1, n
+		switch {
+		case after > 0:
+			from = after + 1
+			if to > after+limit {
+				to = after + limit
+			}
+		case before > 0:
+			to = before - 1
+			fallthrough
+		default:
+			if from < to-limit+1 {
+				from = to - limit + 1
+			}
+		}
+
+		// Messages are always sent from the latest to the oldest.
+		msgs := []discord.Message{}
+		for id := to; id >= from; id-- {
+			msgs = append(msgs, discord.Message{ID: discord.MessageID(id)})
+		}
+
+		json.EncodeStream(w, msgs)
+	}))
+	t.Cleanup(srv.Close)
+
+	oldEndpoint := EndpointChannels
+	EndpointChannels = srv.URL + Path + "/channels/"
+	t.Cleanup(func() { EndpointChannels = oldEndpoint })
+
+	return s
+}
+
+// query returns the given parameter of each query that the server received.
+func (s *messageServer) query(key string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values := make([]string, len(s.queries))
+	for i, q := range s.queries {
+		values[i] = q.Get(key)
+	}
+	return values
+}
+
+func messageIDRange(from, to int) []discord.MessageID {
+	step := 1
+	if from > to {
+		step = -1
+	}
+
+	ids := make([]discord.MessageID, 0, (to-from)*step+1)
+	for id := from; id != to+step; id += step {
+		ids = append(ids, discord.MessageID(id))
+	}
+	return ids
+}
+
+func expectMessageIDs(t *testing.T, msgs []discord.Message, expect []discord.MessageID) {
+	t.Helper()
+
+	if len(msgs) != len(expect) {
+		t.Fatalf("expected %d messages, got %d", len(expect), len(msgs))
+	}
+	for i, msg := range msgs {
+		if msg.ID != expect[i] {
+			t.Fatalf("expected message %d at %d, got %d", expect[i], i, msg.ID)
+		}
+	}
+}
+
+func expectStrings(t *testing.T, name string, got, expect []string) {
+	t.Helper()
+
+	if len(got) != len(expect) {
+		t.Fatalf("expected %s %q, got %q", name, expect, got)
+	}
+	for i := range expect {
+		if got[i] != expect[i] {
+			t.Fatalf("expected %s %q, got %q", name, expect, got)
+		}
+	}
+}
+
+func TestMessagesLimit(t *testing.T) {
+	newMessageServer(t, 250)
+	client := NewClient("no. 3-chan")
+
+	tests := []struct {
+		name   string
+		fetch  func() ([]discord.Message, error)
+		expect []discord.MessageID
+	}{{
+		name:   "unlimited",
+		fetch:  func() ([]discord.Message, error) { return client.Messages(1, 0) },
+		expect: messageIDRange(250, 1),
+	}, {
+		name:   "limited",
+		fetch:  func() ([]discord.Message, error) { return client.Messages(1, 120) },
+		expect: messageIDRange(250, 131),
+	}, {
+		name:   "unlimited before",
+		fetch:  func() ([]discord.Message, error) { return client.MessagesBefore(1, 200, 0) },
+		expect: messageIDRange(199, 1),
+	}, {
+		name:   "unlimited after",
+		fetch:  func() ([]discord.Message, error) { return client.MessagesAfter(1, 20, 0) },
+		expect: messageIDRange(250, 21),
+	}, {
+		name:   "limited after",
+		fetch:  func() ([]discord.Message, error) { return client.MessagesAfter(1, 20, 150) },
+		expect: messageIDRange(170, 21),
+	}, {
+		name:   "nothing after",
+		fetch:  func() ([]discord.Message, error) { return client.MessagesAfter(1, 250, 0) },
+		expect: nil,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msgs, err := test.fetch()
+			if err != nil {
+				t.Fatal("failed to get messages:", err)
+			}
+			expectMessageIDs(t, msgs, test.expect)
+		})
+	}
+}
+
+func TestMessagesIter(t *testing.T) {
+	tests := []struct {
+		name    string
+		iter    func(c *Client) *MessagesIterator
+		expect  []discord.MessageID
+		befores []string
+		afters  []string
+	}{{
+		name:    "latest",
+		iter:    func(c *Client) *MessagesIterator { return c.MessagesIter(1) },
+		expect:  messageIDRange(250, 1),
+		befores: []string{"", "151", "51"},
+		afters:  []string{"", "", ""},
+	}, {
+		name:    "before",
+		iter:    func(c *Client) *MessagesIterator { return c.MessagesIter(1).Before(201) },
+		expect:  messageIDRange(200, 1),
+		befores: []string{"201", "101", "1"},
+		afters:  []string{"", "", ""},
+	}, {
+		name:    "after",
+		iter:    func(c *Client) *MessagesIterator { return c.MessagesIter(1).After(60) },
+		expect:  messageIDRange(61, 250),
+		befores: []string{"", ""},
+		afters:  []string{"60", "160"},
+	}, {
+		name:    "from the start",
+		iter:    func(c *Client) *MessagesIterator { return c.MessagesIter(1).After(0) },
+		expect:  messageIDRange(2, 250),
+		befores: []string{"", "", ""},
+		afters:  []string{"1", "101", "201"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := newMessageServer(t, 250)
+
+			var msgs []discord.Message
+			it := test.iter(NewClient("no. 3-chan"))
+			for it.Next() {
+				msgs = append(msgs, it.Message())
+			}
+			if err := it.Err(); err != nil {
+				t.Fatal("failed to iterate:", err)
+			}
+
+			expectMessageIDs(t, msgs, test.expect)
+			// A short page ends the iteration without another request.
+			expectStrings(t, "befores", srv.query("before"), test.befores)
+			expectStrings(t, "afters", srv.query("after"), test.afters)
+
+			if it.Next() {
+				t.Fatal("unexpected message after the iteration ended")
+			}
+		})
+	}
+}
+
+func TestMessagesIterError(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		// The first page is full, and the second one fails.
+		if requests > 1 {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		msgs := make([]discord.Message, maxMessageFetchLimit)
+		for i := range msgs {
+			msgs[i].ID = discord.MessageID(1000 - i)
+		}
+		json.EncodeStream(w, msgs)
+	}))
+	defer srv.Close()
+
+	oldEndpoint := EndpointChannels
+	EndpointChannels = srv.URL + Path + "/channels/"
+	defer func() { EndpointChannels = oldEndpoint }()
+
+	client := NewClient("no. 3-chan")
+	client.Retries = 1
+
+	var n int
+	it := client.MessagesIter(1)
+	for it.Next() {
+		n++
+	}
+
+	if n != maxMessageFetchLimit {
+		t.Fatalf("expected %d messages before the error, got %d", maxMessageFetchLimit, n)
+	}
+
+	var httpErr *httputil.HTTPError
+	if !errors.As(it.Err(), &httpErr) || httpErr.Status != http.StatusForbidden {
+		t.Fatal("expected a 403 error, got", it.Err())
+	}
+
+	if it.Next() || requests != 2 {
+		t.Fatal("iterator continued after an error")
+	}
+}
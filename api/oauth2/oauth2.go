@@ -0,0 +1,366 @@
+// Package oauth2 implements Discord's OAuth2 flows, which are used to act on
+// behalf of users, such as on a web dashboard that lists the guilds of the
+// logged in user.
+//
+// A typical authorization code flow looks like this:
+//
+//	config := oauth2.Config{
+//		ClientID:     appID,
+//		ClientSecret: secret,
+//		RedirectURI:  "https://example.com/callback",
+//		Scopes:       []string{oauth2.ScopeIdentify, oauth2.ScopeGuilds},
+//	}
+//
+//	// Redirect the user to config.AuthorizeURL(state), then in the callback:
+//	token, err := config.Exchange(ctx, r.FormValue("code"))
+//
+//	client := oauth2.NewRefreshingClient(config.TokenSource(*token))
+//	me, err := client.Me()
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+var (
+	EndpointAuthorize   = api.BaseEndpoint + "/oauth2/authorize"
+	EndpointToken       = api.EndpointOAuth2 + "token"
+	EndpointTokenRevoke = EndpointToken + "/revoke"
+)
+
+// OAuth2 scopes that are commonly used. Refer to Discord's documentation for
+// the full list.
+//
+// https://discord.com/developers/docs/topics/oauth2#shared-resources-oauth2-scopes
+const (
+	ScopeIdentify                              = "identify"
+	ScopeEmail                                 = "email"
+	ScopeConnections                           = "connections"
+	ScopeGuilds                                = "guilds"
+	ScopeGuildsJoin                            = "guilds.join"
+	ScopeGuildsMembersRead                     = "guilds.members.read"
+	ScopeGDMJoin                               = "gdm.join"
+	ScopeBot                                   = "bot"
+	ScopeWebhookIncoming                       = "webhook.incoming"
+	ScopeApplicationsCommands                  = "applications.commands"
+	ScopeApplicationsCommandsUpdate            = "applications.commands.update"
+	ScopeApplicationsCommandsPermissionsUpdate = "applications.commands.permissions.update"
+	ScopeRoleConnectionsWrite                  = "role_connections.write"
+)
+
+// Token is an OAuth2 access token.
+//
+// https://discord.com/developers/docs/topics/oauth2#authorization-code-grant-access-token-response
+type Token struct {
+	// AccessToken is the token used to authenticate requests.
+	AccessToken string `json:"access_token"`
+	// TokenType is the type of the token, which is always "Bearer".
+	TokenType string `json:"token_type"`
+	// ExpiresIn is the number of seconds that the token was valid for when
+	// it was issued.
+	ExpiresIn int `json:"expires_in"`
+	// RefreshToken is the token used to get a new access token once it
+	// expires. Tokens from the client credentials flow don't have one.
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// Scope is the space-separated list of scopes that the token was granted.
+	Scope string `json:"scope"`
+	// Expiry is when the access token expires. It is calculated from
+	// ExpiresIn when the token is received, and is kept when the token is
+	// marshaled, so that stored tokens can be checked for expiry.
+	Expiry time.Time `json:"expiry,omitempty"`
+
+	// Guild is the guild that the bot was added to, if the bot scope was
+	// granted.
+	Guild *discord.Guild `json:"guild,omitempty"`
+	// Webhook is the webhook that was created, if the webhook.incoming scope
+	// was granted.
+	Webhook *discord.Webhook `json:"webhook,omitempty"`
+}
+
+// expiryDelta is how long before its expiry a token is considered expired, so
+// that it doesn't expire while a request is in flight.
+const expiryDelta = 10 * time.Second
+
+// Valid returns true if the token has an access token that hasn't expired.
+func (t Token) Valid() bool {
+	return t.AccessToken != "" && !t.Expired()
+}
+
+// Expired returns true if the access token has expired or is about to. A
+// token without an expiry never expires.
+func (t Token) Expired() bool {
+	return !t.Expiry.IsZero() && time.Now().Add(expiryDelta).After(t.Expiry)
+}
+
+// Scopes returns the scopes that the token was granted.
+func (t Token) Scopes() []string {
+	return strings.Fields(t.Scope)
+}
+
+// HasScope returns true if the token was granted the given scope.
+func (t Token) HasScope(scope string) bool {
+	for _, s := range t.Scopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorization returns the value of the Authorization header for the token.
+func (t Token) Authorization() string {
+	typ := t.TokenType
+	if typ == "" {
+		typ = "Bearer"
+	}
+	return typ + " " + t.AccessToken
+}
+
+// Error is returned when Discord rejects an OAuth2 token request, such as
+// when an authorization code is invalid or has already been used.
+//
+// https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+type Error struct {
+	// Code is the error code, such as "invalid_grant".
+	Code string `json:"error"`
+	// Description is the human-readable description of the error.
+	Description string `json:"error_description,omitempty"`
+
+	// HTTPError is the underlying error.
+	HTTPError *httputil.HTTPError `json:"-"`
+}
+
+// Error implements error.
+func (err *Error) Error() string {
+	if err.Description != "" {
+		return "oauth2: " + err.Code + ": " + err.Description
+	}
+	return "oauth2: " + err.Code
+}
+
+// Unwrap returns the underlying HTTP error.
+func (err *Error) Unwrap() error {
+	return err.HTTPError
+}
+
+// Config is the configuration of an OAuth2 application.
+type Config struct {
+	// ClientID is the ID of the application.
+	ClientID discord.AppID
+	// ClientSecret is the secret of the application.
+	ClientSecret string
+	// RedirectURI is the URI that users are redirected to after authorizing.
+	// It must be one of the redirects registered for the application.
+	RedirectURI string
+	// Scopes is the list of scopes to request.
+	Scopes []string
+
+	// Client is the HTTP client used for token requests. If it's nil, then
+	// httputil.NewClient is used.
+	Client *httputil.Client
+}
+
+// AuthorizeURL returns the URL that users are sent to for authorizing the
+// application. The state is sent back to the redirect URI unchanged, and
+// should be used to protect against CSRF.
+func (c *Config) AuthorizeURL(state string) string {
+	v := url.Values{
+		"client_id":     {c.ClientID.String()},
+		"response_type": {"code"},
+		"scope":         {strings.Join(c.Scopes, " ")},
+	}
+	if c.RedirectURI != "" {
+		v.Set("redirect_uri", c.RedirectURI)
+	}
+	if state != "" {
+		v.Set("state", state)
+	}
+
+	return EndpointAuthorize + "?" + v.Encode()
+}
+
+// Exchange exchanges the authorization code given to the redirect URI for a
+// token.
+func (c *Config) Exchange(ctx context.Context, code string) (*Token, error) {
+	v := url.Values{
+		"grant_type": {"authorization_code"},
+		"code":       {code},
+	}
+	if c.RedirectURI != "" {
+		v.Set("redirect_uri", c.RedirectURI)
+	}
+
+	return c.requestToken(ctx, v)
+}
+
+// ClientCredentials gets a token for the user that owns the application, which
+// is useful for testing. Tokens from this flow can't be refreshed.
+func (c *Config) ClientCredentials(ctx context.Context) (*Token, error) {
+	return c.requestToken(ctx, url.Values{
+		"grant_type": {"client_credentials"},
+		"scope":      {strings.Join(c.Scopes, " ")},
+	})
+}
+
+// Refresh gets a new token using the given refresh token. The old access and
+// refresh tokens are no longer valid afterwards.
+func (c *Config) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	return c.requestToken(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+}
+
+// Revoke revokes the given access or refresh token. Revoking either token
+// revokes both.
+func (c *Config) Revoke(ctx context.Context, token string) error {
+	return c.client(ctx).FastRequest("POST", EndpointTokenRevoke,
+		c.withForm(url.Values{"token": {token}}),
+	)
+}
+
+func (c *Config) requestToken(ctx context.Context, v url.Values) (*Token, error) {
+	var token *Token
+	if err := c.client(ctx).RequestJSON(&token, "POST", EndpointToken, c.withForm(v)); err != nil {
+		return nil, wrapError(err)
+	}
+
+	if token.Expiry.IsZero() && token.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+func (c *Config) client(ctx context.Context) *httputil.Client {
+	client := c.Client
+	if client == nil {
+		client = httputil.NewClient()
+	}
+	return client.WithContext(ctx)
+}
+
+// withForm sets the form-encoded body of the request along with the client's
+// credentials. A new body is made for every attempt, so that retried requests
+// don't send an empty body.
+func (c *Config) withForm(v url.Values) httputil.RequestOption {
+	return func(r httpdriver.Request) error {
+		form := make(url.Values, len(v)+2)
+		for k, vs := range v {
+			form[k] = vs
+		}
+		form.Set("client_id", c.ClientID.String())
+		form.Set("client_secret", c.ClientSecret)
+
+		r.AddHeader(http.Header{
+			"Content-Type": {"application/x-www-form-urlencoded"},
+		})
+		r.WithBody(io.NopCloser(strings.NewReader(form.Encode())))
+		return nil
+	}
+}
+
+// wrapError turns HTTP errors with an OAuth2 error body into an *Error.
+func wrapError(err error) error {
+	var httpErr *httputil.HTTPError
+	if !errors.As(err, &httpErr) {
+		return err
+	}
+
+	oauthErr := &Error{HTTPError: httpErr}
+	if json.Unmarshal(httpErr.Body, oauthErr) != nil || oauthErr.Code == "" {
+		return err
+	}
+
+	return oauthErr
+}
+
+// TokenSource holds a token and refreshes it once it expires. It is safe for
+// concurrent use.
+type TokenSource struct {
+	config *Config
+
+	// OnRefresh, if not nil, is called with the new token whenever the token
+	// is refreshed, so that it can be stored. It must be set before the
+	// TokenSource is used.
+	OnRefresh func(Token)
+
+	mu    sync.Mutex
+	token Token
+}
+
+// TokenSource creates a TokenSource that starts with the given token.
+func (c *Config) TokenSource(token Token) *TokenSource {
+	return &TokenSource{
+		config: c,
+		token:  token,
+	}
+}
+
+// Token returns the current token, refreshing it first if it has expired.
+func (s *TokenSource) Token(ctx context.Context) (Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() {
+		return s.token, nil
+	}
+
+	if s.token.RefreshToken == "" {
+		return s.token, errors.New("oauth2: token expired and has no refresh token")
+	}
+
+	token, err := s.config.Refresh(ctx, s.token.RefreshToken)
+	if err != nil {
+		return s.token, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	s.token = *token
+
+	if s.OnRefresh != nil {
+		s.OnRefresh(*token)
+	}
+
+	return s.token, nil
+}
+
+// NewClient creates an api.Client that authenticates using the given access
+// token as a bearer token. Only the endpoints that the token's scopes allow can
+// be used, such as Me, Guilds, CurrentMember and CurrentAuthorization.
+func NewClient(accessToken string) *api.Client {
+	return api.NewClient("Bearer " + accessToken)
+}
+
+// NewRefreshingClient creates an api.Client that authenticates using the token
+// from the given TokenSource, which is refreshed before any request if it has
+// expired.
+func NewRefreshingClient(src *TokenSource) *api.Client {
+	client := api.NewClient("")
+	// The Authorization header set by the api.Client is replaced, since this
+	// runs after it.
+	client.Client.OnRequest = append(client.Client.OnRequest, func(r httpdriver.Request) error {
+		token, err := src.Token(r.GetContext())
+		if err != nil {
+			return err
+		}
+
+		r.AddHeader(http.Header{"Authorization": {token.Authorization()}})
+		return nil
+	})
+
+	return client
+}
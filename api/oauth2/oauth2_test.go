@@ -0,0 +1,90 @@
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+)
+
+func TestTokenSource(t *testing.T) {
+	var auths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if r.PostFormValue("client_secret") != "secret" {
+				t.Error("unexpected client secret:", r.PostFormValue("client_secret"))
+			}
+
+			switch r.PostFormValue("grant_type") {
+			case "authorization_code":
+				if r.PostFormValue("code") != "code" {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"error":"invalid_grant","error_description":"Invalid code"}`))
+					return
+				}
+				w.Write([]byte(`{"access_token":"old","token_type":"Bearer","expires_in":1,"refresh_token":"refresh","scope":"identify guilds"}`))
+			case "refresh_token":
+				if r.PostFormValue("refresh_token") != "refresh" {
+					t.Error("unexpected refresh token:", r.PostFormValue("refresh_token"))
+				}
+				w.Write([]byte(`{"access_token":"new","token_type":"Bearer","expires_in":604800,"refresh_token":"refresh2","scope":"identify guilds"}`))
+			}
+		default:
+			auths = append(auths, r.Header.Get("Authorization"))
+			w.Write([]byte(`{"id":"1","username":"user"}`))
+		}
+	}))
+	defer srv.Close()
+
+	oldToken := EndpointToken
+	EndpointToken = srv.URL + "/token"
+	defer func() { EndpointToken = oldToken }()
+
+	config := &Config{ClientID: 1, ClientSecret: "secret"}
+
+	_, err := config.Exchange(context.Background(), "bad")
+	var oauthErr *Error
+	if !errors.As(err, &oauthErr) || oauthErr.Code != "invalid_grant" {
+		t.Fatal("expected invalid_grant error, got", err)
+	}
+
+	token, err := config.Exchange(context.Background(), "code")
+	if err != nil {
+		t.Fatal("failed to exchange code:", err)
+	}
+	if !token.Expired() {
+		t.Fatal("token expiring in a second is not expired")
+	}
+	if !token.HasScope(ScopeGuilds) {
+		t.Fatal("token is missing the guilds scope")
+	}
+
+	var refreshed []Token
+	src := config.TokenSource(*token)
+	src.OnRefresh = func(token Token) { refreshed = append(refreshed, token) }
+
+	client := NewRefreshingClient(src)
+	for i := 0; i < 2; i++ {
+		if err := client.FastRequest("GET", srv.URL+api.Path+"/users/@me"); err != nil {
+			t.Fatal("failed to make request:", err)
+		}
+	}
+
+	if len(refreshed) != 1 || refreshed[0].RefreshToken != "refresh2" {
+		t.Fatalf("unexpected refreshes: %+v", refreshed)
+	}
+	if !refreshed[0].Expiry.After(time.Now().Add(time.Hour)) {
+		t.Fatal("refreshed token has no expiry:", refreshed[0].Expiry)
+	}
+
+	for _, auth := range auths {
+		if auth != "Bearer new" {
+			t.Fatalf("unexpected Authorization %q", auth)
+		}
+	}
+}
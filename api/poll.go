@@ -0,0 +1,193 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/internal/intmath"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+)
+
+// MaxPollAnswerVotersFetchLimit is the maximum number of voters that can be
+// fetched in a single request.
+const MaxPollAnswerVotersFetchLimit = 100
+
+// CreatePollData is the data used to create a poll when sending a message.
+//
+// https://discord.com/developers/docs/resources/poll#poll-create-request-object
+type CreatePollData struct {
+	// Question is the question of the poll. Only Text is supported.
+	Question discord.PollMedia `json:"question"`
+	// Answers are each of the answers available in the poll, up to 10.
+	// AnswerID must not be set.
+	Answers []discord.PollAnswer `json:"answers"`
+	// Duration is the number of hours the poll should be open for, up to 32
+	// days. It defaults to 24.
+	Duration int `json:"duration,omitempty"`
+	// AllowMultiselect specifies whether a user can select multiple answers.
+	AllowMultiselect bool `json:"allow_multiselect,omitempty"`
+	// LayoutType is the layout type of the poll. It defaults to
+	// DefaultPollLayout.
+	LayoutType discord.PollLayoutType `json:"layout_type,omitempty"`
+}
+
+// NewPoll creates a new CreatePollData with the given question and text
+// answers.
+func NewPoll(question string, answers ...string) *CreatePollData {
+	data := &CreatePollData{
+		Question: discord.PollMedia{Text: question},
+		Answers:  make([]discord.PollAnswer, len(answers)),
+	}
+	for i, answer := range answers {
+		data.Answers[i].PollMedia.Text = answer
+	}
+	return data
+}
+
+// Validate validates the poll.
+func (data CreatePollData) Validate() error {
+	if data.Question.Text == "" {
+		return errors.New("poll question is empty")
+	}
+	if len(data.Question.Text) > discord.MaxPollQuestionLength {
+		return &discord.OverboundError{
+			Count: len(data.Question.Text),
+			Max:   discord.MaxPollQuestionLength,
+			Thing: "poll question",
+		}
+	}
+
+	if len(data.Answers) == 0 {
+		return errors.New("poll has no answers")
+	}
+	if len(data.Answers) > discord.MaxPollAnswers {
+		return &discord.OverboundError{
+			Count: len(data.Answers),
+			Max:   discord.MaxPollAnswers,
+			Thing: "poll answers",
+		}
+	}
+
+	for i, answer := range data.Answers {
+		if answer.PollMedia.Text == "" && answer.PollMedia.Emoji == nil {
+			return fmt.Errorf("poll answer %d is empty", i)
+		}
+		if len(answer.PollMedia.Text) > discord.MaxPollAnswerLength {
+			return &discord.OverboundError{
+				Count: len(answer.PollMedia.Text),
+				Max:   discord.MaxPollAnswerLength,
+				Thing: fmt.Sprintf("poll answer %d", i),
+			}
+		}
+	}
+
+	if data.Duration < 0 || data.Duration > discord.MaxPollDuration {
+		return fmt.Errorf("poll duration %d is not between 1 and %d hours",
+			data.Duration, discord.MaxPollDuration)
+	}
+
+	return nil
+}
+
+// PollAnswerVoters returns a list of users that voted for the given answer.
+// This method automatically paginates until it reaches the passed limit, or,
+// if the limit is set to 0, has fetched all voters.
+//
+// As the underlying endpoint has a maximum of 100 users per request, at
+// maximum a total of limit/100 rounded up requests will be made, although they
+// may be less, if no more users are available.
+func (c CtxClient) PollAnswerVoters(
+	channelID discord.ChannelID,
+	messageID discord.MessageID, answerID int, limit uint) ([]discord.User, error) {
+
+	return c.PollAnswerVotersAfter(channelID, messageID, answerID, 0, limit)
+}
+
+// PollAnswerVotersAfter returns a list of users that voted for the given
+// answer with an ID higher than after. Pagination works the same as
+// PollAnswerVoters.
+func (c CtxClient) PollAnswerVotersAfter(
+	channelID discord.ChannelID, messageID discord.MessageID,
+	answerID int, after discord.UserID, limit uint) ([]discord.User, error) {
+
+	users := make([]discord.User, 0, limit)
+
+	fetch := uint(MaxPollAnswerVotersFetchLimit)
+
+	unlimited := limit == 0
+
+	for limit > 0 || unlimited {
+		if limit > 0 {
+			// Only fetch as much as we need. Since limit gradually decreases,
+			// we only need to fetch intmath.Min(fetch, limit).
+			fetch = uint(intmath.Min(MaxPollAnswerVotersFetchLimit, int(limit)))
+			limit -= fetch
+		}
+
+		r, err := c.pollAnswerVotersAfter(channelID, messageID, answerID, after, fetch)
+		if err != nil {
+			return users, err
+		}
+		users = append(users, r...)
+
+		if len(r) < MaxPollAnswerVotersFetchLimit {
+			break
+		}
+
+		after = r[len(r)-1].ID
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	return users, nil
+}
+
+func (c CtxClient) pollAnswerVotersAfter(
+	channelID discord.ChannelID, messageID discord.MessageID,
+	answerID int, after discord.UserID, limit uint) ([]discord.User, error) {
+
+	switch {
+	case limit == 0:
+		limit = 25
+	case limit > 100:
+		limit = 100
+	}
+
+	var param struct {
+		After discord.UserID `schema:"after,omitempty"`
+		Limit uint           `schema:"limit"`
+	}
+
+	param.After = after
+	param.Limit = limit
+
+	var resp struct {
+		Users []discord.User `json:"users"`
+	}
+
+	return resp.Users, c.RequestJSON(
+		&resp, "GET", EndpointChannels+channelID.String()+
+			"/polls/"+messageID.String()+
+			"/answers/"+strconv.Itoa(answerID),
+		httputil.WithSchema(c, param),
+	)
+}
+
+// EndPoll immediately ends the poll in the given message. The message must
+// have been sent by the current user. The message with the ended poll is
+// returned.
+//
+// Fires a Message Update Gateway event.
+func (c CtxClient) EndPoll(
+	channelID discord.ChannelID, messageID discord.MessageID) (*discord.Message, error) {
+
+	var msg *discord.Message
+	return msg, c.RequestJSON(
+		&msg, "POST",
+		EndpointChannels+channelID.String()+"/polls/"+messageID.String()+"/expire",
+	)
+}
@@ -0,0 +1,195 @@
+package rate
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// LimiterState is the serializable state of a Limiter. It can be obtained
+// using Limiter.State before shutting down and given back to Limiter.Restore
+// on startup, so that a quickly restarting bot doesn't immediately hit rate
+// limits that it has just exhausted.
+type LimiterState struct {
+	// Global is the time until which the global rate limit is in effect. It
+	// is zero if there is no global rate limit.
+	Global time.Time `json:"global,omitempty"`
+	// Buckets contains the states of the buckets that have not reset yet.
+	Buckets []BucketState `json:"buckets"`
+}
+
+// BucketState is the serializable state of a single rate limit bucket.
+type BucketState struct {
+	// Key is the parsed bucket key of the bucket. Refer to ParseBucketKey.
+	Key string `json:"key"`
+	// Limit is the number of requests that can be made per reset. It is 0 if
+	// it's unknown.
+	Limit uint64 `json:"limit,omitempty"`
+	// Remaining is the number of requests remaining until Reset.
+	Remaining uint64 `json:"remaining"`
+	// Reset is the time when the bucket resets.
+	Reset time.Time `json:"reset"`
+}
+
+// State returns a snapshot of the limiter's state. Buckets that have already
+// reset are omitted, since they carry no information. Custom rate limits are
+// also omitted, since they're restored by the CustomLimits field.
+//
+// Buckets that are being used by an ongoing request are skipped as well, since
+// their state is about to change anyway. For this reason, State should be
+// called once all requests are done, such as right before exiting.
+func (l *Limiter) State() LimiterState {
+	now := time.Now()
+
+	var state LimiterState
+	if global := time.Unix(0, atomic.LoadInt64(l.global)); global.After(now) {
+		state.Global = global
+	}
+
+	l.bucketMu.Lock()
+	defer l.bucketMu.Unlock()
+
+	for _, b := range l.buckets {
+		if b.custom != nil || !b.lock.TryLock() {
+			continue
+		}
+
+		if bs := b.state(); bs.Reset.After(now) {
+			state.Buckets = append(state.Buckets, bs)
+		}
+
+		b.lock.Unlock()
+	}
+
+	return state
+}
+
+// Snapshot returns the current state of the limiter for introspection, such
+// as showing rate limit pressure on a dashboard. Unlike State, it includes
+// every known bucket, including custom rate limits, buckets that are in use and
+// buckets whose Reset has passed, which means that all of their requests are
+// available again.
+func (l *Limiter) Snapshot() LimiterState {
+	var state LimiterState
+	if global := time.Unix(0, atomic.LoadInt64(l.global)); global.After(time.Now()) {
+		state.Global = global
+	}
+
+	l.bucketMu.Lock()
+	defer l.bucketMu.Unlock()
+
+	state.Buckets = make([]BucketState, 0, len(l.buckets))
+	for _, b := range l.buckets {
+		state.Buckets = append(state.Buckets, b.state())
+	}
+
+	sort.Slice(state.Buckets, func(i, j int) bool {
+		return state.Buckets[i].Key < state.Buckets[j].Key
+	})
+
+	return state
+}
+
+func (b *bucket) state() BucketState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return BucketState{
+		Key:       b.key,
+		Limit:     b.limit,
+		Remaining: b.remaining,
+		Reset:     b.reset,
+	}
+}
+
+// Restore loads the given state into the limiter. Buckets that have already
+// reset are ignored, and buckets that already exist in the limiter are
+// overridden only if they're not in use.
+func (l *Limiter) Restore(state LimiterState) {
+	now := time.Now()
+
+	if state.Global.After(now) {
+		atomic.StoreInt64(l.global, state.Global.UnixNano())
+	}
+
+	for _, bs := range state.Buckets {
+		if !bs.Reset.After(now) {
+			continue
+		}
+
+		// Bucket keys are already parsed, so getBucket will give us the same
+		// key back.
+		b := l.getBucket(l.Prefix+bs.Key, true)
+		if b.custom != nil || !b.lock.TryLock() {
+			continue
+		}
+
+		b.mu.Lock()
+		b.limit = bs.Limit
+		b.remaining = bs.Remaining
+		b.reset = bs.Reset
+		b.mu.Unlock()
+
+		b.lock.Unlock()
+	}
+}
+
+// Save writes the limiter's state as JSON into the given writer.
+func (l *Limiter) Save(w io.Writer) error {
+	return json.EncodeStream(w, l.State())
+}
+
+// Load reads the limiter's state as JSON from the given reader and restores
+// it.
+func (l *Limiter) Load(r io.Reader) error {
+	var state LimiterState
+	if err := json.DecodeStream(r, &state); err != nil {
+		return err
+	}
+
+	l.Restore(state)
+	return nil
+}
+
+// SaveFile saves the limiter's state into the file at the given path. The file
+// is written atomically, so a crash while saving will never leave a corrupted
+// file behind.
+func (l *Limiter) SaveFile(path string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".ratelimit-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := l.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// LoadFile loads the limiter's state from the file at the given path. A
+// missing file is not an error, since there may not be a previous state.
+func (l *Limiter) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	return l.Load(f)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -34,6 +35,28 @@ type Limiter struct {
 
 	Prefix string
 
+	// Store, if not nil, stores the state of the buckets and the global rate
+	// limit, so that it can be shared with other limiters, such as those of
+	// other processes using the same token. Custom rate limits are not
+	// stored. It must be set before the limiter is used.
+	Store Store
+	// StoreTimeout is the timeout of the Store calls made by Release, which
+	// has no context of its own. If it's 0, then DefaultStoreTimeout is used.
+	StoreTimeout time.Duration
+	// OnStoreError, if not nil, is called when Release fails to update the
+	// Store. Release itself doesn't fail, since the request has already been
+	// sent, and failing it would make the client retry it. It must not
+	// block.
+	OnStoreError func(error)
+
+	// OnWait, if not nil, is called before Acquire waits for a rate limit
+	// that is known in advance, so no request is sent only to be rate
+	// limited. It must not block.
+	OnWait func(Wait)
+	// OnRateLimited, if not nil, is called when Discord responds with 429 Too
+	// Many Requests. It must not block.
+	OnRateLimited func(RateLimited)
+
 	// global is a pointer to prevent ARM-compatibility alignment.
 	global *int64 // atomic guarded, unixnano
 
@@ -41,6 +64,26 @@ type Limiter struct {
 	buckets  map[string]*bucket
 }
 
+// Wait describes a wait for a rate limit in Acquire.
+type Wait struct {
+	// Key is the parsed bucket key of the bucket. Refer to ParseBucketKey.
+	Key string
+	// Global is true if the wait is for the global rate limit.
+	Global bool
+	// Duration is how long Acquire will wait for.
+	Duration time.Duration
+}
+
+// RateLimited describes a 429 Too Many Requests response from Discord.
+type RateLimited struct {
+	// Key is the parsed bucket key of the bucket. Refer to ParseBucketKey.
+	Key string
+	// Global is true if the global rate limit was hit.
+	Global bool
+	// RetryAfter is how long to wait before retrying.
+	RetryAfter time.Duration
+}
+
 type CustomRateLimit struct {
 	Contains string
 	Reset    time.Duration
@@ -68,16 +111,22 @@ func (opts AcquireOptions) Context(ctx context.Context) context.Context {
 type bucket struct {
 	lock   moreatomic.CtxMutex
 	custom *CustomRateLimit
+	key    string
 
+	// mu guards the fields below, so that they can be read by Snapshot while
+	// lock is held by a request.
+	mu        sync.Mutex
+	limit     uint64
 	remaining uint64
 
 	reset     time.Time
 	lastReset time.Time // only for custom
 }
 
-func newBucket() *bucket {
+func newBucket(key string) *bucket {
 	return &bucket{
 		lock:      *moreatomic.NewCtxMutex(),
+		key:       key,
 		remaining: 1,
 	}
 }
@@ -91,8 +140,29 @@ func NewLimiter(prefix string) *Limiter {
 	}
 }
 
+// apiPathRe matches the path prefix of any version of the API.
+var apiPathRe = regexp.MustCompile(`^/api/v\d+`)
+
+// BucketKey returns the bucket key of the request path. Prefix is removed from
+// the path first, or, if the path doesn't start with it, the API path of
+// whichever version the path uses, so that clients using another version of
+// the API share the same buckets.
+func (l *Limiter) BucketKey(path string) string {
+	if l.Prefix != "" && strings.HasPrefix(path, l.Prefix+"/") {
+		return ParseBucketKey(path[len(l.Prefix):])
+	}
+
+	if loc := apiPathRe.FindStringIndex(path); loc != nil {
+		if rest := path[loc[1]:]; strings.HasPrefix(rest, "/") {
+			path = rest
+		}
+	}
+
+	return ParseBucketKey(path)
+}
+
 func (l *Limiter) getBucket(path string, store bool) *bucket {
-	path = ParseBucketKey(strings.TrimPrefix(path, l.Prefix))
+	path = l.BucketKey(path)
 
 	l.bucketMu.Lock()
 	defer l.bucketMu.Unlock()
@@ -103,7 +173,7 @@ func (l *Limiter) getBucket(path string, store bool) *bucket {
 	}
 
 	if !ok {
-		bc := newBucket()
+		bc := newBucket(path)
 
 		for _, limit := range l.CustomLimits {
 			if strings.Contains(path, limit.Contains) {
@@ -134,42 +204,103 @@ func (l *Limiter) Acquire(ctx context.Context, path string) error {
 		return err
 	}
 
+	// Custom rate limits are only ever tracked locally.
+	if l.Store != nil && b.custom == nil {
+		return l.acquireStore(ctx, b, options)
+	}
+
 	// Deadline until the limiter is released.
 	until := time.Time{}
 	now := time.Now()
+	global := false
 
+	b.mu.Lock()
 	if b.remaining == 0 && b.reset.After(now) {
 		// out of turns, gotta wait
 		until = b.reset
 	} else {
 		// maybe global rate limit has it
 		until = time.Unix(0, atomic.LoadInt64(l.global))
+		global = true
 	}
+	b.mu.Unlock()
 
-	if until.After(now) {
-		if options.DontWait {
-			return ErrTimedOutEarly
-		} else if deadline, ok := ctx.Deadline(); ok && until.After(deadline) {
-			return ErrTimedOutEarly
+	if err := l.wait(ctx, b, until, global, options); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	if b.remaining > 0 {
+		b.remaining--
+	}
+	b.mu.Unlock()
+
+	return nil
+}
+
+// acquireStore acquires the bucket using the limiter's Store.
+func (l *Limiter) acquireStore(ctx context.Context, b *bucket, options AcquireOptions) error {
+	for {
+		global, err := l.Store.Global(ctx)
+		if err != nil {
+			b.lock.Unlock()
+			return fmt.Errorf("rate: failed to get global rate limit: %w", err)
 		}
 
-		select {
-		case <-ctx.Done():
+		if err := l.wait(ctx, b, global, true, options); err != nil {
+			return err
+		}
+
+		until, err := l.Store.Take(ctx, b.key, time.Now())
+		if err != nil {
 			b.lock.Unlock()
-			return ctx.Err()
-		case <-time.After(until.Sub(now)):
+			return fmt.Errorf("rate: failed to take from bucket: %w", err)
+		}
+
+		if !until.After(time.Now()) {
+			return nil
+		}
+
+		// Another process has used up the bucket, so wait for it to reset and
+		// try again.
+		if err := l.wait(ctx, b, until, false, options); err != nil {
+			return err
 		}
 	}
+}
 
-	if b.remaining > 0 {
-		b.remaining--
+// wait waits until the given time for the bucket, if it's in the future. The
+// bucket is unlocked if the wait is interrupted by ctx.
+func (l *Limiter) wait(
+	ctx context.Context, b *bucket, until time.Time, global bool, options AcquireOptions) error {
+
+	now := time.Now()
+	if !until.After(now) {
+		return nil
 	}
 
-	return nil
+	if options.DontWait {
+		return ErrTimedOutEarly
+	} else if deadline, ok := ctx.Deadline(); ok && until.After(deadline) {
+		return ErrTimedOutEarly
+	}
+
+	if l.OnWait != nil {
+		l.OnWait(Wait{Key: b.key, Global: global, Duration: until.Sub(now)})
+	}
+
+	select {
+	case <-ctx.Done():
+		b.lock.Unlock()
+		return ctx.Err()
+	case <-time.After(until.Sub(now)):
+		return nil
+	}
 }
 
 // Release releases the URL from the locks. This doesn't need a context for
-// timing out, since it doesn't block that much.
+// timing out, since it doesn't block that much. Updating the Store is bounded
+// by StoreTimeout instead, and its errors are given to OnStoreError.
 func (l *Limiter) Release(path string, headers http.Header) error {
 	b := l.getBucket(path, false)
 	if b == nil {
@@ -179,6 +310,22 @@ func (l *Limiter) Release(path string, headers http.Header) error {
 	// TryUnlock because Release may be called when Acquire has not been.
 	defer b.lock.TryUnlock()
 
+	// Call OnRateLimited and update the Store after unlocking, so that they
+	// can use Snapshot.
+	var limited *RateLimited
+	var update *BucketState
+	defer func() {
+		if limited != nil && l.OnRateLimited != nil {
+			l.OnRateLimited(*limited)
+		}
+		if err := l.updateStore(update, limited); err != nil && l.OnStoreError != nil {
+			l.OnStoreError(err)
+		}
+	}()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	// Check custom limiter
 	if b.custom != nil {
 		now := time.Now()
@@ -201,6 +348,7 @@ func (l *Limiter) Release(path string, headers http.Header) error {
 		global = headers.Get("X-RateLimit-Global")
 
 		// seconds
+		limit      = headers.Get("X-RateLimit-Limit")
 		remaining  = headers.Get("X-RateLimit-Remaining")
 		reset      = headers.Get("X-RateLimit-Reset") // float
 		retryAfter = headers.Get("Retry-After")
@@ -213,7 +361,8 @@ func (l *Limiter) Release(path string, headers http.Header) error {
 			return fmt.Errorf("invalid retryAfter %q: %w", retryAfter, err)
 		}
 
-		at := time.Now().Add(time.Duration(i) * time.Second)
+		wait := time.Duration(i) * time.Second
+		at := time.Now().Add(wait)
 
 		if global != "" { // probably "true"
 			atomic.StoreInt64(l.global, at.UnixNano())
@@ -221,6 +370,8 @@ func (l *Limiter) Release(path string, headers http.Header) error {
 			b.reset = at
 		}
 
+		limited = &RateLimited{Key: b.key, Global: global != "", RetryAfter: wait}
+
 	case reset != "":
 		unix, err := strconv.ParseFloat(reset, 64)
 		if err != nil {
@@ -233,6 +384,15 @@ func (l *Limiter) Release(path string, headers http.Header) error {
 		b.reset = time.Unix(sec, nsec).Add(ExtraDelay)
 	}
 
+	if limit != "" {
+		u, err := strconv.ParseUint(limit, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid limit %q: %w", limit, err)
+		}
+
+		b.limit = u
+	}
+
 	if remaining != "" {
 		u, err := strconv.ParseUint(remaining, 10, 64)
 		if err != nil {
@@ -242,5 +402,15 @@ func (l *Limiter) Release(path string, headers http.Header) error {
 		b.remaining = u
 	}
 
+	// Only update the Store if the response had the bucket's state.
+	if l.Store != nil && (remaining != "" || reset != "" || (retryAfter != "" && global == "")) {
+		update = &BucketState{
+			Key:       b.key,
+			Limit:     b.limit,
+			Remaining: b.remaining,
+			Reset:     b.reset,
+		}
+	}
+
 	return nil
 }
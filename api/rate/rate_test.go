@@ -1,7 +1,9 @@
 package rate
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -78,3 +80,194 @@ func TestRatelimitGlobal(t *testing.T) {
 		t.Error("did not ratelimit correctly, got:", time.Since(sent))
 	}
 }
+
+func TestLimiterPersist(t *testing.T) {
+	l := NewLimiter("/api/v9")
+
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Remaining", "0")
+	headers.Set("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(time.Hour).Unix()))
+
+	mockRequest(t, l, "/api/v9/channels/1/messages", headers)
+
+	// Reset bucket that should be omitted.
+	expired := http.Header{}
+	expired.Set("X-RateLimit-Remaining", "0")
+	expired.Set("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(-time.Hour).Unix()))
+
+	mockRequest(t, l, "/api/v9/users/@me", expired)
+
+	var buf bytes.Buffer
+	if err := l.Save(&buf); err != nil {
+		t.Fatal("failed to save:", err)
+	}
+
+	restored := NewLimiter("/api/v9")
+	if err := restored.Load(&buf); err != nil {
+		t.Fatal("failed to load:", err)
+	}
+
+	state := restored.State()
+	if len(state.Buckets) != 1 {
+		t.Fatalf("expected 1 bucket, got %d", len(state.Buckets))
+	}
+
+	if b := state.Buckets[0]; b.Key != "/channels/1/messages" || b.Remaining != 0 {
+		t.Fatalf("unexpected bucket %+v", b)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := restored.Acquire(ctx, "/api/v9/channels/1/messages")
+	if !errors.Is(err, ErrTimedOutEarly) {
+		t.Fatal("expected restored bucket to be rate limited, got", err)
+	}
+}
+
+func TestLimiterCallbacks(t *testing.T) {
+	l := NewLimiter("")
+
+	var limited []RateLimited
+	l.OnRateLimited = func(r RateLimited) {
+		limited = append(limited, r)
+		// Snapshot must not deadlock when called from the callback.
+		l.Snapshot()
+	}
+
+	var waits []Wait
+	l.OnWait = func(w Wait) { waits = append(waits, w) }
+
+	tooMany := http.Header{}
+	tooMany.Set("Retry-After", "0")
+	mockRequest(t, l, "/channels/1/messages", tooMany)
+
+	if len(limited) != 1 || limited[0].Key != "/channels/1/messages" || limited[0].Global {
+		t.Fatalf("unexpected rate limits: %+v", limited)
+	}
+
+	reset := float64(time.Now().Add(100*time.Millisecond).UnixNano()) / float64(time.Second)
+
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Limit", "5")
+	headers.Set("X-RateLimit-Remaining", "0")
+	headers.Set("X-RateLimit-Reset", fmt.Sprintf("%.3f", reset))
+	mockRequest(t, l, "/guilds/1/channels", headers)
+
+	snapshot := l.Snapshot()
+	if len(snapshot.Buckets) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(snapshot.Buckets))
+	}
+	if b := snapshot.Buckets[1]; b.Key != "/guilds/1/channels" || b.Limit != 5 || b.Remaining != 0 {
+		t.Fatalf("unexpected bucket %+v", b)
+	}
+
+	mockRequest(t, l, "/guilds/1/channels", nil)
+
+	if len(waits) != 1 || waits[0].Key != "/guilds/1/channels" || waits[0].Duration <= 0 {
+		t.Fatalf("unexpected waits: %+v", waits)
+	}
+}
+
+func TestLimiterStore(t *testing.T) {
+	store := NewMemoryStore()
+
+	// Two limiters share the same store, like two processes would.
+	l1 := NewLimiter("")
+	l1.Store = store
+	l2 := NewLimiter("")
+	l2.Store = store
+
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Remaining", "0")
+	headers.Set("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(time.Hour).Unix()))
+
+	mockRequest(t, l1, "/channels/1/messages", headers)
+
+	ctx := AcquireOptions{DontWait: true}.Context(context.Background())
+
+	err := l2.Acquire(ctx, "/channels/1/messages")
+	if !errors.Is(err, ErrTimedOutEarly) {
+		t.Fatal("expected bucket exhausted by the other limiter, got", err)
+	}
+	l2.Release("/channels/1/messages", nil)
+
+	if err := l2.Acquire(ctx, "/channels/2/messages"); err != nil {
+		t.Fatal("unexpected error for another bucket:", err)
+	}
+	l2.Release("/channels/2/messages", nil)
+
+	global := http.Header{}
+	global.Set("X-RateLimit-Global", "true")
+	global.Set("Retry-After", "60")
+
+	mockRequest(t, l1, "/channels/2/messages", global)
+
+	err = l2.Acquire(ctx, "/guilds/1")
+	if !errors.Is(err, ErrTimedOutEarly) {
+		t.Fatal("expected global rate limit from the other limiter, got", err)
+	}
+}
+
+// blockingStore is a Store whose Update blocks until its context is done.
+type blockingStore struct{ *MemoryStore }
+
+func (s blockingStore) Update(ctx context.Context, state BucketState) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestLimiterStoreTimeout(t *testing.T) {
+	l := NewLimiter("")
+	l.Store = blockingStore{NewMemoryStore()}
+	l.StoreTimeout = 10 * time.Millisecond
+
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Remaining", "1")
+	headers.Set("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(time.Hour).Unix()))
+
+	if err := l.Acquire(context.Background(), "/channels/1/messages"); err != nil {
+		t.Fatal("failed to acquire:", err)
+	}
+
+	var storeErr error
+	l.OnStoreError = func(err error) { storeErr = err }
+
+	start := time.Now()
+	if err := l.Release("/channels/1/messages", headers); err != nil {
+		t.Fatal("expected Release to succeed, got", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatal("Release blocked for", elapsed)
+	}
+	if !errors.Is(storeErr, context.DeadlineExceeded) {
+		t.Fatal("expected the store update to time out, got", storeErr)
+	}
+}
+
+func TestMemoryStoreRefill(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+
+	store.Update(context.Background(), BucketState{
+		Key:       "/channels/1/messages",
+		Limit:     2,
+		Remaining: 0,
+		Reset:     now.Add(time.Second),
+	})
+
+	until, _ := store.Take(context.Background(), "/channels/1/messages", now)
+	if !until.Equal(now.Add(time.Second)) {
+		t.Fatal("expected to wait for the reset, got", until)
+	}
+
+	// Once the bucket resets, it's refilled from its limit.
+	later := now.Add(2 * time.Second)
+	if until, _ := store.Take(context.Background(), "/channels/1/messages", later); !until.IsZero() {
+		t.Fatal("expected take to succeed, got", until)
+	}
+
+	if b := store.buckets["/channels/1/messages"]; b.Remaining != 1 || !b.Reset.IsZero() {
+		t.Fatalf("expected the bucket to be refilled, got %+v", b)
+	}
+}
@@ -0,0 +1,172 @@
+package rate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// RedisClient is the part of a Redis client that RedisStore needs, which is
+// only evaluating Lua scripts. This keeps the package free of any particular
+// Redis library; a client can be adapted using RedisEvalFunc. For example,
+// using github.com/redis/go-redis:
+//
+//	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	store := rate.NewRedisStore(rate.RedisEvalFunc(
+//		func(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+//			return rdb.Eval(ctx, script, keys, args...).Result()
+//		},
+//	), "mybot:ratelimit:")
+type RedisClient interface {
+	// Eval evaluates the Lua script with the given keys and arguments and
+	// returns its result. Integer results must be returned as an integer or
+	// a string.
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+}
+
+// RedisEvalFunc is a function that implements RedisClient.
+type RedisEvalFunc func(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+
+// Eval implements RedisClient.
+func (f RedisEvalFunc) Eval(
+	ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+
+	return f(ctx, script, keys, args...)
+}
+
+// RedisStore is a Store that keeps the state in Redis, so that it can be
+// shared by multiple processes. Every operation is a single Lua script, so
+// they are atomic. Keys expire once they no longer matter.
+type RedisStore struct {
+	Client RedisClient
+	// Prefix is prepended to every Redis key. It should be unique to the bot
+	// token.
+	Prefix string
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// NewRedisStore creates a new RedisStore.
+func NewRedisStore(client RedisClient, prefix string) *RedisStore {
+	return &RedisStore{
+		Client: client,
+		Prefix: prefix,
+	}
+}
+
+// redisStaleBucket is how long a bucket is kept after it resets or, if its
+// reset is unknown, after it's updated.
+const redisStaleBucket = time.Minute
+
+// Times are stored as Unix milliseconds, which Lua's doubles can represent
+// exactly.
+const (
+	redisTakeScript = `
+local remaining = tonumber(redis.call('HGET', KEYS[1], 'remaining'))
+if remaining == nil then
+	return 0
+end
+if remaining > 0 then
+	redis.call('HINCRBY', KEYS[1], 'remaining', -1)
+	return 0
+end
+local reset = tonumber(redis.call('HGET', KEYS[1], 'reset')) or 0
+if reset > tonumber(ARGV[1]) then
+	return reset
+end
+local limit = tonumber(redis.call('HGET', KEYS[1], 'limit')) or 0
+if reset > 0 and limit > 0 then
+	redis.call('HSET', KEYS[1], 'remaining', limit - 1, 'reset', 0)
+end
+return 0
+`
+	redisUpdateScript = `
+redis.call('HSET', KEYS[1], 'limit', ARGV[1], 'remaining', ARGV[2], 'reset', ARGV[3])
+redis.call('PEXPIREAT', KEYS[1], ARGV[4])
+return 0
+`
+	redisGlobalScript = `
+return tonumber(redis.call('GET', KEYS[1])) or 0
+`
+	redisSetGlobalScript = `
+local current = tonumber(redis.call('GET', KEYS[1])) or 0
+if tonumber(ARGV[1]) > current then
+	redis.call('SET', KEYS[1], ARGV[1])
+	redis.call('PEXPIREAT', KEYS[1], ARGV[1])
+end
+return 0
+`
+)
+
+// Take implements Store.
+func (s *RedisStore) Take(ctx context.Context, key string, now time.Time) (time.Time, error) {
+	return s.evalTime(ctx, redisTakeScript, s.bucketKey(key), unixMilli(now))
+}
+
+// Update implements Store.
+func (s *RedisStore) Update(ctx context.Context, state BucketState) error {
+	var reset int64
+	expire := time.Now().Add(redisStaleBucket)
+
+	if !state.Reset.IsZero() {
+		reset = unixMilli(state.Reset)
+		expire = state.Reset.Add(redisStaleBucket)
+	}
+
+	_, err := s.Client.Eval(ctx, redisUpdateScript, []string{s.bucketKey(state.Key)},
+		state.Limit, state.Remaining, reset, unixMilli(expire))
+	return err
+}
+
+// Global implements Store.
+func (s *RedisStore) Global(ctx context.Context) (time.Time, error) {
+	return s.evalTime(ctx, redisGlobalScript, s.Prefix+"global")
+}
+
+// SetGlobal implements Store.
+func (s *RedisStore) SetGlobal(ctx context.Context, until time.Time) error {
+	_, err := s.Client.Eval(ctx, redisSetGlobalScript, []string{s.Prefix + "global"},
+		unixMilli(until))
+	return err
+}
+
+func (s *RedisStore) bucketKey(key string) string {
+	return s.Prefix + "bucket:" + key
+}
+
+// evalTime evaluates a script that returns Unix milliseconds or 0.
+func (s *RedisStore) evalTime(
+	ctx context.Context, script, key string, args ...interface{}) (time.Time, error) {
+
+	v, err := s.Client.Eval(ctx, script, []string{key}, args...)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var ms int64
+
+	switch v := v.(type) {
+	case int64:
+		ms = v
+	case int:
+		ms = int64(v)
+	case string:
+		ms, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid integer reply %q: %w", v, err)
+		}
+	default:
+		return time.Time{}, fmt.Errorf("unexpected reply type %T", v)
+	}
+
+	if ms == 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Unix(0, ms*int64(time.Millisecond)), nil
+}
+
+func unixMilli(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
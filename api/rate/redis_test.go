@@ -0,0 +1,198 @@
+package rate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type redisCall struct {
+	script string
+	keys   []string
+	args   []interface{}
+}
+
+// fakeRedis records the scripts that it's asked to evaluate and replies with
+// reply and err.
+type fakeRedis struct {
+	calls []redisCall
+	reply interface{}
+	err   error
+}
+
+func (r *fakeRedis) Eval(
+	ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+
+	r.calls = append(r.calls, redisCall{script, keys, args})
+	return r.reply, r.err
+}
+
+func (r *fakeRedis) lastCall(t *testing.T, script string, keys ...string) redisCall {
+	t.Helper()
+
+	if len(r.calls) == 0 {
+		t.Fatal("no script was evaluated")
+	}
+
+	call := r.calls[len(r.calls)-1]
+	if call.script != script {
+		t.Fatalf("unexpected script evaluated:\n%s", call.script)
+	}
+	if len(call.keys) != len(keys) {
+		t.Fatalf("expected keys %q, got %q", keys, call.keys)
+	}
+	for i := range keys {
+		if call.keys[i] != keys[i] {
+			t.Fatalf("expected keys %q, got %q", keys, call.keys)
+		}
+	}
+
+	return call
+}
+
+func expectArgs(t *testing.T, call redisCall, args ...interface{}) {
+	t.Helper()
+
+	if len(call.args) != len(args) {
+		t.Fatalf("expected args %v, got %v", args, call.args)
+	}
+	for i := range args {
+		if call.args[i] != args[i] {
+			t.Fatalf("expected args %v, got %v", args, call.args)
+		}
+	}
+}
+
+func TestRedisStoreTake(t *testing.T) {
+	redis := &fakeRedis{}
+	store := NewRedisStore(redis, "bot:")
+
+	now := time.Unix(1700000000, 123*int64(time.Millisecond))
+	reset := time.Unix(1700000005, 0)
+
+	tests := []struct {
+		name   string
+		reply  interface{}
+		expect time.Time
+	}{
+		{"taken", int64(0), time.Time{}},
+		{"int64 reset", reset.UnixNano() / int64(time.Millisecond), reset},
+		{"int reset", int(reset.UnixNano() / int64(time.Millisecond)), reset},
+		{"string reset", "1700000005000", reset},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			redis.reply = test.reply
+
+			until, err := store.Take(context.Background(), "channels/1", now)
+			if err != nil {
+				t.Fatal("failed to take:", err)
+			}
+			if !until.Equal(test.expect) {
+				t.Fatalf("expected %v, got %v", test.expect, until)
+			}
+
+			call := redis.lastCall(t, redisTakeScript, "bot:bucket:channels/1")
+			expectArgs(t, call, int64(1700000000123))
+		})
+	}
+}
+
+func TestRedisStoreReplyErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply interface{}
+		err   error
+	}{
+		{"eval error", nil, errors.New("connection refused")},
+		{"invalid integer", "soon", nil},
+		{"unexpected type", []interface{}{int64(1)}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			redis := &fakeRedis{reply: test.reply, err: test.err}
+			store := NewRedisStore(redis, "bot:")
+
+			if _, err := store.Take(context.Background(), "channels/1", time.Now()); err == nil {
+				t.Fatal("expected Take to fail")
+			}
+			if _, err := store.Global(context.Background()); err == nil {
+				t.Fatal("expected Global to fail")
+			}
+		})
+	}
+}
+
+func TestRedisStoreUpdate(t *testing.T) {
+	redis := &fakeRedis{reply: int64(0)}
+	store := NewRedisStore(redis, "bot:")
+
+	reset := time.Unix(1700000005, 0)
+
+	err := store.Update(context.Background(), BucketState{
+		Key:       "channels/1",
+		Limit:     5,
+		Remaining: 4,
+		Reset:     reset,
+	})
+	if err != nil {
+		t.Fatal("failed to update:", err)
+	}
+
+	// The bucket expires a while after it resets.
+	call := redis.lastCall(t, redisUpdateScript, "bot:bucket:channels/1")
+	expectArgs(t, call, uint64(5), uint64(4), int64(1700000005000), int64(1700000065000))
+
+	// Without a reset, the bucket expires a while after now.
+	before := unixMilli(time.Now().Add(redisStaleBucket))
+
+	err = store.Update(context.Background(), BucketState{Key: "channels/2", Remaining: 1})
+	if err != nil {
+		t.Fatal("failed to update:", err)
+	}
+
+	after := unixMilli(time.Now().Add(redisStaleBucket))
+
+	call = redis.lastCall(t, redisUpdateScript, "bot:bucket:channels/2")
+	if len(call.args) != 4 {
+		t.Fatalf("expected 4 args, got %v", call.args)
+	}
+	expectArgs(t, redisCall{args: call.args[:3]}, uint64(0), uint64(1), int64(0))
+	if expire, _ := call.args[3].(int64); expire < before || expire > after {
+		t.Fatalf("expected expiry between %d and %d, got %d", before, after, expire)
+	}
+}
+
+func TestRedisStoreGlobal(t *testing.T) {
+	redis := &fakeRedis{reply: int64(0)}
+	store := NewRedisStore(redis, "bot:")
+
+	until, err := store.Global(context.Background())
+	if err != nil {
+		t.Fatal("failed to get global:", err)
+	}
+	if !until.IsZero() {
+		t.Fatal("expected no global rate limit, got", until)
+	}
+	call := redis.lastCall(t, redisGlobalScript, "bot:global")
+	expectArgs(t, call)
+
+	redis.reply = "1700000005000"
+
+	until, err = store.Global(context.Background())
+	if err != nil {
+		t.Fatal("failed to get global:", err)
+	}
+	if expect := time.Unix(1700000005, 0); !until.Equal(expect) {
+		t.Fatalf("expected %v, got %v", expect, until)
+	}
+
+	if err := store.SetGlobal(context.Background(), time.Unix(1700000010, 0)); err != nil {
+		t.Fatal("failed to set global:", err)
+	}
+	call = redis.lastCall(t, redisSetGlobalScript, "bot:global")
+	expectArgs(t, call, int64(1700000010000))
+}
@@ -0,0 +1,141 @@
+package rate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Store stores the state of rate limit buckets and the global rate limit for
+// a Limiter. The same store can be given to multiple limiters, such as those
+// of multiple processes running the same bot, so that they all share one
+// budget instead of each getting rate limited on their own.
+//
+// Bucket keys are the parsed bucket keys without the limiter's prefix, so
+// stores must not be shared between limiters of different tokens. All methods
+// must be safe to call concurrently.
+type Store interface {
+	// Take takes a request from the bucket with the given key. If the bucket
+	// has no requests remaining and has not reset at now, then nothing is
+	// taken and the time when it resets is returned. Otherwise, the zero time
+	// is returned. A bucket that has reset is refilled from its limit, since
+	// it may be taken from again before a response updates it.
+	Take(ctx context.Context, key string, now time.Time) (time.Time, error)
+	// Update replaces the state of the bucket after a response.
+	Update(ctx context.Context, state BucketState) error
+	// Global returns the time until which the global rate limit is in
+	// effect, or the zero time if there is none.
+	Global(ctx context.Context) (time.Time, error)
+	// SetGlobal sets the time until which the global rate limit is in effect.
+	SetGlobal(ctx context.Context, until time.Time) error
+}
+
+// DefaultStoreTimeout is the timeout of the Store calls made by Release if the
+// limiter's StoreTimeout is 0.
+const DefaultStoreTimeout = 5 * time.Second
+
+// updateStore updates the limiter's Store after a response. Either argument
+// may be nil.
+func (l *Limiter) updateStore(state *BucketState, limited *RateLimited) error {
+	if l.Store == nil || (state == nil && (limited == nil || !limited.Global)) {
+		return nil
+	}
+
+	timeout := l.StoreTimeout
+	if timeout == 0 {
+		timeout = DefaultStoreTimeout
+	}
+
+	// The request's context may already be done by the time the response is
+	// released, and the state should still be stored, so the calls get their
+	// own bounded context.
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if state != nil {
+		if err := l.Store.Update(ctx, *state); err != nil {
+			return fmt.Errorf("rate: failed to update bucket: %w", err)
+		}
+	}
+
+	if limited != nil && limited.Global {
+		until := time.Now().Add(limited.RetryAfter)
+		if err := l.Store.SetGlobal(ctx, until); err != nil {
+			return fmt.Errorf("rate: failed to set global rate limit: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// MemoryStore is a Store that keeps the state in memory. It can be shared by
+// limiters in the same process, and it's useful for testing.
+type MemoryStore struct {
+	mu      sync.Mutex
+	global  time.Time
+	buckets map[string]BucketState
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates a new empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]BucketState{},
+	}
+}
+
+// Take implements Store.
+func (s *MemoryStore) Take(ctx context.Context, key string, now time.Time) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		return time.Time{}, nil
+	}
+
+	if b.Remaining == 0 {
+		if b.Reset.After(now) {
+			return b.Reset, nil
+		}
+		if b.Reset.IsZero() || b.Limit == 0 {
+			return time.Time{}, nil
+		}
+		// The new reset is unknown until a response updates the bucket.
+		b.Remaining = b.Limit
+		b.Reset = time.Time{}
+	}
+
+	b.Remaining--
+	s.buckets[key] = b
+
+	return time.Time{}, nil
+}
+
+// Update implements Store.
+func (s *MemoryStore) Update(ctx context.Context, state BucketState) error {
+	s.mu.Lock()
+	s.buckets[state.Key] = state
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Global implements Store.
+func (s *MemoryStore) Global(ctx context.Context) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.global, nil
+}
+
+// SetGlobal implements Store.
+func (s *MemoryStore) SetGlobal(ctx context.Context, until time.Time) error {
+	s.mu.Lock()
+	s.global = until
+	s.mu.Unlock()
+
+	return nil
+}
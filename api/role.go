@@ -13,7 +13,7 @@ type AddRoleData struct {
 // AddRole adds a role to a guild member.
 //
 // Requires the MANAGE_ROLES permission.
-func (c *Client) AddRole(
+func (c CtxClient) AddRole(
 	guildID discord.GuildID,
 	userID discord.UserID, roleID discord.RoleID, data AddRoleData) error {
 
@@ -29,7 +29,7 @@ func (c *Client) AddRole(
 // Requires the MANAGE_ROLES permission.
 //
 // Fires a Guild Member Update Gateway event.
-func (c *Client) RemoveRole(
+func (c CtxClient) RemoveRole(
 	guildID discord.GuildID,
 	userID discord.UserID, roleID discord.RoleID, reason AuditLogReason) error {
 
@@ -41,7 +41,7 @@ func (c *Client) RemoveRole(
 }
 
 // Roles returns a list of role objects for the guild.
-func (c *Client) Roles(guildID discord.GuildID) ([]discord.Role, error) {
+func (c CtxClient) Roles(guildID discord.GuildID) ([]discord.Role, error) {
 	var roles []discord.Role
 	return roles, c.RequestJSON(&roles, "GET", EndpointGuilds+guildID.String()+"/roles")
 }
@@ -87,7 +87,7 @@ type CreateRoleData struct {
 // Requires the MANAGE_ROLES permission.
 //
 // Fires a Guild Role Create Gateway event.
-func (c *Client) CreateRole(guildID discord.GuildID, data CreateRoleData) (*discord.Role, error) {
+func (c CtxClient) CreateRole(guildID discord.GuildID, data CreateRoleData) (*discord.Role, error) {
 	var role *discord.Role
 	return role, c.RequestJSON(
 		&role, "POST",
@@ -117,7 +117,7 @@ type (
 // Requires the MANAGE_ROLES permission.
 //
 // Fires multiple Guild Role Update Gateway events.
-func (c *Client) MoveRoles(guildID discord.GuildID, data MoveRolesData) ([]discord.Role, error) {
+func (c CtxClient) MoveRoles(guildID discord.GuildID, data MoveRolesData) ([]discord.Role, error) {
 	var roles []discord.Role
 	return roles, c.RequestJSON(
 		&roles, "PATCH",
@@ -155,7 +155,7 @@ type ModifyRoleData struct {
 // ModifyRole modifies a guild role.
 //
 // Requires the MANAGE_ROLES permission.
-func (c *Client) ModifyRole(
+func (c CtxClient) ModifyRole(
 	guildID discord.GuildID, roleID discord.RoleID, data ModifyRoleData) (*discord.Role, error) {
 
 	var role *discord.Role
@@ -169,7 +169,7 @@ func (c *Client) ModifyRole(
 // DeleteRole deletes a guild role.
 //
 // Requires the MANAGE_ROLES permission.
-func (c *Client) DeleteRole(
+func (c CtxClient) DeleteRole(
 	guildID discord.GuildID, roleID discord.RoleID, reason AuditLogReason) error {
 
 	return c.FastRequest(
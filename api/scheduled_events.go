@@ -2,6 +2,7 @@ package api
 
 import (
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/internal/intmath"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
 )
@@ -68,10 +69,76 @@ type GuildScheduledEventUser struct {
 	Member *discord.Member `json:"member"`
 }
 
+// MaxScheduledEventUsersFetchLimit is the maximum number of users that can be
+// fetched from a scheduled event in a single request.
+const MaxScheduledEventUsersFetchLimit = 100
+
+// ScheduledEventUsers returns a list of users that are interested in the
+// scheduled event, sorted by their ID.
+//
+// If withMember is true, then the Member field of each user is set if the user
+// is still a member of the guild.
+//
+// As the underlying endpoint has a maximum of 100 users per request, at
+// maximum a total of limit/100 rounded up requests will be made, although they
+// may be less, if no more users are available.
+//
+// When fetching the users, those with the smallest ID will be fetched first.
+//
+// If limit is 0, no limit is used and all users are fetched.
+func (c CtxClient) ScheduledEventUsers(
+	guildID discord.GuildID, eventID discord.EventID,
+	withMember bool, limit uint) ([]GuildScheduledEventUser, error) {
+
+	return c.ScheduledEventUsersAfter(guildID, eventID, withMember, 0, limit)
+}
+
+// ScheduledEventUsersAfter returns a list of users that are interested in the
+// scheduled event, starting after the given user ID. Pagination works the same
+// as ScheduledEventUsers.
+func (c CtxClient) ScheduledEventUsersAfter(
+	guildID discord.GuildID, eventID discord.EventID,
+	withMember bool, after discord.UserID, limit uint) ([]GuildScheduledEventUser, error) {
+
+	users := make([]GuildScheduledEventUser, 0, limit)
+
+	fetch := uint(MaxScheduledEventUsersFetchLimit)
+
+	unlimited := limit == 0
+
+	for limit > 0 || unlimited {
+		if limit > 0 {
+			// Only fetch as much as we need. Since limit gradually decreases,
+			// we only need to fetch intmath.Min(fetch, limit).
+			fetch = uint(intmath.Min(MaxScheduledEventUsersFetchLimit, int(limit)))
+			limit -= fetch
+		}
+
+		u, err := c.ListScheduledEventUsers(
+			guildID, eventID, option.NewNullableInt(int(fetch)), withMember, 0, after)
+		if err != nil {
+			return users, err
+		}
+		users = append(users, u...)
+
+		if len(u) < MaxScheduledEventUsersFetchLimit {
+			break
+		}
+
+		after = u[len(u)-1].User.ID
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	return users, nil
+}
+
 // ListScheduledEventUsers returns a list of users currently in a scheduled event.
 //
 // https://discord.com/developers/docs/resources/guild-scheduled-event#get-guild-scheduled-event-users
-func (c *Client) ListScheduledEventUsers(
+func (c CtxClient) ListScheduledEventUsers(
 	guildID discord.GuildID, eventID discord.EventID, limit option.NullableInt,
 	withMember bool, before, after discord.UserID) ([]GuildScheduledEventUser, error) {
 	var eventUsers []GuildScheduledEventUser
@@ -96,7 +163,7 @@ func (c *Client) ListScheduledEventUsers(
 // ListScheduledEvents lists the scheduled events in a guild.
 //
 // https://discord.com/developers/docs/resources/guild-scheduled-event#get-guild-scheduled-event-users
-func (c *Client) ListScheduledEvents(guildID discord.GuildID, withUserCount bool) ([]discord.GuildScheduledEvent, error) {
+func (c CtxClient) ListScheduledEvents(guildID discord.GuildID, withUserCount bool) ([]discord.GuildScheduledEvent, error) {
 	var scheduledEvents []discord.GuildScheduledEvent
 	var params struct {
 		WithUserCount bool `schema:"with_user_count"`
@@ -111,7 +178,7 @@ func (c *Client) ListScheduledEvents(guildID discord.GuildID, withUserCount bool
 // CreateScheduledEvent creates a new scheduled event.
 //
 // https://discord.com/developers/docs/resources/guild-scheduled-event#create-guild-scheduled-event
-func (c *Client) CreateScheduledEvent(guildID discord.GuildID, reason AuditLogReason,
+func (c CtxClient) CreateScheduledEvent(guildID discord.GuildID, reason AuditLogReason,
 	data CreateScheduledEventData) (*discord.GuildScheduledEvent, error) {
 	var scheduledEvent *discord.GuildScheduledEvent
 	return scheduledEvent, c.RequestJSON(
@@ -125,7 +192,7 @@ func (c *Client) CreateScheduledEvent(guildID discord.GuildID, reason AuditLogRe
 // EditScheduledEvent modifies the attributes of a scheduled event.
 //
 // https://discord.com/developers/docs/resources/guild-scheduled-event#modify-guild-scheduled-event
-func (c *Client) EditScheduledEvent(guildID discord.GuildID, eventID discord.EventID, reason AuditLogReason,
+func (c CtxClient) EditScheduledEvent(guildID discord.GuildID, eventID discord.EventID, reason AuditLogReason,
 	data EditScheduledEventData) (*discord.GuildScheduledEvent, error) {
 	var modifiedEvent *discord.GuildScheduledEvent
 	return modifiedEvent, c.RequestJSON(
@@ -139,7 +206,7 @@ func (c *Client) EditScheduledEvent(guildID discord.GuildID, eventID discord.Eve
 // DeleteScheduledEvent deletes a scheduled event.
 //
 // https://discord.com/developers/docs/resources/guild-scheduled-event#delete-guild-scheduled-event
-func (c *Client) DeleteScheduledEvent(guildID discord.GuildID, eventID discord.EventID) error {
+func (c CtxClient) DeleteScheduledEvent(guildID discord.GuildID, eventID discord.EventID) error {
 	return c.FastRequest(
 		"DELETE", EndpointGuilds+guildID.String()+"/scheduled-events/"+eventID.String(),
 	)
@@ -148,7 +215,7 @@ func (c *Client) DeleteScheduledEvent(guildID discord.GuildID, eventID discord.E
 // ScheduledEvent retrieves the information on the scheduled event
 //
 // https://discord.com/developers/docs/resources/guild-scheduled-event#get-guild-scheduled-event
-func (c *Client) ScheduledEvent(guildID discord.GuildID, eventID discord.EventID, withUserCount bool) (*discord.GuildScheduledEvent, error) {
+func (c CtxClient) ScheduledEvent(guildID discord.GuildID, eventID discord.EventID, withUserCount bool) (*discord.GuildScheduledEvent, error) {
 	var params struct {
 		WithUserCount bool `schema:"with_user_count"`
 	}
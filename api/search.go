@@ -26,7 +26,7 @@ type SearchResponse struct {
 }
 
 // Search searches through a guild's messages. It only works for user accounts.
-func (c *Client) Search(guildID discord.GuildID, data SearchData) (SearchResponse, error) {
+func (c CtxClient) Search(guildID discord.GuildID, data SearchData) (SearchResponse, error) {
 	var resp SearchResponse
 
 	return resp, c.RequestJSON(
@@ -3,7 +3,10 @@ package api
 import (
 	"errors"
 	"fmt"
+	"math/rand"
 	"mime/multipart"
+	"sync/atomic"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
@@ -94,8 +97,14 @@ var ErrEmptyMessage = errors.New("message is empty")
 type SendMessageData struct {
 	// Content are the message contents (up to 2000 characters).
 	Content string `json:"content,omitempty"`
-	// Nonce is a nonce that can be used for optimistic message sending.
+	// Nonce is a nonce that can be used for optimistic message sending. It can
+	// be up to 25 characters long.
 	Nonce string `json:"nonce,omitempty"`
+	// EnforceNonce, if true, makes Discord check the Nonce against the
+	// messages recently sent by the current user. If a message with the same
+	// nonce was created in the past few minutes, that message is returned
+	// instead of creating a new one.
+	EnforceNonce bool `json:"enforce_nonce,omitempty"`
 
 	// TTS is true if this is a TTS message.
 	TTS bool `json:"tts,omitempty"`
@@ -117,12 +126,42 @@ type SendMessageData struct {
 	// Only MessageID is necessary. You may also include a channel_id and
 	// guild_id in the reference. However, they are not necessary, but will be
 	// validated if sent.
+	//
+	// If the reference is of type discord.ForwardMessageReference, the
+	// referenced message is forwarded instead, and both MessageID and
+	// ChannelID are required. A forward cannot have any content of its own.
 	Reference *discord.MessageReference `json:"message_reference,omitempty"`
 
-	// Flags specifies the message flags to set (only `SuppressEmbeds` and `SuppressNotifications` can be set).
+	// Poll is the poll to attach to the message.
+	Poll *CreatePollData `json:"poll,omitempty"`
+
+	// Flags specifies the message flags to set. Only SuppressEmbeds,
+	// SuppressNotifications, VoiceMessage and ComponentsV2 can be set.
 	Flags discord.MessageFlags `json:"flags"`
 }
 
+// Silent returns a copy of data with the SuppressNotifications flag set, which
+// sends the message without triggering push and desktop notifications.
+func (data SendMessageData) Silent() SendMessageData {
+	data.Flags |= discord.SuppressNotifications
+	return data
+}
+
+// isEmpty returns true if the message has no content. Messages using
+// ComponentsV2 are laid out only using components, and forwards have no
+// content of their own.
+func (data SendMessageData) isEmpty() bool {
+	if data.Flags.Has(discord.ComponentsV2) {
+		return len(data.Components) == 0 && len(data.Files) == 0
+	}
+	// Forwards take their content from the forwarded message.
+	if data.Reference != nil && data.Reference.Type == discord.ForwardMessageReference {
+		return false
+	}
+	return data.Content == "" && len(data.Embeds) == 0 && len(data.Files) == 0 &&
+		data.Poll == nil
+}
+
 // NeedsMultipart returns true if the SendMessageData has files.
 func (data SendMessageData) NeedsMultipart() bool {
 	return len(data.Files) > 0
@@ -151,9 +190,9 @@ func (data SendMessageData) WriteMultipart(body *multipart.Writer) error {
 // content or embed, and when sending multipart/form-data, you must send at
 // least one of content, embed or file. For a file attachment, the
 // Content-Disposition subpart header MUST contain a filename parameter.
-func (c *Client) SendMessageComplex(
+func (c CtxClient) SendMessageComplex(
 	channelID discord.ChannelID, data SendMessageData) (*discord.Message, error) {
-	if data.Content == "" && len(data.Embeds) == 0 && len(data.Files) == 0 {
+	if data.isEmpty() {
 		return nil, ErrEmptyMessage
 	}
 
@@ -163,20 +202,134 @@ func (c *Client) SendMessageComplex(
 		}
 	}
 
+	if len(data.Embeds) > discord.MaxMessageEmbeds {
+		return nil, &discord.OverboundError{
+			Count: len(data.Embeds),
+			Max:   discord.MaxMessageEmbeds,
+			Thing: "embeds",
+		}
+	}
+
 	sum := 0
 	for i, embed := range data.Embeds {
 		if err := embed.Validate(); err != nil {
 			return nil, fmt.Errorf("embed error at %d: %w", i, err)
 		}
 		sum += embed.Length()
-		if sum > 6000 {
-			return nil, &discord.OverboundError{Count: sum, Max: 6000, Thing: "sum of all text in embeds"}
+		if sum > discord.MaxEmbedsLength {
+			return nil, &discord.OverboundError{Count: sum, Max: discord.MaxEmbedsLength, Thing: "sum of all text in embeds"}
 		}
 
 		data.Embeds[i] = embed // embed.Validate changes fields
 	}
 
+	if data.Poll != nil {
+		if err := data.Poll.Validate(); err != nil {
+			return nil, fmt.Errorf("poll error: %w", err)
+		}
+	}
+
+	if err := c.verifyUploadSize(channelID, data.Files); err != nil {
+		return nil, err
+	}
+
 	var URL = EndpointChannels + channelID.String() + "/messages"
 	var msg *discord.Message
-	return msg, sendpart.POST(c.Client, data, &msg, URL)
+	return msg, c.sendpart("POST", data, &msg, URL)
+}
+
+// UploadLimit returns the maximum total size in bytes of the files in a message
+// sent to the channel, which depends on the premium tier of its guild. The
+// channel and guild are fetched unless Session.CachedUploadLimit knows the
+// limit.
+func (c CtxClient) UploadLimit(channelID discord.ChannelID) (int64, error) {
+	if c.Session.CachedUploadLimit != nil {
+		if limit, ok := c.Session.CachedUploadLimit(channelID); ok {
+			return limit, nil
+		}
+	}
+
+	ch, err := c.Channel(channelID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if !ch.GuildID.IsValid() {
+		return discord.DefaultUploadLimit, nil
+	}
+
+	g, err := c.Guild(ch.GuildID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get guild: %w", err)
+	}
+
+	return g.NitroBoost.UploadLimit(), nil
+}
+
+// verifyUploadSize returns an error if the files are larger than the upload
+// limit of the channel, so that they aren't uploaded only to be rejected.
+// Files of unknown size are not counted. The limit is only looked up if the
+// files are over the default limit, and if it can't be, then the files are
+// sent anyway.
+func (c CtxClient) verifyUploadSize(channelID discord.ChannelID, files []sendpart.File) error {
+	var size int64
+	for _, file := range files {
+		if n := file.Len(); n > 0 {
+			size += n
+		}
+	}
+
+	if size <= discord.DefaultUploadLimit {
+		return nil
+	}
+
+	limit := discord.NitroLevel3.UploadLimit()
+	if size <= limit {
+		var err error
+		if limit, err = c.UploadLimit(channelID); err != nil {
+			return nil
+		}
+	}
+
+	if size > limit {
+		return &discord.OverboundError{
+			Count: int(size),
+			Max:   int(limit),
+			Thing: "total size of files in bytes",
+		}
+	}
+
+	return nil
+}
+
+var nonceIncrement uint32
+
+// NewNonce generates a new snowflake nonce for use with
+// SendMessageData.Nonce. Nonces generated by the same process are unique.
+func NewNonce() string {
+	increment := atomic.AddUint32(&nonceIncrement, 1) & 0xFFF
+	worker := uint64(rand.Intn(1 << 10))
+
+	sf := discord.NewSnowflake(time.Now()) | discord.Snowflake(worker<<12|uint64(increment))
+	return sf.String()
+}
+
+// SendMessageOnce sends a message using SendMessageComplex, except that the
+// message is created at most once, even if the request is retried after a
+// network error. If data has no nonce, a new one is generated using NewNonce,
+// and EnforceNonce is always set.
+//
+// If Discord already created a message with the same nonce, such as when an
+// earlier attempt succeeded but its response was lost, the existing message is
+// returned as if it was just sent. To safely call SendMessageOnce again after
+// it fails, set the nonce beforehand, so that both calls share it.
+func (c CtxClient) SendMessageOnce(
+	channelID discord.ChannelID, data SendMessageData) (*discord.Message, error) {
+
+	if data.Nonce == "" {
+		data.Nonce = NewNonce()
+	}
+	data.EnforceNonce = true
+
+	return c.SendMessageComplex(channelID, data)
 }
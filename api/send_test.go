@@ -1,7 +1,12 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -118,6 +123,22 @@ func TestSendMessage(t *testing.T) {
 		}
 	})
 
+	t.Run("components v2 only", func(t *testing.T) {
+		var data = SendMessageData{
+			Components: discord.ContainerComponents{&discord.ActionRowComponent{}},
+			Flags:      discord.ComponentsV2,
+		}
+
+		if err := send(data); err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+
+		data.Flags = 0
+		if err := send(data); err != ErrEmptyMessage {
+			t.Fatal("Unexpected error:", err)
+		}
+	})
+
 	t.Run("invalid allowed mentions", func(t *testing.T) {
 		var data = SendMessageData{
 			Content: "hime arikawa",
@@ -167,3 +188,62 @@ func mustMarshal(t *testing.T, v interface{}) string {
 	}
 	return string(j)
 }
+
+func TestNewNonce(t *testing.T) {
+	seen := make(map[string]struct{})
+
+	for i := 0; i < 1000; i++ {
+		nonce := NewNonce()
+		if len(nonce) > 25 {
+			t.Fatalf("nonce %q is longer than 25 characters", nonce)
+		}
+		if _, ok := seen[nonce]; ok {
+			t.Fatalf("nonce %q generated twice", nonce)
+		}
+		seen[nonce] = struct{}{}
+	}
+}
+
+func TestVerifyUploadSize(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case Path + "/channels/1":
+			io.WriteString(w, `{"id":"1","guild_id":"2"}`)
+		case Path + "/guilds/2":
+			io.WriteString(w, `{"id":"2","premium_tier":2}`)
+		default:
+			t.Error("unexpected request to", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	oldChannels, oldGuilds := EndpointChannels, EndpointGuilds
+	EndpointChannels = srv.URL + Path + "/channels/"
+	EndpointGuilds = srv.URL + Path + "/guilds/"
+	defer func() { EndpointChannels, EndpointGuilds = oldChannels, oldGuilds }()
+
+	client := NewClient("no. 3-chan").WithCtx(context.Background())
+
+	file := func(size int64) sendpart.File {
+		return sendpart.File{Name: "file", Reader: strings.NewReader(""), Size: size}
+	}
+
+	if err := client.verifyUploadSize(1, []sendpart.File{file(1 << 20)}); err != nil {
+		t.Fatal("unexpected error for small file:", err)
+	}
+	if requests != 0 {
+		t.Fatal("upload limit was fetched for a small file")
+	}
+
+	if err := client.verifyUploadSize(1, []sendpart.File{file(20 << 20), file(20 << 20)}); err != nil {
+		t.Fatal("unexpected error for files under the level 2 limit:", err)
+	}
+
+	var overbound *discord.OverboundError
+	err := client.verifyUploadSize(1, []sendpart.File{file(30 << 20), file(30 << 20)})
+	if !errors.As(err, &overbound) || overbound.Max != 50<<20 {
+		t.Fatal("expected overbound error, got", err)
+	}
+}
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// MaxSoundSize is the maximum size of a soundboard sound file in bytes.
+const MaxSoundSize = 512 * 1024
+
+// ErrInvalidSoundCT is returned when a Sound is neither MP3 nor OGG.
+var ErrInvalidSoundCT = errors.New("unknown sound content-type")
+
+// SoundTooLargeError is returned when a Sound is larger than the maximum size.
+type SoundTooLargeError struct {
+	Size, Max int
+}
+
+func (err SoundTooLargeError) Error() string {
+	return fmt.Sprintf("Sound is %.02fkb, larger than %.02fkb",
+		float64(err.Size)/1000, float64(err.Max)/1000)
+}
+
+// Sound wraps around a soundboard sound file in the Data URI Scheme that
+// Discord uses, like Image. The file must be an MP3 or OGG file.
+type Sound struct {
+	// ContentType is optional and will be automatically detected. However, it
+	// should always be "audio/mpeg" or "audio/ogg".
+	ContentType string
+	// Content is the raw content of the file.
+	Content []byte
+}
+
+// Validate validates the sound's size against maxSize, if it's not 0, and its
+// content type.
+func (s Sound) Validate(maxSize int) error {
+	if maxSize > 0 && len(s.Content) > maxSize {
+		return SoundTooLargeError{len(s.Content), maxSize}
+	}
+
+	switch s.contentType() {
+	case "audio/mpeg", "audio/ogg":
+		return nil
+	default:
+		return ErrInvalidSoundCT
+	}
+}
+
+func (s Sound) contentType() string {
+	if s.ContentType != "" {
+		return s.ContentType
+	}
+
+	switch ct := http.DetectContentType(s.Content); ct {
+	case "application/ogg":
+		return "audio/ogg"
+	default:
+		return ct
+	}
+}
+
+// MarshalJSON marshals the sound into a Data URI string.
+func (s Sound) MarshalJSON() ([]byte, error) {
+	if err := s.Validate(MaxSoundSize); err != nil {
+		return nil, err
+	}
+
+	b64enc := make([]byte, base64.StdEncoding.EncodedLen(len(s.Content)))
+	base64.StdEncoding.Encode(b64enc, s.Content)
+
+	return bytes.Join([][]byte{
+		[]byte(`"data:`),
+		[]byte(s.contentType()),
+		[]byte(";base64,"),
+		b64enc,
+		[]byte(`"`),
+	}, nil), nil
+}
+
+// https://discord.com/developers/docs/resources/soundboard#send-soundboard-sound-json-params
+type SendSoundboardSoundData struct {
+	// SoundID is the ID of the sound to play.
+	SoundID discord.SoundID `json:"sound_id"`
+	// SourceGuildID is the ID of the guild that the sound is from. It is
+	// required to play sounds from other guilds.
+	SourceGuildID discord.GuildID `json:"source_guild_id,omitempty"`
+}
+
+// SendSoundboardSound plays a soundboard sound in the voice channel that the
+// current user is connected to.
+//
+// Requires the SPEAK and USE_SOUNDBOARD permissions, and also the
+// USE_EXTERNAL_SOUNDS permission if the sound is from a different guild. The
+// current user must not be deafened, muted or suppressed.
+//
+// Fires a Voice Channel Effect Send Gateway event.
+func (c CtxClient) SendSoundboardSound(
+	channelID discord.ChannelID, data SendSoundboardSoundData) error {
+
+	return c.FastRequest(
+		"POST",
+		EndpointChannels+channelID.String()+"/send-soundboard-sound",
+		httputil.WithJSONBody(data),
+	)
+}
+
+// DefaultSoundboardSounds returns the default soundboard sounds that can be
+// used by all users.
+func (c CtxClient) DefaultSoundboardSounds() ([]discord.SoundboardSound, error) {
+	var sounds []discord.SoundboardSound
+	return sounds, c.RequestJSON(&sounds, "GET", Endpoint+"soundboard-default-sounds")
+}
+
+// GuildSoundboardSounds returns the soundboard sounds of the guild.
+//
+// Requires the MANAGE_GUILD_EXPRESSIONS permission.
+func (c CtxClient) GuildSoundboardSounds(guildID discord.GuildID) ([]discord.SoundboardSound, error) {
+	var resp struct {
+		Items []discord.SoundboardSound `json:"items"`
+	}
+	return resp.Items, c.RequestJSON(
+		&resp, "GET",
+		EndpointGuilds+guildID.String()+"/soundboard-sounds",
+	)
+}
+
+// GuildSoundboardSound returns a soundboard sound of the guild.
+//
+// Requires the MANAGE_GUILD_EXPRESSIONS permission.
+func (c CtxClient) GuildSoundboardSound(
+	guildID discord.GuildID, soundID discord.SoundID) (*discord.SoundboardSound, error) {
+
+	var sound *discord.SoundboardSound
+	return sound, c.RequestJSON(
+		&sound, "GET",
+		EndpointGuilds+guildID.String()+"/soundboard-sounds/"+soundID.String(),
+	)
+}
+
+// https://discord.com/developers/docs/resources/soundboard#create-guild-soundboard-sound-json-params
+type CreateGuildSoundboardSoundData struct {
+	// Name is the name of the sound (2-32 characters).
+	Name string `json:"name"`
+	// Sound is the MP3 or OGG sound file, up to 512KB and 5.2 seconds long.
+	Sound Sound `json:"sound"`
+	// Volume is the volume of the sound, from 0 to 1. It defaults to 1.
+	Volume option.Float `json:"volume,omitempty"`
+	// EmojiID is the ID of the sound's custom emoji.
+	EmojiID discord.EmojiID `json:"emoji_id,omitempty"`
+	// EmojiName is the unicode character of the sound's standard emoji.
+	EmojiName string `json:"emoji_name,omitempty"`
+
+	AuditLogReason `json:"-"`
+}
+
+// CreateGuildSoundboardSound creates a new soundboard sound in the guild.
+//
+// Requires the CREATE_GUILD_EXPRESSIONS permission.
+//
+// Fires a Guild Soundboard Sound Create Gateway event.
+func (c CtxClient) CreateGuildSoundboardSound(
+	guildID discord.GuildID, data CreateGuildSoundboardSoundData) (*discord.SoundboardSound, error) {
+
+	var sound *discord.SoundboardSound
+	return sound, c.RequestJSON(
+		&sound, "POST",
+		EndpointGuilds+guildID.String()+"/soundboard-sounds",
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
+	)
+}
+
+// https://discord.com/developers/docs/resources/soundboard#modify-guild-soundboard-sound-json-params
+type ModifyGuildSoundboardSoundData struct {
+	// Name is the name of the sound (2-32 characters).
+	Name option.String `json:"name,omitempty"`
+	// Volume is the volume of the sound, from 0 to 1.
+	Volume option.Float `json:"volume,omitempty"`
+	// EmojiID is the ID of the sound's custom emoji. A pointer to 0 removes
+	// the emoji.
+	EmojiID *discord.EmojiID `json:"emoji_id,omitempty"`
+	// EmojiName is the unicode character of the sound's standard emoji.
+	EmojiName option.NullableString `json:"emoji_name,omitempty"`
+
+	AuditLogReason `json:"-"`
+}
+
+// ModifyGuildSoundboardSound modifies a soundboard sound in the guild.
+//
+// Requires the MANAGE_GUILD_EXPRESSIONS permission for any sound, or the
+// CREATE_GUILD_EXPRESSIONS permission for sounds created by the current user.
+//
+// Fires a Guild Soundboard Sound Update Gateway event.
+func (c CtxClient) ModifyGuildSoundboardSound(
+	guildID discord.GuildID, soundID discord.SoundID,
+	data ModifyGuildSoundboardSoundData) (*discord.SoundboardSound, error) {
+
+	var sound *discord.SoundboardSound
+	return sound, c.RequestJSON(
+		&sound, "PATCH",
+		EndpointGuilds+guildID.String()+"/soundboard-sounds/"+soundID.String(),
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
+	)
+}
+
+// DeleteGuildSoundboardSound deletes a soundboard sound from the guild.
+//
+// Requires the MANAGE_GUILD_EXPRESSIONS permission for any sound, or the
+// CREATE_GUILD_EXPRESSIONS permission for sounds created by the current user.
+//
+// Fires a Guild Soundboard Sound Delete Gateway event.
+func (c CtxClient) DeleteGuildSoundboardSound(
+	guildID discord.GuildID, soundID discord.SoundID, reason AuditLogReason) error {
+
+	return c.FastRequest(
+		"DELETE",
+		EndpointGuilds+guildID.String()+"/soundboard-sounds/"+soundID.String(),
+		httputil.WithHeaders(reason.Header()),
+	)
+}
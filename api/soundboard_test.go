@@ -0,0 +1,28 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSoundValidate(t *testing.T) {
+	sound := Sound{ContentType: "audio/ogg", Content: make([]byte, 10)}
+
+	if err := sound.Validate(10); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	var tooLarge SoundTooLargeError
+	if err := sound.Validate(5); !errors.As(err, &tooLarge) {
+		t.Fatal("expected SoundTooLargeError, got", err)
+	}
+	if tooLarge.Size != 10 || tooLarge.Max != 5 || !strings.HasPrefix(tooLarge.Error(), "Sound ") {
+		t.Fatalf("unexpected error %v", tooLarge)
+	}
+
+	sound.ContentType = "image/png"
+	if err := sound.Validate(0); !errors.Is(err, ErrInvalidSoundCT) {
+		t.Fatal("expected ErrInvalidSoundCT, got", err)
+	}
+}
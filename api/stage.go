@@ -17,6 +17,12 @@ type CreateStageInstanceData struct {
 	//
 	// Defaults to discord.GuildOnlyStage.
 	PrivacyLevel discord.PrivacyLevel `json:"privacy_level,omitempty"`
+	// SendStartNotification notifies @everyone that the Stage instance has
+	// started. It requires the MENTION_EVERYONE permission.
+	SendStartNotification bool `json:"send_start_notification,omitempty"`
+	// GuildScheduledEventID is the id of the scheduled event associated with
+	// the Stage instance.
+	GuildScheduledEventID discord.EventID `json:"guild_scheduled_event_id,omitempty"`
 
 	AuditLogReason `json:"-"`
 }
@@ -25,7 +31,7 @@ type CreateStageInstanceData struct {
 // channel.
 //
 // It requires the user to be a moderator of the Stage channel.
-func (c *Client) CreateStageInstance(
+func (c CtxClient) CreateStageInstance(
 	data CreateStageInstanceData) (*discord.StageInstance, error) {
 
 	var s *discord.StageInstance
@@ -36,6 +42,13 @@ func (c *Client) CreateStageInstance(
 	)
 }
 
+// StageInstance returns the Stage instance associated with the Stage channel,
+// if it exists.
+func (c CtxClient) StageInstance(channelID discord.ChannelID) (*discord.StageInstance, error) {
+	var s *discord.StageInstance
+	return s, c.RequestJSON(&s, "GET", EndpointStageInstances+channelID.String())
+}
+
 // https://discord.com/developers/docs/resources/stage-instance#update-stage-instance-json-params
 type UpdateStageInstanceData struct {
 	// Topic is the topic of the Stage instance (1-120 characters).
@@ -49,7 +62,7 @@ type UpdateStageInstanceData struct {
 // UpdateStageInstance updates fields of an existing Stage instance.
 //
 // It requires the user to be a moderator of the Stage channel.
-func (c *Client) UpdateStageInstance(
+func (c CtxClient) UpdateStageInstance(
 	channelID discord.ChannelID, data UpdateStageInstanceData) error {
 
 	return c.FastRequest(
@@ -59,7 +72,10 @@ func (c *Client) UpdateStageInstance(
 	)
 }
 
-func (c *Client) DeleteStageInstance(channelID discord.ChannelID, reason AuditLogReason) error {
+// DeleteStageInstance deletes the Stage instance, which ends the Stage.
+//
+// It requires the user to be a moderator of the Stage channel.
+func (c CtxClient) DeleteStageInstance(channelID discord.ChannelID, reason AuditLogReason) error {
 	return c.FastRequest(
 		"DELETE", EndpointStageInstances+channelID.String(),
 		httputil.WithHeaders(reason.Header()),
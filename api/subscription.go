@@ -0,0 +1,151 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+)
+
+var EndpointSKUs = Endpoint + "skus/"
+
+// https://discord.com/developers/docs/resources/subscription#query-string-params
+type SKUSubscriptionsData struct {
+	// Before lists subscriptions before this ID.
+	Before discord.SubscriptionID `schema:"before,omitempty"`
+	// After lists subscriptions after this ID.
+	After discord.SubscriptionID `schema:"after,omitempty"`
+	// Limit is the number of results to return (1-100). It defaults to 50.
+	Limit uint `schema:"limit,omitempty"`
+	// UserID is the user ID for which to return subscriptions. It is
+	// required except for OAuth queries.
+	UserID discord.UserID `schema:"user_id,omitempty"`
+}
+
+// SKUSubscriptions returns all subscriptions containing the SKU, filtered by
+// user.
+func (c CtxClient) SKUSubscriptions(
+	skuID discord.SKUID, data SKUSubscriptionsData) ([]discord.Subscription, error) {
+
+	var subs []discord.Subscription
+	return subs, c.RequestJSON(
+		&subs, "GET",
+		EndpointSKUs+skuID.String()+"/subscriptions",
+		httputil.WithSchema(c, data),
+	)
+}
+
+// SKUSubscription returns a subscription by its ID.
+func (c CtxClient) SKUSubscription(
+	skuID discord.SKUID, subscriptionID discord.SubscriptionID) (*discord.Subscription, error) {
+
+	var sub *discord.Subscription
+	return sub, c.RequestJSON(
+		&sub, "GET",
+		EndpointSKUs+skuID.String()+"/subscriptions/"+subscriptionID.String(),
+	)
+}
+
+// UserHasActiveSubscription returns true if the user with the given ID
+// currently has an active subscription for the SKU.
+func (c CtxClient) UserHasActiveSubscription(skuID discord.SKUID, userID discord.UserID) (bool, error) {
+	subs, err := c.SKUSubscriptions(skuID, SKUSubscriptionsData{
+		UserID: userID,
+		Limit:  100,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, sub := range subs {
+		if sub.Active() && sub.HasSKU(skuID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// https://discord.com/developers/docs/resources/entitlement#list-entitlements-query-string-params
+type EntitlementsData struct {
+	// UserID is the user ID to look up entitlements for.
+	UserID discord.UserID
+	// SKUIDs optionally limits the returned entitlements to the given SKUs.
+	SKUIDs []discord.SKUID
+	// Before retrieves entitlements before this entitlement ID.
+	Before discord.EntitlementID
+	// After retrieves entitlements after this entitlement ID.
+	After discord.EntitlementID
+	// Limit is the number of entitlements to return (1-100). It defaults to
+	// 100.
+	Limit uint
+	// GuildID is the guild ID to look up entitlements for.
+	GuildID discord.GuildID
+	// ExcludeEnded is whether or not ended entitlements should be omitted.
+	ExcludeEnded bool
+	// ExcludeDeleted is whether or not deleted entitlements should be
+	// omitted. Discord defaults this to true.
+	ExcludeDeleted *bool
+}
+
+// Entitlements returns all entitlements for the given app, active and
+// expired.
+func (c CtxClient) Entitlements(
+	appID discord.AppID, data EntitlementsData) ([]discord.Entitlement, error) {
+
+	var param struct {
+		UserID         discord.UserID        `schema:"user_id,omitempty"`
+		SKUIDs         string                `schema:"sku_ids,omitempty"`
+		Before         discord.EntitlementID `schema:"before,omitempty"`
+		After          discord.EntitlementID `schema:"after,omitempty"`
+		Limit          uint                  `schema:"limit,omitempty"`
+		GuildID        discord.GuildID       `schema:"guild_id,omitempty"`
+		ExcludeEnded   bool                  `schema:"exclude_ended,omitempty"`
+		ExcludeDeleted *bool                 `schema:"exclude_deleted,omitempty"`
+	}
+
+	skuIDs := make([]string, len(data.SKUIDs))
+	for i, id := range data.SKUIDs {
+		skuIDs[i] = id.String()
+	}
+
+	param.UserID = data.UserID
+	param.SKUIDs = strings.Join(skuIDs, ",")
+	param.Before = data.Before
+	param.After = data.After
+	param.Limit = data.Limit
+	param.GuildID = data.GuildID
+	param.ExcludeEnded = data.ExcludeEnded
+	param.ExcludeDeleted = data.ExcludeDeleted
+
+	var ents []discord.Entitlement
+	return ents, c.RequestJSON(
+		&ents, "GET",
+		EndpointApplications+appID.String()+"/entitlements",
+		httputil.WithSchema(c, param),
+	)
+}
+
+// GuildHasActiveSubscription returns true if the guild with the given ID
+// currently has an active entitlement for the SKU, which is how guild
+// subscriptions are granted.
+func (c CtxClient) GuildHasActiveSubscription(
+	appID discord.AppID, skuID discord.SKUID, guildID discord.GuildID) (bool, error) {
+
+	ents, err := c.Entitlements(appID, EntitlementsData{
+		SKUIDs:       []discord.SKUID{skuID},
+		GuildID:      guildID,
+		ExcludeEnded: true,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, ent := range ents {
+		if ent.SKUID == skuID && ent.Active() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
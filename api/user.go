@@ -1,6 +1,11 @@
 package api
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
@@ -12,7 +17,7 @@ var (
 )
 
 // User returns a user object for a given user ID.
-func (c *Client) User(userID discord.UserID) (*discord.User, error) {
+func (c CtxClient) User(userID discord.UserID) (*discord.User, error) {
 	var u *discord.User
 	return u, c.RequestJSON(&u, "GET", EndpointUsers+userID.String())
 }
@@ -20,7 +25,7 @@ func (c *Client) User(userID discord.UserID) (*discord.User, error) {
 // Me returns the user object of the requester's account. For OAuth2, this
 // requires the identify scope, which will return the object without an email,
 // and optionally the email scope, which returns the object with an email.
-func (c *Client) Me() (*discord.User, error) {
+func (c CtxClient) Me() (*discord.User, error) {
 	var me *discord.User
 	return me, c.RequestJSON(&me, "GET", EndpointMe)
 }
@@ -37,7 +42,7 @@ type ModifyCurrentUserData struct {
 }
 
 // ModifyCurrentUser modifies the requester's user account settings.
-func (c *Client) ModifyCurrentUser(data ModifyCurrentUserData) (*discord.User, error) {
+func (c CtxClient) ModifyCurrentUser(data ModifyCurrentUserData) (*discord.User, error) {
 	var u *discord.User
 	return u, c.RequestJSON(
 		&u,
@@ -46,35 +51,65 @@ func (c *Client) ModifyCurrentUser(data ModifyCurrentUserData) (*discord.User, e
 	)
 }
 
+// CurrentMember returns the member object of the current user in a guild. For
+// OAuth2, this requires the guilds.members.read scope.
+func (c CtxClient) CurrentMember(guildID discord.GuildID) (*discord.Member, error) {
+	var m *discord.Member
+	return m, c.RequestJSON(&m, "GET", EndpointMe+"/guilds/"+guildID.String()+"/member")
+}
+
 // ModifyCurrentMember modifies the nickname of the current user in a guild.
 //
 // Fires a Guild Member Update Gateway event.
-func (c *Client) ModifyCurrentMember(
+func (c CtxClient) ModifyCurrentMember(
 	guildID discord.GuildID, nick string) error {
 
-	var param struct {
-		Nick string `json:"nick"`
-	}
+	_, err := c.ModifyCurrentMemberWithData(guildID, ModifyCurrentMemberData{
+		Nick: option.NewNullableString(nick),
+	})
+	return err
+}
 
-	param.Nick = nick
+// https://discord.com/developers/docs/resources/guild#modify-current-member-json-params
+type ModifyCurrentMemberData struct {
+	// Nick is the value to set the current user's nickname to. A null value
+	// removes the nickname.
+	Nick option.NullableString `json:"nick,omitempty"`
+	// Avatar is the current user's guild avatar. Use NullImage to remove it.
+	Avatar *Image `json:"avatar,omitempty"`
+	// Banner is the current user's guild banner. Use NullImage to remove it.
+	Banner *Image `json:"banner,omitempty"`
+	// Bio is the current user's guild bio. A null value removes the bio.
+	Bio option.NullableString `json:"bio,omitempty"`
 
-	return c.FastRequest(
-		"PATCH",
+	AuditLogReason `json:"-"`
+}
+
+// ModifyCurrentMemberWithData modifies the current user's member in a guild,
+// such as their guild nickname, avatar or banner.
+//
+// Fires a Guild Member Update Gateway event.
+func (c CtxClient) ModifyCurrentMemberWithData(
+	guildID discord.GuildID, data ModifyCurrentMemberData) (*discord.Member, error) {
+
+	var m *discord.Member
+	return m, c.RequestJSON(
+		&m, "PATCH",
 		EndpointGuilds+guildID.String()+"/members/@me",
-		httputil.WithJSONBody(param),
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
 	)
 }
 
 // PrivateChannels returns a list of DM channel objects. For bots, this is no
 // longer a supported method of getting recent DMs, and will return an empty
 // array.
-func (c *Client) PrivateChannels() ([]discord.Channel, error) {
+func (c CtxClient) PrivateChannels() ([]discord.Channel, error) {
 	var dms []discord.Channel
 	return dms, c.RequestJSON(&dms, "GET", EndpointMe+"/channels")
 }
 
 // CreatePrivateChannel creates a new DM channel with a user.
-func (c *Client) CreatePrivateChannel(recipientID discord.UserID) (*discord.Channel, error) {
+func (c CtxClient) CreatePrivateChannel(recipientID discord.UserID) (*discord.Channel, error) {
 	var param struct {
 		RecipientID discord.UserID `json:"recipient_id"`
 	}
@@ -85,16 +120,155 @@ func (c *Client) CreatePrivateChannel(recipientID discord.UserID) (*discord.Chan
 	return dm, c.RequestJSON(&dm, "POST", EndpointMe+"/channels", httputil.WithJSONBody(param))
 }
 
+// https://discord.com/developers/docs/resources/user#create-group-dm-json-params
+type CreateGroupDMData struct {
+	// AccessTokens are the OAuth2 access tokens of the users to add to the
+	// group DM, which must have been granted the gdm.join scope.
+	AccessTokens []string `json:"access_tokens"`
+	// Nicks maps the ID of each user to their nickname in the group DM.
+	Nicks map[discord.UserID]string `json:"nicks,omitempty"`
+}
+
+// CreateGroupDM creates a new group DM with the users whose access tokens are
+// given. Group DMs created this way are limited to 10 active group DMs, and
+// are not shown in the Discord client.
+func (c CtxClient) CreateGroupDM(data CreateGroupDMData) (*discord.Channel, error) {
+	var dm *discord.Channel
+	return dm, c.RequestJSON(&dm, "POST", EndpointMe+"/channels", httputil.WithJSONBody(data))
+}
+
+// SendDM sends a message to the user in their DM channel, which is created if
+// needed. Refer to SendDMComplex for more information.
+func (c CtxClient) SendDM(
+	userID discord.UserID, content string, embeds ...discord.Embed) (*discord.Message, error) {
+
+	return c.SendDMComplex(userID, SendMessageData{
+		Content: content,
+		Embeds:  embeds,
+	})
+}
+
+// SendDMComplex sends a message to the user in their DM channel, which is
+// created if needed. The IDs of the DM channels of the last 1000 users that
+// were sent a DM are cached in the Session, so only the first message to each
+// user usually needs an extra request. If a cached channel no longer exists,
+// then it's evicted and the DM channel is created again. However, if data has
+// files, their readers were already consumed, so the error is returned
+// instead; calling SendDMComplex again with new readers will create the DM
+// channel.
+//
+// Sending a DM fails if the user doesn't share a guild with the bot or has
+// disabled DMs from the guilds that they share.
+func (c CtxClient) SendDMComplex(
+	userID discord.UserID, data SendMessageData) (*discord.Message, error) {
+
+	channelID, cached := c.Session.dmChannels.get(userID)
+	if cached {
+		msg, err := c.SendMessageComplex(channelID, data)
+
+		var httpErr *httputil.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Status != http.StatusNotFound {
+			return msg, err
+		}
+
+		c.Session.dmChannels.evict(userID, channelID)
+
+		if len(data.Files) > 0 {
+			return nil, err
+		}
+	}
+
+	dm, err := c.CreatePrivateChannel(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DM channel: %w", err)
+	}
+
+	c.Session.dmChannels.put(userID, dm.ID)
+	return c.SendMessageComplex(dm.ID, data)
+}
+
+// maxDMChannels is the maximum number of DM channel IDs that a Session
+// caches for SendDM.
+const maxDMChannels = 1000
+
+// dmChannelCache caches the DM channel ID of each user that SendDM was used
+// with, since the channel usually never changes. It holds at most
+// maxDMChannels IDs, after which the least recently added one is evicted for
+// each new one. A nil cache caches nothing.
+type dmChannelCache struct {
+	mu    sync.Mutex
+	ids   map[discord.UserID]discord.ChannelID
+	order []discord.UserID // in the order that they were added
+}
+
+func newDMChannelCache() *dmChannelCache {
+	return &dmChannelCache{ids: make(map[discord.UserID]discord.ChannelID)}
+}
+
+func (c *dmChannelCache) get(userID discord.UserID) (discord.ChannelID, bool) {
+	if c == nil {
+		return 0, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id, ok := c.ids[userID]
+	return id, ok
+}
+
+func (c *dmChannelCache) put(userID discord.UserID, channelID discord.ChannelID) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.ids[userID]; !ok {
+		if len(c.order) >= maxDMChannels {
+			delete(c.ids, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.order = append(c.order, userID)
+	}
+
+	c.ids[userID] = channelID
+}
+
+// evict removes the user's DM channel ID from the cache if it's still the
+// given one.
+func (c *dmChannelCache) evict(userID discord.UserID, channelID discord.ChannelID) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ids[userID] != channelID {
+		return
+	}
+
+	delete(c.ids, userID)
+	for i, id := range c.order {
+		if id == userID {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
 // UserConnections returns a list of connection objects. Requires the
 // connections OAuth2 scope.
-func (c *Client) UserConnections() ([]discord.Connection, error) {
+func (c CtxClient) UserConnections() ([]discord.Connection, error) {
 	var conn []discord.Connection
 	return conn, c.RequestJSON(&conn, "GET", EndpointMe+"/connections")
 }
 
 // Note gets the note for the given user. This endpoint is undocumented and
 // might only work for user accounts.
-func (c *Client) Note(userID discord.UserID) (string, error) {
+func (c CtxClient) Note(userID discord.UserID) (string, error) {
 	var body struct {
 		Note string `json:"note"`
 	}
@@ -104,7 +278,7 @@ func (c *Client) Note(userID discord.UserID) (string, error) {
 
 // SetNote sets a note for the user. This endpoint is undocumented and might
 // only work for user accounts.
-func (c *Client) SetNote(userID discord.UserID, note string) error {
+func (c CtxClient) SetNote(userID discord.UserID, note string) error {
 	var body = struct {
 		Note string `json:"note"`
 	}{
@@ -119,7 +293,7 @@ func (c *Client) SetNote(userID discord.UserID, note string) error {
 
 // SetRelationship sets the relationship type between the current user and the
 // given user.
-func (c *Client) SetRelationship(userID discord.UserID, t discord.RelationshipType) error {
+func (c CtxClient) SetRelationship(userID discord.UserID, t discord.RelationshipType) error {
 	var body = struct {
 		Type discord.RelationshipType `json:"type"`
 	}{
@@ -134,6 +308,6 @@ func (c *Client) SetRelationship(userID discord.UserID, t discord.RelationshipTy
 
 // DeleteRelationship deletes the relationship between the current user and the
 // given user.
-func (c *Client) DeleteRelationship(userID discord.UserID) error {
+func (c CtxClient) DeleteRelationship(userID discord.UserID) error {
 	return c.FastRequest("DELETE", EndpointMe+"/relationships/"+userID.String())
 }
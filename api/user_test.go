@@ -0,0 +1,140 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+)
+
+func TestSendDM(t *testing.T) {
+	var mu sync.Mutex
+	var creates int
+	var sentTo []string
+	deleted := map[string]bool{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == Path+"/users/@me/channels":
+			creates++
+			json.EncodeStream(w, discord.Channel{
+				ID:   discord.ChannelID(100 + creates),
+				Type: discord.DirectMessage,
+			})
+
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			channelID := strings.Split(r.URL.Path, "/")[4]
+			sentTo = append(sentTo, channelID)
+
+			if deleted[channelID] {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code":10003,"message":"Unknown Channel"}`))
+				return
+			}
+
+			w.Write([]byte(`{"id":"1","channel_id":"` + channelID + `"}`))
+
+		default:
+			t.Error("unexpected request to", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.SendDM(5, "hi"); err != nil {
+			t.Fatal("failed to send DM:", err)
+		}
+	}
+
+	// The second DM uses the cached channel.
+	if creates != 1 {
+		t.Fatalf("expected 1 DM channel to be created, got %d", creates)
+	}
+	expectStrings(t, "channels", sentTo, []string{"101", "101"})
+
+	// A deleted channel is evicted, and a new one is created.
+	deleted["101"] = true
+	sentTo = nil
+
+	if _, err := client.SendDM(5, "hi"); err != nil {
+		t.Fatal("failed to send DM:", err)
+	}
+	if _, err := client.SendDM(5, "hi"); err != nil {
+		t.Fatal("failed to send DM:", err)
+	}
+
+	if creates != 2 {
+		t.Fatalf("expected 2 DM channels to be created, got %d", creates)
+	}
+	expectStrings(t, "channels", sentTo, []string{"101", "102", "102"})
+
+	// Files can't be sent twice, so a deleted channel fails the send, but the
+	// next one recreates the channel.
+	deleted["102"] = true
+	sentTo = nil
+
+	file := func() SendMessageData {
+		return SendMessageData{
+			Files: []sendpart.File{{Name: "a.txt", Reader: strings.NewReader("a")}},
+		}
+	}
+
+	if _, err := client.SendDMComplex(5, file()); err == nil {
+		t.Fatal("expected an error sending files to a deleted DM channel")
+	}
+	if _, err := client.SendDMComplex(5, file()); err != nil {
+		t.Fatal("failed to send DM:", err)
+	}
+
+	if creates != 3 {
+		t.Fatalf("expected 3 DM channels to be created, got %d", creates)
+	}
+	expectStrings(t, "channels", sentTo, []string{"102", "103"})
+}
+
+func TestDMChannelCache(t *testing.T) {
+	cache := newDMChannelCache()
+
+	for i := 1; i <= maxDMChannels+1; i++ {
+		cache.put(discord.UserID(i), discord.ChannelID(i))
+	}
+
+	if len(cache.ids) != maxDMChannels || len(cache.order) != maxDMChannels {
+		t.Fatalf("expected %d cached channels, got %d", maxDMChannels, len(cache.ids))
+	}
+	if _, ok := cache.get(1); ok {
+		t.Fatal("the oldest channel wasn't evicted")
+	}
+	if id, ok := cache.get(maxDMChannels + 1); !ok || id != maxDMChannels+1 {
+		t.Fatal("the newest channel wasn't cached")
+	}
+
+	// Evicting a channel that was already replaced keeps the new one.
+	cache.put(2, 200)
+	cache.evict(2, 2)
+	if id, ok := cache.get(2); !ok || id != 200 {
+		t.Fatal("the replaced channel was evicted")
+	}
+
+	cache.evict(2, 200)
+	if _, ok := cache.get(2); ok || len(cache.order) != maxDMChannels-1 {
+		t.Fatal("the channel wasn't evicted")
+	}
+
+	// A nil cache caches nothing.
+	var nilCache *dmChannelCache
+	nilCache.put(1, 1)
+	if _, ok := nilCache.get(1); ok {
+		t.Fatal("a nil cache cached a channel")
+	}
+}
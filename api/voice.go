@@ -0,0 +1,139 @@
+package api
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+var EndpointVoice = Endpoint + "voice/"
+
+// VoiceRegions returns the voice regions that can be used as the RTCRegion of
+// voice and stage channels. Use VoiceRegionsGuild to get the regions available
+// to a guild, which may include VIP regions.
+//
+// https://discord.com/developers/docs/resources/voice#list-voice-regions
+func (c CtxClient) VoiceRegions() ([]discord.VoiceRegion, error) {
+	var vrs []discord.VoiceRegion
+	return vrs, c.RequestJSON(&vrs, "GET", EndpointVoice+"regions")
+}
+
+// CurrentUserVoiceState returns the current user's voice state in the given
+// guild.
+//
+// https://discord.com/developers/docs/resources/voice#get-current-user-voice-state
+func (c CtxClient) CurrentUserVoiceState(guildID discord.GuildID) (*discord.VoiceState, error) {
+	var vs *discord.VoiceState
+	return vs, c.RequestJSON(
+		&vs, "GET",
+		EndpointGuilds+guildID.String()+"/voice-states/@me",
+	)
+}
+
+// UserVoiceState returns the voice state of the user with the given ID in the
+// given guild.
+//
+// https://discord.com/developers/docs/resources/voice#get-user-voice-state
+func (c CtxClient) UserVoiceState(
+	guildID discord.GuildID, userID discord.UserID) (*discord.VoiceState, error) {
+
+	var vs *discord.VoiceState
+	return vs, c.RequestJSON(
+		&vs, "GET",
+		EndpointGuilds+guildID.String()+"/voice-states/"+userID.String(),
+	)
+}
+
+// https://discord.com/developers/docs/resources/voice#modify-current-user-voice-state-json-params
+type ModifyCurrentUserVoiceStateData struct {
+	// ChannelID is the ID of the channel the user is currently in.
+	ChannelID discord.ChannelID `json:"channel_id,omitempty"`
+	// Suppress toggles the user's suppress state.
+	Suppress option.Bool `json:"suppress,omitempty"`
+	// RequestToSpeakTimestamp sets the user's request to speak. A pointer to a
+	// zero-value Timestamp will remove the request to speak.
+	RequestToSpeakTimestamp *discord.Timestamp `json:"request_to_speak_timestamp,omitempty"`
+}
+
+// ModifyCurrentUserVoiceState updates the current user's voice state in a
+// stage channel.
+//
+// The channel must currently point to a stage channel, and the current user
+// must already have joined it. The MUTE_MEMBERS permission is required to
+// unsuppress yourself, and the REQUEST_TO_SPEAK permission is required to
+// request to speak. You can always clear your own request to speak, as well as
+// suppress yourself.
+//
+// Fires a Voice State Update Gateway event.
+func (c CtxClient) ModifyCurrentUserVoiceState(
+	guildID discord.GuildID, data ModifyCurrentUserVoiceStateData) error {
+
+	return c.FastRequest(
+		"PATCH",
+		EndpointGuilds+guildID.String()+"/voice-states/@me",
+		httputil.WithJSONBody(data),
+	)
+}
+
+// https://discord.com/developers/docs/resources/voice#modify-user-voice-state-json-params
+type ModifyUserVoiceStateData struct {
+	// ChannelID is the ID of the channel the user is currently in.
+	ChannelID discord.ChannelID `json:"channel_id"`
+	// Suppress toggles the user's suppress state.
+	Suppress option.Bool `json:"suppress,omitempty"`
+}
+
+// ModifyUserVoiceState updates another user's voice state in a stage channel.
+//
+// The channel must currently point to a stage channel, and the user must
+// already have joined it. The MUTE_MEMBERS permission is required to suppress
+// or unsuppress the user, and the ChannelID must not be invalid.
+//
+// Fires a Voice State Update Gateway event.
+func (c CtxClient) ModifyUserVoiceState(
+	guildID discord.GuildID, userID discord.UserID, data ModifyUserVoiceStateData) error {
+
+	return c.FastRequest(
+		"PATCH",
+		EndpointGuilds+guildID.String()+"/voice-states/"+userID.String(),
+		httputil.WithJSONBody(data),
+	)
+}
+
+// SuppressCurrentUser moves the current user to the audience of the Stage
+// channel that it is in.
+func (c CtxClient) SuppressCurrentUser(guildID discord.GuildID, channelID discord.ChannelID) error {
+	return c.ModifyCurrentUserVoiceState(guildID, ModifyCurrentUserVoiceStateData{
+		ChannelID: channelID,
+		Suppress:  option.True,
+	})
+}
+
+// UnsuppressCurrentUser moves the current user to the speakers of the Stage
+// channel that it is in. It requires the MUTE_MEMBERS permission; otherwise,
+// use ModifyCurrentUserVoiceState to request to speak instead.
+func (c CtxClient) UnsuppressCurrentUser(guildID discord.GuildID, channelID discord.ChannelID) error {
+	return c.ModifyCurrentUserVoiceState(guildID, ModifyCurrentUserVoiceStateData{
+		ChannelID: channelID,
+		Suppress:  option.False,
+	})
+}
+
+// RequestToSpeak requests to speak in the Stage channel that the current user
+// is in. It requires the REQUEST_TO_SPEAK permission.
+func (c CtxClient) RequestToSpeak(guildID discord.GuildID, channelID discord.ChannelID) error {
+	now := discord.NowTimestamp()
+	return c.ModifyCurrentUserVoiceState(guildID, ModifyCurrentUserVoiceStateData{
+		ChannelID:               channelID,
+		RequestToSpeakTimestamp: &now,
+	})
+}
+
+// CancelRequestToSpeak clears the current user's request to speak in the Stage
+// channel that it is in.
+func (c CtxClient) CancelRequestToSpeak(guildID discord.GuildID, channelID discord.ChannelID) error {
+	return c.ModifyCurrentUserVoiceState(guildID, ModifyCurrentUserVoiceStateData{
+		ChannelID:               channelID,
+		RequestToSpeakTimestamp: &discord.Timestamp{},
+	})
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+	"github.com/diamondburned/arikawa/v3/voice/opus"
+)
+
+// MaxWaveformSamples is the maximum number of samples in the waveform of a
+// voice message.
+const MaxWaveformSamples = 256
+
+// VoiceMessageFilename is the name of the file uploaded by SendVoiceMessage.
+const VoiceMessageFilename = "voice-message.ogg"
+
+// ErrEmptyVoiceMessage is returned when a voice message has no audio.
+var ErrEmptyVoiceMessage = errors.New("voice message has no audio")
+
+// NewVoiceMessageFile reads the Ogg Opus stream from r and returns a file with
+// its duration and waveform filled in, which is required for it to be sent as a
+// voice message. The whole stream is read into memory.
+//
+// The waveform is estimated from the size of each Opus packet, since the audio
+// is not decoded. This works well for the variable bitrate streams produced by
+// most encoders, but the waveform of a constant bitrate stream will be flat.
+func NewVoiceMessageFile(r io.Reader) (sendpart.File, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return sendpart.File{}, fmt.Errorf("failed to read audio: %w", err)
+	}
+
+	or, err := opus.NewOggReader(bytes.NewReader(b))
+	if err != nil {
+		return sendpart.File{}, err
+	}
+
+	var sizes []int
+	for {
+		packet, err := or.ReadPacket()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return sendpart.File{}, fmt.Errorf("failed to read packet: %w", err)
+		}
+		sizes = append(sizes, len(packet))
+	}
+
+	samples := int64(or.Granule()) - int64(or.PreSkip)
+	if len(sizes) == 0 || samples <= 0 {
+		return sendpart.File{}, ErrEmptyVoiceMessage
+	}
+
+	return sendpart.File{
+		Name:         VoiceMessageFilename,
+		Reader:       bytes.NewReader(b),
+		ContentType:  "audio/ogg",
+		DurationSecs: float64(samples) / opus.SampleRate,
+		Waveform:     base64.StdEncoding.EncodeToString(waveform(sizes)),
+	}, nil
+}
+
+// waveform downsamples the packet sizes into at most MaxWaveformSamples
+// samples, scaled so that the largest sample is 255.
+func waveform(sizes []int) []byte {
+	n := len(sizes)
+	if n > MaxWaveformSamples {
+		n = MaxWaveformSamples
+	}
+
+	avgs := make([]int, n)
+	var max int
+
+	for i := range avgs {
+		bucket := sizes[i*len(sizes)/n : (i+1)*len(sizes)/n]
+
+		var sum int
+		for _, size := range bucket {
+			// Packets this small are silence or comfort noise.
+			if size > len(opus.SilenceFrame) {
+				sum += size
+			}
+		}
+
+		avgs[i] = sum / len(bucket)
+		if avgs[i] > max {
+			max = avgs[i]
+		}
+	}
+
+	wave := make([]byte, n)
+	if max == 0 {
+		return wave
+	}
+
+	for i, avg := range avgs {
+		wave[i] = byte(avg * 255 / max)
+	}
+
+	return wave
+}
+
+// SendVoiceMessage sends the Ogg Opus stream from r as a voice message. Voice
+// messages cannot have any content, embeds or other files. Refer to
+// NewVoiceMessageFile for how the stream is read.
+//
+// Requires the SEND_VOICE_MESSAGES permission.
+func (c CtxClient) SendVoiceMessage(
+	channelID discord.ChannelID, r io.Reader) (*discord.Message, error) {
+
+	file, err := NewVoiceMessageFile(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SendMessageComplex(channelID, SendMessageData{
+		Files: []sendpart.File{file},
+		Flags: discord.VoiceMessage,
+	})
+}
@@ -14,6 +14,8 @@ type CreateWebhookData struct {
 	Name string `json:"name"`
 	// Avatar is the image for the default webhook avatar.
 	Avatar *Image `json:"avatar"`
+
+	AuditLogReason `json:"-"`
 }
 
 // CreateWebhook creates a new webhook.
@@ -21,21 +23,21 @@ type CreateWebhookData struct {
 // Webhooks cannot be named "clyde".
 //
 // Requires the MANAGE_WEBHOOKS permission.
-func (c *Client) CreateWebhook(
+func (c CtxClient) CreateWebhook(
 	channelID discord.ChannelID, data CreateWebhookData) (*discord.Webhook, error) {
 
 	var w *discord.Webhook
 	return w, c.RequestJSON(
 		&w, "POST",
 		EndpointChannels+channelID.String()+"/webhooks",
-		httputil.WithJSONBody(data),
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
 	)
 }
 
 // ChannelWebhooks returns the webhooks of the channel with the given ID.
 //
 // Requires the MANAGE_WEBHOOKS permission.
-func (c *Client) ChannelWebhooks(channelID discord.ChannelID) ([]discord.Webhook, error) {
+func (c CtxClient) ChannelWebhooks(channelID discord.ChannelID) ([]discord.Webhook, error) {
 	var ws []discord.Webhook
 	return ws, c.RequestJSON(&ws, "GET", EndpointChannels+channelID.String()+"/webhooks")
 }
@@ -43,13 +45,13 @@ func (c *Client) ChannelWebhooks(channelID discord.ChannelID) ([]discord.Webhook
 // GuildWebhooks returns the webhooks of the guild with the given ID.
 //
 // Requires the MANAGE_WEBHOOKS permission.
-func (c *Client) GuildWebhooks(guildID discord.GuildID) ([]discord.Webhook, error) {
+func (c CtxClient) GuildWebhooks(guildID discord.GuildID) ([]discord.Webhook, error) {
 	var ws []discord.Webhook
 	return ws, c.RequestJSON(&ws, "GET", EndpointGuilds+guildID.String()+"/webhooks")
 }
 
 // Webhook returns the webhook with the given id.
-func (c *Client) Webhook(webhookID discord.WebhookID) (*discord.Webhook, error) {
+func (c CtxClient) Webhook(webhookID discord.WebhookID) (*discord.Webhook, error) {
 	var w *discord.Webhook
 	return w, c.RequestJSON(&w, "GET", EndpointWebhooks+webhookID.String())
 }
@@ -62,25 +64,27 @@ type ModifyWebhookData struct {
 	Avatar *Image `json:"avatar,omitempty"`
 	// ChannelID is the new channel id this webhook should be moved to.
 	ChannelID discord.ChannelID `json:"channel_id,omitempty"`
+
+	AuditLogReason `json:"-"`
 }
 
 // ModifyWebhook modifies a webhook.
 //
 // Requires the MANAGE_WEBHOOKS permission.
-func (c *Client) ModifyWebhook(
+func (c CtxClient) ModifyWebhook(
 	webhookID discord.WebhookID, data ModifyWebhookData) (*discord.Webhook, error) {
 
 	var w *discord.Webhook
 	return w, c.RequestJSON(
 		&w, "PATCH",
 		EndpointWebhooks+webhookID.String(),
-		httputil.WithJSONBody(data),
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
 	)
 }
 
 // DeleteWebhook deletes a webhook permanently.
 //
 // Requires the MANAGE_WEBHOOKS permission.
-func (c *Client) DeleteWebhook(webhookID discord.WebhookID) error {
+func (c CtxClient) DeleteWebhook(webhookID discord.WebhookID) error {
 	return c.FastRequest("DELETE", EndpointWebhooks+webhookID.String())
 }
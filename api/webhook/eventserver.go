@@ -0,0 +1,232 @@
+package webhook
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// EventWebhookType is the type of a webhook event payload.
+//
+// https://discord.com/developers/docs/events/webhook-events#webhook-types
+type EventWebhookType uint8
+
+const (
+	// PingEventWebhook is sent by Discord to test the endpoint.
+	PingEventWebhook EventWebhookType = 0
+	// EventWebhook contains an event.
+	EventWebhook EventWebhookType = 1
+)
+
+// EventType is the type of an event sent through webhook events.
+//
+// https://discord.com/developers/docs/events/webhook-events#event-types
+type EventType string
+
+const (
+	ApplicationAuthorizedEventType   EventType = "APPLICATION_AUTHORIZED"
+	ApplicationDeauthorizedEventType EventType = "APPLICATION_DEAUTHORIZED"
+	EntitlementCreateEventType       EventType = "ENTITLEMENT_CREATE"
+)
+
+// EventPayload is the payload of a webhook event request sent by Discord.
+//
+// https://discord.com/developers/docs/events/webhook-events#payload-structure
+type EventPayload struct {
+	// Version is the version scheme for the webhook event. It is currently
+	// always 1.
+	Version int `json:"version"`
+	// AppID is the ID of the application.
+	AppID discord.AppID `json:"application_id"`
+	// Type is the type of the webhook event.
+	Type EventWebhookType `json:"type"`
+	// Event is the event data. It is nil for PingEventWebhook payloads.
+	Event *EventBody `json:"event,omitempty"`
+}
+
+// EventBody is the body of an event.
+//
+// https://discord.com/developers/docs/events/webhook-events#event-body-object
+type EventBody struct {
+	// Type is the event type.
+	Type EventType `json:"type"`
+	// Timestamp is the time at which the event was sent.
+	Timestamp discord.Timestamp `json:"timestamp"`
+	// Data is the raw event data.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Event is a decoded webhook event given to an EventHandler.
+type Event struct {
+	// AppID is the ID of the application.
+	AppID discord.AppID
+	// Type is the event type.
+	Type EventType
+	// Timestamp is the time at which the event was sent.
+	Timestamp discord.Timestamp
+	// Data is the event data. It is one of the *Event types in this package
+	// if Type is known; otherwise, it is the raw JSON data.
+	Data interface{}
+}
+
+// ApplicationAuthorizedEvent is sent when the application was authorized by a
+// user to a guild or to their account.
+//
+// https://discord.com/developers/docs/events/webhook-events#application-authorized
+type ApplicationAuthorizedEvent struct {
+	// IntegrationType is the installation context of the authorization. It
+	// is nil if the application wasn't authorized with the
+	// applications.commands scope.
+	IntegrationType *discord.ApplicationIntegrationType `json:"integration_type,omitempty"`
+	// User is the user who authorized the application.
+	User discord.User `json:"user"`
+	// Scopes is the list of scopes the user authorized.
+	Scopes []string `json:"scopes"`
+	// Guild is the guild that the application was authorized to, if any.
+	Guild *discord.Guild `json:"guild,omitempty"`
+}
+
+// ApplicationDeauthorizedEvent is sent when the application was deauthorized
+// by a user.
+//
+// https://discord.com/developers/docs/events/webhook-events#application-deauthorized
+type ApplicationDeauthorizedEvent struct {
+	// User is the user who deauthorized the application.
+	User discord.User `json:"user"`
+}
+
+// EntitlementCreateEvent is sent when an entitlement was created.
+//
+// https://discord.com/developers/docs/events/webhook-events#entitlement-create
+type EntitlementCreateEvent struct {
+	discord.Entitlement
+}
+
+// DecodeEvent decodes the given event body into an Event.
+func DecodeEvent(appID discord.AppID, body EventBody) (*Event, error) {
+	ev := Event{
+		AppID:     appID,
+		Type:      body.Type,
+		Timestamp: body.Timestamp,
+	}
+
+	switch body.Type {
+	case ApplicationAuthorizedEventType:
+		ev.Data = &ApplicationAuthorizedEvent{}
+	case ApplicationDeauthorizedEventType:
+		ev.Data = &ApplicationDeauthorizedEvent{}
+	case EntitlementCreateEventType:
+		ev.Data = &EntitlementCreateEvent{}
+	default:
+		ev.Data = body.Data
+		return &ev, nil
+	}
+
+	if err := json.Unmarshal(body.Data, ev.Data); err != nil {
+		return nil, fmt.Errorf("cannot decode %s event: %w", body.Type, err)
+	}
+
+	return &ev, nil
+}
+
+// EventHandler is a type whose method is called on every incoming webhook
+// event.
+type EventHandler interface {
+	// HandleEvent is called synchronously for every event. Discord expects a
+	// response within 3 seconds, so long-running work should be done in the
+	// background.
+	HandleEvent(*Event)
+}
+
+// EventHandlerFunc is a function type that implements EventHandler.
+type EventHandlerFunc func(*Event)
+
+var _ EventHandler = EventHandlerFunc(nil)
+
+// HandleEvent implements EventHandler.
+func (f EventHandlerFunc) HandleEvent(ev *Event) { f(ev) }
+
+// EventServer provides a HTTP handler to verify and handle webhook events sent
+// by Discord to the application's Webhook Events URL.
+type EventServer struct {
+	ErrorFunc InteractionErrorFunc
+
+	eventHandler EventHandler
+	httpHandler  http.Handler
+	pubkey       ed25519.PublicKey
+}
+
+// NewEventServer creates a new EventServer instance. pubkey should be
+// hex-encoded.
+func NewEventServer(pubkey string, handler EventHandler) (*EventServer, error) {
+	pubkeyB, err := hex.DecodeString(pubkey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode hex pubkey: %w", err)
+	}
+
+	s := EventServer{
+		ErrorFunc: func(w http.ResponseWriter, r *http.Request, code int, err error) {
+			writeError(w, code, err)
+		},
+		eventHandler: handler,
+		pubkey:       pubkeyB,
+	}
+
+	s.httpHandler = http.HandlerFunc(s.handle)
+	if len(s.pubkey) != 0 {
+		s.httpHandler = withVerification(s.pubkey, s.errorFunc, s.httpHandler)
+	}
+
+	return &s, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *EventServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.httpHandler.ServeHTTP(w, r)
+}
+
+func (s *EventServer) errorFunc(w http.ResponseWriter, r *http.Request, code int, err error) {
+	s.ErrorFunc(w, r, code, err)
+}
+
+func (s *EventServer) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		s.ErrorFunc(w, r, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	var payload EventPayload
+
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		s.ErrorFunc(w, r, 400, fmt.Errorf("cannot decode event payload: %w", err))
+		return
+	}
+
+	switch payload.Type {
+	case PingEventWebhook:
+		// Nothing to do.
+	case EventWebhook:
+		if payload.Event == nil {
+			s.ErrorFunc(w, r, 400, errors.New("missing event body"))
+			return
+		}
+
+		ev, err := DecodeEvent(payload.AppID, *payload.Event)
+		if err != nil {
+			s.ErrorFunc(w, r, 400, err)
+			return
+		}
+
+		s.eventHandler.HandleEvent(ev)
+	default:
+		s.ErrorFunc(w, r, 400, fmt.Errorf("unknown webhook type %d", payload.Type))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
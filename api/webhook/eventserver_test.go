@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestEventServer(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal("failed to generate key:", err)
+	}
+
+	events := make(chan *Event, 1)
+
+	s, err := NewEventServer(hex.EncodeToString(pub), EventHandlerFunc(func(ev *Event) {
+		events <- ev
+	}))
+	if err != nil {
+		t.Fatal("failed to create server:", err)
+	}
+
+	do := func(body string, sign bool) int {
+		const timestamp = "1700000000"
+
+		req := httptest.NewRequest("POST", "/", bytes.NewBufferString(body))
+
+		if sign {
+			sig := ed25519.Sign(priv, []byte(timestamp+body))
+			req.Header.Set("X-Signature-Ed25519", hex.EncodeToString(sig))
+		} else {
+			req.Header.Set("X-Signature-Ed25519", hex.EncodeToString(make([]byte, 64)))
+		}
+		req.Header.Set("X-Signature-Timestamp", timestamp)
+
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := do(`{"version":1,"application_id":"1","type":0}`, true); code != http.StatusNoContent {
+		t.Fatal("unexpected ping status code", code)
+	}
+
+	if code := do(`{"version":1,"application_id":"1","type":0}`, false); code != http.StatusUnauthorized {
+		t.Fatal("unexpected status code for bad signature", code)
+	}
+
+	const entitlement = `{
+		"version": 1,
+		"application_id": "1",
+		"type": 1,
+		"event": {
+			"type": "ENTITLEMENT_CREATE",
+			"timestamp": "2024-10-18T18:41:21.109604+00:00",
+			"data": {
+				"id": "2",
+				"sku_id": "3",
+				"application_id": "1",
+				"user_id": "4",
+				"type": 8,
+				"deleted": false
+			}
+		}
+	}`
+
+	if code := do(entitlement, true); code != http.StatusNoContent {
+		t.Fatal("unexpected event status code", code)
+	}
+
+	ev := <-events
+	if ev.Type != EntitlementCreateEventType || ev.AppID != 1 {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+
+	data, ok := ev.Data.(*EntitlementCreateEvent)
+	if !ok {
+		t.Fatalf("unexpected event data %T", ev.Data)
+	}
+
+	if data.SKUID != 3 || data.UserID != 4 || data.Type != discord.ApplicationSubscriptionEntitlement {
+		t.Fatalf("unexpected entitlement %+v", data.Entitlement)
+	}
+
+	if !data.Active() {
+		t.Fatal("expected entitlement to be active")
+	}
+}
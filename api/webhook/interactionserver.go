@@ -109,7 +109,7 @@ func NewInteractionServer(pubkey string, handler InteractionHandler) (*Interacti
 
 	s.httpHandler = http.HandlerFunc(s.handle)
 	if len(s.pubkey) != 0 {
-		s.httpHandler = s.withVerification(s.httpHandler)
+		s.httpHandler = withVerification(s.pubkey, s.errorFunc, s.httpHandler)
 	}
 
 	return &s, nil
@@ -154,30 +154,38 @@ func (s *InteractionServer) handle(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// errorFunc calls ErrorFunc. It allows ErrorFunc to be changed after the
+// handlers are created.
+func (s *InteractionServer) errorFunc(w http.ResponseWriter, r *http.Request, code int, err error) {
+	s.ErrorFunc(w, r, code, err)
+}
+
 // withVerification was written thanks to @bsdlp and their code
 // https://github.com/bsdlp/discord-interactions-go/blob/a2ba844/interactions/verify_example_test.go#L63.
-func (s *InteractionServer) withVerification(next http.Handler) http.Handler {
+func withVerification(
+	pubkey ed25519.PublicKey, errorFunc InteractionErrorFunc, next http.Handler) http.Handler {
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		signature := r.Header.Get("X-Signature-Ed25519")
 		if signature == "" {
-			s.ErrorFunc(w, r, 401, errors.New("missing header X-Signature-Ed25519"))
+			errorFunc(w, r, 401, errors.New("missing header X-Signature-Ed25519"))
 			return
 		}
 
 		sig, err := hex.DecodeString(signature)
 		if err != nil {
-			s.ErrorFunc(w, r, 400, fmt.Errorf("X-Signature-Ed25519 is not valid hex-encoded: %w", err))
+			errorFunc(w, r, 400, fmt.Errorf("X-Signature-Ed25519 is not valid hex-encoded: %w", err))
 			return
 		}
 
 		if len(sig) != ed25519.SignatureSize || sig[63]&224 != 0 {
-			s.ErrorFunc(w, r, 400, errors.New("invalid X-Signature-Ed25519 data"))
+			errorFunc(w, r, 400, errors.New("invalid X-Signature-Ed25519 data"))
 			return
 		}
 
 		timestamp := r.Header.Get("X-Signature-Timestamp")
 		if timestamp == "" {
-			s.ErrorFunc(w, r, 401, errors.New("missing header X-Signature-Timestamp"))
+			errorFunc(w, r, 401, errors.New("missing header X-Signature-Timestamp"))
 			return
 		}
 
@@ -186,12 +194,12 @@ func (s *InteractionServer) withVerification(next http.Handler) http.Handler {
 		msg.WriteString(timestamp)
 
 		if _, err := io.Copy(&msg, r.Body); err != nil {
-			s.ErrorFunc(w, r, 500, fmt.Errorf("cannot read body: %w", err))
+			errorFunc(w, r, 500, fmt.Errorf("cannot read body: %w", err))
 			return
 		}
 
-		if !ed25519.Verify(s.pubkey, msg.Bytes(), sig) {
-			s.ErrorFunc(w, r, 401, errors.New("signature mismatch"))
+		if !ed25519.Verify(pubkey, msg.Bytes(), sig) {
+			errorFunc(w, r, 401, errors.New("signature mismatch"))
 			return
 		}
 
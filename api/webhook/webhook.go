@@ -55,11 +55,17 @@ type Session struct {
 
 // OnRequest should be called on each client request to inject itself.
 func (s *Session) OnRequest(r httpdriver.Request) error {
+	if s.Limiter == nil {
+		return nil
+	}
 	return s.Limiter.Acquire(r.GetContext(), r.GetPath())
 }
 
 // OnResponse should be called after each client request to clean itself up.
 func (s *Session) OnResponse(r httpdriver.Request, resp httpdriver.Response) error {
+	if s.Limiter == nil {
+		return nil
+	}
 	return s.Limiter.Release(r.GetPath(), httpdriver.OptHeader(resp))
 }
 
@@ -164,7 +170,14 @@ type ExecuteData struct {
 
 	// ThreadID causes the message to be sent to the specified thread within
 	// the webhook's channel. The thread will automatically be unarchived.
-	ThreadID discord.CommandID `json:"-"`
+	ThreadID discord.ChannelID `json:"-"`
+	// ThreadName is the name of the thread to create, if the webhook's
+	// channel is a forum or media channel. Either this or ThreadID must be set
+	// for such channels.
+	ThreadName string `json:"thread_name,omitempty"`
+	// AppliedTags are the IDs of the tags to apply to the thread created using
+	// ThreadName.
+	AppliedTags []discord.TagID `json:"applied_tags,omitempty"`
 
 	// Username overrides the default username of the webhook
 	Username string `json:"username,omitempty"`
@@ -179,7 +192,9 @@ type ExecuteData struct {
 	Embeds []discord.Embed `json:"embeds,omitempty"`
 
 	// Components is the list of components (such as buttons) to be attached to
-	// the message.
+	// the message. Webhooks owned by an application can use all components,
+	// while other webhooks can only use non-interactive components, such as
+	// link buttons.
 	Components discord.ContainerComponents `json:"components,omitempty"`
 
 	// Files represents a list of files to upload. This will not be
@@ -188,6 +203,30 @@ type ExecuteData struct {
 
 	// AllowedMentions are the allowed mentions for the message.
 	AllowedMentions *api.AllowedMentions `json:"allowed_mentions,omitempty"`
+
+	// Poll is the poll to attach to the message.
+	Poll *api.CreatePollData `json:"poll,omitempty"`
+
+	// Flags specifies the message flags to set. Only SuppressEmbeds,
+	// SuppressNotifications, VoiceMessage and ComponentsV2 can be set.
+	Flags discord.MessageFlags `json:"flags,omitempty"`
+}
+
+// Silent returns a copy of data with the SuppressNotifications flag set, which
+// sends the message without triggering push and desktop notifications.
+func (data ExecuteData) Silent() ExecuteData {
+	data.Flags |= discord.SuppressNotifications
+	return data
+}
+
+// isEmpty returns true if the message has no content. Messages using
+// ComponentsV2 are laid out only using components.
+func (data ExecuteData) isEmpty() bool {
+	if data.Flags.Has(discord.ComponentsV2) {
+		return len(data.Components) == 0 && len(data.Files) == 0
+	}
+	return data.Content == "" && len(data.Embeds) == 0 && len(data.Files) == 0 &&
+		data.Poll == nil
 }
 
 // NeedsMultipart returns true if the ExecuteWebhookData has files.
@@ -216,7 +255,7 @@ func (c *Client) ExecuteAndWait(data ExecuteData) (*discord.Message, error) {
 }
 
 func (c *Client) execute(data ExecuteData, wait bool) (*discord.Message, error) {
-	if data.Content == "" && len(data.Embeds) == 0 && len(data.Files) == 0 {
+	if data.isEmpty() {
 		return nil, api.ErrEmptyMessage
 	}
 
@@ -232,18 +271,22 @@ func (c *Client) execute(data ExecuteData, wait bool) (*discord.Message, error)
 			return nil, fmt.Errorf("embed error at %d: %w", i, err)
 		}
 		sum += embed.Length()
-		if sum > 6000 {
-			return nil, &discord.OverboundError{sum, 6000, "sum of all text in embeds"}
+		if sum > discord.MaxEmbedsLength {
+			return nil, &discord.OverboundError{sum, discord.MaxEmbedsLength, "sum of all text in embeds"}
 		}
 	}
 
-	param := make(url.Values, 2)
+	param := make(url.Values, 3)
 	if wait {
 		param["wait"] = []string{"true"}
 	}
 	if data.ThreadID.IsValid() {
 		param["thread_id"] = []string{data.ThreadID.String()}
 	}
+	if len(data.Components) > 0 {
+		// Components are ignored without this.
+		param["with_components"] = []string{"true"}
+	}
 
 	var URL = api.EndpointWebhooks + c.ID.String() + "/" + c.Token + "?" + param.Encode()
 
@@ -258,19 +301,31 @@ func (c *Client) execute(data ExecuteData, wait bool) (*discord.Message, error)
 
 // Message returns a previously-sent webhook message from the same token.
 func (c *Client) Message(messageID discord.MessageID) (*discord.Message, error) {
+	return c.ThreadMessage(0, messageID)
+}
+
+// ThreadMessage returns a previously-sent webhook message from the same token
+// in a thread within the webhook's channel. If threadID is 0, then it's the
+// same as Message.
+func (c *Client) ThreadMessage(
+	threadID discord.ChannelID, messageID discord.MessageID) (*discord.Message, error) {
+
 	var m *discord.Message
-	return m, c.RequestJSON(
-		&m, "GET",
-		api.EndpointWebhooks+c.ID.String()+"/"+c.Token+"/messages/"+messageID.String())
+	return m, c.RequestJSON(&m, "GET", c.messageURL(threadID, messageID, false))
 }
 
 // https://discord.com/developers/docs/resources/webhook#edit-webhook-message-jsonform-params
 type EditMessageData struct {
+	// ThreadID is the ID of the thread that the message is in, if it's in a
+	// thread within the webhook's channel.
+	ThreadID discord.ChannelID `json:"-"`
+
 	// Content is the new message contents (up to 2000 characters).
 	Content option.NullableString `json:"content,omitempty"`
 	// Embeds contains embedded rich content.
 	Embeds *[]discord.Embed `json:"embeds,omitempty"`
-	// Components contains the new components to attach.
+	// Components contains the new components to attach. Refer to
+	// ExecuteData's Components for which components can be used.
 	Components *discord.ContainerComponents `json:"components,omitempty"`
 	// AllowedMentions are the allowed mentions for a message.
 	AllowedMentions *api.AllowedMentions `json:"allowed_mentions,omitempty"`
@@ -294,14 +349,16 @@ func (c *Client) EditMessage(messageID discord.MessageID, data EditMessageData)
 				return nil, fmt.Errorf("embed error: %w", err)
 			}
 			sum += e.Length()
-			if sum > 6000 {
-				return nil, &discord.OverboundError{sum, 6000, "sum of text in embeds"}
+			if sum > discord.MaxEmbedsLength {
+				return nil, &discord.OverboundError{sum, discord.MaxEmbedsLength, "sum of text in embeds"}
 			}
 		}
 	}
+	withComponents := data.Components != nil && len(*data.Components) > 0
+
 	var msg *discord.Message
 	return msg, sendpart.PATCH(c.Client, data, &msg,
-		api.EndpointWebhooks+c.ID.String()+"/"+c.Token+"/messages/"+messageID.String())
+		c.messageURL(data.ThreadID, messageID, withComponents))
 }
 
 // NeedsMultipart returns true if the SendMessageData has files.
@@ -316,6 +373,31 @@ func (data EditMessageData) WriteMultipart(body *multipart.Writer) error {
 // DeleteMessage deletes a message that was previously created by the same
 // webhook.
 func (c *Client) DeleteMessage(messageID discord.MessageID) error {
-	return c.FastRequest("DELETE",
-		api.EndpointWebhooks+c.ID.String()+"/"+c.Token+"/messages/"+messageID.String())
+	return c.DeleteThreadMessage(0, messageID)
+}
+
+// DeleteThreadMessage deletes a message that was previously created by the
+// same webhook in a thread within the webhook's channel. If threadID is 0,
+// then it's the same as DeleteMessage.
+func (c *Client) DeleteThreadMessage(threadID discord.ChannelID, messageID discord.MessageID) error {
+	return c.FastRequest("DELETE", c.messageURL(threadID, messageID, false))
+}
+
+func (c *Client) messageURL(
+	threadID discord.ChannelID, messageID discord.MessageID, withComponents bool) string {
+
+	u := api.EndpointWebhooks + c.ID.String() + "/" + c.Token + "/messages/" + messageID.String()
+
+	param := make(url.Values, 2)
+	if threadID.IsValid() {
+		param["thread_id"] = []string{threadID.String()}
+	}
+	if withComponents {
+		param["with_components"] = []string{"true"}
+	}
+	if len(param) > 0 {
+		u += "?" + param.Encode()
+	}
+
+	return u
 }
@@ -0,0 +1,213 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Activity limits.
+const (
+	MaxActivityButtons     = 2
+	MaxActivityButtonLabel = 32
+	MaxActivityButtonURL   = 512
+	MaxActivityText        = 128
+)
+
+// NewActivity creates a new activity of the given type and name. The methods
+// on the returned Activity can be chained to build a rich presence:
+//
+//	a := discord.NewActivity(discord.GameActivity, "Chess").
+//		WithDetails("Ranked match").
+//		WithState("In game").
+//		WithStartTime(time.Now()).
+//		WithParty("lobby-1", 1, 2).
+//		WithButton("Spectate", "https://example.com/spectate")
+//
+//	if err := a.Validate(); err != nil {
+//		return err
+//	}
+func NewActivity(t ActivityType, name string) *Activity {
+	return &Activity{Type: t, Name: name}
+}
+
+// NewCustomActivity creates a new custom status activity with the given text,
+// which is shown as-is, and an optional emoji.
+func NewCustomActivity(state string, emoji *Emoji) *Activity {
+	return &Activity{
+		Type:  CustomActivity,
+		Name:  "Custom Status",
+		State: state,
+		Emoji: emoji,
+	}
+}
+
+// NewStreamingActivity creates a new streaming activity with the given name and
+// stream URL, which must be a Twitch or YouTube URL.
+func NewStreamingActivity(name string, url URL) *Activity {
+	return &Activity{Type: StreamingActivity, Name: name, URL: url}
+}
+
+// WithDetails sets what the user is currently doing.
+func (a *Activity) WithDetails(details string) *Activity {
+	a.Details = details
+	return a
+}
+
+// WithState sets the current party status of the user.
+func (a *Activity) WithState(state string) *Activity {
+	a.State = state
+	return a
+}
+
+// WithStartTime sets the time at which the activity started, which is shown
+// as the elapsed time.
+func (a *Activity) WithStartTime(t time.Time) *Activity {
+	a.timestamps().Start = TimeToMilliseconds(t)
+	return a
+}
+
+// WithEndTime sets the time at which the activity ends, which is shown as the
+// remaining time.
+func (a *Activity) WithEndTime(t time.Time) *Activity {
+	a.timestamps().End = TimeToMilliseconds(t)
+	return a
+}
+
+func (a *Activity) timestamps() *ActivityTimestamps {
+	if a.Timestamps == nil {
+		a.Timestamps = &ActivityTimestamps{}
+	}
+	return a.Timestamps
+}
+
+// WithLargeImage sets the large image of the activity and its hover text.
+func (a *Activity) WithLargeImage(image, text string) *Activity {
+	assets := a.assets()
+	assets.LargeImage = image
+	assets.LargeText = text
+	return a
+}
+
+// WithSmallImage sets the small image of the activity and its hover text.
+func (a *Activity) WithSmallImage(image, text string) *Activity {
+	assets := a.assets()
+	assets.SmallImage = image
+	assets.SmallText = text
+	return a
+}
+
+func (a *Activity) assets() *ActivityAssets {
+	if a.Assets == nil {
+		a.Assets = &ActivityAssets{}
+	}
+	return a.Assets
+}
+
+// WithParty sets the party of the activity and its current and maximum size.
+func (a *Activity) WithParty(id string, size, max int) *Activity {
+	a.Party = &ActivityParty{ID: id, Size: [2]int{size, max}}
+	return a
+}
+
+// WithSecrets sets the secrets used to join, spectate or match the activity.
+func (a *Activity) WithSecrets(join, spectate, match string) *Activity {
+	a.Secrets = &ActivitySecrets{Join: join, Spectate: spectate, Match: match}
+	return a
+}
+
+// WithButton adds a button that opens the given URL.
+func (a *Activity) WithButton(label string, url URL) *Activity {
+	a.Buttons = append(a.Buttons, ActivityButton{Label: label, URL: url})
+	return a
+}
+
+// WithAppID sets the application that the activity is for.
+func (a *Activity) WithAppID(id AppID) *Activity {
+	a.AppID = id
+	return a
+}
+
+// Validate validates the activity.
+func (a *Activity) Validate() error {
+	switch a.Type {
+	case GameActivity, StreamingActivity, ListeningActivity, WatchingActivity,
+		CustomActivity, CompetingActivity:
+	default:
+		return fmt.Errorf("unknown activity type %d", a.Type)
+	}
+
+	if a.Name == "" {
+		return errors.New("activity name is required")
+	}
+
+	if a.Type == CustomActivity && a.State == "" && a.Emoji == nil {
+		return errors.New("custom activity needs either a state or an emoji")
+	}
+
+	if a.Type == StreamingActivity {
+		if !strings.HasPrefix(a.URL, "https://twitch.tv/") &&
+			!strings.HasPrefix(a.URL, "https://www.twitch.tv/") &&
+			!strings.HasPrefix(a.URL, "https://youtube.com/") &&
+			!strings.HasPrefix(a.URL, "https://www.youtube.com/") {
+			return errors.New("streaming activity URL must be a Twitch or YouTube URL")
+		}
+	}
+
+	if len(a.Details) > MaxActivityText {
+		return &OverboundError{len(a.Details), MaxActivityText, "details"}
+	}
+
+	if len(a.State) > MaxActivityText {
+		return &OverboundError{len(a.State), MaxActivityText, "state"}
+	}
+
+	if a.Timestamps != nil && a.Timestamps.Start > 0 && a.Timestamps.End > 0 &&
+		a.Timestamps.End < a.Timestamps.Start {
+		return errors.New("activity end time is before its start time")
+	}
+
+	if a.Assets != nil {
+		if len(a.Assets.LargeText) > MaxActivityText {
+			return &OverboundError{len(a.Assets.LargeText), MaxActivityText, "large image text"}
+		}
+		if len(a.Assets.SmallText) > MaxActivityText {
+			return &OverboundError{len(a.Assets.SmallText), MaxActivityText, "small image text"}
+		}
+	}
+
+	if a.Party != nil {
+		size, max := a.Party.Size[0], a.Party.Size[1]
+		if size < 0 || max < 0 || size > max {
+			return fmt.Errorf("invalid party size %d of %d", size, max)
+		}
+	}
+
+	if len(a.Buttons) > MaxActivityButtons {
+		return &OverboundError{len(a.Buttons), MaxActivityButtons, "buttons"}
+	}
+
+	for i, button := range a.Buttons {
+		if button.Label == "" {
+			return fmt.Errorf("button %d has no label", i)
+		}
+		if len(button.Label) > MaxActivityButtonLabel {
+			return &OverboundError{len(button.Label), MaxActivityButtonLabel,
+				fmt.Sprintf("button %d label", i)}
+		}
+		if button.URL == "" {
+			return fmt.Errorf("button %d has no URL", i)
+		}
+		if len(button.URL) > MaxActivityButtonURL {
+			return &OverboundError{len(button.URL), MaxActivityButtonURL,
+				fmt.Sprintf("button %d URL", i)}
+		}
+	}
+
+	if len(a.Buttons) > 0 && a.Secrets != nil {
+		return errors.New("activity cannot have both buttons and secrets")
+	}
+
+	return nil
+}
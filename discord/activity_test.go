@@ -0,0 +1,68 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestActivityValidate(t *testing.T) {
+	now := time.Now()
+
+	valid := NewActivity(GameActivity, "Chess").
+		WithDetails("Ranked match").
+		WithState("In game").
+		WithStartTime(now).
+		WithEndTime(now.Add(time.Hour)).
+		WithLargeImage("board", "The board").
+		WithParty("lobby", 1, 2).
+		WithButton("Spectate", "https://example.com/spectate")
+
+	if err := valid.Validate(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	invalid := map[string]*Activity{
+		"no name":          NewActivity(GameActivity, ""),
+		"empty custom":     NewCustomActivity("", nil),
+		"stream url":       NewStreamingActivity("Live", "https://example.com"),
+		"party size":       NewActivity(GameActivity, "Chess").WithParty("lobby", 3, 2),
+		"end before start": NewActivity(GameActivity, "Chess").WithStartTime(now).WithEndTime(now.Add(-time.Hour)),
+		"buttons": NewActivity(GameActivity, "Chess").
+			WithButton("1", "https://example.com").
+			WithButton("2", "https://example.com").
+			WithButton("3", "https://example.com"),
+		"buttons and secrets": NewActivity(GameActivity, "Chess").
+			WithButton("1", "https://example.com").
+			WithSecrets("join", "", ""),
+	}
+
+	for name, a := range invalid {
+		if err := a.Validate(); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestActivityButtonUnmarshal(t *testing.T) {
+	var buttons []ActivityButton
+	err := json.Unmarshal([]byte(`["Watch", {"label": "Join", "url": "https://example.com"}]`), &buttons)
+	if err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+
+	expect := []ActivityButton{
+		{Label: "Watch"},
+		{Label: "Join", URL: "https://example.com"},
+	}
+
+	if len(buttons) != len(expect) {
+		t.Fatalf("expected %d buttons, got %d", len(expect), len(buttons))
+	}
+	for i := range expect {
+		if buttons[i] != expect[i] {
+			t.Errorf("button %d: expected %+v, got %+v", i, expect[i], buttons[i])
+		}
+	}
+}
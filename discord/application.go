@@ -55,8 +55,48 @@ type Application struct {
 	CustomInstallURL string `json:"custom_install_url,omitempty"`
 	// RoleConnectionsVerificationURL is the application's role connection verification entry point, which when configured will render the app as a verification method in the guild role verification configuration.
 	RoleConnectionsVerificationURL string `json:"role_connections_verification_url,omitempty"`
+
+	// Bot is the bot user of the application, if it has one.
+	Bot *User `json:"bot,omitempty"`
+	// RedirectURIs are the redirect URIs of the application's OAuth2 flow.
+	RedirectURIs []string `json:"redirect_uris,omitempty"`
+	// InteractionsEndpointURL is the URL that interactions are sent to
+	// instead of the gateway, if set.
+	InteractionsEndpointURL string `json:"interactions_endpoint_url,omitempty"`
+	// IntegrationTypesConfig contains the default install settings of each
+	// installation context that the application supports.
+	IntegrationTypesConfig map[ApplicationIntegrationType]ApplicationIntegrationTypeConfig `json:"integration_types_config,omitempty"`
+	// ApproximateGuildCount is the approximate number of guilds that the
+	// application has been added to.
+	ApproximateGuildCount int `json:"approximate_guild_count,omitempty"`
+	// ApproximateUserInstallCount is the approximate number of users that
+	// have installed the application.
+	ApproximateUserInstallCount int `json:"approximate_user_install_count,omitempty"`
+}
+
+// ApplicationIntegrationTypeConfig is the default install settings of an
+// installation context.
+//
+// https://discord.com/developers/docs/resources/application#application-object-application-integration-type-configuration-object
+type ApplicationIntegrationTypeConfig struct {
+	// OAuth2InstallParams is the install settings of the in-app authorization
+	// link for the context, if it's enabled.
+	OAuth2InstallParams *InstallParams `json:"oauth2_install_params,omitempty"`
 }
 
+// ApplicationIntegrationType is where an application can be installed, also
+// called its supported installation contexts.
+//
+// https://discord.com/developers/docs/resources/application#application-object-application-integration-types
+type ApplicationIntegrationType uint8
+
+const (
+	// GuildInstall means the application is installable to servers.
+	GuildInstall ApplicationIntegrationType = 0
+	// UserInstall means the application is installable to users.
+	UserInstall ApplicationIntegrationType = 1
+)
+
 type ApplicationFlags uint32
 
 const AppFlagAutoModerationRuleCreateBadge ApplicationFlags = 1 << 6
@@ -68,11 +108,36 @@ const (
 	AppFlagGatewayGuildMembersLimited
 	AppFlagVerificationPendingGuildLimit
 	AppFlagEmbedded
+	AppFlagGatewayMessageContent
+	AppFlagGatewayMessageContentLimited
 )
 
+// AppFlagApplicationCommandBadge is set on applications that have registered
+// global application commands.
+const AppFlagApplicationCommandBadge ApplicationFlags = 1 << 23
+
+// IsOwner returns true if the given user owns the application. For applications
+// that belong to a team, this is the owner of the team.
+func (a Application) IsOwner(userID UserID) bool {
+	if a.Team != nil {
+		return a.Team.OwnerID == userID
+	}
+	return a.Owner != nil && a.Owner.ID == userID
+}
+
+// IsAdmin returns true if the given user owns the application or is an admin
+// of the team that the application belongs to. Team members that have not
+// accepted their invite are not considered admins.
+func (a Application) IsAdmin(userID UserID) bool {
+	if a.IsOwner(userID) {
+		return true
+	}
+	return a.Team != nil && a.Team.HasRole(userID, TeamAdminRole)
+}
+
 type Team struct {
 	// Icon is a hash of the image of the team's icon.
-	Icon *Hash `json:"hash"`
+	Icon *Hash `json:"icon"`
 	// ID is the unique ID of the team.
 	ID TeamID `json:"id"`
 	// Members is the members of the team.
@@ -83,6 +148,34 @@ type Team struct {
 	OwnerID UserID `json:"owner_user_id"`
 }
 
+// Member returns the team member with the given user ID, or nil if the user
+// is not in the team.
+func (t Team) Member(userID UserID) *TeamMember {
+	for i, member := range t.Members {
+		if member.User.ID == userID {
+			return &t.Members[i]
+		}
+	}
+	return nil
+}
+
+// HasRole returns true if the given user is the owner of the team or is an
+// accepted member with at least the given role. Roles are ordered from
+// TeamReadOnlyRole to TeamAdminRole, so a member with TeamAdminRole also has
+// TeamDeveloperRole.
+func (t Team) HasRole(userID UserID, role TeamMemberRole) bool {
+	if t.OwnerID == userID {
+		return true
+	}
+
+	member := t.Member(userID)
+	if member == nil || member.MembershipState != MembershipAccepted {
+		return false
+	}
+
+	return member.Role.rank() >= role.rank()
+}
+
 type TeamMember struct {
 	// MembershipState is the user's membership state on the team.
 	MembershipState MembershipState `json:"membership_state"`
@@ -92,6 +185,40 @@ type TeamMember struct {
 	TeamID TeamID `json:"team_id"`
 	// User is the avatar, discriminator, ID, and username of the user.
 	User User `json:"user"`
+	// Role is the role of the team member.
+	Role TeamMemberRole `json:"role"`
+}
+
+// TeamMemberRole is the role of a team member. The owner of the team is not
+// represented by a role; use Team.OwnerID instead.
+//
+// https://discord.com/developers/docs/topics/teams#team-member-roles
+type TeamMemberRole string
+
+const (
+	// TeamAdminRole is the role of admins, who have similar access as owners,
+	// except that they cannot take destructive actions on the team or
+	// team-owned apps.
+	TeamAdminRole TeamMemberRole = "admin"
+	// TeamDeveloperRole is the role of developers, who can access information
+	// about team-owned apps, like the client secret or public key.
+	TeamDeveloperRole TeamMemberRole = "developer"
+	// TeamReadOnlyRole is the role of read-only members, who can access
+	// information about a team and any team-owned apps.
+	TeamReadOnlyRole TeamMemberRole = "read_only"
+)
+
+func (r TeamMemberRole) rank() int {
+	switch r {
+	case TeamAdminRole:
+		return 3
+	case TeamDeveloperRole:
+		return 2
+	case TeamReadOnlyRole:
+		return 1
+	default:
+		return 0
+	}
 }
 
 type MembershipState uint8
@@ -103,25 +230,73 @@ const (
 
 // https://discord.com/developers/docs/interactions/slash-commands#application-command-permissions-object-guild-application-command-permissions-structure
 type GuildCommandPermissions struct {
+	// ID is the ID of the command, or the ID of the application if the
+	// permissions apply to all of its commands.
 	ID          CommandID            `json:"id"`
 	AppID       AppID                `json:"application_id"`
 	GuildID     GuildID              `json:"guild_id"`
 	Permissions []CommandPermissions `json:"permissions"`
 }
 
+// IsApplication returns true if the permissions apply to all of the
+// application's commands in the guild, rather than a single command.
+func (p GuildCommandPermissions) IsApplication() bool {
+	return Snowflake(p.ID) == Snowflake(p.AppID)
+}
+
 // https://discord.com/developers/docs/interactions/slash-commands#application-command-permissions-object-application-command-permissions-structure
 type CommandPermissions struct {
+	// ID is the ID of the role, user or channel, depending on Type. Refer to
+	// EveryoneRoleID and AllChannelsID for the IDs that target every member or
+	// every channel.
 	ID         Snowflake             `json:"id"`
 	Type       CommandPermissionType `json:"type"`
 	Permission bool                  `json:"permission"`
 }
 
+// RoleID returns the ID as a role ID. It is only valid for
+// RoleCommandPermission.
+func (p CommandPermissions) RoleID() RoleID { return RoleID(p.ID) }
+
+// UserID returns the ID as a user ID. It is only valid for
+// UserCommandPermission.
+func (p CommandPermissions) UserID() UserID { return UserID(p.ID) }
+
+// ChannelID returns the ID as a channel ID. It is only valid for
+// ChannelCommandPermission.
+func (p CommandPermissions) ChannelID() ChannelID { return ChannelID(p.ID) }
+
+// IsEveryone returns true if the permission applies to every member of the
+// guild with the given ID.
+func (p CommandPermissions) IsEveryone(guildID GuildID) bool {
+	return p.Type == RoleCommandPermission && p.RoleID() == EveryoneRoleID(guildID)
+}
+
+// IsAllChannels returns true if the permission applies to every channel in
+// the guild with the given ID.
+func (p CommandPermissions) IsAllChannels(guildID GuildID) bool {
+	return p.Type == ChannelCommandPermission && p.ChannelID() == AllChannelsID(guildID)
+}
+
+// EveryoneRoleID returns the ID of the @everyone role of the guild, which is
+// the same as the guild's ID.
+func EveryoneRoleID(guildID GuildID) RoleID {
+	return RoleID(guildID)
+}
+
+// AllChannelsID returns the ID used by command permissions to target every
+// channel in the guild, which is the guild's ID minus 1.
+func AllChannelsID(guildID GuildID) ChannelID {
+	return ChannelID(guildID - 1)
+}
+
 type CommandPermissionType uint8
 
 // https://discord.com/developers/docs/interactions/slash-commands#application-command-permissions-object-application-command-permission-type
 const (
-	RoleCommandPermission = iota + 1
+	RoleCommandPermission CommandPermissionType = iota + 1
 	UserCommandPermission
+	ChannelCommandPermission
 )
 
 // https://discord.com/developers/docs/resources/application#install-params-object
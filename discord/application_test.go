@@ -0,0 +1,49 @@
+package discord
+
+import "testing"
+
+func TestTeamHasRole(t *testing.T) {
+	team := Team{
+		OwnerID: 1,
+		Members: []TeamMember{
+			{User: User{ID: 2}, Role: TeamAdminRole, MembershipState: MembershipAccepted},
+			{User: User{ID: 3}, Role: TeamDeveloperRole, MembershipState: MembershipAccepted},
+			{User: User{ID: 4}, Role: TeamReadOnlyRole, MembershipState: MembershipAccepted},
+			{User: User{ID: 5}, Role: TeamAdminRole, MembershipState: MembershipInvited},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		user   UserID
+		role   TeamMemberRole
+		expect bool
+	}{
+		{"owner is admin", 1, TeamAdminRole, true},
+		{"owner is read-only", 1, TeamReadOnlyRole, true},
+		{"admin is admin", 2, TeamAdminRole, true},
+		{"admin is developer", 2, TeamDeveloperRole, true},
+		{"admin is read-only", 2, TeamReadOnlyRole, true},
+		{"developer is not admin", 3, TeamAdminRole, false},
+		{"developer is developer", 3, TeamDeveloperRole, true},
+		{"developer is read-only", 3, TeamReadOnlyRole, true},
+		{"read-only is not developer", 4, TeamDeveloperRole, false},
+		{"read-only is read-only", 4, TeamReadOnlyRole, true},
+		{"invited admin is not admin", 5, TeamAdminRole, false},
+		{"invited admin is not read-only", 5, TeamReadOnlyRole, false},
+		{"stranger is not read-only", 6, TeamReadOnlyRole, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if has := team.HasRole(test.user, test.role); has != test.expect {
+				t.Fatalf("expected HasRole(%d, %q) = %v", test.user, test.role, test.expect)
+			}
+		})
+	}
+
+	app := Application{Team: &team}
+	if !app.IsAdmin(1) || !app.IsAdmin(2) || app.IsAdmin(3) || app.IsAdmin(5) {
+		t.Fatal("IsAdmin doesn't match the team roles")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/diamondburned/arikawa/v3/utils/json"
 )
 
+//go:generate go run ../utils/cmd/genauditlog -o auditlog_changes.go -s Permissions auditlog.go
+
 // https://discord.com/developers/docs/resources/audit-log#audit-log-object
 type AuditLog struct {
 	// Webhooks is the list of webhooks found in the audit log.
@@ -171,6 +173,21 @@ type AuditEntryInfo struct {
 //	}
 //
 //	log.Println("Transferred ownership from user", oldOwnerID, "to", newOwnerID)
+//
+// Alternatively, DecodeValues decodes the values into the type in the key's
+// comment, so the values can be used in a type switch:
+//
+//	_, newValue, err := change.DecodeValues()
+//	if err != nil {
+//	    return err
+//	}
+//
+//	switch v := newValue.(type) {
+//	case discord.UserID:
+//	    log.Println("New user:", v)
+//	case []discord.Overwrite:
+//	    log.Println("New overwrites:", len(v))
+//	}
 type AuditLogChange struct {
 	// Key is the name of audit log change key.
 	Key AuditLogChangeKey `json:"key"`
@@ -192,8 +209,74 @@ func (a AuditLogChange) UnmarshalValues(old, new interface{}) error {
 	return nil
 }
 
+// DecodeNewValue decodes NewValue into the type in the comment of its key, such
+// as UserID for AuditGuildOwnerID. The returned value is not a pointer, and it
+// is nil if there is no new value. If the type of the key is not known, then
+// NewValue is returned as-is.
+func (a AuditLogChange) DecodeNewValue() (interface{}, error) {
+	return a.Key.DecodeValue(a.NewValue)
+}
+
+// DecodeOldValue decodes OldValue the same way as DecodeNewValue.
+func (a AuditLogChange) DecodeOldValue() (interface{}, error) {
+	return a.Key.DecodeValue(a.OldValue)
+}
+
+// DecodeValues decodes both values of the AuditLogChange. Refer to
+// DecodeNewValue.
+func (a AuditLogChange) DecodeValues() (old, new interface{}, err error) {
+	old, err = a.DecodeOldValue()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode old value: %w", err)
+	}
+	new, err = a.DecodeNewValue()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode new value: %w", err)
+	}
+	return old, new, nil
+}
+
+// AuditLogRole is a partial role, which is the type of the values of the
+// AuditGuildRoleAdd and AuditGuildRoleRemove keys.
+type AuditLogRole struct {
+	// ID is the ID of the role.
+	ID RoleID `json:"id"`
+	// Name is the name of the role.
+	Name string `json:"name"`
+}
+
 type AuditLogChangeKey string
 
+// DecodeValue decodes a value of the key into the type in the key's comment.
+// Refer to AuditLogChange.DecodeNewValue.
+func (k AuditLogChangeKey) DecodeValue(raw json.Raw) (interface{}, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	v, ok, err := k.decodeValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return raw, nil
+	}
+	return v, nil
+}
+
+// unmarshalChangeValue unmarshals raw into v. If isString is true, then the
+// value is encoded inside a JSON string, like Permissions.
+func unmarshalChangeValue(raw json.Raw, v interface{}, isString bool) error {
+	if isString {
+		var str string
+		if err := raw.UnmarshalTo(&str); err != nil {
+			return err
+		}
+		raw = json.Raw(str)
+	}
+	return raw.UnmarshalTo(v)
+}
+
 // https://discord.com/developers/docs/resources/audit-log#audit-log-change-object-audit-log-change-key
 const (
 	// AuditGuildName gets sent if the guild's name was changed.
@@ -252,11 +335,11 @@ const (
 	AuditGuildVanityURLCode AuditLogChangeKey = "vanity_url_code"
 	// AuditGuildRoleAdd gets sent if a new role was added.
 	//
-	// Type: []Role{ID, Name}
+	// Type: []AuditLogRole
 	AuditGuildRoleAdd AuditLogChangeKey = "$add"
 	// AuditGuildRoleRemove gets sent if a role was removed.
 	//
-	// Type: []Role{ID, Name}
+	// Type: []AuditLogRole
 	AuditGuildRoleRemove AuditLogChangeKey = "$remove"
 	// AuditGuildPruneDeleteDays gets sent if there was a change in number of
 	// days after which inactive and role-unassigned members are kicked.
@@ -407,8 +490,9 @@ const (
 	//
 	// Type: Snowflake
 	AuditAnyID AuditLogChangeKey = "id"
-	// AuditAnyType is the type of the entity created.
-	// Type ChannelType or string
+	// AuditAnyType is the type of the entity created. It is a ChannelType for
+	// channels and a string for integrations, so it is left as JSON when
+	// decoded.
 	AuditAnyType AuditLogChangeKey = "type"
 )
 
@@ -0,0 +1,71 @@
+package discord
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestAuditLogChangeDecodeValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		change AuditLogChange
+		old    interface{}
+		new    interface{}
+	}{
+		{
+			name: "snowflake",
+			change: AuditLogChange{
+				Key:      AuditGuildOwnerID,
+				OldValue: json.Raw(`"1"`),
+				NewValue: json.Raw(`"2"`),
+			},
+			old: UserID(1),
+			new: UserID(2),
+		},
+		{
+			name: "string permissions",
+			change: AuditLogChange{
+				Key:      AuditRoleAllow,
+				NewValue: json.Raw(`"1024"`),
+			},
+			old: nil,
+			new: PermissionViewChannel,
+		},
+		{
+			name: "roles",
+			change: AuditLogChange{
+				Key:      AuditGuildRoleAdd,
+				NewValue: json.Raw(`[{"id":"3","name":"role"}]`),
+			},
+			old: nil,
+			new: []AuditLogRole{{ID: 3, Name: "role"}},
+		},
+		{
+			name: "unknown",
+			change: AuditLogChange{
+				Key:      AuditAnyType,
+				OldValue: json.Raw(`null`),
+				NewValue: json.Raw(`"twitch"`),
+			},
+			old: nil,
+			new: json.Raw(`"twitch"`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			old, new, err := test.change.DecodeValues()
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if !reflect.DeepEqual(old, test.old) {
+				t.Errorf("old value: expected %#v, got %#v", test.old, old)
+			}
+			if !reflect.DeepEqual(new, test.new) {
+				t.Errorf("new value: expected %#v, got %#v", test.new, new)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package discord
+
+// AutoModerationRule is a rule that automatically moderates the content in a
+// guild.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object
+type AutoModerationRule struct {
+	// ID is the id of the rule.
+	ID AutoModerationRuleID `json:"id"`
+	// GuildID is the id of the guild that the rule belongs to.
+	GuildID GuildID `json:"guild_id"`
+	// Name is the name of the rule.
+	Name string `json:"name"`
+	// CreatorID is the id of the user who first created the rule.
+	CreatorID UserID `json:"creator_id"`
+	// EventType is the type of event that the rule is checked on.
+	EventType AutoModerationEventType `json:"event_type"`
+	// TriggerType is the type of content that triggers the rule.
+	TriggerType AutoModerationTriggerType `json:"trigger_type"`
+	// TriggerMetadata is the additional data used to determine whether the
+	// rule should be triggered, depending on TriggerType.
+	TriggerMetadata AutoModerationTriggerMetadata `json:"trigger_metadata"`
+	// Actions are the actions that are executed when the rule is triggered.
+	Actions []AutoModerationAction `json:"actions"`
+	// Enabled is whether the rule is enabled.
+	Enabled bool `json:"enabled"`
+	// ExemptRoles are the roles that are not affected by the rule. There can
+	// be at most 20.
+	ExemptRoles []RoleID `json:"exempt_roles"`
+	// ExemptChannels are the channels that are not affected by the rule. There
+	// can be at most 50.
+	ExemptChannels []ChannelID `json:"exempt_channels"`
+}
+
+// AutoModerationEventType is the context in which a rule is checked.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-event-types
+type AutoModerationEventType uint8
+
+const (
+	// AutoModerationMessageSend checks the rule when a member sends or edits
+	// a message.
+	AutoModerationMessageSend AutoModerationEventType = iota + 1
+	// AutoModerationMemberUpdate checks the rule when a member edits their
+	// profile.
+	AutoModerationMemberUpdate
+)
+
+// AutoModerationTriggerType is the type of content that can trigger a rule.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-trigger-types
+type AutoModerationTriggerType uint8
+
+const (
+	// AutoModerationKeyword checks if the content contains words from a
+	// user-defined list of keywords.
+	AutoModerationKeyword AutoModerationTriggerType = 1
+	// AutoModerationSpam checks if the content represents generic spam.
+	AutoModerationSpam AutoModerationTriggerType = 3
+	// AutoModerationKeywordPreset checks if the content contains words from
+	// internal pre-defined wordsets.
+	AutoModerationKeywordPreset AutoModerationTriggerType = 4
+	// AutoModerationMentionSpam checks if the content contains more unique
+	// mentions than allowed.
+	AutoModerationMentionSpam AutoModerationTriggerType = 5
+	// AutoModerationMemberProfile checks if the member's profile contains
+	// words from a user-defined list of keywords.
+	AutoModerationMemberProfile AutoModerationTriggerType = 6
+)
+
+// AutoModerationTriggerMetadata is the additional data used to determine
+// whether a rule should be triggered. Which fields are used depends on the
+// trigger type of the rule.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-trigger-metadata
+type AutoModerationTriggerMetadata struct {
+	// KeywordFilter are the substrings which will be searched for in the
+	// content. It is used by AutoModerationKeyword and
+	// AutoModerationMemberProfile.
+	KeywordFilter []string `json:"keyword_filter,omitempty"`
+	// RegexPatterns are the Rust-flavored regular expression patterns which
+	// will be matched against the content. It is used by
+	// AutoModerationKeyword and AutoModerationMemberProfile.
+	RegexPatterns []string `json:"regex_patterns,omitempty"`
+	// Presets are the internally pre-defined wordsets which will be searched
+	// for in the content. It is used by AutoModerationKeywordPreset.
+	Presets []AutoModerationKeywordPresetType `json:"presets,omitempty"`
+	// AllowList are the substrings which should not trigger the rule. It is
+	// used by AutoModerationKeyword, AutoModerationKeywordPreset and
+	// AutoModerationMemberProfile.
+	AllowList []string `json:"allow_list,omitempty"`
+	// MentionTotalLimit is the total number of unique role and user mentions
+	// allowed per message. It is used by AutoModerationMentionSpam.
+	MentionTotalLimit int `json:"mention_total_limit,omitempty"`
+	// MentionRaidProtectionEnabled is whether to automatically detect mention
+	// raids. It is used by AutoModerationMentionSpam.
+	MentionRaidProtectionEnabled bool `json:"mention_raid_protection_enabled,omitempty"`
+}
+
+// AutoModerationKeywordPresetType is an internally pre-defined wordset.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-keyword-preset-types
+type AutoModerationKeywordPresetType uint8
+
+const (
+	// AutoModerationProfanity contains words that may be considered
+	// forms of swearing or cursing.
+	AutoModerationProfanity AutoModerationKeywordPresetType = iota + 1
+	// AutoModerationSexualContent contains words that refer to sexually
+	// explicit behavior or activity.
+	AutoModerationSexualContent
+	// AutoModerationSlurs contains personal insults or words that may be
+	// considered hate speech.
+	AutoModerationSlurs
+)
+
+// AutoModerationAction is an action which will execute whenever a rule is
+// triggered.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object
+type AutoModerationAction struct {
+	// Type is the type of action.
+	Type AutoModerationActionType `json:"type"`
+	// Metadata is the additional data needed during execution for some
+	// action types.
+	Metadata *AutoModerationActionMetadata `json:"metadata,omitempty"`
+}
+
+// AutoModerationActionType is the type of an AutoModerationAction.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object-action-types
+type AutoModerationActionType uint8
+
+const (
+	// AutoModerationBlockMessage blocks the content of a message according
+	// to the rule.
+	AutoModerationBlockMessage AutoModerationActionType = iota + 1
+	// AutoModerationSendAlertMessage logs the user content to a specified
+	// channel.
+	AutoModerationSendAlertMessage
+	// AutoModerationTimeout times out the user for a specified duration. It
+	// can only be used with AutoModerationKeyword and
+	// AutoModerationMentionSpam rules, and requires the MODERATE_MEMBERS
+	// permission.
+	AutoModerationTimeout
+	// AutoModerationBlockMemberInteraction prevents the member from using
+	// text, voice or other interactions. It can only be used with
+	// AutoModerationMemberProfile rules.
+	AutoModerationBlockMemberInteraction
+)
+
+// AutoModerationActionMetadata is the additional data needed during the
+// execution of an action.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object-action-metadata
+type AutoModerationActionMetadata struct {
+	// ChannelID is the channel to which the user content should be logged. It
+	// is used by AutoModerationSendAlertMessage.
+	ChannelID ChannelID `json:"channel_id,omitempty"`
+	// Duration is the timeout duration, which can be at most 4 weeks. It is
+	// used by AutoModerationTimeout.
+	Duration Seconds `json:"duration_seconds,omitempty"`
+	// CustomMessage is the additional explanation that will be shown to
+	// members whenever their message is blocked. It can be at most 150
+	// characters and is used by AutoModerationBlockMessage.
+	CustomMessage string `json:"custom_message,omitempty"`
+}
@@ -0,0 +1,124 @@
+package discord
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CDNBaseURL is the base URL of Discord's CDN, which every CDN URL is built
+// from. It can be changed to use a proxy.
+var CDNBaseURL = "https://cdn.discordapp.com/"
+
+// Valid CDN sizes, which are powers of two.
+const (
+	MinCDNSize = 16
+	MaxCDNSize = 4096
+)
+
+// CDN builds the URL of an asset on Discord's CDN, such as an avatar or icon.
+// The asset accessors, such as User's AvatarCDN, return a CDN, which can then
+// be changed before calling URL:
+//
+//	url := user.AvatarCDN().WithType(discord.WebPImage).WithSize(128).URL()
+//
+// The zero value has no asset, and its URL is empty.
+type CDN struct {
+	// Path is the path to the asset relative to CDNBaseURL, without the file
+	// extension, such as "avatars/<user_id>/<avatar_hash>".
+	Path string
+	// Animated is whether the asset is animated. Animated assets are GIFs if
+	// Type is AutoImage, and stay animated if Type is WebPImage.
+	Animated bool
+	// Type is the image type of the asset. If it's empty or AutoImage, then
+	// the type is GIF for animated assets and PNG otherwise.
+	Type ImageType
+	// Size is the size of the asset in pixels. If it's 0, then the size is
+	// left to Discord.
+	Size int
+}
+
+// NewCDN creates a CDN for the asset with the given hash in the given
+// directory, such as "avatars/<user_id>". Assets with hashes that start with
+// "a_" are animated. If the hash is empty, then the zero CDN is returned.
+func NewCDN(dir string, hash Hash) CDN {
+	if hash == "" {
+		return CDN{}
+	}
+
+	return CDN{
+		Path:     dir + "/" + hash,
+		Animated: strings.HasPrefix(hash, "a_"),
+	}
+}
+
+// DefaultAvatarCDN creates a CDN for the default avatar with the given index.
+// Default avatars are always PNG images.
+func DefaultAvatarCDN(index int) CDN {
+	return CDN{
+		Path: "embed/avatars/" + strconv.Itoa(index),
+		Type: PNGImage,
+	}
+}
+
+// IsValid returns true if c has an asset.
+func (c CDN) IsValid() bool {
+	return c.Path != ""
+}
+
+// WithType returns a copy of c with the given image type.
+func (c CDN) WithType(t ImageType) CDN {
+	c.Type = t
+	return c
+}
+
+// WithSize returns a copy of c with the given size. Sizes that aren't powers of
+// two are rounded up, and sizes outside of MinCDNSize and MaxCDNSize are
+// clamped.
+func (c CDN) WithSize(size int) CDN {
+	c.Size = size
+	return c
+}
+
+// ImageType returns the image type that the URL uses.
+func (c CDN) ImageType() ImageType {
+	if c.Type == "" || c.Type == AutoImage {
+		if c.Animated {
+			return GIFImage
+		}
+		return PNGImage
+	}
+	return c.Type
+}
+
+// URL returns the URL of the asset, or an empty string if there's none.
+func (c CDN) URL() URL {
+	if !c.IsValid() {
+		return ""
+	}
+
+	t := c.ImageType()
+	u := CDNBaseURL + c.Path + string(t)
+
+	var query []string
+	if c.Size > 0 {
+		query = append(query, "size="+strconv.Itoa(cdnSize(c.Size)))
+	}
+	if c.Animated && t == WebPImage {
+		query = append(query, "animated=true")
+	}
+
+	if len(query) > 0 {
+		u += "?" + strings.Join(query, "&")
+	}
+
+	return u
+}
+
+// cdnSize rounds size up to a valid CDN size.
+func cdnSize(size int) int {
+	valid := MinCDNSize
+	for valid < size && valid < MaxCDNSize {
+		valid *= 2
+	}
+	return valid
+}
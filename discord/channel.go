@@ -25,6 +25,15 @@ const (
 	ThreadRequireTag
 )
 
+// HideMediaDownloadOptions hides the embedded media download options in a
+// GuildMedia channel.
+const HideMediaDownloadOptions ChannelFlags = 1 << 15
+
+// Has returns true if f has all of the given flags.
+func (f ChannelFlags) Has(flags ChannelFlags) bool {
+	return f&flags == flags
+}
+
 // Channel represents a guild or DM channel within Discord.
 //
 // https://discord.com/developers/docs/resources/channel#channel-object
@@ -127,8 +136,13 @@ type Channel struct {
 	DefaultReactionEmoji *ForumReaction `json:"default_reaction_emoji,omitempty"`
 	// DefaultThreadRateLimitPerUser is the initial rate_limit_per_user to set on newly created threads in a channel. this field is copied to the thread at creation time and does not live update.
 	DefaultThreadRateLimitPerUser int `json:"default_thread_rate_limit_per_user,omitempty"`
-	// DefaultSoftOrder is the default sort order type used to order posts in GUILD_FORUM channels. Defaults to null, which indicates a preferred sort order hasn't been set by a channel admin.
-	DefaultSoftOrder *SortOrderType `json:"default_sort_order,omitempty"`
+	// DefaultSortOrder is the default sort order type used to order posts in GUILD_FORUM channels. Defaults to null, which indicates a preferred sort order hasn't been set by a channel admin.
+	DefaultSortOrder *SortOrderType `json:"default_sort_order,omitempty"`
+	// DefaultSoftOrder is the same as DefaultSortOrder. It's only set when
+	// the channel is unmarshaled.
+	//
+	// Deprecated: Use DefaultSortOrder instead.
+	DefaultSoftOrder *SortOrderType `json:"-"`
 	// DefaultForumLayout is the default forum layout view used to display posts in GUILD_FORUM channels. Defaults to 0, which indicates a layout view has not been set by a channel admin.
 	DefaultForumLayout ForumLayoutType `json:"default_forum_layout,omitempty"`
 }
@@ -148,6 +162,8 @@ func (ch *Channel) UnmarshalJSON(data []byte) error {
 		ch.VideoQualityMode = 1
 	}
 
+	ch.DefaultSoftOrder = ch.DefaultSortOrder
+
 	return nil
 }
 
@@ -161,6 +177,11 @@ func (ch Channel) Mention() string {
 	return ch.ID.Mention()
 }
 
+// IconCDN returns the CDN of the group DM icon. It is invalid if there is none.
+func (ch Channel) IconCDN() CDN {
+	return NewCDN("channel-icons/"+ch.ID.String(), ch.Icon)
+}
+
 // IconURL returns the URL to the channel icon in the PNG format.
 // An empty string is returned if there's no icon.
 func (ch Channel) IconURL() string {
@@ -172,12 +193,7 @@ func (ch Channel) IconURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP
 func (ch Channel) IconURLWithType(t ImageType) string {
-	if ch.Icon == "" {
-		return ""
-	}
-
-	return "https://cdn.discordapp.com/channel-icons/" +
-		ch.ID.String() + "/" + t.format(ch.Icon)
+	return ch.IconCDN().WithType(t).URL()
 }
 
 // ChannelType describes the type of the channel.
@@ -221,8 +237,18 @@ const (
 	GuildDirectory
 	// GuildForum is a channel that can only contain threads.
 	GuildForum
+	// GuildMedia is a channel that can only contain threads, similar to
+	// GuildForum channels, but whose threads are shown as media.
+	GuildMedia
 )
 
+// IsThreadOnly returns true if the channel type can only contain threads, that
+// is, if it's a GuildForum or GuildMedia channel. Threads in these channels
+// are started using api.Client.StartThreadInForum.
+func (t ChannelType) IsThreadOnly() bool {
+	return t == GuildForum || t == GuildMedia
+}
+
 // GuildNews aliases to GuildAnnouncement.
 //
 // Deprecated: use GuildAnnouncement instead.
@@ -367,9 +393,14 @@ const (
 	// Sort forum posts by activity.
 	SortOrderTypeLatestActivity SortOrderType = iota
 	// Sort forum posts by creation time (from most recent to oldest)
-	SoftOrderTypeCreationDate
+	SortOrderTypeCreationDate
 )
 
+// SoftOrderTypeCreationDate is the same as SortOrderTypeCreationDate.
+//
+// Deprecated: Use SortOrderTypeCreationDate instead.
+const SoftOrderTypeCreationDate = SortOrderTypeCreationDate
+
 // https://discord.com/developers/docs/resources/channel#channel-object-forum-layout-types
 type ForumLayoutType uint8
 
@@ -0,0 +1,20 @@
+package discord
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChannelDefaultSortOrder(t *testing.T) {
+	var ch Channel
+	if err := json.Unmarshal([]byte(`{"id":"1","default_sort_order":1}`), &ch); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+
+	if ch.DefaultSortOrder == nil || *ch.DefaultSortOrder != SortOrderTypeCreationDate {
+		t.Fatalf("unexpected DefaultSortOrder %v", ch.DefaultSortOrder)
+	}
+	if ch.DefaultSoftOrder != ch.DefaultSortOrder {
+		t.Fatal("DefaultSoftOrder is not set")
+	}
+}
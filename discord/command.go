@@ -80,8 +80,10 @@ const (
 	EnglishUK     Language = "en-GB"
 	EnglishUS     Language = "en-US"
 	Spanish       Language = "es-ES"
+	SpanishLATAM  Language = "es-419"
 	French        Language = "fr"
 	Croatian      Language = "hr"
+	Indonesian    Language = "id"
 	Italian       Language = "it"
 	Lithuanian    Language = "lt"
 	Hungarian     Language = "hu"
@@ -1,6 +1,7 @@
 package discord
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -315,6 +316,14 @@ func ParseComponent(b []byte) (Component, error) {
 		c = &StringSelectComponent{}
 	case TextInputComponentType:
 		c = &TextInputComponent{}
+	case UserSelectComponentType:
+		c = &UserSelectComponent{}
+	case RoleSelectComponentType:
+		c = &RoleSelectComponent{}
+	case MentionableSelectComponentType:
+		c = &MentionableSelectComponent{}
+	case ChannelSelectComponentType:
+		c = &ChannelSelectComponent{}
 	default:
 		c = &UnknownComponent{typ: t.Type}
 	}
@@ -458,6 +467,7 @@ const (
 	successButtonStyle
 	dangerButtonStyle
 	linkButtonStyleNum
+	premiumButtonStyleNum
 	basicButtonStyleLen
 )
 
@@ -480,6 +490,15 @@ func (s linkButtonStyle) style() int { return int(linkButtonStyleNum) }
 // LinkButtonStyle is a button style that navigates to a URL.
 func LinkButtonStyle(url URL) ButtonComponentStyle { return linkButtonStyle(url) }
 
+type premiumButtonStyle SKUID
+
+func (s premiumButtonStyle) style() int { return int(premiumButtonStyleNum) }
+
+// PremiumButtonStyle is a button style that prompts the user to purchase the
+// SKU with the given ID. Premium buttons cannot have a custom ID, label or
+// emoji, and they don't send an interaction when clicked.
+func PremiumButtonStyle(skuID SKUID) ButtonComponentStyle { return premiumButtonStyle(skuID) }
+
 // Button is a clickable button that may be added to an interaction
 // response.
 type ButtonComponent struct {
@@ -530,6 +549,7 @@ func (b *ButtonComponent) MarshalJSON() ([]byte, error) {
 		Type  ComponentType `json:"type"`
 		Style int           `json:"style"`
 		URL   URL           `json:"url,omitempty"`
+		SKUID SKUID         `json:"sku_id,omitempty"`
 	}
 
 	msg := Msg{
@@ -538,8 +558,14 @@ func (b *ButtonComponent) MarshalJSON() ([]byte, error) {
 		button: (*button)(b),
 	}
 
-	if link, ok := b.Style.(linkButtonStyle); ok {
-		msg.URL = URL(link)
+	switch style := b.Style.(type) {
+	case linkButtonStyle:
+		msg.URL = URL(style)
+	case premiumButtonStyle:
+		if b.CustomID != "" || b.Label != "" || b.Emoji != nil {
+			return nil, errors.New("premium button cannot have a custom ID, label or emoji")
+		}
+		msg.SKUID = SKUID(style)
 	}
 
 	return json.Marshal(msg)
@@ -554,6 +580,7 @@ func (b *ButtonComponent) UnmarshalJSON(j []byte) error {
 		*button
 		Style basicButtonStyle `json:"style"`
 		URL   URL              `json:"url,omitempty"`
+		SKUID SKUID            `json:"sku_id,omitempty"`
 	}{
 		button: (*button)(b),
 	}
@@ -569,6 +596,8 @@ func (b *ButtonComponent) UnmarshalJSON(j []byte) error {
 	switch msg.Style {
 	case linkButtonStyleNum:
 		b.Style = LinkButtonStyle(msg.URL)
+	case premiumButtonStyleNum:
+		b.Style = PremiumButtonStyle(msg.SKUID)
 	default:
 		b.Style = msg.Style
 	}
@@ -709,6 +738,8 @@ func (i *TextInputComponent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m)
 }
 
+// UserSelectComponent is a dropdown menu that lets the user choose from the
+// users in the guild or DM.
 type UserSelectComponent struct {
 	// CustomID is the custom unique ID.
 	CustomID ComponentID `json:"custom_id,omitempty"`
@@ -720,6 +751,9 @@ type UserSelectComponent struct {
 	ValueLimits [2]int `json:"-"`
 	// Disabled disables the select if true.
 	Disabled bool `json:"disabled,omitempty"`
+	// DefaultValues are the users that are selected by default. The
+	// number of default values must be within ValueLimits.
+	DefaultValues []SelectDefaultValue `json:"default_values,omitempty"`
 }
 
 // ID implements the Component interface.
@@ -760,6 +794,8 @@ func (s *UserSelectComponent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(msg)
 }
 
+// RoleSelectComponent is a dropdown menu that lets the user choose from the
+// roles in the guild.
 type RoleSelectComponent struct {
 	// CustomID is the custom unique ID.
 	CustomID ComponentID `json:"custom_id,omitempty"`
@@ -771,6 +807,9 @@ type RoleSelectComponent struct {
 	ValueLimits [2]int `json:"-"`
 	// Disabled disables the select if true.
 	Disabled bool `json:"disabled,omitempty"`
+	// DefaultValues are the roles that are selected by default. The
+	// number of default values must be within ValueLimits.
+	DefaultValues []SelectDefaultValue `json:"default_values,omitempty"`
 }
 
 // ID implements the Component interface.
@@ -811,6 +850,8 @@ func (s *RoleSelectComponent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(msg)
 }
 
+// MentionableSelectComponent is a dropdown menu that lets the user choose
+// from both the users and the roles in the guild.
 type MentionableSelectComponent struct {
 	// CustomID is the custom unique ID.
 	CustomID ComponentID `json:"custom_id,omitempty"`
@@ -822,6 +863,9 @@ type MentionableSelectComponent struct {
 	ValueLimits [2]int `json:"-"`
 	// Disabled disables the select if true.
 	Disabled bool `json:"disabled,omitempty"`
+	// DefaultValues are the users or roles that are selected by default. The
+	// number of default values must be within ValueLimits.
+	DefaultValues []SelectDefaultValue `json:"default_values,omitempty"`
 }
 
 // ID implements the Component interface.
@@ -862,6 +906,8 @@ func (s *MentionableSelectComponent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(msg)
 }
 
+// ChannelSelectComponent is a dropdown menu that lets the user choose from the
+// channels in the guild.
 type ChannelSelectComponent struct {
 	// CustomID is the custom unique ID.
 	CustomID ComponentID `json:"custom_id,omitempty"`
@@ -873,6 +919,9 @@ type ChannelSelectComponent struct {
 	ValueLimits [2]int `json:"-"`
 	// Disabled disables the select if true.
 	Disabled bool `json:"disabled,omitempty"`
+	// DefaultValues are the channels that are selected by default. The
+	// number of default values must be within ValueLimits.
+	DefaultValues []SelectDefaultValue `json:"default_values,omitempty"`
 	// ChannelTypes is the types of channels that can be chosen from.
 	ChannelTypes []ChannelType `json:"channel_types,omitempty"`
 }
@@ -915,6 +964,39 @@ func (s *ChannelSelectComponent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(msg)
 }
 
+// SelectDefaultValue is a value that is selected by default in a user, role,
+// mentionable or channel select.
+type SelectDefaultValue struct {
+	// ID is the ID of the user, role or channel.
+	ID Snowflake `json:"id"`
+	// Type is the type of the value.
+	Type SelectDefaultValueType `json:"type"`
+}
+
+// SelectDefaultValueType is the type of a SelectDefaultValue.
+type SelectDefaultValueType string
+
+const (
+	UserDefaultValue    SelectDefaultValueType = "user"
+	RoleDefaultValue    SelectDefaultValueType = "role"
+	ChannelDefaultValue SelectDefaultValueType = "channel"
+)
+
+// UserDefault returns a SelectDefaultValue for the given user.
+func UserDefault(id UserID) SelectDefaultValue {
+	return SelectDefaultValue{ID: Snowflake(id), Type: UserDefaultValue}
+}
+
+// RoleDefault returns a SelectDefaultValue for the given role.
+func RoleDefault(id RoleID) SelectDefaultValue {
+	return SelectDefaultValue{ID: Snowflake(id), Type: RoleDefaultValue}
+}
+
+// ChannelDefault returns a SelectDefaultValue for the given channel.
+func ChannelDefault(id ChannelID) SelectDefaultValue {
+	return SelectDefaultValue{ID: Snowflake(id), Type: ChannelDefaultValue}
+}
+
 // Unknown is reserved for components with unknown or not yet implemented
 // components types. It can also be used in place of a ComponentInteraction.
 type UnknownComponent struct {
@@ -81,3 +81,18 @@ func ExampleContainerComponents_Unmarshal() {
 	//   "Button1": true
 	// }
 }
+
+func ExamplePremiumButtonStyle() {
+	button := &discord.ButtonComponent{
+		Style: discord.PremiumButtonStyle(1234567890),
+	}
+
+	b, err := json.Marshal(button)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	fmt.Println(string(b))
+	// Output:
+	// {"type":2,"style":6,"sku_id":"1234567890"}
+}
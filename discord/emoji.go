@@ -52,6 +52,18 @@ func (e Emoji) IsUnicode() bool {
 	return !e.IsCustom()
 }
 
+// Mention returns the message formatting of the emoji. For unicode emojis, it
+// is the emoji itself.
+func (e Emoji) Mention() string {
+	if !e.IsCustom() {
+		return e.Name
+	}
+	if e.Animated {
+		return "<a:" + e.Name + ":" + e.ID.String() + ">"
+	}
+	return "<:" + e.Name + ":" + e.ID.String() + ">"
+}
+
 // CreatedAt returns a time object representing when the emoji was created.
 //
 // This will only work for custom emojis.
@@ -59,15 +71,19 @@ func (e Emoji) CreatedAt() time.Time {
 	return e.ID.Time()
 }
 
+// CDN returns the CDN of the emoji. It is invalid for unicode emojis.
+func (e Emoji) CDN() CDN {
+	if e.IsUnicode() {
+		return CDN{}
+	}
+	return CDN{Path: "emojis/" + e.ID.String(), Animated: e.Animated}
+}
+
 // EmojiURL returns the URL of the emoji and auto-detects a suitable type.
 //
 // This will only work for custom emojis.
 func (e Emoji) EmojiURL() string {
-	if e.Animated {
-		return e.EmojiURLWithType(GIFImage)
-	}
-
-	return e.EmojiURLWithType(PNGImage)
+	return e.CDN().URL()
 }
 
 // EmojiURLWithType returns the URL to the emoji's image.
@@ -76,15 +92,7 @@ func (e Emoji) EmojiURL() string {
 //
 // Supported ImageTypes: PNG, GIF
 func (e Emoji) EmojiURLWithType(t ImageType) string {
-	if e.IsUnicode() {
-		return ""
-	}
-
-	if t == AutoImage {
-		return e.EmojiURL()
-	}
-
-	return "https://cdn.discordapp.com/emojis/" + t.format(e.ID.String())
+	return e.CDN().WithType(t).URL()
 }
 
 // APIEmoji represents an emoji identifier string formatted to be used with the
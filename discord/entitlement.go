@@ -0,0 +1,79 @@
+package discord
+
+import "time"
+
+// Entitlement represents that a user or guild has access to a premium
+// offering in the application.
+//
+// https://discord.com/developers/docs/monetization/entitlements#entitlement-object
+type Entitlement struct {
+	// ID is the ID of the entitlement.
+	ID EntitlementID `json:"id"`
+	// SKUID is the ID of the SKU.
+	SKUID SKUID `json:"sku_id"`
+	// AppID is the ID of the parent application.
+	AppID AppID `json:"application_id"`
+	// UserID is the ID of the user that is granted access to the
+	// entitlement's SKU.
+	UserID UserID `json:"user_id,omitempty"`
+	// GuildID is the ID of the guild that is granted access to the
+	// entitlement's SKU.
+	GuildID GuildID `json:"guild_id,omitempty"`
+	// Type is the type of entitlement.
+	Type EntitlementType `json:"type"`
+	// Deleted is whether the entitlement was deleted.
+	Deleted bool `json:"deleted"`
+	// StartsAt is the start date at which the entitlement is valid. It is
+	// invalid for test entitlements.
+	StartsAt Timestamp `json:"starts_at,omitempty"`
+	// EndsAt is the date at which the entitlement is no longer valid. It is
+	// invalid for test entitlements.
+	EndsAt Timestamp `json:"ends_at,omitempty"`
+	// Consumed is whether the entitlement was consumed, which only applies to
+	// consumable items.
+	Consumed bool `json:"consumed,omitempty"`
+}
+
+// Active returns true if the entitlement is currently valid.
+func (e Entitlement) Active() bool {
+	if e.Deleted {
+		return false
+	}
+
+	now := time.Now()
+	if e.StartsAt.IsValid() && now.Before(e.StartsAt.Time()) {
+		return false
+	}
+	if e.EndsAt.IsValid() && !now.Before(e.EndsAt.Time()) {
+		return false
+	}
+
+	return true
+}
+
+// https://discord.com/developers/docs/monetization/entitlements#entitlement-object-entitlement-types
+type EntitlementType uint8
+
+const (
+	// PurchaseEntitlement is an entitlement purchased by the user.
+	PurchaseEntitlement EntitlementType = iota + 1
+	// PremiumSubscriptionEntitlement is an entitlement for a Discord Nitro
+	// subscription.
+	PremiumSubscriptionEntitlement
+	// DeveloperGiftEntitlement is an entitlement gifted by the developer.
+	DeveloperGiftEntitlement
+	// TestModePurchaseEntitlement is an entitlement purchased by a developer
+	// in application test mode.
+	TestModePurchaseEntitlement
+	// FreePurchaseEntitlement is an entitlement granted when the SKU was
+	// free.
+	FreePurchaseEntitlement
+	// UserGiftEntitlement is an entitlement gifted by another user.
+	UserGiftEntitlement
+	// PremiumPurchaseEntitlement is an entitlement claimed by a user for free
+	// as a Nitro subscriber.
+	PremiumPurchaseEntitlement
+	// ApplicationSubscriptionEntitlement is an entitlement purchased as an
+	// app subscription.
+	ApplicationSubscriptionEntitlement
+)
@@ -116,6 +116,13 @@ type Guild struct {
 	ApproximatePresences uint64 `json:"approximate_presence_count,omitempty"`
 	// NSFWLevel is the level of NSFW of the guild.
 	NSFWLevel NSFWLevel `json:"nsfw_level"`
+
+	// SafetyAlertsChannelID is the id of the channel where admins and
+	// moderators of community guilds receive safety alerts from Discord.
+	SafetyAlertsChannelID ChannelID `json:"safety_alerts_channel_id,omitempty"`
+	// IncidentsData contains the incident actions of the guild, such as
+	// paused invites and DMs. It is nil if there are none.
+	IncidentsData *IncidentsData `json:"incidents_data,omitempty"`
 }
 
 // CreatedAt returns a time object representing when the guild was created.
@@ -123,6 +130,16 @@ func (g Guild) CreatedAt() time.Time {
 	return g.ID.Time()
 }
 
+// HasFeature returns true if the guild has the given feature enabled.
+func (g Guild) HasFeature(feature GuildFeature) bool {
+	return hasFeature(g.Features, feature)
+}
+
+// IconCDN returns the CDN of the guild icon. It is invalid if there is none.
+func (g Guild) IconCDN() CDN {
+	return NewCDN("icons/"+g.ID.String(), g.Icon)
+}
+
 // IconURL returns the URL to the guild icon and auto detects a suitable type.
 // An empty string is returned if there's no icon.
 func (g Guild) IconURL() string {
@@ -134,11 +151,13 @@ func (g Guild) IconURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP, GIF
 func (g Guild) IconURLWithType(t ImageType) string {
-	if g.Icon == "" {
-		return ""
-	}
+	return g.IconCDN().WithType(t).URL()
+}
 
-	return "https://cdn.discordapp.com/icons/" + g.ID.String() + "/" + t.format(g.Icon)
+// BannerCDN returns the CDN of the guild banner. It is invalid if there is
+// none.
+func (g Guild) BannerCDN() CDN {
+	return NewCDN("banners/"+g.ID.String(), g.Banner)
 }
 
 // BannerURL returns the URL to the banner, which is the image on top of the
@@ -152,12 +171,13 @@ func (g Guild) BannerURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP
 func (g Guild) BannerURLWithType(t ImageType) string {
-	if g.Banner == "" {
-		return ""
-	}
+	return g.BannerCDN().WithType(t).URL()
+}
 
-	return "https://cdn.discordapp.com/banners/" +
-		g.ID.String() + "/" + t.format(g.Banner)
+// SplashCDN returns the CDN of the guild splash. It is invalid if there is
+// none.
+func (g Guild) SplashCDN() CDN {
+	return NewCDN("splashes/"+g.ID.String(), g.Splash)
 }
 
 // SplashURL returns the URL to the guild splash, which is the invite page's
@@ -171,12 +191,13 @@ func (g Guild) SplashURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP
 func (g Guild) SplashURLWithType(t ImageType) string {
-	if g.Splash == "" {
-		return ""
-	}
+	return g.SplashCDN().WithType(t).URL()
+}
 
-	return "https://cdn.discordapp.com/splashes/" +
-		g.ID.String() + "/" + t.format(g.Splash)
+// DiscoverySplashCDN returns the CDN of the guild discovery splash. It is
+// invalid if there is none.
+func (g Guild) DiscoverySplashCDN() CDN {
+	return NewCDN("discovery-splashes/"+g.ID.String(), g.DiscoverySplash)
 }
 
 // DiscoverySplashURL returns the URL to the guild discovery splash.
@@ -190,12 +211,33 @@ func (g Guild) DiscoverySplashURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP
 func (g Guild) DiscoverySplashURLWithType(t ImageType) string {
-	if g.DiscoverySplash == "" {
-		return ""
-	}
+	return g.DiscoverySplashCDN().WithType(t).URL()
+}
+
+// IncidentsData contains the incident actions of a guild and when suspicious
+// activity was last detected.
+//
+// https://discord.com/developers/docs/resources/guild#incidents-data-object
+type IncidentsData struct {
+	// InvitesDisabledUntil is when invites are enabled again.
+	InvitesDisabledUntil Timestamp `json:"invites_disabled_until"`
+	// DMsDisabledUntil is when direct messages between members that aren't
+	// friends are enabled again.
+	DMsDisabledUntil Timestamp `json:"dms_disabled_until"`
+	// DMSpamDetectedAt is when DM spam was last detected.
+	DMSpamDetectedAt Timestamp `json:"dm_spam_detected_at,omitempty"`
+	// RaidDetectedAt is when a raid was last detected.
+	RaidDetectedAt Timestamp `json:"raid_detected_at,omitempty"`
+}
+
+// InvitesDisabled returns true if invites are currently disabled.
+func (d IncidentsData) InvitesDisabled() bool {
+	return d.InvitesDisabledUntil.IsValid() && d.InvitesDisabledUntil.Time().After(time.Now())
+}
 
-	return "https://cdn.discordapp.com/splashes/" +
-		g.ID.String() + "/" + t.format(g.DiscoverySplash)
+// DMsDisabled returns true if direct messages are currently disabled.
+func (d IncidentsData) DMsDisabled() bool {
+	return d.DMsDisabledUntil.IsValid() && d.DMsDisabledUntil.Time().After(time.Now())
 }
 
 // https://discord.com/developers/docs/resources/guild#guild-object-guild-nsfw-level
@@ -225,7 +267,9 @@ type GuildPreview struct {
 	// Emojis are the custom guild emojis.
 	Emojis []Emoji `json:"emojis"`
 	// Features are the enabled guild features.
-	Features []GuildFeature `json:"guild_features"`
+	Features []GuildFeature `json:"features"`
+	// Stickers are the custom guild stickers.
+	Stickers []Sticker `json:"stickers"`
 
 	// ApproximateMembers is the approximate number of members in this guild.
 	ApproximateMembers uint64 `json:"approximate_member_count"`
@@ -237,12 +281,57 @@ type GuildPreview struct {
 	Description string `json:"description,omitempty"`
 }
 
+// HasFeature returns true if the guild has the given feature enabled.
+func (g GuildPreview) HasFeature(feature GuildFeature) bool {
+	return hasFeature(g.Features, feature)
+}
+
+func hasFeature(features []GuildFeature, feature GuildFeature) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// WelcomeScreen is the screen shown to new members in guilds with the
+// Community feature.
+//
+// https://discord.com/developers/docs/resources/guild#welcome-screen-object
+type WelcomeScreen struct {
+	// Description is the guild description shown in the welcome screen.
+	Description string `json:"description,omitempty"`
+	// WelcomeChannels are the channels shown in the welcome screen, up to 5.
+	WelcomeChannels []WelcomeScreenChannel `json:"welcome_channels"`
+}
+
+// WelcomeScreenChannel is a channel shown in a WelcomeScreen.
+//
+// https://discord.com/developers/docs/resources/guild#welcome-screen-object-welcome-screen-channel-structure
+type WelcomeScreenChannel struct {
+	// ChannelID is the ID of the channel.
+	ChannelID ChannelID `json:"channel_id"`
+	// Description is the description shown for the channel.
+	Description string `json:"description"`
+	// EmojiID is the ID of the channel's custom emoji, if any.
+	EmojiID EmojiID `json:"emoji_id,omitempty"`
+	// EmojiName is the name of the channel's custom emoji, or the unicode
+	// character of its standard emoji, if any.
+	EmojiName string `json:"emoji_name,omitempty"`
+}
+
 // CreatedAt returns a time object representing when the guild the preview
 // represents was created.
 func (g GuildPreview) CreatedAt() time.Time {
 	return g.ID.Time()
 }
 
+// IconCDN returns the CDN of the guild icon. It is invalid if there is none.
+func (g GuildPreview) IconCDN() CDN {
+	return NewCDN("icons/"+g.ID.String(), g.Icon)
+}
+
 // IconURL returns the URL to the guild icon and auto detects a suitable type.
 // An empty string is returned if there's no icon.
 func (g GuildPreview) IconURL() string {
@@ -254,11 +343,13 @@ func (g GuildPreview) IconURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP, GIF
 func (g GuildPreview) IconURLWithType(t ImageType) string {
-	if g.Icon == "" {
-		return ""
-	}
+	return g.IconCDN().WithType(t).URL()
+}
 
-	return "https://cdn.discordapp.com/icons/" + g.ID.String() + "/" + t.format(g.Icon)
+// SplashCDN returns the CDN of the guild splash. It is invalid if there is
+// none.
+func (g GuildPreview) SplashCDN() CDN {
+	return NewCDN("splashes/"+g.ID.String(), g.Splash)
 }
 
 // SplashURL returns the URL to the guild splash, which is the invite page's
@@ -272,12 +363,13 @@ func (g GuildPreview) SplashURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP
 func (g GuildPreview) SplashURLWithType(t ImageType) string {
-	if g.Splash == "" {
-		return ""
-	}
+	return g.SplashCDN().WithType(t).URL()
+}
 
-	return "https://cdn.discordapp.com/splashes/" +
-		g.ID.String() + "/" + t.format(g.Splash)
+// DiscoverySplashCDN returns the CDN of the guild discovery splash. It is
+// invalid if there is none.
+func (g GuildPreview) DiscoverySplashCDN() CDN {
+	return NewCDN("discovery-splashes/"+g.ID.String(), g.DiscoverySplash)
 }
 
 // DiscoverySplashURL returns the URL to the guild discovery splash.
@@ -291,12 +383,7 @@ func (g GuildPreview) DiscoverySplashURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP
 func (g GuildPreview) DiscoverySplashURLWithType(t ImageType) string {
-	if g.DiscoverySplash == "" {
-		return ""
-	}
-
-	return "https://cdn.discordapp.com/splashes/" +
-		g.ID.String() + "/" + t.format(g.DiscoverySplash)
+	return g.DiscoverySplashCDN().WithType(t).URL()
 }
 
 // https://discord.com/developers/docs/topics/permissions#role-object
@@ -348,6 +435,11 @@ func (r Role) Mention() string {
 	return r.ID.Mention()
 }
 
+// IconCDN returns the CDN of the role icon. It is invalid if there is none.
+func (r Role) IconCDN() CDN {
+	return NewCDN("role-icons/"+r.ID.String(), r.Icon)
+}
+
 // IconURL returns the URL to the role icon png.
 // An empty string is returned if there's no icon.
 func (r Role) IconURL() string {
@@ -359,11 +451,7 @@ func (r Role) IconURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP
 func (r Role) IconURLWithType(t ImageType) string {
-	if r.Icon == "" {
-		return ""
-	}
-
-	return "https://cdn.discordapp.com/role-icons/" + r.ID.String() + "/" + t.format(r.Icon)
+	return r.IconCDN().WithType(t).URL()
 }
 
 // SortRolesByPosition sorts the roles by their position.
@@ -388,6 +476,10 @@ type Member struct {
 	RoleIDs []RoleID `json:"roles"`
 	// Avatar is this member's guild avatar.
 	Avatar Hash `json:"avatar,omitempty"`
+	// Banner is this member's guild banner.
+	Banner Hash `json:"banner,omitempty"`
+	// AvatarDecoration is the data for this member's guild avatar decoration.
+	AvatarDecoration *AvatarDecorationData `json:"avatar_decoration_data,omitempty"`
 
 	// Joined specifies when the user joined the guild.
 	Joined Timestamp `json:"joined_at"`
@@ -413,6 +505,22 @@ func (m Member) Mention() string {
 	return "<@" + m.User.ID.String() + ">"
 }
 
+// DisplayName returns the name that the member is displayed as in the guild.
+// It is the member's nickname if they have one, otherwise their global
+// display name if they have one, otherwise their username.
+func (m Member) DisplayName() string {
+	if m.Nick != "" {
+		return m.Nick
+	}
+	return m.User.DisplayOrUsername()
+}
+
+// AvatarCDN returns the CDN of the member's guild avatar. It is invalid if
+// there is none.
+func (m Member) AvatarCDN(guildID GuildID) CDN {
+	return NewCDN("guilds/"+guildID.String()+"/users/"+m.User.ID.String()+"/avatars", m.Avatar)
+}
+
 // AvatarURL returns the URL of the Avatar Image. It automatically detects a
 // suitable type.
 func (m Member) AvatarURL(guildID GuildID) string {
@@ -424,23 +532,111 @@ func (m Member) AvatarURL(guildID GuildID) string {
 //
 // Supported Image Types: PNG, JPEG, WebP, GIF
 func (m Member) AvatarURLWithType(t ImageType, guildID GuildID) string {
-	if m.Avatar == "" {
-		return ""
+	return m.AvatarCDN(guildID).WithType(t).URL()
+}
+
+// AvatarURLInGuild returns the URL of the avatar that the member has in the
+// guild with the given ID. It is the member's guild avatar if they have one,
+// otherwise their user avatar.
+func (m Member) AvatarURLInGuild(guildID GuildID) string {
+	if m.Avatar != "" {
+		return m.AvatarURL(guildID)
 	}
+	return m.User.AvatarURL()
+}
 
-	return "https://cdn.discordapp.com/guilds/" + guildID.String() + "/users/" + m.User.ID.String() + "/avatars/" + t.format(m.Avatar)
+// BannerCDN returns the CDN of the member's guild banner. It is invalid if
+// there is none.
+func (m Member) BannerCDN(guildID GuildID) CDN {
+	return NewCDN("guilds/"+guildID.String()+"/users/"+m.User.ID.String()+"/banners", m.Banner)
+}
+
+// BannerURL returns the URL of the Banner Image. It automatically detects a
+// suitable type.
+func (m Member) BannerURL(guildID GuildID) string {
+	return m.BannerURLWithType(AutoImage, guildID)
+}
+
+// BannerURLWithType returns the URL of the Banner Image using the passed type.
+// If the member has no Banner, an empty string will be returned.
+//
+// Supported Image Types: PNG, JPEG, WebP, GIF
+func (m Member) BannerURLWithType(t ImageType, guildID GuildID) string {
+	return m.BannerCDN(guildID).WithType(t).URL()
+}
+
+// BannerURLInGuild returns the URL of the banner that the member has in the
+// guild with the given ID. It is the member's guild banner if they have one,
+// otherwise their user banner, which may be empty.
+func (m Member) BannerURLInGuild(guildID GuildID) string {
+	if m.Banner != "" {
+		return m.BannerURL(guildID)
+	}
+	return m.User.BannerURL()
+}
+
+// IsTimedOut returns true if the member is currently timed out.
+func (m Member) IsTimedOut() bool {
+	return m.CommunicationDisabledUntil.IsValid() &&
+		m.CommunicationDisabledUntil.Time().After(time.Now())
+}
+
+// AvatarDecorationURL returns the URL of the member's guild avatar decoration.
+// If the member has no guild avatar decoration, an empty string will be
+// returned.
+func (m Member) AvatarDecorationURL() string {
+	if m.AvatarDecoration == nil {
+		return ""
+	}
+	return m.AvatarDecoration.URL()
 }
 
 // MemberFlags represents the bit set of member flags.
+//
+// https://discord.com/developers/docs/resources/guild#guild-member-object-guild-member-flags
 type MemberFlags uint32
 
 const (
-	MemberFlagsDidRejoin = 1 << iota
+	// MemberFlagsDidRejoin is set if the member has left and rejoined the
+	// guild.
+	MemberFlagsDidRejoin MemberFlags = 1 << iota
+	// MemberFlagsCompletedOnboarding is set if the member has completed
+	// onboarding.
 	MemberFlagsCompletedOnboarding
+	// MemberFlagsBypassesVerification is set if the member is exempt from the
+	// guild's verification requirements. It is the only flag that can be
+	// modified, which requires the MANAGE_GUILD, MANAGE_ROLES or
+	// MODERATE_MEMBERS and KICK_MEMBERS and BAN_MEMBERS permissions.
 	MemberFlagsBypassesVerification
+	// MemberFlagsStartedOnboarding is set if the member has started
+	// onboarding.
 	MemberFlagsStartedOnboarding
+	// MemberFlagsIsGuest is set if the member is a guest and can only access
+	// the voice channel that they were invited to.
+	MemberFlagsIsGuest
+	// MemberFlagsStartedHomeActions is set if the member has started the
+	// Server Guide new member actions.
+	MemberFlagsStartedHomeActions
+	// MemberFlagsCompletedHomeActions is set if the member has completed the
+	// Server Guide new member actions.
+	MemberFlagsCompletedHomeActions
+	// MemberFlagsAutomodQuarantinedUsername is set if the member's username,
+	// display name or nickname is blocked by AutoMod.
+	MemberFlagsAutomodQuarantinedUsername
+	_
+	// MemberFlagsDMSettingsUpsellAcknowledged is set if the member has
+	// dismissed the DM settings upsell.
+	MemberFlagsDMSettingsUpsellAcknowledged
+	// MemberFlagsAutomodQuarantinedGuildTag is set if the member's guild tag
+	// is blocked by AutoMod.
+	MemberFlagsAutomodQuarantinedGuildTag
 )
 
+// Has returns true if f has all of the given flags.
+func (f MemberFlags) Has(flags MemberFlags) bool {
+	return f&flags == flags
+}
+
 // https://discord.com/developers/docs/resources/guild#ban-object
 type Ban struct {
 	// Reason is the reason for the ban.
@@ -20,6 +20,24 @@ const (
 	NitroLevel3
 )
 
+// DefaultUploadLimit is the maximum total size in bytes of the files in a
+// message sent outside of boosted guilds, such as in DMs.
+const DefaultUploadLimit = 10 << 20
+
+// UploadLimit returns the maximum total size in bytes of the files in a message
+// sent to a guild with the premium tier. Messages sent by users with Nitro may
+// have larger files.
+func (b NitroBoost) UploadLimit() int64 {
+	switch {
+	case b >= NitroLevel3:
+		return 100 << 20
+	case b == NitroLevel2:
+		return 50 << 20
+	default:
+		return DefaultUploadLimit
+	}
+}
+
 // MFALevel is the required MFA level for a guild.
 type MFALevel uint8
 
@@ -39,6 +57,7 @@ const (
 	SuppressPremiumSubscriptions
 )
 
+// GuildFeature is a feature that is enabled in a guild.
 type GuildFeature string
 
 // https://discord.com/developers/docs/resources/guild#guild-object-guild-features
@@ -72,8 +91,71 @@ const (
 	AnimatedIcon GuildFeature = "ANIMATED_ICON"
 	// Banner is set, if the guild has access to set a guild banner image.
 	Banner GuildFeature = "BANNER"
+	// AnimatedBanner is set, if the guild has access to set an animated guild
+	// banner image.
+	AnimatedBanner GuildFeature = "ANIMATED_BANNER"
+	// ApplicationCommandPermissionsV2 is set, if the guild is using the new
+	// permissions system for application commands.
+	ApplicationCommandPermissionsV2 GuildFeature = "APPLICATION_COMMAND_PERMISSIONS_V2"
+	// AutoModeration is set, if the guild has set up auto moderation rules.
+	AutoModeration GuildFeature = "AUTO_MODERATION"
+	// Community is set, if the guild can enable the welcome screen, membership
+	// screening, stage channels, discovery and receives community updates.
+	Community GuildFeature = "COMMUNITY"
+	// CreatorMonetizableProvisional is set, if the guild has enabled
+	// monetization.
+	CreatorMonetizableProvisional GuildFeature = "CREATOR_MONETIZABLE_PROVISIONAL"
+	// CreatorStorePage is set, if the guild has enabled the role subscription
+	// promo page.
+	CreatorStorePage GuildFeature = "CREATOR_STORE_PAGE"
+	// DeveloperSupportServer is set, if the guild has been set as a support
+	// server on the App Directory.
+	DeveloperSupportServer GuildFeature = "DEVELOPER_SUPPORT_SERVER"
+	// InvitesDisabled is set, if the guild has paused invites, preventing new
+	// users from joining.
+	InvitesDisabled GuildFeature = "INVITES_DISABLED"
+	// MemberVerificationGateEnabled is set, if the guild has enabled
+	// membership screening.
+	MemberVerificationGateEnabled GuildFeature = "MEMBER_VERIFICATION_GATE_ENABLED"
+	// MoreSoundboard is set, if the guild has more soundboard sound slots.
+	MoreSoundboard GuildFeature = "MORE_SOUNDBOARD"
+	// MoreStickers is set, if the guild has increased custom sticker slots.
+	MoreStickers GuildFeature = "MORE_STICKERS"
+	// PreviewEnabled is set, if the guild can be previewed before joining via
+	// membership screening or the directory.
+	PreviewEnabled GuildFeature = "PREVIEW_ENABLED"
+	// RaidAlertsDisabled is set, if the guild has disabled alerts for join
+	// raids in the configured safety alerts channel.
+	RaidAlertsDisabled GuildFeature = "RAID_ALERTS_DISABLED"
+	// RoleIcons is set, if the guild is able to set role icons.
+	RoleIcons GuildFeature = "ROLE_ICONS"
+	// RoleSubscriptionsAvailableForPurchase is set, if the guild has role
+	// subscriptions that can be purchased.
+	RoleSubscriptionsAvailableForPurchase GuildFeature = "ROLE_SUBSCRIPTIONS_AVAILABLE_FOR_PURCHASE"
+	// RoleSubscriptionsEnabled is set, if the guild has enabled role
+	// subscriptions.
+	RoleSubscriptionsEnabled GuildFeature = "ROLE_SUBSCRIPTIONS_ENABLED"
+	// Soundboard is set, if the guild has created soundboard sounds.
+	Soundboard GuildFeature = "SOUNDBOARD"
+	// TicketedEventsEnabled is set, if the guild has enabled ticketed events.
+	TicketedEventsEnabled GuildFeature = "TICKETED_EVENTS_ENABLED"
+	// WelcomeScreenEnabled is set, if the guild has enabled the welcome
+	// screen.
+	WelcomeScreenEnabled GuildFeature = "WELCOME_SCREEN_ENABLED"
 )
 
+// IsMutable returns true if the feature can be enabled or disabled using
+// ModifyGuild. Enabling Community and Discoverable has additional
+// requirements.
+func (f GuildFeature) IsMutable() bool {
+	switch f {
+	case Community, Discoverable, InvitesDisabled, RaidAlertsDisabled:
+		return true
+	default:
+		return false
+	}
+}
+
 // ExplicitFilter is the explicit content filter level of a guild.
 type ExplicitFilter enum.Enum
 
@@ -0,0 +1,37 @@
+package discord
+
+// GuildTemplate is a snapshot of a guild that can be used to create new
+// guilds.
+//
+// https://discord.com/developers/docs/resources/guild-template#guild-template-object
+type GuildTemplate struct {
+	// Code is the template code, which is its unique ID.
+	Code string `json:"code"`
+	// Name is the name of the template.
+	Name string `json:"name"`
+	// Description is the description of the template.
+	Description string `json:"description,omitempty"`
+	// UsageCount is the number of times the template has been used.
+	UsageCount int `json:"usage_count"`
+	// CreatorID is the ID of the user who created the template.
+	CreatorID UserID `json:"creator_id"`
+	// Creator is the user who created the template.
+	Creator User `json:"creator"`
+	// CreatedAt is when the template was created.
+	CreatedAt Timestamp `json:"created_at"`
+	// UpdatedAt is when the template was last synced to the source guild.
+	UpdatedAt Timestamp `json:"updated_at"`
+	// SourceGuildID is the ID of the guild that the template is based on.
+	SourceGuildID GuildID `json:"source_guild_id"`
+	// SerializedSourceGuild is the guild snapshot that the template contains.
+	// Only some of its fields are filled, and its roles and channels have
+	// placeholder IDs.
+	SerializedSourceGuild Guild `json:"serialized_source_guild"`
+	// IsDirty is true if the template has unsynced changes.
+	IsDirty bool `json:"is_dirty,omitempty"`
+}
+
+// URL returns the link to the template.
+func (t GuildTemplate) URL() URL {
+	return "https://discord.new/" + t.Code
+}
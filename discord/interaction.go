@@ -333,6 +333,8 @@ func (s *StringSelectInteraction) data() {}
 type ChannelSelectInteraction struct {
 	CustomID ComponentID `json:"custom_id"`
 	Values   []ChannelID `json:"values"`
+	// Resolved contains the selected objects.
+	Resolved SelectResolvedData `json:"resolved"`
 }
 
 // ID implements ComponentInteraction.
@@ -353,6 +355,8 @@ func (s *ChannelSelectInteraction) data() {}
 type RoleSelectInteraction struct {
 	CustomID ComponentID `json:"custom_id"`
 	Values   []RoleID    `json:"values"`
+	// Resolved contains the selected objects.
+	Resolved SelectResolvedData `json:"resolved"`
 }
 
 // ID implements ComponentInteraction.
@@ -373,6 +377,8 @@ func (s *RoleSelectInteraction) data() {}
 type UserSelectInteraction struct {
 	CustomID ComponentID `json:"custom_id"`
 	Values   []UserID    `json:"values"`
+	// Resolved contains the selected objects.
+	Resolved SelectResolvedData `json:"resolved"`
 }
 
 // ID implements ComponentInteraction.
@@ -393,6 +399,8 @@ func (s *UserSelectInteraction) data() {}
 type MentionableSelectInteraction struct {
 	CustomID ComponentID `json:"custom_id"`
 	Values   []Snowflake `json:"values"`
+	// Resolved contains the selected objects.
+	Resolved SelectResolvedData `json:"resolved"`
 }
 
 // ID implements ComponentInteraction.
@@ -409,6 +417,22 @@ func (s *MentionableSelectInteraction) InteractionType() InteractionDataType {
 func (s *MentionableSelectInteraction) resp() {}
 func (s *MentionableSelectInteraction) data() {}
 
+// SelectResolvedData contains the objects selected in a user, role,
+// mentionable or channel select, keyed by their IDs.
+type SelectResolvedData struct {
+	// Users contains the selected users, including the users of the selected
+	// members.
+	Users map[UserID]User `json:"users,omitempty"`
+	// Members contains partial member objects (missing User, Deaf and Mute)
+	// of the selected users, if the select was used in a guild.
+	Members map[UserID]Member `json:"members,omitempty"`
+	// Roles contains the selected roles.
+	Roles map[RoleID]Role `json:"roles,omitempty"`
+	// Channels contains partial channel objects that only have ID, Name, Type
+	// and Permissions. Threads will also have ThreadMetadata and ParentID.
+	Channels map[ChannelID]Channel `json:"channels,omitempty"`
+}
+
 // ButtonInteraction is a button component's response. It is the custom ID of
 // the button within the component tree.
 type ButtonInteraction struct {
@@ -760,6 +784,42 @@ func (m *ModalInteraction) InteractionType() InteractionDataType {
 
 func (m *ModalInteraction) data() {}
 
+// Value returns the value submitted in the text input with the given custom
+// ID. False is returned if the modal has no such text input.
+func (m *ModalInteraction) Value(id ComponentID) (string, bool) {
+	for _, container := range m.Components {
+		row, ok := container.(*ActionRowComponent)
+		if !ok {
+			continue
+		}
+		for _, component := range *row {
+			input, ok := component.(*TextInputComponent)
+			if ok && input.CustomID == id {
+				return input.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Values returns the values submitted in all text inputs of the modal, keyed
+// by their custom IDs.
+func (m *ModalInteraction) Values() map[ComponentID]string {
+	values := make(map[ComponentID]string)
+	for _, container := range m.Components {
+		row, ok := container.(*ActionRowComponent)
+		if !ok {
+			continue
+		}
+		for _, component := range *row {
+			if input, ok := component.(*TextInputComponent); ok {
+				values[input.CustomID] = input.Value
+			}
+		}
+	}
+	return values
+}
+
 // UnknownInteractionData describes an Interaction response with an unknown
 // type.
 type UnknownInteractionData struct {
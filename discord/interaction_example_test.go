@@ -87,3 +87,27 @@ func mustJSON(v interface{}) internaljson.Raw {
 	}
 	return internaljson.Raw(b)
 }
+
+func ExampleParseComponentInteraction_userSelect() {
+	data := []byte(`{
+		"component_type": 5,
+		"custom_id": "pick_user",
+		"values": ["1"],
+		"resolved": {
+			"users": {"1": {"id": "1", "username": "arikawa"}}
+		}
+	}`)
+
+	ci, err := discord.ParseComponentInteraction(data)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	sel := ci.(*discord.UserSelectInteraction)
+	for _, id := range sel.Values {
+		fmt.Println(sel.Resolved.Users[id].Username)
+	}
+
+	// Output:
+	// arikawa
+}
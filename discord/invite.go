@@ -14,10 +14,14 @@ type Invite struct {
 	// Inviter is the user who created the invite
 	Inviter *User `json:"inviter,omitempty"`
 
-	// Target is the target user for this invite.
+	// TargetType is the type of target for this voice channel invite.
+	TargetType InviteTargetType `json:"target_type,omitempty"`
+	// Target is the user whose stream to display for this voice channel
+	// stream invite.
 	Target *User `json:"target_user,omitempty"`
-	// Target type is the type of user target for this invite.
-	TargetType InviteUserType `json:"target_user_type,omitempty"`
+	// TargetApplication is the embedded application to open for this voice
+	// channel embedded application invite.
+	TargetApplication *Application `json:"target_application,omitempty"`
 
 	// ApproximatePresences is the approximate count of online members (only
 	// present when Target is set).
@@ -42,12 +46,29 @@ func (i Invite) LongURL() string {
 	return "https://discord.com/invite/" + i.Code
 }
 
-// https://discord.com/developers/docs/resources/invite#invite-object-target-user-types
-type InviteUserType uint8
+// InviteTargetType is the type of target of a voice channel invite.
+//
+// https://discord.com/developers/docs/resources/invite#invite-object-invite-target-types
+type InviteTargetType uint8
+
+const (
+	// InviteTargetStream is an invite to watch the stream of a user.
+	InviteTargetStream InviteTargetType = iota + 1
+	// InviteTargetEmbeddedApplication is an invite to open an embedded
+	// application, also known as an activity.
+	InviteTargetEmbeddedApplication
+)
+
+// InviteUserType is the old name of InviteTargetType.
+//
+// Deprecated: Use InviteTargetType.
+type InviteUserType = InviteTargetType
 
 const (
-	InviteNormalUser InviteUserType = iota
-	InviteUserStream
+	// Deprecated: Invites without a target have a zero TargetType.
+	InviteNormalUser InviteTargetType = 0
+	// Deprecated: Use InviteTargetStream.
+	InviteUserStream = InviteTargetStream
 )
 
 // Extra information about an invite, will extend the invite object.
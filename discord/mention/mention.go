@@ -0,0 +1,254 @@
+// Package mention parses the mentions and other message formatting in the
+// content of messages, such as user mentions, custom emojis and timestamps.
+//
+// Like in the Discord client, formatting inside code blocks, inline code or
+// after a backslash is not parsed.
+package mention
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// Type is the type of a Mention.
+type Type uint8
+
+const (
+	_ Type = iota
+	// User is a user mention, such as "<@123>".
+	User
+	// Role is a role mention, such as "<@&123>".
+	Role
+	// Channel is a channel mention, such as "<#123>".
+	Channel
+	// Emoji is a custom emoji, such as "<:name:123>" or "<a:name:123>".
+	Emoji
+	// Timestamp is a formatted timestamp, such as "<t:1618953630:R>".
+	Timestamp
+	// Command is a command mention, such as "</name:123>".
+	Command
+	// Everyone is an @everyone mention.
+	Everyone
+	// Here is an @here mention.
+	Here
+)
+
+// Mention is a single mention in the content of a message.
+type Mention struct {
+	// Type is the type of the mention.
+	Type Type
+	// Start and End are the byte offsets of the mention in the content, so
+	// content[Start:End] is the whole mention.
+	Start, End int
+	// ID is the ID of the mentioned user, role, channel, emoji or command.
+	ID discord.Snowflake
+	// Name is the name of the emoji or command. For subcommands, it is the
+	// full name, such as "tag get".
+	Name string
+	// Animated is true if the emoji is animated.
+	Animated bool
+	// Time is the time of the timestamp.
+	Time time.Time
+	// Style is the style of the timestamp.
+	Style discord.TimestampStyle
+}
+
+// UserID returns the ID of the mentioned user.
+func (m Mention) UserID() discord.UserID { return discord.UserID(m.ID) }
+
+// RoleID returns the ID of the mentioned role.
+func (m Mention) RoleID() discord.RoleID { return discord.RoleID(m.ID) }
+
+// ChannelID returns the ID of the mentioned channel.
+func (m Mention) ChannelID() discord.ChannelID { return discord.ChannelID(m.ID) }
+
+// CommandID returns the ID of the mentioned command.
+func (m Mention) CommandID() discord.CommandID { return discord.CommandID(m.ID) }
+
+// Emoji returns the mentioned custom emoji.
+func (m Mention) Emoji() discord.Emoji {
+	return discord.Emoji{
+		ID:       discord.EmojiID(m.ID),
+		Name:     m.Name,
+		Animated: m.Animated,
+	}
+}
+
+var mentionRegex = regexp.MustCompile(`` +
+	`<@!?(\d+)>` + "|" +
+	`<@&(\d+)>` + "|" +
+	`<#(\d+)>` + "|" +
+	`<(a?):(\w{2,32}):(\d+)>` + "|" +
+	`<t:(-?\d+)(?::([tTdDfFR]))?>` + "|" +
+	`</([^:<>/\n]{1,100}):(\d+)>` + "|" +
+	`@(everyone|here)`,
+)
+
+// Parse returns all mentions in the content in the order that they appear.
+func Parse(content string) []Mention {
+	code := codeSpans(content)
+
+	var mentions []Mention
+
+	for _, match := range mentionRegex.FindAllStringSubmatchIndex(content, -1) {
+		start, end := match[0], match[1]
+
+		if start > 0 && content[start-1] == '\\' {
+			continue
+		}
+		if inSpans(code, start) {
+			continue
+		}
+
+		group := func(i int) string {
+			if match[2*i] < 0 {
+				return ""
+			}
+			return content[match[2*i]:match[2*i+1]]
+		}
+
+		m := Mention{Start: start, End: end}
+		var id string
+
+		switch {
+		case group(1) != "":
+			m.Type = User
+			id = group(1)
+		case group(2) != "":
+			m.Type = Role
+			id = group(2)
+		case group(3) != "":
+			m.Type = Channel
+			id = group(3)
+		case group(6) != "":
+			m.Type = Emoji
+			m.Animated = group(4) == "a"
+			m.Name = group(5)
+			id = group(6)
+		case group(7) != "":
+			unix, err := strconv.ParseInt(group(7), 10, 64)
+			if err != nil {
+				continue
+			}
+			m.Type = Timestamp
+			m.Time = time.Unix(unix, 0)
+			m.Style = discord.TimestampStyle(group(8))
+		case group(10) != "":
+			m.Type = Command
+			m.Name = group(9)
+			id = group(10)
+		case group(11) == "everyone":
+			m.Type = Everyone
+		case group(11) == "here":
+			m.Type = Here
+		default:
+			continue
+		}
+
+		if id != "" {
+			sf, err := discord.ParseSnowflake(id)
+			if err != nil {
+				continue
+			}
+			m.ID = sf
+		}
+
+		mentions = append(mentions, m)
+	}
+
+	return mentions
+}
+
+// UserIDs returns the IDs of the users mentioned in the content, without
+// duplicates.
+func UserIDs(content string) []discord.UserID {
+	var ids []discord.UserID
+	for _, id := range mentionedIDs(content, User) {
+		ids = append(ids, discord.UserID(id))
+	}
+	return ids
+}
+
+// RoleIDs returns the IDs of the roles mentioned in the content, without
+// duplicates.
+func RoleIDs(content string) []discord.RoleID {
+	var ids []discord.RoleID
+	for _, id := range mentionedIDs(content, Role) {
+		ids = append(ids, discord.RoleID(id))
+	}
+	return ids
+}
+
+// ChannelIDs returns the IDs of the channels mentioned in the content, without
+// duplicates.
+func ChannelIDs(content string) []discord.ChannelID {
+	var ids []discord.ChannelID
+	for _, id := range mentionedIDs(content, Channel) {
+		ids = append(ids, discord.ChannelID(id))
+	}
+	return ids
+}
+
+func mentionedIDs(content string, t Type) []discord.Snowflake {
+	var ids []discord.Snowflake
+	seen := map[discord.Snowflake]bool{}
+
+	for _, m := range Parse(content) {
+		if m.Type == t && !seen[m.ID] {
+			seen[m.ID] = true
+			ids = append(ids, m.ID)
+		}
+	}
+
+	return ids
+}
+
+// codeSpans returns the byte ranges of the code blocks and inline code in the
+// content.
+func codeSpans(content string) [][2]int {
+	var spans [][2]int
+
+	for i := 0; i < len(content); {
+		if content[i] == '\\' {
+			i += 2
+			continue
+		}
+		if content[i] != '`' {
+			i++
+			continue
+		}
+
+		// Count the backticks that open the span, which must be closed by
+		// the same number of backticks.
+		n := 1
+		for i+n < len(content) && content[i+n] == '`' && n < 3 {
+			n++
+		}
+		delim := content[i : i+n]
+
+		end := strings.Index(content[i+n:], delim)
+		if end < 0 {
+			i += n
+			continue
+		}
+
+		end += i + n + n
+		spans = append(spans, [2]int{i, end})
+		i = end
+	}
+
+	return spans
+}
+
+func inSpans(spans [][2]int, i int) bool {
+	for _, span := range spans {
+		if span[0] <= i && i < span[1] {
+			return true
+		}
+	}
+	return false
+}
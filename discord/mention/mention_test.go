@@ -0,0 +1,47 @@
+package mention
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestParse(t *testing.T) {
+	const content = "hi <@1> and <@!2> in <#3> with <@&4> <a:wave:5> " +
+		"at <t:1618953630:R>, try </tag get:6> @here " +
+		"`<@7>` ```\n<@8>\n``` \\<@9>"
+
+	expect := []Mention{
+		{Type: User, ID: 1},
+		{Type: User, ID: 2},
+		{Type: Channel, ID: 3},
+		{Type: Role, ID: 4},
+		{Type: Emoji, ID: 5, Name: "wave", Animated: true},
+		{Type: Timestamp, Time: time.Unix(1618953630, 0), Style: discord.RelativeTime},
+		{Type: Command, ID: 6, Name: "tag get"},
+		{Type: Here},
+	}
+
+	mentions := Parse(content)
+	if len(mentions) != len(expect) {
+		t.Fatalf("expected %d mentions, got %d: %+v", len(expect), len(mentions), mentions)
+	}
+
+	for i, m := range mentions {
+		raw := content[m.Start:m.End]
+		m.Start, m.End = 0, 0
+
+		if !reflect.DeepEqual(m, expect[i]) {
+			t.Errorf("mention %d (%q): expected %+v, got %+v", i, raw, expect[i], m)
+		}
+	}
+}
+
+func TestUserIDs(t *testing.T) {
+	ids := UserIDs("<@1> <@!1> <@2>")
+	if expect := []discord.UserID{1, 2}; !reflect.DeepEqual(ids, expect) {
+		t.Fatalf("expected %v, got %v", expect, ids)
+	}
+}
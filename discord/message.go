@@ -101,6 +101,9 @@ type Message struct {
 	// replied-to message. If null, the message was deleted. If present and
 	// non-null, it is a message object
 	ReferencedMessage *Message `json:"referenced_message,omitempty"`
+	// MessageSnapshots contains the forwarded message if the message is a
+	// forward, i.e. if Reference is of type ForwardMessageReference.
+	MessageSnapshots []MessageSnapshot `json:"message_snapshots,omitempty"`
 
 	// Interaction is the interaction that the message is in response to.
 	// This is only present if the message is in response to an interaction.
@@ -108,6 +111,18 @@ type Message struct {
 
 	// Stickers contains the sticker "items" sent with the message.
 	Stickers []StickerItem `json:"sticker_items,omitempty"`
+
+	// Poll is the poll attached to the message, if any.
+	Poll *Poll `json:"poll,omitempty"`
+
+	// RoleSubscriptionData is the data of the role subscription purchase or
+	// renewal that prompted the message. It is only present for messages of
+	// type RoleSubscriptionPurchaseMessage.
+	RoleSubscriptionData *RoleSubscriptionData `json:"role_subscription_data,omitempty"`
+	// PurchaseNotification is the data of the purchase that prompted the
+	// message. It is only present for messages of type
+	// PurchaseNotificationMessage.
+	PurchaseNotification *PurchaseNotification `json:"purchase_notification,omitempty"`
 }
 
 // URL generates a Discord client URL to the message. If the message doesn't
@@ -170,12 +185,34 @@ const (
 	StageStartMessage
 	StageEndMessage
 	StageSpeakerMessage
-	_
+	StageRaiseHandMessage
 	StageTopicMessage
 
 	GuildApplicationPremiumSubscriptionMessage
 )
 
+const (
+	// GuildIncidentAlertModeEnabledMessage is sent when a guild's security
+	// actions, such as pausing invites or DMs, are enabled.
+	GuildIncidentAlertModeEnabledMessage MessageType = iota + 36
+	// GuildIncidentAlertModeDisabledMessage is sent when a guild's security
+	// actions are disabled.
+	GuildIncidentAlertModeDisabledMessage
+	// GuildIncidentReportRaidMessage is sent when a raid is reported.
+	GuildIncidentReportRaidMessage
+	// GuildIncidentReportFalseAlarmMessage is sent when a reported raid is
+	// marked as a false alarm.
+	GuildIncidentReportFalseAlarmMessage
+)
+
+// PurchaseNotificationMessage is sent when a guild product is purchased. The
+// message has a PurchaseNotification.
+const PurchaseNotificationMessage MessageType = 44
+
+// PollResultMessage is sent when a poll ends. It references the message
+// containing the poll and has an embed with the results.
+const PollResultMessage MessageType = 46
+
 type MessageFlags enum.Enum
 
 // NullMessage is the JSON null value of MessageFlags.
@@ -207,12 +244,33 @@ const (
 	// MessageLoading specifies whether the message is an Interaction Response
 	// and the bot is "thinking"
 	MessageLoading
-	// TODO: add FailedToMentionSomeRolesInThread
+	// FailedToMentionSomeRolesInThread specifies whether the message failed
+	// to mention some roles and add their members to the thread.
+	FailedToMentionSomeRolesInThread
+)
 
-	// SuppressNotifications specifies whether the message will not trigger push and desktop notifications.
-	SuppressNotifications = 1 << 12
+const (
+	// SuppressNotifications specifies whether the message will not trigger
+	// push and desktop notifications. These are also known as @silent
+	// messages.
+	SuppressNotifications MessageFlags = 1 << (iota + 12)
+	// VoiceMessage specifies whether the message is a voice message.
+	VoiceMessage
+	// MessageHasSnapshot specifies whether the message has a snapshot (via
+	// Message Forwarding).
+	MessageHasSnapshot
+	// ComponentsV2 specifies whether the message uses the new components
+	// system, which allows laying out the message using components only.
+	// Content and embeds cannot be sent with this flag set, and it cannot be
+	// removed once set.
+	ComponentsV2
 )
 
+// Has returns true if f has all of the given flags.
+func (f MessageFlags) Has(flags MessageFlags) bool {
+	return f&flags == flags
+}
+
 // StickerItem contains partial data of a Sticker.
 //
 // https://discord.com/developers/docs/resources/sticker#sticker-item-object
@@ -225,11 +283,16 @@ type StickerItem struct {
 	FormatType StickerFormatType `json:"format_type"`
 }
 
-// StickerURLWithType returns the URL to the emoji's image.
+// CDN returns the CDN of the sticker, whose type depends on its format.
+func (s StickerItem) CDN() CDN {
+	return stickerCDN(s.ID, s.FormatType)
+}
+
+// StickerURLWithType returns the URL to the sticker's image.
 //
 // Supported ImageTypes: PNG
 func (s StickerItem) StickerURLWithType(t ImageType) string {
-	return "https://cdn.discordapp.com/stickers/" + t.format(s.ID.String())
+	return s.CDN().WithType(t).URL()
 }
 
 // https://discord.com/developers/docs/resources/channel#message-object-message-sticker-structure
@@ -280,11 +343,16 @@ func (s Sticker) TagList() []string {
 	return tags
 }
 
-// StickerURLWithType returns the URL to the emoji's image.
+// CDN returns the CDN of the sticker, whose type depends on its format.
+func (s Sticker) CDN() CDN {
+	return stickerCDN(s.ID, s.FormatType)
+}
+
+// StickerURLWithType returns the URL to the sticker's image.
 //
 // Supported ImageTypes: PNG
 func (s Sticker) StickerURLWithType(t ImageType) string {
-	return "https://cdn.discordapp.com/stickers/" + t.format(s.ID.String())
+	return s.CDN().WithType(t).URL()
 }
 
 type StickerType int
@@ -301,11 +369,29 @@ type StickerFormatType uint8
 
 // https://discord.com/developers/docs/resources/channel#message-object-message-sticker-format-types
 const (
-	StickerFormatPNG    = 1
-	StickerFormatAPNG   = 2
-	StickerFormatLottie = 3
+	StickerFormatPNG    StickerFormatType = 1
+	StickerFormatAPNG   StickerFormatType = 2
+	StickerFormatLottie StickerFormatType = 3
+	StickerFormatGIF    StickerFormatType = 4
 )
 
+// stickerCDN returns the CDN of the sticker with the given format. Lottie
+// stickers are JSON files, and APNG stickers are PNG files.
+func stickerCDN(id StickerID, format StickerFormatType) CDN {
+	c := CDN{Path: "stickers/" + id.String()}
+
+	switch format {
+	case StickerFormatLottie:
+		c.Type = ".json"
+	case StickerFormatGIF:
+		c.Animated = true
+	default:
+		c.Type = PNGImage
+	}
+
+	return c
+}
+
 // https://discord.com/developers/docs/resources/channel#channel-mention-object
 type ChannelMention struct {
 	// ChannelID is the ID of the channel.
@@ -365,7 +451,7 @@ func (m MessageApplication) CreatedAt() time.Time {
 	return m.ID.Time()
 }
 
-// MessageReference is used in four situations:
+// MessageReference is used in five situations:
 //
 // # Crosspost messages
 //
@@ -394,8 +480,23 @@ func (m MessageApplication) CreatedAt() time.Time {
 //
 // Replies are created by including a message_reference when sending a message.
 // When sending, only MessageID is required.
+//
+// # Forwards
+//
+// Messages forwarding another message have the ForwardMessageReference type,
+// MessageID and ChannelID, and GuildID if it is in a guild, with data of the
+// message that was forwarded. The forwarded message itself is in the
+// MessageSnapshots field of the forward.
+//
+// Forwards are created by including a message_reference of the
+// ForwardMessageReference type when sending a message. When sending, both
+// MessageID and ChannelID are required.
+//
 // https://discord.com/developers/docs/resources/channel#message-object-message-reference-structure
 type MessageReference struct {
+	// Type is the type of the reference. It defaults to
+	// DefaultMessageReference.
+	Type MessageReferenceType `json:"type,omitempty"`
 	// MessageID is the id of the originating message.
 	MessageID MessageID `json:"message_id,omitempty"`
 	// ChannelID is the id of the originating message's channel.
@@ -404,6 +505,59 @@ type MessageReference struct {
 	GuildID GuildID `json:"guild_id,omitempty"`
 }
 
+// MessageReferenceType is the type of a MessageReference, which determines
+// how the referenced message is used.
+//
+// https://discord.com/developers/docs/resources/message#message-reference-types
+type MessageReferenceType uint8
+
+const (
+	// DefaultMessageReference is a standard reference used by replies,
+	// crossposts, pins and channel follows.
+	DefaultMessageReference MessageReferenceType = iota
+	// ForwardMessageReference is a reference used to forward a message.
+	ForwardMessageReference
+)
+
+// MessageSnapshot is a snapshot of a message at the time that it was
+// forwarded.
+//
+// https://discord.com/developers/docs/resources/message#message-snapshot-object
+type MessageSnapshot struct {
+	// Message is the forwarded message. Only some of its fields are present;
+	// see MessageSnapshotMessage.
+	Message MessageSnapshotMessage `json:"message"`
+}
+
+// MessageSnapshotMessage is the partial message contained in a
+// MessageSnapshot. A snapshot does not contain the author or the channel of
+// the forwarded message; those are in the MessageReference of the forward.
+type MessageSnapshotMessage struct {
+	// Type is the type of message.
+	Type MessageType `json:"type"`
+	// Flags are the MessageFlags.
+	Flags MessageFlags `json:"flags"`
+	// Content contains the contents of the message.
+	Content string `json:"content"`
+	// Timestamp specifies when the message was sent.
+	Timestamp Timestamp `json:"timestamp,omitempty"`
+	// EditedTimestamp specifies when this message was edited.
+	EditedTimestamp Timestamp `json:"edited_timestamp,omitempty"`
+	// Mentions contains the users specifically mentioned in the message.
+	Mentions []User `json:"mentions"`
+	// MentionRoleIDs contains the ids of the roles specifically mentioned in
+	// the message.
+	MentionRoleIDs []RoleID `json:"mention_roles"`
+	// Attachments contains any attached files.
+	Attachments []Attachment `json:"attachments"`
+	// Embeds contains any embedded content.
+	Embeds []Embed `json:"embeds"`
+	// Components contains any attached components.
+	Components ContainerComponents `json:"components,omitempty"`
+	// Stickers contains the sticker "items" sent with the message.
+	Stickers []StickerItem `json:"sticker_items,omitempty"`
+}
+
 //
 
 // https://discord.com/developers/docs/interactions/receiving-and-responding#message-interaction-object-message-interaction-structure
@@ -452,9 +606,79 @@ type Attachment struct {
 	// attachments on messages are guaranteed to be available as long as
 	// the message itself exists.
 	Ephemeral bool `json:"ephemeral,omitempty"`
+
+	// DurationSecs is the duration of the audio file, if it is a voice
+	// message.
+	DurationSecs float64 `json:"duration_secs,omitempty"`
+	// Waveform is the base64-encoded byte array representing a sampled
+	// waveform, if it is a voice message.
+	Waveform string `json:"waveform,omitempty"`
+	// Flags are the attachment's flags.
+	Flags AttachmentFlags `json:"flags,omitempty"`
 }
 
+// AttachmentFlags are the flags of an attachment.
 //
+// https://discord.com/developers/docs/resources/message#attachment-object-attachment-flags
+type AttachmentFlags uint32
+
+const (
+	_ AttachmentFlags = 1 << iota
+	_
+	// AttachmentIsRemix specifies whether the attachment has been edited using
+	// the remix feature on mobile.
+	AttachmentIsRemix
+)
+
+// Has returns true if f has all of the given flags.
+func (f AttachmentFlags) Has(flags AttachmentFlags) bool {
+	return f&flags == flags
+}
+
+//
+
+// RoleSubscriptionData is the data of a role subscription purchase or renewal.
+//
+// https://discord.com/developers/docs/resources/message#role-subscription-data-object
+type RoleSubscriptionData struct {
+	// RoleSubscriptionListingID is the ID of the SKU and listing that the user
+	// is subscribed to.
+	RoleSubscriptionListingID Snowflake `json:"role_subscription_listing_id"`
+	// TierName is the name of the tier that the user is subscribed to.
+	TierName string `json:"tier_name"`
+	// TotalMonthsSubscribed is the cumulative number of months that the user
+	// has been subscribed for.
+	TotalMonthsSubscribed int `json:"total_months_subscribed"`
+	// IsRenewal is whether the message is for a subscription renewal rather
+	// than a new purchase.
+	IsRenewal bool `json:"is_renewal"`
+}
+
+// PurchaseNotification is the data of a purchase that prompted a message.
+type PurchaseNotification struct {
+	// Type is the type of the purchase.
+	Type PurchaseNotificationType `json:"type"`
+	// GuildProductPurchase is the purchased guild product, if Type is
+	// GuildProductPurchaseNotification.
+	GuildProductPurchase *GuildProductPurchase `json:"guild_product_purchase,omitempty"`
+}
+
+// PurchaseNotificationType is the type of a PurchaseNotification.
+type PurchaseNotificationType uint8
+
+const (
+	// GuildProductPurchaseNotification is the type of a notification for a
+	// guild product purchase.
+	GuildProductPurchaseNotification PurchaseNotificationType = iota
+)
+
+// GuildProductPurchase is a purchased guild product.
+type GuildProductPurchase struct {
+	// ListingID is the ID of the product listing.
+	ListingID Snowflake `json:"listing_id"`
+	// ProductName is the name of the product.
+	ProductName string `json:"product_name"`
+}
 
 // https://discord.com/developers/docs/resources/channel#reaction-object
 type Reaction struct {
@@ -464,8 +688,14 @@ type Reaction struct {
 	CountDetails ReactionCountDetails `json:"count_details"`
 	// Me specifies whether the current user reacted using this emoji.
 	Me bool `json:"me"`
+	// MeBurst specifies whether the current user super-reacted using this
+	// emoji.
+	MeBurst bool `json:"me_burst"`
 	// Emoji contains emoji information.
 	Emoji Emoji `json:"emoji"`
+	// BurstColors are the colors used for the super reaction, as hex strings
+	// such as "#ffffff".
+	BurstColors []string `json:"burst_colors,omitempty"`
 }
 
 // https://discord.com/developers/docs/resources/channel#reaction-count-details-object
@@ -475,3 +705,15 @@ type ReactionCountDetails struct {
 	// Normal is the count of normal reactions.
 	Normal int `json:"normal"`
 }
+
+// ReactionType is the type of a reaction.
+//
+// https://discord.com/developers/docs/resources/message#get-reactions-reaction-types
+type ReactionType uint8
+
+const (
+	// NormalReaction is a normal reaction.
+	NormalReaction ReactionType = iota
+	// BurstReaction is a burst (super) reaction.
+	BurstReaction
+)
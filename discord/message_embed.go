@@ -3,6 +3,8 @@ package discord
 import (
 	"fmt"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 // Color describes an RGB color (with NO alpha). If a value is -1, then it's
@@ -97,6 +99,22 @@ func NewEmbed() *Embed {
 	}
 }
 
+// Embed limits. Text lengths are counted in characters.
+const (
+	MaxEmbedTitle       = 256
+	MaxEmbedDescription = 4096
+	MaxEmbedFields      = 25
+	MaxEmbedFieldName   = 256
+	MaxEmbedFieldValue  = 1024
+	MaxEmbedFooterText  = 2048
+	MaxEmbedAuthorName  = 256
+	// MaxEmbedsLength is the maximum sum of the lengths of all embeds in a
+	// message.
+	MaxEmbedsLength = 6000
+	// MaxMessageEmbeds is the maximum number of embeds in a message.
+	MaxMessageEmbeds = 10
+)
+
 // Validate validates the embed.
 func (e *Embed) Validate() error {
 	if e.Type == "" {
@@ -107,66 +125,195 @@ func (e *Embed) Validate() error {
 		e.Color = DefaultEmbedColor
 	}
 
-	if len(e.Title) > 256 {
-		return &OverboundError{len(e.Title), 256, "title"}
+	if n := utf8.RuneCountInString(e.Title); n > MaxEmbedTitle {
+		return &OverboundError{n, MaxEmbedTitle, "title"}
 	}
 
-	if len(e.Description) > 4096 {
-		return &OverboundError{len(e.Description), 4096, "description"}
+	if n := utf8.RuneCountInString(e.Description); n > MaxEmbedDescription {
+		return &OverboundError{n, MaxEmbedDescription, "description"}
 	}
 
-	if len(e.Fields) > 25 {
-		return &OverboundError{len(e.Fields), 25, "fields"}
+	if len(e.Fields) > MaxEmbedFields {
+		return &OverboundError{len(e.Fields), MaxEmbedFields, "fields"}
 	}
 
 	if e.Footer != nil {
-		if len(e.Footer.Text) > 2048 {
-			return &OverboundError{len(e.Footer.Text), 2048, "footer text"}
+		if n := utf8.RuneCountInString(e.Footer.Text); n > MaxEmbedFooterText {
+			return &OverboundError{n, MaxEmbedFooterText, "footer text"}
 		}
 	}
 
 	if e.Author != nil {
-		if len(e.Author.Name) > 256 {
-			return &OverboundError{len(e.Author.Name), 256, "author name"}
+		if n := utf8.RuneCountInString(e.Author.Name); n > MaxEmbedAuthorName {
+			return &OverboundError{n, MaxEmbedAuthorName, "author name"}
 		}
 	}
 
 	for i, field := range e.Fields {
-		if len(field.Name) > 256 {
-			return &OverboundError{len(field.Name), 256,
+		if n := utf8.RuneCountInString(field.Name); n > MaxEmbedFieldName {
+			return &OverboundError{n, MaxEmbedFieldName,
 				fmt.Sprintf("field %d name", i)}
 		}
 
-		if len(field.Value) > 1024 {
-			return &OverboundError{len(field.Value), 1024,
+		if n := utf8.RuneCountInString(field.Value); n > MaxEmbedFieldValue {
+			return &OverboundError{n, MaxEmbedFieldValue,
 				fmt.Sprintf("field %d value", i)}
 		}
 	}
 
-	if sum := e.Length(); sum > 6000 {
-		return &OverboundError{sum, 6000, "sum of all characters"}
+	if sum := e.Length(); sum > MaxEmbedsLength {
+		return &OverboundError{sum, MaxEmbedsLength, "sum of all characters"}
 	}
 
 	return nil
 }
 
-// Length returns the sum of the lengths of all text in the embed.
+// Length returns the number of characters of all text in the embed.
 func (e Embed) Length() int {
 	var sum = 0 +
-		len(e.Title) +
-		len(e.Description)
+		utf8.RuneCountInString(e.Title) +
+		utf8.RuneCountInString(e.Description)
 	if e.Footer != nil {
-		sum += len(e.Footer.Text)
+		sum += utf8.RuneCountInString(e.Footer.Text)
 	}
 	if e.Author != nil {
-		sum += len(e.Author.Name)
+		sum += utf8.RuneCountInString(e.Author.Name)
 	}
 	for _, field := range e.Fields {
-		sum += len(field.Name) + len(field.Value)
+		sum += field.length()
 	}
 	return sum
 }
 
+// Split splits the embed into multiple embeds if its description or fields
+// don't fit into one. The description is split into chunks of at most
+// MaxEmbedDescription characters, preferably at line breaks, and field values
+// that are too long are continued in fields with blank names. The fields are
+// then spread over as many embeds as needed.
+//
+// The first embed keeps the title, author, URL and thumbnail, and the last
+// embed keeps the footer, image and timestamp. Every embed keeps the color.
+// Titles, author names, field names and footers that are too long are not
+// shortened, so they will still fail Validate.
+//
+// Each embed fits within MaxEmbedsLength on its own, but since the limit
+// applies to all embeds of a message, they may have to be sent in separate
+// messages.
+func (e Embed) Split() []Embed {
+	descs := splitText(e.Description, MaxEmbedDescription)
+
+	var fields []EmbedField
+	for _, field := range e.Fields {
+		for i, value := range splitText(field.Value, MaxEmbedFieldValue) {
+			f := EmbedField{Name: field.Name, Value: value, Inline: field.Inline}
+			if i > 0 {
+				f.Name = blankFieldName
+			}
+			fields = append(fields, f)
+		}
+	}
+
+	if len(descs) <= 1 && len(fields) == len(e.Fields) &&
+		len(fields) <= MaxEmbedFields && e.Length() <= MaxEmbedsLength {
+		return []Embed{e}
+	}
+
+	// Reserve room for the footer in every embed, since it's only known which
+	// embed is the last one at the end.
+	var footer int
+	if e.Footer != nil {
+		footer = utf8.RuneCountInString(e.Footer.Text)
+	}
+	budget := MaxEmbedsLength - footer
+
+	first := Embed{
+		Title:     e.Title,
+		Type:      e.Type,
+		URL:       e.URL,
+		Color:     e.Color,
+		Thumbnail: e.Thumbnail,
+		Video:     e.Video,
+		Provider:  e.Provider,
+		Author:    e.Author,
+	}
+
+	embeds := []Embed{first}
+	next := func() *Embed {
+		embeds = append(embeds, Embed{Type: e.Type, Color: e.Color})
+		return &embeds[len(embeds)-1]
+	}
+
+	current := &embeds[0]
+	for i, desc := range descs {
+		if i > 0 {
+			current = next()
+		}
+		current.Description = desc
+	}
+
+	for _, field := range fields {
+		if len(current.Fields) >= MaxEmbedFields ||
+			current.Length()+field.length() > budget {
+			current = next()
+		}
+		current.Fields = append(current.Fields, field)
+	}
+
+	// The fields have room for the footer, but the title, author and
+	// description might not.
+	if current.Length()+footer > MaxEmbedsLength {
+		current = next()
+	}
+
+	current.Footer = e.Footer
+	current.Image = e.Image
+	current.Timestamp = e.Timestamp
+
+	return embeds
+}
+
+// blankFieldName is the name of fields that continue the value of the previous
+// field. Field names cannot be empty, so a zero-width space is used.
+const blankFieldName = "\u200b"
+
+func (f EmbedField) length() int {
+	return utf8.RuneCountInString(f.Name) + utf8.RuneCountInString(f.Value)
+}
+
+// splitText splits s into chunks of at most max characters. Each chunk ends at
+// the last line break or else the last space that fits, if there is one.
+func splitText(s string, max int) []string {
+	var chunks []string
+
+	for utf8.RuneCountInString(s) > max {
+		// Find the byte offset of the max-th character.
+		end := 0
+		for i := 0; i < max; i++ {
+			_, size := utf8.DecodeRuneInString(s[end:])
+			end += size
+		}
+
+		cut := strings.LastIndexByte(s[:end], '\n')
+		if cut <= 0 {
+			cut = strings.LastIndexByte(s[:end], ' ')
+		}
+		if cut <= 0 {
+			chunks = append(chunks, s[:end])
+			s = s[end:]
+			continue
+		}
+
+		chunks = append(chunks, s[:cut])
+		s = s[cut+1:]
+	}
+
+	if s != "" || len(chunks) == 0 {
+		chunks = append(chunks, s)
+	}
+
+	return chunks
+}
+
 // EmbedTypes are "loosely defined" and, for the most part, are not used by our
 // clients for rendering. Embed attributes power what is rendered.
 //
@@ -0,0 +1,129 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEmbedLength(t *testing.T) {
+	e := Embed{
+		Title:  "héllo",
+		Footer: &EmbedFooter{Text: "日本"},
+		Fields: []EmbedField{{Name: "a", Value: "bc"}},
+	}
+
+	if l := e.Length(); l != 10 {
+		t.Fatalf("expected length 10, got %d", l)
+	}
+}
+
+func TestEmbedSplit(t *testing.T) {
+	t.Run("fits", func(t *testing.T) {
+		e := Embed{Title: "title", Description: "description"}
+		if embeds := e.Split(); len(embeds) != 1 || embeds[0].Description != e.Description {
+			t.Fatalf("unexpected split: %+v", embeds)
+		}
+	})
+
+	t.Run("description", func(t *testing.T) {
+		line := strings.Repeat("a", 99) + "\n"
+		e := Embed{
+			Title:       "title",
+			Description: strings.Repeat(line, 50), // 5000 characters
+			Footer:      &EmbedFooter{Text: "footer"},
+			Color:       0xFF0000,
+		}
+
+		embeds := e.Split()
+		if len(embeds) != 2 {
+			t.Fatalf("expected 2 embeds, got %d", len(embeds))
+		}
+
+		if embeds[0].Title != "title" || embeds[1].Title != "" {
+			t.Error("title is not only in the first embed")
+		}
+		if embeds[0].Footer != nil || embeds[1].Footer == nil {
+			t.Error("footer is not only in the last embed")
+		}
+		if embeds[1].Color != e.Color {
+			t.Error("color is not kept")
+		}
+		if !strings.HasSuffix(embeds[0].Description, "a") {
+			t.Error("description is not split at a line break")
+		}
+
+		assertEmbedsValid(t, embeds)
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		e := Embed{Title: "title"}
+		for i := 0; i < 30; i++ {
+			e.Fields = append(e.Fields, EmbedField{Name: "name", Value: strings.Repeat("v", 300)})
+		}
+		e.Fields[0].Value = strings.Repeat("word ", 300) // 1500 characters
+
+		embeds := e.Split()
+		if len(embeds) != 2 {
+			t.Fatalf("expected 2 embeds, got %d", len(embeds))
+		}
+
+		var fields int
+		for _, embed := range embeds {
+			fields += len(embed.Fields)
+		}
+		if fields != 31 {
+			t.Fatalf("expected 31 fields, got %d", fields)
+		}
+
+		if name := embeds[0].Fields[1].Name; name != blankFieldName {
+			t.Fatalf("expected continued field to have a blank name, got %q", name)
+		}
+
+		assertEmbedsValid(t, embeds)
+	})
+
+	t.Run("footer", func(t *testing.T) {
+		e := Embed{
+			Title:       strings.Repeat("t", 256),
+			Description: strings.Repeat("d", 4000),
+			Footer:      &EmbedFooter{Text: strings.Repeat("f", 2000)},
+		}
+
+		embeds := e.Split()
+		if len(embeds) != 2 {
+			t.Fatalf("expected 2 embeds, got %d", len(embeds))
+		}
+		if embeds[0].Description != e.Description || embeds[1].Footer == nil {
+			t.Fatal("footer is not moved onto its own embed")
+		}
+
+		assertEmbedsValid(t, embeds)
+	})
+}
+
+func assertEmbedsValid(t *testing.T, embeds []Embed) {
+	t.Helper()
+
+	for i, embed := range embeds {
+		if n := embed.Length(); n > MaxEmbedsLength {
+			t.Errorf("embed %d is %d characters long", i, n)
+		}
+		if err := embed.Validate(); err != nil {
+			t.Errorf("embed %d is invalid: %v", i, err)
+		}
+	}
+}
+
+func TestSplitText(t *testing.T) {
+	s := strings.Repeat("日", 10)
+	chunks := splitText(s, 4)
+	if len(chunks) != 3 || chunks[2] != "日日" {
+		t.Fatalf("unexpected chunks: %q", chunks)
+	}
+	for _, chunk := range chunks {
+		if !utf8.ValidString(chunk) {
+			t.Fatalf("chunk %q is not valid UTF-8", chunk)
+		}
+	}
+}
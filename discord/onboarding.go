@@ -0,0 +1,113 @@
+package discord
+
+import "github.com/diamondburned/arikawa/v3/utils/json"
+
+// GuildOnboarding is the onboarding flow of a guild, which is shown to new
+// members when they join.
+//
+// https://discord.com/developers/docs/resources/guild#guild-onboarding-object
+type GuildOnboarding struct {
+	// GuildID is the ID of the guild that the onboarding is part of.
+	GuildID GuildID `json:"guild_id"`
+	// Prompts are the prompts shown during onboarding and in Customize
+	// Community.
+	Prompts []OnboardingPrompt `json:"prompts"`
+	// DefaultChannelIDs are the channels that members get opted into
+	// automatically.
+	DefaultChannelIDs []ChannelID `json:"default_channel_ids"`
+	// Enabled is whether onboarding is enabled in the guild.
+	Enabled bool `json:"enabled"`
+	// Mode is the criteria that is used to determine whether onboarding can
+	// be enabled.
+	Mode OnboardingMode `json:"mode"`
+}
+
+// OnboardingMode defines the criteria used to satisfy the onboarding
+// constraints that are required for enabling it.
+//
+// https://discord.com/developers/docs/resources/guild#guild-onboarding-object-onboarding-mode
+type OnboardingMode uint8
+
+const (
+	// OnboardingDefault only counts the default channels towards the
+	// constraints.
+	OnboardingDefault OnboardingMode = iota
+	// OnboardingAdvanced counts the default channels and the questions
+	// towards the constraints.
+	OnboardingAdvanced
+)
+
+// OnboardingPrompt is a question that is asked during onboarding.
+//
+// https://discord.com/developers/docs/resources/guild#guild-onboarding-object-onboarding-prompt-structure
+type OnboardingPrompt struct {
+	// ID is the ID of the prompt.
+	ID Snowflake `json:"id"`
+	// Type is the type of the prompt.
+	Type OnboardingPromptType `json:"type"`
+	// Options are the options that are available within the prompt.
+	Options []OnboardingPromptOption `json:"options"`
+	// Title is the title of the prompt.
+	Title string `json:"title"`
+	// SingleSelect is whether users are limited to selecting one option.
+	SingleSelect bool `json:"single_select"`
+	// Required is whether the prompt is required before a user completes the
+	// onboarding flow.
+	Required bool `json:"required"`
+	// InOnboarding is whether the prompt is present in the onboarding flow.
+	// If false, the prompt will only appear in the Channels & Roles tab.
+	InOnboarding bool `json:"in_onboarding"`
+}
+
+// OnboardingPromptType is the type of an OnboardingPrompt.
+//
+// https://discord.com/developers/docs/resources/guild#guild-onboarding-object-prompt-types
+type OnboardingPromptType uint8
+
+const (
+	MultipleChoicePrompt OnboardingPromptType = iota
+	DropdownPrompt
+)
+
+// OnboardingPromptOption is an option that can be chosen in an
+// OnboardingPrompt.
+//
+// https://discord.com/developers/docs/resources/guild#guild-onboarding-object-prompt-option-structure
+type OnboardingPromptOption struct {
+	// ID is the ID of the option.
+	ID Snowflake `json:"id"`
+	// ChannelIDs are the channels that a member is added to when the option
+	// is selected.
+	ChannelIDs []ChannelID `json:"channel_ids"`
+	// RoleIDs are the roles that are assigned to a member when the option is
+	// selected.
+	RoleIDs []RoleID `json:"role_ids"`
+	// Emoji is the emoji of the option, if any. Only its ID, Name and
+	// Animated fields are used.
+	Emoji *Emoji `json:"emoji,omitempty"`
+	// Title is the title of the option.
+	Title string `json:"title"`
+	// Description is the description of the option, if any.
+	Description string `json:"description,omitempty"`
+}
+
+// MarshalJSON marshals the option in the format that Discord expects when
+// modifying the onboarding, which sends the emoji as separate fields.
+func (o OnboardingPromptOption) MarshalJSON() ([]byte, error) {
+	type raw OnboardingPromptOption
+
+	msg := struct {
+		raw
+		EmojiID       EmojiID `json:"emoji_id,omitempty"`
+		EmojiName     string  `json:"emoji_name,omitempty"`
+		EmojiAnimated bool    `json:"emoji_animated,omitempty"`
+	}{raw: raw(o)}
+
+	if o.Emoji != nil {
+		msg.EmojiID = o.Emoji.ID
+		msg.EmojiName = o.Emoji.Name
+		msg.EmojiAnimated = o.Emoji.Animated
+	}
+
+	return json.Marshal(msg)
+}
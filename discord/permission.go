@@ -1,5 +1,7 @@
 package discord
 
+import "strconv"
+
 type Permissions uint64
 
 // https://discord.com/developers/docs/topics/permissions#permissions-bitwise-permission-flags
@@ -95,12 +97,22 @@ const (
 	PermissionViewCreatorMonetizationAnalytics
 	// Allows for using soundboard in a voice channel
 	PermissionUseSoundboard
-	_
-	_
+	// Allows for creating emojis, stickers and soundboard sounds, and editing
+	// and deleting those created by the current user
+	PermissionCreateGuildExpressions
+	// Allows for creating scheduled events, and editing and deleting those
+	// created by the current user
+	PermissionCreateEvents
 	// Allows the usage of custom soundboard sounds from other servers
 	PermissionUseExternalSounds
 	// Allows sending voice messages
 	PermissionSendVoiceMessages
+	_
+	_
+	// Allows sending polls
+	PermissionSendPolls
+	// Allows user-installed apps to send public responses
+	PermissionUseExternalApps
 
 	PermissionAllText = 0 |
 		PermissionViewChannel |
@@ -118,7 +130,10 @@ const (
 		PermissionCreatePrivateThreads |
 		PermissionUseExternalStickers |
 		PermissionAddReactions |
-		PermissionSendMessagesInThreads
+		PermissionSendMessagesInThreads |
+		PermissionSendVoiceMessages |
+		PermissionSendPolls |
+		PermissionUseExternalApps
 
 	PermissionAllVoice = 0 |
 		PermissionViewChannel |
@@ -131,7 +146,9 @@ const (
 		PermissionUseVAD |
 		PermissionPrioritySpeaker |
 		PermissionRequestToSpeak |
-		PermissionStartEmbeddedActivities
+		PermissionStartEmbeddedActivities |
+		PermissionUseSoundboard |
+		PermissionUseExternalSounds
 
 	PermissionAllChannel = 0 |
 		PermissionAllText |
@@ -151,7 +168,12 @@ const (
 		PermissionManageNicknames |
 		PermissionChangeNickname |
 		PermissionViewAuditLog |
-		PermissionManageEvents
+		PermissionManageEvents |
+		PermissionViewGuildInsights |
+		PermissionModerateMembers |
+		PermissionViewCreatorMonetizationAnalytics |
+		PermissionCreateGuildExpressions |
+		PermissionCreateEvents
 )
 
 func NewPermissions(p ...Permissions) *Permissions {
@@ -162,6 +184,7 @@ func NewPermissions(p ...Permissions) *Permissions {
 	return &perm
 }
 
+// Has returns true if p has all of the given permissions.
 func (p Permissions) Has(perm Permissions) bool {
 	return HasFlag(uint64(p), uint64(perm))
 }
@@ -170,15 +193,108 @@ func (p Permissions) Add(perm Permissions) Permissions {
 	return p | perm
 }
 
-// CalcOverrides calculates the permissions for a member in the given channel.
-// Most of the time, you should use state.State.Permissions instead.
-func CalcOverrides(
-	guild Guild, channel Channel, member Member, roles []Role) Permissions {
+// permissionNames are the names of the permissions as documented by Discord,
+// indexed by their bit.
+var permissionNames = [...]string{
+	"CREATE_INSTANT_INVITE",
+	"KICK_MEMBERS",
+	"BAN_MEMBERS",
+	"ADMINISTRATOR",
+	"MANAGE_CHANNELS",
+	"MANAGE_GUILD",
+	"ADD_REACTIONS",
+	"VIEW_AUDIT_LOG",
+	"PRIORITY_SPEAKER",
+	"STREAM",
+	"VIEW_CHANNEL",
+	"SEND_MESSAGES",
+	"SEND_TTS_MESSAGES",
+	"MANAGE_MESSAGES",
+	"EMBED_LINKS",
+	"ATTACH_FILES",
+	"READ_MESSAGE_HISTORY",
+	"MENTION_EVERYONE",
+	"USE_EXTERNAL_EMOJIS",
+	"VIEW_GUILD_INSIGHTS",
+	"CONNECT",
+	"SPEAK",
+	"MUTE_MEMBERS",
+	"DEAFEN_MEMBERS",
+	"MOVE_MEMBERS",
+	"USE_VAD",
+	"CHANGE_NICKNAME",
+	"MANAGE_NICKNAMES",
+	"MANAGE_ROLES",
+	"MANAGE_WEBHOOKS",
+	"MANAGE_GUILD_EXPRESSIONS",
+	"USE_APPLICATION_COMMANDS",
+	"REQUEST_TO_SPEAK",
+	"MANAGE_EVENTS",
+	"MANAGE_THREADS",
+	"CREATE_PUBLIC_THREADS",
+	"CREATE_PRIVATE_THREADS",
+	"USE_EXTERNAL_STICKERS",
+	"SEND_MESSAGES_IN_THREADS",
+	"USE_EMBEDDED_ACTIVITIES",
+	"MODERATE_MEMBERS",
+	"VIEW_CREATOR_MONETIZATION_ANALYTICS",
+	"USE_SOUNDBOARD",
+	"CREATE_GUILD_EXPRESSIONS",
+	"CREATE_EVENTS",
+	"USE_EXTERNAL_SOUNDS",
+	"SEND_VOICE_MESSAGES",
+	"",
+	"",
+	"SEND_POLLS",
+	"USE_EXTERNAL_APPS",
+}
+
+// Describe returns the names of the permissions in p as documented by Discord,
+// such as "SEND_MESSAGES", in the order of their bits. Unknown permissions are
+// described by their bit, such as "1<<60".
+func (p Permissions) Describe() []string {
+	var names []string
+	for bit := uint(0); bit < 64; bit++ {
+		if p&(1<<bit) == 0 {
+			continue
+		}
+		if bit < uint(len(permissionNames)) && permissionNames[bit] != "" {
+			names = append(names, permissionNames[bit])
+		} else {
+			names = append(names, "1<<"+strconv.FormatUint(uint64(bit), 10))
+		}
+	}
+	return names
+}
 
+// CalcGuildPermissions calculates the permissions that the member has in the
+// guild, without any channel overwrites. roles must contain the guild's
+// @everyone role and the roles of the member.
+//
+// The guild owner and members with PermissionAdministrator have PermissionAll.
+// Members that are timed out only have PermissionViewChannel and
+// PermissionReadMessageHistory.
+func CalcGuildPermissions(guild Guild, member Member, roles []Role) Permissions {
 	if guild.OwnerID == member.User.ID {
 		return PermissionAll
 	}
 
+	perm := basePermissions(guild, member, roles)
+	if perm.Has(PermissionAdministrator) {
+		return PermissionAll
+	}
+
+	if member.IsTimedOut() {
+		perm &= timedOutPermissions
+	}
+
+	return perm
+}
+
+// timedOutPermissions are the only permissions that a timed out member keeps.
+const timedOutPermissions = PermissionViewChannel | PermissionReadMessageHistory
+
+func basePermissions(guild Guild, member Member, roles []Role) Permissions {
 	var perm Permissions
 
 	for _, role := range roles {
@@ -197,6 +313,26 @@ func CalcOverrides(
 		}
 	}
 
+	return perm
+}
+
+// CalcOverrides calculates the permissions for a member in the given channel.
+// Most of the time, you should use state.State.Permissions instead. For
+// threads, the parent channel must be given, since threads have no overwrites
+// of their own.
+//
+// Like CalcGuildPermissions, the guild owner and administrators have
+// PermissionAll, and timed out members only keep PermissionViewChannel and
+// PermissionReadMessageHistory. Permissions that depend on others are also
+// removed, such as every permission if the member can't view the channel.
+func CalcOverrides(
+	guild Guild, channel Channel, member Member, roles []Role) Permissions {
+
+	if guild.OwnerID == member.User.ID {
+		return PermissionAll
+	}
+
+	perm := basePermissions(guild, member, roles)
 	if perm.Has(PermissionAdministrator) {
 		return PermissionAll
 	}
@@ -236,5 +372,87 @@ func CalcOverrides(
 		return PermissionAll
 	}
 
+	if member.IsTimedOut() {
+		perm &= timedOutPermissions
+	}
+
+	// Members that can't view a channel can't do anything else in it, and
+	// members that can't send messages can't do anything that needs sending
+	// a message.
+	if !perm.Has(PermissionViewChannel) {
+		return 0
+	}
+	if !perm.Has(PermissionSendMessages) {
+		perm &^= PermissionMentionEveryone |
+			PermissionSendTTSMessages |
+			PermissionAttachFiles |
+			PermissionEmbedLinks
+	}
+
 	return perm
 }
+
+// HighestRole returns the role of the member with the highest position, or nil
+// if the member has no roles. Roles with the same position are ordered by
+// their IDs, like in the Discord client.
+func HighestRole(member Member, roles []Role) *Role {
+	var highest *Role
+
+	for i, role := range roles {
+		for _, id := range member.RoleIDs {
+			if id == role.ID {
+				if highest == nil || roleAbove(role, *highest) {
+					highest = &roles[i]
+				}
+				break
+			}
+		}
+	}
+
+	return highest
+}
+
+// roleAbove returns true if role a is above role b in the role hierarchy.
+func roleAbove(a, b Role) bool {
+	if a.Position != b.Position {
+		return a.Position > b.Position
+	}
+	return a.ID < b.ID
+}
+
+// CanManageRole returns true if the role is below the highest role of the
+// member, which is needed for the member to assign, edit or delete it. The
+// guild owner can manage every role. The member must still have
+// PermissionManageRoles.
+func CanManageRole(guild Guild, member Member, role Role, roles []Role) bool {
+	if guild.OwnerID == member.User.ID {
+		return true
+	}
+
+	highest := HighestRole(member, roles)
+	return highest != nil && roleAbove(*highest, role)
+}
+
+// CanModerate returns true if the moderator is above the target in the role
+// hierarchy, which is needed to kick, ban, time out or change the nickname of
+// the target. The guild owner can moderate everyone else, and nobody can
+// moderate the guild owner. The moderator must still have the permission for
+// the action.
+func CanModerate(guild Guild, moderator, target Member, roles []Role) bool {
+	switch {
+	case moderator.User.ID == target.User.ID:
+		return false
+	case guild.OwnerID == target.User.ID:
+		return false
+	case guild.OwnerID == moderator.User.ID:
+		return true
+	}
+
+	mod := HighestRole(moderator, roles)
+	if mod == nil {
+		return false
+	}
+
+	tgt := HighestRole(target, roles)
+	return tgt == nil || roleAbove(*mod, *tgt)
+}
@@ -0,0 +1,117 @@
+package discord
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPermissionsDescribe(t *testing.T) {
+	if PermissionSendVoiceMessages != 1<<46 || PermissionUseExternalApps != 1<<50 {
+		t.Fatal("permission bits are misaligned")
+	}
+
+	perm := PermissionSendMessages | PermissionSendVoiceMessages | 1<<60
+	expect := []string{"SEND_MESSAGES", "SEND_VOICE_MESSAGES", "1<<60"}
+
+	if names := perm.Describe(); !reflect.DeepEqual(names, expect) {
+		t.Fatalf("expected %q, got %q", expect, names)
+	}
+}
+
+func TestCalcOverrides(t *testing.T) {
+	guild := Guild{ID: 1, OwnerID: 100}
+	roles := []Role{
+		{ID: 1, Permissions: PermissionViewChannel | PermissionSendMessages | PermissionAttachFiles},
+		{ID: 2, Position: 2, Permissions: PermissionKickMembers},
+		{ID: 3, Position: 1, Permissions: PermissionAdministrator},
+	}
+
+	member := Member{User: User{ID: 200}, RoleIDs: []RoleID{2}}
+
+	tests := []struct {
+		name    string
+		member  Member
+		channel Channel
+		expect  Permissions
+	}{
+		{
+			name:   "base",
+			member: member,
+			expect: PermissionViewChannel | PermissionSendMessages | PermissionAttachFiles |
+				PermissionKickMembers,
+		},
+		{
+			name:   "owner",
+			member: Member{User: User{ID: 100}},
+			expect: PermissionAll,
+		},
+		{
+			name:   "administrator",
+			member: Member{User: User{ID: 300}, RoleIDs: []RoleID{3}},
+			channel: Channel{Overwrites: []Overwrite{
+				{ID: 1, Type: OverwriteRole, Deny: PermissionViewChannel},
+			}},
+			expect: PermissionAll,
+		},
+		{
+			name:   "denied send",
+			member: member,
+			channel: Channel{Overwrites: []Overwrite{
+				{ID: 2, Type: OverwriteRole, Deny: PermissionSendMessages},
+			}},
+			expect: PermissionViewChannel | PermissionKickMembers,
+		},
+		{
+			name:   "denied view",
+			member: member,
+			channel: Channel{Overwrites: []Overwrite{
+				{ID: 200, Type: OverwriteMember, Deny: PermissionViewChannel},
+			}},
+			expect: 0,
+		},
+		{
+			name: "timed out",
+			member: Member{
+				User:                       User{ID: 200},
+				RoleIDs:                    []RoleID{2},
+				CommunicationDisabledUntil: NewTimestamp(time.Now().Add(time.Hour)),
+			},
+			expect: PermissionViewChannel,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			perm := CalcOverrides(guild, test.channel, test.member, roles)
+			if perm != test.expect {
+				t.Fatalf("expected %q, got %q", test.expect.Describe(), perm.Describe())
+			}
+		})
+	}
+}
+
+func TestCanModerate(t *testing.T) {
+	guild := Guild{ID: 1, OwnerID: 100}
+	roles := []Role{
+		{ID: 2, Position: 2},
+		{ID: 3, Position: 1},
+	}
+
+	owner := Member{User: User{ID: 100}}
+	high := Member{User: User{ID: 200}, RoleIDs: []RoleID{2, 3}}
+	low := Member{User: User{ID: 300}, RoleIDs: []RoleID{3}}
+
+	switch {
+	case !CanModerate(guild, high, low, roles):
+		t.Error("higher member cannot moderate lower member")
+	case CanModerate(guild, low, high, roles):
+		t.Error("lower member can moderate higher member")
+	case CanModerate(guild, high, owner, roles):
+		t.Error("member can moderate the owner")
+	case !CanModerate(guild, owner, high, roles):
+		t.Error("owner cannot moderate member")
+	case CanManageRole(guild, low, roles[1], roles):
+		t.Error("member can manage their highest role")
+	}
+}
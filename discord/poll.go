@@ -0,0 +1,110 @@
+package discord
+
+// Poll limits.
+const (
+	MaxPollAnswers        = 10
+	MaxPollQuestionLength = 300
+	MaxPollAnswerLength   = 55
+	// MaxPollDuration is the maximum duration of a poll in hours.
+	MaxPollDuration = 32 * 24
+)
+
+// Poll is a poll attached to a message.
+//
+// https://discord.com/developers/docs/resources/poll#poll-object
+type Poll struct {
+	// Question is the question of the poll. Only Text is supported.
+	Question PollMedia `json:"question"`
+	// Answers are the answers available in the poll.
+	Answers []PollAnswer `json:"answers"`
+	// Expiry is the time when the poll ends. It is invalid for polls that
+	// never expire, which are not yet supported by Discord.
+	Expiry Timestamp `json:"expiry,omitempty"`
+	// AllowMultiselect specifies whether a user can select multiple answers.
+	AllowMultiselect bool `json:"allow_multiselect"`
+	// LayoutType is the layout type of the poll.
+	LayoutType PollLayoutType `json:"layout_type"`
+	// Results are the results of the poll. It may be nil, in which case the
+	// results are unknown, such as when the poll has just been created.
+	Results *PollResults `json:"results,omitempty"`
+}
+
+// Answer returns the answer with the given ID, or nil if there is none.
+func (p Poll) Answer(id int) *PollAnswer {
+	for i := range p.Answers {
+		if p.Answers[i].AnswerID == id {
+			return &p.Answers[i]
+		}
+	}
+	return nil
+}
+
+// PollLayoutType is the layout type of a poll.
+//
+// https://discord.com/developers/docs/resources/poll#layout-type
+type PollLayoutType uint8
+
+const (
+	_ PollLayoutType = iota
+	// DefaultPollLayout is the default layout type.
+	DefaultPollLayout
+)
+
+// PollMedia is the content of a poll's question or answer.
+//
+// https://discord.com/developers/docs/resources/poll#poll-media-object
+type PollMedia struct {
+	// Text is the text of the field. It is always set for questions, and is
+	// a maximum of 300 characters long for questions and 55 characters long
+	// for answers.
+	Text string `json:"text,omitempty"`
+	// Emoji is the emoji of the field. It is only supported for answers. When
+	// creating a poll, only either the ID of a custom emoji or the Name of a
+	// Unicode emoji should be set.
+	Emoji *ComponentEmoji `json:"emoji,omitempty"`
+}
+
+// PollAnswer is an answer of a poll.
+//
+// https://discord.com/developers/docs/resources/poll#poll-answer-object
+type PollAnswer struct {
+	// AnswerID is the ID of the answer. It is only sent by Discord, and is
+	// usually the index of the answer starting from 1.
+	AnswerID int `json:"answer_id,omitempty"`
+	// PollMedia is the content of the answer.
+	PollMedia PollMedia `json:"poll_media"`
+}
+
+// PollResults are the results of a poll. While a poll is in progress, the
+// results may not be exact.
+//
+// https://discord.com/developers/docs/resources/poll#poll-results-object
+type PollResults struct {
+	// IsFinalized specifies whether the votes have been precisely counted.
+	IsFinalized bool `json:"is_finalized"`
+	// AnswerCounts are the counts of each answer. Answers without any votes
+	// are omitted.
+	AnswerCounts []PollAnswerCount `json:"answer_counts"`
+}
+
+// Count returns the number of votes for the answer with the given ID.
+func (r PollResults) Count(answerID int) int {
+	for _, count := range r.AnswerCounts {
+		if count.ID == answerID {
+			return count.Count
+		}
+	}
+	return 0
+}
+
+// PollAnswerCount is the number of votes for an answer.
+//
+// https://discord.com/developers/docs/resources/poll#poll-results-object-poll-answer-count-object-structure
+type PollAnswerCount struct {
+	// ID is the ID of the answer.
+	ID int `json:"id"`
+	// Count is the number of votes for the answer.
+	Count int `json:"count"`
+	// MeVoted specifies whether the current user voted for the answer.
+	MeVoted bool `json:"me_voted"`
+}
@@ -15,7 +15,7 @@ func DurationSinceEpoch(t time.Time) time.Duration {
 	return time.Duration(t.UnixNano()) - Epoch
 }
 
-//go:generate go run ../utils/cmd/gensnowflake -o snowflake_types.go AppID AttachmentID AuditLogEntryID ChannelID CommandID EmojiID GuildID IntegrationID InteractionID MessageID RoleID StageID StickerID StickerPackID TagID TeamID UserID WebhookID EventID EntityID
+//go:generate go run ../utils/cmd/gensnowflake -o snowflake_types.go AppID AttachmentID AuditLogEntryID ChannelID CommandID EmojiID GuildID IntegrationID InteractionID MessageID RoleID StageID StickerID StickerPackID TagID TeamID UserID WebhookID EventID EntityID EntitlementID SKUID SubscriptionID AutoModerationRuleID SoundID
 
 // Mention generates the mention syntax for this channel ID.
 func (s ChannelID) Mention() string { return "<#" + s.String() + ">" }
@@ -26,6 +26,11 @@ func (s RoleID) Mention() string { return "<@&" + s.String() + ">" }
 // Mention generates the mention syntax for this user ID.
 func (s UserID) Mention() string { return "<@" + s.String() + ">" }
 
+// Mention generates the mention syntax for the command with this ID and the
+// given name. Subcommands are mentioned using their full name, such as
+// "tag get".
+func (s CommandID) Mention(name string) string { return "</" + name + ":" + s.String() + ">" }
+
 // Snowflake is the format of Discord's ID type. It is a format that can be
 // sorted chronologically.
 type Snowflake uint64
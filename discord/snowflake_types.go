@@ -483,3 +483,123 @@ func (s EntityID) Time() time.Time   { return Snowflake(s).Time() }
 func (s EntityID) Worker() uint8     { return Snowflake(s).Worker() }
 func (s EntityID) PID() uint8        { return Snowflake(s).PID() }
 func (s EntityID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// EntitlementID is the snowflake type for a EntitlementID.
+type EntitlementID Snowflake
+
+// NullEntitlementID gets encoded into a null. This is used for optional and nullable snowflake fields.
+const NullEntitlementID = EntitlementID(NullSnowflake)
+
+func (s EntitlementID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *EntitlementID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s EntitlementID) String() string { return Snowflake(s).String() }
+
+// IsValid returns whether or not the snowflake is valid.
+func (s EntitlementID) IsValid() bool { return Snowflake(s).IsValid() }
+
+// IsNull returns whether or not the snowflake is null. This method is rarely
+// ever useful; most people should use IsValid instead.
+func (s EntitlementID) IsNull() bool { return Snowflake(s).IsNull() }
+
+func (s EntitlementID) Time() time.Time   { return Snowflake(s).Time() }
+func (s EntitlementID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s EntitlementID) PID() uint8        { return Snowflake(s).PID() }
+func (s EntitlementID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// SKUID is the snowflake type for a SKUID.
+type SKUID Snowflake
+
+// NullSKUID gets encoded into a null. This is used for optional and nullable snowflake fields.
+const NullSKUID = SKUID(NullSnowflake)
+
+func (s SKUID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *SKUID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s SKUID) String() string { return Snowflake(s).String() }
+
+// IsValid returns whether or not the snowflake is valid.
+func (s SKUID) IsValid() bool { return Snowflake(s).IsValid() }
+
+// IsNull returns whether or not the snowflake is null. This method is rarely
+// ever useful; most people should use IsValid instead.
+func (s SKUID) IsNull() bool { return Snowflake(s).IsNull() }
+
+func (s SKUID) Time() time.Time   { return Snowflake(s).Time() }
+func (s SKUID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s SKUID) PID() uint8        { return Snowflake(s).PID() }
+func (s SKUID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// SubscriptionID is the snowflake type for a SubscriptionID.
+type SubscriptionID Snowflake
+
+// NullSubscriptionID gets encoded into a null. This is used for optional and nullable snowflake fields.
+const NullSubscriptionID = SubscriptionID(NullSnowflake)
+
+func (s SubscriptionID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *SubscriptionID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s SubscriptionID) String() string { return Snowflake(s).String() }
+
+// IsValid returns whether or not the snowflake is valid.
+func (s SubscriptionID) IsValid() bool { return Snowflake(s).IsValid() }
+
+// IsNull returns whether or not the snowflake is null. This method is rarely
+// ever useful; most people should use IsValid instead.
+func (s SubscriptionID) IsNull() bool { return Snowflake(s).IsNull() }
+
+func (s SubscriptionID) Time() time.Time   { return Snowflake(s).Time() }
+func (s SubscriptionID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s SubscriptionID) PID() uint8        { return Snowflake(s).PID() }
+func (s SubscriptionID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// AutoModerationRuleID is the snowflake type for a AutoModerationRuleID.
+type AutoModerationRuleID Snowflake
+
+// NullAutoModerationRuleID gets encoded into a null. This is used for optional and nullable snowflake fields.
+const NullAutoModerationRuleID = AutoModerationRuleID(NullSnowflake)
+
+func (s AutoModerationRuleID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *AutoModerationRuleID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s AutoModerationRuleID) String() string { return Snowflake(s).String() }
+
+// IsValid returns whether or not the snowflake is valid.
+func (s AutoModerationRuleID) IsValid() bool { return Snowflake(s).IsValid() }
+
+// IsNull returns whether or not the snowflake is null. This method is rarely
+// ever useful; most people should use IsValid instead.
+func (s AutoModerationRuleID) IsNull() bool { return Snowflake(s).IsNull() }
+
+func (s AutoModerationRuleID) Time() time.Time   { return Snowflake(s).Time() }
+func (s AutoModerationRuleID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s AutoModerationRuleID) PID() uint8        { return Snowflake(s).PID() }
+func (s AutoModerationRuleID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// SoundID is the snowflake type for a SoundID.
+type SoundID Snowflake
+
+// NullSoundID gets encoded into a null. This is used for optional and nullable snowflake fields.
+const NullSoundID = SoundID(NullSnowflake)
+
+func (s SoundID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *SoundID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s SoundID) String() string { return Snowflake(s).String() }
+
+// IsValid returns whether or not the snowflake is valid.
+func (s SoundID) IsValid() bool { return Snowflake(s).IsValid() }
+
+// IsNull returns whether or not the snowflake is null. This method is rarely
+// ever useful; most people should use IsValid instead.
+func (s SoundID) IsNull() bool { return Snowflake(s).IsNull() }
+
+func (s SoundID) Time() time.Time   { return Snowflake(s).Time() }
+func (s SoundID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s SoundID) PID() uint8        { return Snowflake(s).PID() }
+func (s SoundID) Increment() uint16 { return Snowflake(s).Increment() }
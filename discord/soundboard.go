@@ -0,0 +1,41 @@
+package discord
+
+// SoundboardSound is a sound that can be played in voice channels using the
+// soundboard. Sounds that have no GuildID are default sounds, which are
+// available to everyone.
+//
+// https://discord.com/developers/docs/resources/soundboard#soundboard-sound-object
+type SoundboardSound struct {
+	// Name is the name of the sound.
+	Name string `json:"name"`
+	// SoundID is the ID of the sound.
+	SoundID SoundID `json:"sound_id"`
+	// Volume is the volume of the sound, from 0 to 1.
+	Volume float64 `json:"volume"`
+	// EmojiID is the ID of the sound's custom emoji, if any.
+	EmojiID EmojiID `json:"emoji_id,omitempty"`
+	// EmojiName is the unicode character of the sound's standard emoji, if
+	// any.
+	EmojiName string `json:"emoji_name,omitempty"`
+	// GuildID is the ID of the guild that the sound is in. It is 0 for
+	// default sounds.
+	GuildID GuildID `json:"guild_id,omitempty"`
+	// Available is whether the sound can be used. It may be false due to the
+	// loss of Server Boosts.
+	Available bool `json:"available"`
+	// User is the user who created the sound. It is only present if the
+	// current user has the CREATE_GUILD_EXPRESSIONS or
+	// MANAGE_GUILD_EXPRESSIONS permission.
+	User *User `json:"user,omitempty"`
+}
+
+// IsDefault returns true if the sound is a default sound, which is available
+// in every guild.
+func (s SoundboardSound) IsDefault() bool {
+	return !s.GuildID.IsValid()
+}
+
+// URL returns the URL to the sound file.
+func (s SoundboardSound) URL() URL {
+	return "https://cdn.discordapp.com/soundboard-sounds/" + s.SoundID.String()
+}
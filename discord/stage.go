@@ -16,6 +16,9 @@ type StageInstance struct {
 	PrivacyLevel PrivacyLevel `json:"privacy_level"`
 	// NotDiscoverable defines whether or not Stage discovery is disabled.
 	NotDiscoverable bool `json:"discoverable_disabled"`
+	// GuildScheduledEventID is the id of the scheduled event for the Stage
+	// instance, if any.
+	GuildScheduledEventID EventID `json:"guild_scheduled_event_id,omitempty"`
 }
 
 type PrivacyLevel int
@@ -0,0 +1,84 @@
+package discord
+
+import "time"
+
+// Subscription represents a user making recurring payments for at least one
+// SKU over an ongoing period.
+//
+// https://discord.com/developers/docs/resources/subscription#subscription-object
+type Subscription struct {
+	// ID is the ID of the subscription.
+	ID SubscriptionID `json:"id"`
+	// UserID is the ID of the user who is subscribed.
+	UserID UserID `json:"user_id"`
+	// SKUIDs is the list of SKUs subscribed to.
+	SKUIDs []SKUID `json:"sku_ids"`
+	// EntitlementIDs is the list of entitlements granted for this
+	// subscription.
+	EntitlementIDs []EntitlementID `json:"entitlement_ids"`
+	// RenewalSKUIDs is the list of SKUs that this user will be subscribed to
+	// at renewal.
+	RenewalSKUIDs []SKUID `json:"renewal_sku_ids,omitempty"`
+	// CurrentPeriodStart is the start of the current subscription period.
+	CurrentPeriodStart Timestamp `json:"current_period_start"`
+	// CurrentPeriodEnd is the end of the current subscription period.
+	CurrentPeriodEnd Timestamp `json:"current_period_end"`
+	// Status is the current status of the subscription.
+	Status SubscriptionStatus `json:"status"`
+	// CanceledAt is when the subscription was canceled.
+	CanceledAt Timestamp `json:"canceled_at,omitempty"`
+	// Country is the ISO3166-1 alpha-2 country code of the payment source
+	// used to purchase the subscription. It is missing unless queried with a
+	// private OAuth scope.
+	Country string `json:"country,omitempty"`
+}
+
+// Active returns true if the subscription currently grants access to its
+// SKUs. Subscriptions that are ending are still active until the end of the
+// current period.
+func (s Subscription) Active() bool {
+	switch s.Status {
+	case SubscriptionActive, SubscriptionEnding:
+		return !s.CurrentPeriodEnd.IsValid() || time.Now().Before(s.CurrentPeriodEnd.Time())
+	default:
+		return false
+	}
+}
+
+// HasSKU returns true if the subscription is for the SKU with the given ID.
+func (s Subscription) HasSKU(skuID SKUID) bool {
+	for _, id := range s.SKUIDs {
+		if id == skuID {
+			return true
+		}
+	}
+	return false
+}
+
+// https://discord.com/developers/docs/resources/subscription#subscription-statuses
+type SubscriptionStatus uint8
+
+const (
+	// SubscriptionActive means the subscription is active and scheduled to
+	// renew.
+	SubscriptionActive SubscriptionStatus = iota
+	// SubscriptionEnding means the subscription is active but will not renew.
+	SubscriptionEnding
+	// SubscriptionInactive means the subscription is inactive and not being
+	// charged.
+	SubscriptionInactive
+)
+
+// String returns the name of the subscription status.
+func (s SubscriptionStatus) String() string {
+	switch s {
+	case SubscriptionActive:
+		return "Active"
+	case SubscriptionEnding:
+		return "Ending"
+	case SubscriptionInactive:
+		return "Inactive"
+	default:
+		return "Unknown"
+	}
+}
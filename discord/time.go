@@ -65,6 +65,41 @@ func (t Timestamp) Time() time.Time {
 	return time.Time(t)
 }
 
+// TimestampStyle is the style of a formatted timestamp, which is shown in the
+// user's timezone and locale.
+//
+// https://discord.com/developers/docs/reference#message-formatting-timestamp-styles
+type TimestampStyle string
+
+const (
+	// DefaultTimestampStyle is the default style, which is the same as
+	// ShortDateTime.
+	DefaultTimestampStyle TimestampStyle = ""
+	// ShortTime looks like "16:20".
+	ShortTime TimestampStyle = "t"
+	// LongTime looks like "16:20:30".
+	LongTime TimestampStyle = "T"
+	// ShortDate looks like "20/04/2021".
+	ShortDate TimestampStyle = "d"
+	// LongDate looks like "20 April 2021".
+	LongDate TimestampStyle = "D"
+	// ShortDateTime looks like "20 April 2021 16:20".
+	ShortDateTime TimestampStyle = "f"
+	// LongDateTime looks like "Tuesday, 20 April 2021 16:20".
+	LongDateTime TimestampStyle = "F"
+	// RelativeTime looks like "2 months ago".
+	RelativeTime TimestampStyle = "R"
+)
+
+// FormattedTimestamp returns the message formatting for the given time, which
+// is rendered by Discord in the given style.
+func FormattedTimestamp(t time.Time, style TimestampStyle) string {
+	if style == DefaultTimestampStyle {
+		return "<t:" + strconv.FormatInt(t.Unix(), 10) + ">"
+	}
+	return "<t:" + strconv.FormatInt(t.Unix(), 10) + ":" + string(style) + ">"
+}
+
 //
 
 type UnixTimestamp int64
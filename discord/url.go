@@ -1,6 +1,10 @@
 package discord
 
-import "strings"
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
 
 type ImageType string
 
@@ -18,17 +22,34 @@ const (
 	GIFImage ImageType = ".gif"
 )
 
-func (t ImageType) format(name string) string {
-	if t == AutoImage {
-		if strings.HasPrefix(name, "a_") {
-			return name + ".gif"
-		}
+type URL = string
+type Hash = string
+
+// URLExpiry returns the time at which the given signed CDN URL expires. The
+// expiry is taken from the hexadecimal Unix timestamp in the URL's "ex" query
+// parameter. False is returned if the URL is not signed.
+func URLExpiry(u URL) (time.Time, bool) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	ex := parsed.Query().Get("ex")
+	if ex == "" {
+		return time.Time{}, false
+	}
 
-		return name + ".png"
+	unix, err := strconv.ParseInt(ex, 16, 64)
+	if err != nil {
+		return time.Time{}, false
 	}
 
-	return name + string(t)
+	return time.Unix(unix, 0), true
 }
 
-type URL = string
-type Hash = string
+// URLExpired returns true if the given signed CDN URL has expired or will
+// expire within the given margin. Unsigned URLs never expire.
+func URLExpired(u URL, margin time.Duration) bool {
+	expiry, ok := URLExpiry(u)
+	return ok && time.Now().Add(margin).After(expiry)
+}
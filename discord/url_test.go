@@ -0,0 +1,58 @@
+package discord
+
+import (
+	"testing"
+	"time"
+)
+
+func TestURLExpiry(t *testing.T) {
+	const u = "https://cdn.discordapp.com/attachments/1/2/a.png?ex=65f1c2a0&is=65df4da0&hm=abcdef&"
+
+	expiry, ok := URLExpiry(u)
+	if !ok {
+		t.Fatal("expected URL to be signed")
+	}
+
+	if expect := time.Unix(0x65f1c2a0, 0); !expiry.Equal(expect) {
+		t.Fatalf("expected expiry %v, got %v", expect, expiry)
+	}
+
+	if !URLExpired(u, 0) {
+		t.Fatal("expected URL to be expired")
+	}
+
+	if _, ok := URLExpiry("https://cdn.discordapp.com/avatars/1/a.png"); ok {
+		t.Fatal("unexpected expiry for unsigned URL")
+	}
+
+	if URLExpired("https://cdn.discordapp.com/avatars/1/a.png", time.Hour) {
+		t.Fatal("unsigned URL must not expire")
+	}
+}
+
+func TestCDN(t *testing.T) {
+	tests := []struct {
+		name   string
+		cdn    CDN
+		expect URL
+	}{
+		{"zero", CDN{}, ""},
+		{"static", NewCDN("icons/1", "abc"), "https://cdn.discordapp.com/icons/1/abc.png"},
+		{"animated", NewCDN("icons/1", "a_abc"), "https://cdn.discordapp.com/icons/1/a_abc.gif"},
+		{
+			"animated webp",
+			NewCDN("icons/1", "a_abc").WithType(WebPImage).WithSize(100),
+			"https://cdn.discordapp.com/icons/1/a_abc.webp?size=128&animated=true",
+		},
+		{"clamped size", NewCDN("icons/1", "abc").WithSize(9000), "https://cdn.discordapp.com/icons/1/abc.png?size=4096"},
+		{"default avatar", User{ID: 1 << 22, Discriminator: "0"}.AvatarCDN(), "https://cdn.discordapp.com/embed/avatars/1.png"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if url := test.cdn.URL(); url != test.expect {
+				t.Fatalf("expected %q, got %q", test.expect, url)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package discord
 import (
 	"strconv"
 	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
 )
 
 type User struct {
@@ -10,7 +12,9 @@ type User struct {
 	Username      string `json:"username"`
 	Discriminator string `json:"discriminator"` // This is "0" if the user has migrated to the new username system.
 	Avatar        Hash   `json:"avatar"`
-	DisplayName   string `json:"global_name"`
+	// DisplayName is the user's global display name, if set. For the name
+	// that should be displayed, use DisplayOrUsername.
+	DisplayName string `json:"global_name"`
 
 	// These fields may be omitted
 
@@ -29,6 +33,12 @@ type User struct {
 
 	Banner Hash  `json:"banner,omitempty"`
 	Accent Color `json:"accent_color,omitempty"`
+
+	// AvatarDecoration is the data for the user's avatar decoration, if any.
+	AvatarDecoration *AvatarDecorationData `json:"avatar_decoration_data,omitempty"`
+	// PrimaryGuild is the user's primary guild, whose tag may be shown next
+	// to their name, if any.
+	PrimaryGuild *PrimaryGuild `json:"primary_guild,omitempty"`
 }
 
 // CreatedAt returns a time object representing when the user was created.
@@ -41,7 +51,8 @@ func (u User) Mention() string {
 	return u.ID.Mention()
 }
 
-// Tag returns a tag of the user.
+// Tag returns a tag of the user. For users that have migrated to the new
+// username system, this is just their username.
 func (u User) Tag() string {
 	if u.Discriminator == "0" || u.Discriminator == "0000" {
 		return u.Username
@@ -66,6 +77,27 @@ func (u User) DisplayOrTag() string {
 	return u.Tag()
 }
 
+// AvatarCDN returns the CDN of the user's avatar. If the user has no avatar,
+// then it is the CDN of their default avatar.
+func (u User) AvatarCDN() CDN {
+	if u.Avatar != "" {
+		return NewCDN("avatars/"+u.ID.String(), u.Avatar)
+	}
+
+	var index int
+	if u.Discriminator != "0" && u.Discriminator != "" {
+		disc, err := strconv.Atoi(u.Discriminator)
+		if err != nil { // this should never happen
+			return CDN{}
+		}
+		index = disc % 5
+	} else {
+		index = int(uint64(u.ID>>22) % 6)
+	}
+
+	return DefaultAvatarCDN(index)
+}
+
 // AvatarURL returns the URL of the Avatar Image. It automatically detects a
 // suitable type.
 func (u User) AvatarURL() string {
@@ -82,22 +114,16 @@ func (u User) AvatarURLWithType(t ImageType) string {
 		if t != PNGImage && t != AutoImage {
 			return ""
 		}
-
-		var picNo string
-		if u.Discriminator != "0" {
-			disc, err := strconv.Atoi(u.Discriminator)
-			if err != nil { // this should never happen
-				return ""
-			}
-			picNo = strconv.Itoa(disc % 5)
-		} else {
-			picNo = strconv.FormatUint(uint64(u.ID>>22)%6, 10)
-		}
-
-		return "https://cdn.discordapp.com/embed/avatars/" + picNo + ".png"
+		return u.AvatarCDN().URL()
 	}
 
-	return "https://cdn.discordapp.com/avatars/" + u.ID.String() + "/" + t.format(u.Avatar)
+	return u.AvatarCDN().WithType(t).URL()
+}
+
+// BannerCDN returns the CDN of the user's banner. It is invalid if there is
+// none.
+func (u User) BannerCDN() CDN {
+	return NewCDN("banners/"+u.ID.String(), u.Banner)
 }
 
 // BannerURL returns the URL of the Banner Image. It automatically detects a
@@ -109,11 +135,82 @@ func (u User) BannerURL() string {
 // BannerURLWithType returns the URL of the Banner Image using the passed type.
 // If the user has no Banner, an empty string will be returned.
 func (u User) BannerURLWithType(t ImageType) string {
-	if u.Banner == "" {
+	return u.BannerCDN().WithType(t).URL()
+}
+
+// AvatarDecorationURL returns the URL of the user's avatar decoration. If the
+// user has no avatar decoration, an empty string will be returned.
+func (u User) AvatarDecorationURL() string {
+	if u.AvatarDecoration == nil {
 		return ""
 	}
+	return u.AvatarDecoration.URL()
+}
 
-	return "https://cdn.discordapp.com/banners/" + u.ID.String() + "/" + t.format(u.Banner)
+// GuildTag returns the tag of the user's primary guild if they are displaying it,
+// otherwise an empty string.
+func (u User) GuildTag() string {
+	if u.PrimaryGuild == nil || !u.PrimaryGuild.IdentityEnabled {
+		return ""
+	}
+	return u.PrimaryGuild.Tag
+}
+
+// AvatarDecorationData is the data for a user's avatar decoration.
+//
+// https://discord.com/developers/docs/resources/user#avatar-decoration-data-object
+type AvatarDecorationData struct {
+	// Asset is the avatar decoration hash.
+	Asset Hash `json:"asset"`
+	// SKUID is the ID of the avatar decoration's SKU.
+	SKUID SKUID `json:"sku_id"`
+}
+
+// CDN returns the CDN of the avatar decoration. Avatar decorations are always
+// PNG images, which may be animated.
+func (d AvatarDecorationData) CDN() CDN {
+	if d.Asset == "" {
+		return CDN{}
+	}
+	return CDN{Path: "avatar-decoration-presets/" + d.Asset, Type: PNGImage}
+}
+
+// URL returns the URL of the avatar decoration. Avatar decorations are always
+// PNG images.
+func (d AvatarDecorationData) URL() string {
+	return d.CDN().URL()
+}
+
+// PrimaryGuild is the guild that a user has chosen to represent them, whose
+// tag and badge are shown next to their name.
+//
+// https://discord.com/developers/docs/resources/user#user-object-user-primary-guild
+type PrimaryGuild struct {
+	// IdentityGuildID is the ID of the user's primary guild.
+	IdentityGuildID GuildID `json:"identity_guild_id,omitempty"`
+	// IdentityEnabled is whether the user is displaying the primary guild's
+	// tag. It may be false if the guild no longer supports tags.
+	IdentityEnabled bool `json:"identity_enabled,omitempty"`
+	// Tag is the text of the user's guild tag, which is up to 4 characters
+	// long.
+	Tag string `json:"tag,omitempty"`
+	// Badge is the guild tag badge hash.
+	Badge Hash `json:"badge,omitempty"`
+}
+
+// BadgeCDN returns the CDN of the guild tag badge. It is invalid if there is
+// none.
+func (g PrimaryGuild) BadgeCDN() CDN {
+	if !g.IdentityGuildID.IsValid() {
+		return CDN{}
+	}
+	return NewCDN("guild-tag-badges/"+g.IdentityGuildID.String(), g.Badge)
+}
+
+// BadgeURL returns the URL of the guild tag badge. Badges are always PNG
+// images. If there is no badge, an empty string will be returned.
+func (g PrimaryGuild) BadgeURL() string {
+	return g.BadgeCDN().WithType(PNGImage).URL()
 }
 
 type UserFlags uint32
@@ -197,6 +294,7 @@ type Activity struct {
 	Party   *ActivityParty   `json:"party,omitempty"`
 	Assets  *ActivityAssets  `json:"assets,omitempty"`
 	Secrets *ActivitySecrets `json:"secrets,omitempty"`
+	Buttons []ActivityButton `json:"buttons,omitempty"`
 
 	// Undocumented fields
 	SyncID    string `json:"sync_id,omitempty"`
@@ -248,6 +346,26 @@ type ActivitySecrets struct {
 	Match    string `json:"match,omitempty"`
 }
 
+// ActivityButton is a button shown on an activity. Buttons received from
+// Discord only have their labels, since the URLs are not visible to other
+// users.
+type ActivityButton struct {
+	Label string `json:"label"`
+	URL   URL    `json:"url,omitempty"`
+}
+
+// UnmarshalJSON unmarshals either a button object or a label string, which is
+// what Discord sends in presences.
+func (b *ActivityButton) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		b.URL = ""
+		return json.Unmarshal(data, &b.Label)
+	}
+
+	type raw ActivityButton
+	return json.Unmarshal(data, (*raw)(b))
+}
+
 // A Relationship between the logged in user and the user in the struct. This
 // struct is undocumented.
 type Relationship struct {
@@ -0,0 +1,49 @@
+package discord
+
+import "testing"
+
+func TestDisplayNames(t *testing.T) {
+	tests := []struct {
+		name   string
+		member Member
+		expect string
+		tag    string
+	}{
+		{
+			name: "nickname",
+			member: Member{
+				Nick: "nick",
+				User: User{Username: "user", DisplayName: "global", Discriminator: "0"},
+			},
+			expect: "nick",
+			tag:    "user",
+		},
+		{
+			name: "global name",
+			member: Member{
+				User: User{Username: "user", DisplayName: "global", Discriminator: "0"},
+			},
+			expect: "global",
+			tag:    "user",
+		},
+		{
+			name: "username",
+			member: Member{
+				User: User{Username: "user", Discriminator: "1234"},
+			},
+			expect: "user",
+			tag:    "user#1234",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if name := test.member.DisplayName(); name != test.expect {
+				t.Errorf("expected display name %q, got %q", test.expect, name)
+			}
+			if tag := test.member.User.Tag(); tag != test.tag {
+				t.Errorf("expected tag %q, got %q", test.tag, tag)
+			}
+		})
+	}
+}
@@ -36,6 +36,9 @@ func init() {
 		func() ws.Event { return new(GuildBanRemoveEvent) },
 		func() ws.Event { return new(GuildEmojisUpdateEvent) },
 		func() ws.Event { return new(GuildIntegrationsUpdateEvent) },
+		func() ws.Event { return new(IntegrationCreateEvent) },
+		func() ws.Event { return new(IntegrationUpdateEvent) },
+		func() ws.Event { return new(IntegrationDeleteEvent) },
 		func() ws.Event { return new(GuildMemberAddEvent) },
 		func() ws.Event { return new(GuildMemberRemoveEvent) },
 		func() ws.Event { return new(GuildMemberUpdateEvent) },
@@ -53,6 +56,8 @@ func init() {
 		func() ws.Event { return new(MessageReactionRemoveEvent) },
 		func() ws.Event { return new(MessageReactionRemoveAllEvent) },
 		func() ws.Event { return new(MessageReactionRemoveEmojiEvent) },
+		func() ws.Event { return new(MessagePollVoteAddEvent) },
+		func() ws.Event { return new(MessagePollVoteRemoveEvent) },
 		func() ws.Event { return new(MessageAckEvent) },
 		func() ws.Event { return new(PresenceUpdateEvent) },
 		func() ws.Event { return new(PresencesReplaceEvent) },
@@ -63,6 +68,7 @@ func init() {
 		func() ws.Event { return new(VoiceServerUpdateEvent) },
 		func() ws.Event { return new(WebhooksUpdateEvent) },
 		func() ws.Event { return new(InteractionCreateEvent) },
+		func() ws.Event { return new(ApplicationCommandPermissionsUpdateEvent) },
 		func() ws.Event { return new(UserGuildSettingsUpdateEvent) },
 		func() ws.Event { return new(UserSettingsUpdateEvent) },
 		func() ws.Event { return new(UserNoteUpdateEvent) },
@@ -76,6 +82,20 @@ func init() {
 		func() ws.Event { return new(GuildScheduledEventDeleteEvent) },
 		func() ws.Event { return new(GuildScheduledEventUserAddEvent) },
 		func() ws.Event { return new(GuildScheduledEventUserRemoveEvent) },
+		func() ws.Event { return new(StageInstanceCreateEvent) },
+		func() ws.Event { return new(StageInstanceUpdateEvent) },
+		func() ws.Event { return new(StageInstanceDeleteEvent) },
+		func() ws.Event { return new(AutoModerationRuleCreateEvent) },
+		func() ws.Event { return new(AutoModerationRuleUpdateEvent) },
+		func() ws.Event { return new(AutoModerationRuleDeleteEvent) },
+		func() ws.Event { return new(AutoModerationActionExecutionEvent) },
+		func() ws.Event { return new(RequestSoundboardSoundsCommand) },
+		func() ws.Event { return new(GuildSoundboardSoundCreateEvent) },
+		func() ws.Event { return new(GuildSoundboardSoundUpdateEvent) },
+		func() ws.Event { return new(GuildSoundboardSoundDeleteEvent) },
+		func() ws.Event { return new(GuildSoundboardSoundsUpdateEvent) },
+		func() ws.Event { return new(SoundboardSoundsEvent) },
+		func() ws.Event { return new(VoiceChannelEffectSendEvent) },
 		func() ws.Event { return new(IdentifyCommand) },
 	)
 }
@@ -260,6 +280,24 @@ func (*GuildIntegrationsUpdateEvent) Op() ws.OpCode { return dispatchOp }
 // EventType implements Event.
 func (*GuildIntegrationsUpdateEvent) EventType() ws.EventType { return "GUILD_INTEGRATIONS_UPDATE" }
 
+// Op implements Event. It always returns 0.
+func (*IntegrationCreateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*IntegrationCreateEvent) EventType() ws.EventType { return "INTEGRATION_CREATE" }
+
+// Op implements Event. It always returns 0.
+func (*IntegrationUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*IntegrationUpdateEvent) EventType() ws.EventType { return "INTEGRATION_UPDATE" }
+
+// Op implements Event. It always returns 0.
+func (*IntegrationDeleteEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*IntegrationDeleteEvent) EventType() ws.EventType { return "INTEGRATION_DELETE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildMemberAddEvent) Op() ws.OpCode { return dispatchOp }
 
@@ -364,6 +402,18 @@ func (*MessageReactionRemoveEmojiEvent) EventType() ws.EventType {
 	return "MESSAGE_REACTION_REMOVE_EMOJI"
 }
 
+// Op implements Event. It always returns 0.
+func (*MessagePollVoteAddEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*MessagePollVoteAddEvent) EventType() ws.EventType { return "MESSAGE_POLL_VOTE_ADD" }
+
+// Op implements Event. It always returns 0.
+func (*MessagePollVoteRemoveEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*MessagePollVoteRemoveEvent) EventType() ws.EventType { return "MESSAGE_POLL_VOTE_REMOVE" }
+
 // Op implements Event. It always returns 0.
 func (*MessageAckEvent) Op() ws.OpCode { return dispatchOp }
 
@@ -424,6 +474,14 @@ func (*InteractionCreateEvent) Op() ws.OpCode { return dispatchOp }
 // EventType implements Event.
 func (*InteractionCreateEvent) EventType() ws.EventType { return "INTERACTION_CREATE" }
 
+// Op implements Event. It always returns 0.
+func (*ApplicationCommandPermissionsUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*ApplicationCommandPermissionsUpdateEvent) EventType() ws.EventType {
+	return "APPLICATION_COMMAND_PERMISSIONS_UPDATE"
+}
+
 // Op implements Event. It always returns 0.
 func (*UserGuildSettingsUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
@@ -512,6 +570,100 @@ func (*GuildScheduledEventUserRemoveEvent) EventType() ws.EventType {
 	return "GUILD_SCHEDULED_EVENT_USER_REMOVE"
 }
 
+// Op implements Event. It always returns 0.
+func (*StageInstanceCreateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*StageInstanceCreateEvent) EventType() ws.EventType { return "STAGE_INSTANCE_CREATE" }
+
+// Op implements Event. It always returns 0.
+func (*StageInstanceUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*StageInstanceUpdateEvent) EventType() ws.EventType { return "STAGE_INSTANCE_UPDATE" }
+
+// Op implements Event. It always returns 0.
+func (*StageInstanceDeleteEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*StageInstanceDeleteEvent) EventType() ws.EventType { return "STAGE_INSTANCE_DELETE" }
+
+// Op implements Event. It always returns 0.
+func (*AutoModerationRuleCreateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*AutoModerationRuleCreateEvent) EventType() ws.EventType { return "AUTO_MODERATION_RULE_CREATE" }
+
+// Op implements Event. It always returns 0.
+func (*AutoModerationRuleUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*AutoModerationRuleUpdateEvent) EventType() ws.EventType { return "AUTO_MODERATION_RULE_UPDATE" }
+
+// Op implements Event. It always returns 0.
+func (*AutoModerationRuleDeleteEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*AutoModerationRuleDeleteEvent) EventType() ws.EventType { return "AUTO_MODERATION_RULE_DELETE" }
+
+// Op implements Event. It always returns 0.
+func (*AutoModerationActionExecutionEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*AutoModerationActionExecutionEvent) EventType() ws.EventType {
+	return "AUTO_MODERATION_ACTION_EXECUTION"
+}
+
+// Op implements Event. It always returns Op 31.
+func (*RequestSoundboardSoundsCommand) Op() ws.OpCode { return 31 }
+
+// EventType implements Event.
+func (*RequestSoundboardSoundsCommand) EventType() ws.EventType { return "" }
+
+// Op implements Event. It always returns 0.
+func (*GuildSoundboardSoundCreateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*GuildSoundboardSoundCreateEvent) EventType() ws.EventType {
+	return "GUILD_SOUNDBOARD_SOUND_CREATE"
+}
+
+// Op implements Event. It always returns 0.
+func (*GuildSoundboardSoundUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*GuildSoundboardSoundUpdateEvent) EventType() ws.EventType {
+	return "GUILD_SOUNDBOARD_SOUND_UPDATE"
+}
+
+// Op implements Event. It always returns 0.
+func (*GuildSoundboardSoundDeleteEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*GuildSoundboardSoundDeleteEvent) EventType() ws.EventType {
+	return "GUILD_SOUNDBOARD_SOUND_DELETE"
+}
+
+// Op implements Event. It always returns 0.
+func (*GuildSoundboardSoundsUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*GuildSoundboardSoundsUpdateEvent) EventType() ws.EventType {
+	return "GUILD_SOUNDBOARD_SOUNDS_UPDATE"
+}
+
+// Op implements Event. It always returns 0.
+func (*SoundboardSoundsEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*SoundboardSoundsEvent) EventType() ws.EventType { return "SOUNDBOARD_SOUNDS" }
+
+// Op implements Event. It always returns 0.
+func (*VoiceChannelEffectSendEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*VoiceChannelEffectSendEvent) EventType() ws.EventType { return "VOICE_CHANNEL_EFFECT_SEND" }
+
 // Op implements Event. It always returns Op 2.
 func (*IdentifyCommand) Op() ws.OpCode { return 2 }
 
@@ -276,6 +276,10 @@ type GuildCreateEvent struct {
 	Channels    []discord.Channel    `json:"channels,omitempty"`
 	Threads     []discord.Channel    `json:"threads,omitempty"`
 	Presences   []discord.Presence   `json:"presences,omitempty"`
+
+	ScheduledEvents  []discord.GuildScheduledEvent `json:"guild_scheduled_events,omitempty"`
+	StageInstances   []discord.StageInstance       `json:"stage_instances,omitempty"`
+	SoundboardSounds []discord.SoundboardSound     `json:"soundboard_sounds,omitempty"`
 }
 
 // GuildUpdateEvent is a dispatch event.
@@ -294,11 +298,13 @@ type GuildDeleteEvent struct {
 	Unavailable bool `json:"unavailable"`
 }
 
-// GuildAuditLogEntryCreateEvent is a dispatch event.
-
+// GuildAuditLogEntryCreateEvent is a dispatch event. It is sent when an audit
+// log entry is created, and requires the View Audit Log permission.
+//
 // https://discord.com/developers/docs/topics/gateway#guilds
 type GuildAuditLogEntryCreateEvent struct {
 	discord.AuditLogEntry
+	GuildID discord.GuildID `json:"guild_id"`
 }
 
 // GuildBanAddEvent is a dispatch event.
@@ -332,6 +338,33 @@ type GuildIntegrationsUpdateEvent struct {
 	GuildID discord.GuildID `json:"guild_id"`
 }
 
+// IntegrationCreateEvent is a dispatch event. It is sent when an integration is
+// added to a guild.
+//
+// https://discord.com/developers/docs/topics/gateway-events#integration-create
+type IntegrationCreateEvent struct {
+	discord.Integration
+	GuildID discord.GuildID `json:"guild_id"`
+}
+
+// IntegrationUpdateEvent is a dispatch event.
+//
+// https://discord.com/developers/docs/topics/gateway-events#integration-update
+type IntegrationUpdateEvent struct {
+	discord.Integration
+	GuildID discord.GuildID `json:"guild_id"`
+}
+
+// IntegrationDeleteEvent is a dispatch event.
+//
+// https://discord.com/developers/docs/topics/gateway-events#integration-delete
+type IntegrationDeleteEvent struct {
+	ID      discord.IntegrationID `json:"id"`
+	GuildID discord.GuildID       `json:"guild_id"`
+	// AppID is the ID of the bot or OAuth2 application of the integration.
+	AppID discord.AppID `json:"application_id,omitempty"`
+}
+
 // GuildMemberAddEvent is a dispatch event.
 //
 // https://discord.com/developers/docs/topics/gateway#guilds
@@ -359,6 +392,9 @@ type GuildMemberUpdateEvent struct {
 	Avatar                     discord.Hash      `json:"avatar"`
 	IsPending                  bool              `json:"pending,omitempty"`
 	CommunicationDisabledUntil discord.Timestamp `json:"communication_disabled_until"`
+
+	AvatarDecoration *discord.AvatarDecorationData `json:"avatar_decoration_data,omitempty"`
+	Flags            discord.MemberFlags           `json:"flags,omitempty"`
 }
 
 // UpdateMember updates the given discord.Member.
@@ -369,6 +405,8 @@ func (u *GuildMemberUpdateEvent) UpdateMember(m *discord.Member) {
 	m.Avatar = u.Avatar
 	m.IsPending = u.IsPending
 	m.CommunicationDisabledUntil = u.CommunicationDisabledUntil
+	m.AvatarDecoration = u.AvatarDecoration
+	m.Flags = u.Flags
 }
 
 // GuildMembersChunkEvent is a dispatch event. It is sent when the Guild Request
@@ -476,9 +514,10 @@ type InviteCreateEvent struct {
 	GuildID   discord.GuildID   `json:"guild_id,omitempty"`
 
 	// Similar to discord.Invite
-	Inviter    *discord.User          `json:"inviter,omitempty"`
-	Target     *discord.User          `json:"target_user,omitempty"`
-	TargetType discord.InviteUserType `json:"target_user_type,omitempty"`
+	Inviter           *discord.User            `json:"inviter,omitempty"`
+	TargetType        discord.InviteTargetType `json:"target_type,omitempty"`
+	Target            *discord.User            `json:"target_user,omitempty"`
+	TargetApplication *discord.Application     `json:"target_application,omitempty"`
 
 	discord.InviteMetadata
 }
@@ -538,6 +577,18 @@ type MessageReactionAddEvent struct {
 
 	GuildID discord.GuildID `json:"guild_id,omitempty"`
 	Member  *discord.Member `json:"member,omitempty"`
+
+	// MessageAuthorID is the ID of the user who authored the message that
+	// was reacted to.
+	MessageAuthorID discord.UserID `json:"message_author_id,omitempty"`
+
+	// Burst is true if the reaction is a super reaction.
+	Burst bool `json:"burst"`
+	// BurstColors are the colors used for the super reaction, as hex strings
+	// such as "#ffffff".
+	BurstColors []string `json:"burst_colors,omitempty"`
+	// Type is the type of the reaction.
+	Type discord.ReactionType `json:"type"`
 }
 
 // MessageReactionRemoveEvent is a dispatch event.
@@ -549,6 +600,11 @@ type MessageReactionRemoveEvent struct {
 	MessageID discord.MessageID `json:"message_id"`
 	Emoji     discord.Emoji     `json:"emoji"`
 	GuildID   discord.GuildID   `json:"guild_id,omitempty"`
+
+	// Burst is true if the reaction is a super reaction.
+	Burst bool `json:"burst"`
+	// Type is the type of the reaction.
+	Type discord.ReactionType `json:"type"`
 }
 
 // MessageReactionRemoveAllEvent is a dispatch event.
@@ -570,6 +626,31 @@ type MessageReactionRemoveEmojiEvent struct {
 	GuildID   discord.GuildID   `json:"guild_id,omitempty"`
 }
 
+// MessagePollVoteAddEvent is a dispatch event. It is sent when a user votes
+// on a poll. If the poll allows multiple answers, one event is sent for each
+// answer.
+//
+// https://discord.com/developers/docs/topics/gateway-events#message-poll-vote-add
+type MessagePollVoteAddEvent struct {
+	UserID    discord.UserID    `json:"user_id"`
+	ChannelID discord.ChannelID `json:"channel_id"`
+	MessageID discord.MessageID `json:"message_id"`
+	GuildID   discord.GuildID   `json:"guild_id,omitempty"`
+	AnswerID  int               `json:"answer_id"`
+}
+
+// MessagePollVoteRemoveEvent is a dispatch event. It is sent when a user
+// removes their vote on a poll.
+//
+// https://discord.com/developers/docs/topics/gateway-events#message-poll-vote-remove
+type MessagePollVoteRemoveEvent struct {
+	UserID    discord.UserID    `json:"user_id"`
+	ChannelID discord.ChannelID `json:"channel_id"`
+	MessageID discord.MessageID `json:"message_id"`
+	GuildID   discord.GuildID   `json:"guild_id,omitempty"`
+	AnswerID  int               `json:"answer_id"`
+}
+
 // MessageAckEvent is a dispatch event.
 type MessageAckEvent struct {
 	MessageID discord.MessageID `json:"message_id"`
@@ -639,6 +720,14 @@ type InteractionCreateEvent struct {
 	discord.InteractionEvent
 }
 
+// ApplicationCommandPermissionsUpdateEvent is a dispatch event. It is sent when
+// the permissions of one of the application's commands are updated.
+//
+// https://discord.com/developers/docs/topics/gateway-events#application-command-permissions-update
+type ApplicationCommandPermissionsUpdateEvent struct {
+	discord.GuildCommandPermissions
+}
+
 // Undocumented
 
 // UserGuildSettingsUpdateEvent is a dispatch event. It is undocumented.
@@ -705,6 +794,8 @@ type ReadyEvent struct {
 
 	User      discord.User `json:"user"`
 	SessionID string       `json:"session_id"`
+	// ResumeGatewayURL is the URL to connect to when resuming the session.
+	ResumeGatewayURL string `json:"resume_gateway_url"`
 
 	PrivateChannels []discord.Channel  `json:"private_channels"`
 	Guilds          []GuildCreateEvent `json:"guilds"`
@@ -1058,3 +1149,176 @@ type GuildScheduledEventUserRemoveEvent struct {
 	// GuildID is the id of where the scheduled event belongs
 	GuildID discord.GuildID `json:"guild_id"`
 }
+
+// StageInstanceCreateEvent is a dispatch event. It is sent when a Stage
+// instance is created, i.e. when a Stage starts.
+//
+// https://discord.com/developers/docs/topics/gateway-events#stage-instance-create
+type StageInstanceCreateEvent struct {
+	discord.StageInstance
+}
+
+// StageInstanceUpdateEvent is a dispatch event. It is sent when a Stage
+// instance is updated.
+//
+// https://discord.com/developers/docs/topics/gateway-events#stage-instance-update
+type StageInstanceUpdateEvent struct {
+	discord.StageInstance
+}
+
+// StageInstanceDeleteEvent is a dispatch event. It is sent when a Stage
+// instance is deleted, i.e. when a Stage ends.
+//
+// https://discord.com/developers/docs/topics/gateway-events#stage-instance-delete
+type StageInstanceDeleteEvent struct {
+	discord.StageInstance
+}
+
+// AutoModerationRuleCreateEvent is a dispatch event. It is sent when a rule is
+// created.
+//
+// https://discord.com/developers/docs/topics/gateway-events#auto-moderation-rule-create
+type AutoModerationRuleCreateEvent struct {
+	discord.AutoModerationRule
+}
+
+// AutoModerationRuleUpdateEvent is a dispatch event. It is sent when a rule is
+// updated.
+//
+// https://discord.com/developers/docs/topics/gateway-events#auto-moderation-rule-update
+type AutoModerationRuleUpdateEvent struct {
+	discord.AutoModerationRule
+}
+
+// AutoModerationRuleDeleteEvent is a dispatch event. It is sent when a rule is
+// deleted.
+//
+// https://discord.com/developers/docs/topics/gateway-events#auto-moderation-rule-delete
+type AutoModerationRuleDeleteEvent struct {
+	discord.AutoModerationRule
+}
+
+// AutoModerationActionExecutionEvent is a dispatch event. It is sent when a
+// rule is triggered and an action is executed, such as when a message is
+// blocked.
+//
+// https://discord.com/developers/docs/topics/gateway-events#auto-moderation-action-execution
+type AutoModerationActionExecutionEvent struct {
+	// GuildID is the id of the guild in which the action was executed.
+	GuildID discord.GuildID `json:"guild_id"`
+	// Action is the action which was executed.
+	Action discord.AutoModerationAction `json:"action"`
+	// RuleID is the id of the rule which the action belongs to.
+	RuleID discord.AutoModerationRuleID `json:"rule_id"`
+	// RuleTriggerType is the trigger type of the rule which was triggered.
+	RuleTriggerType discord.AutoModerationTriggerType `json:"rule_trigger_type"`
+	// UserID is the id of the user which generated the content which
+	// triggered the rule.
+	UserID discord.UserID `json:"user_id"`
+	// ChannelID is the id of the channel in which the user content was
+	// posted.
+	ChannelID discord.ChannelID `json:"channel_id,omitempty"`
+	// MessageID is the id of any user message which the content belongs to.
+	// It is not set if the message was blocked or if the content was not
+	// part of any message.
+	MessageID discord.MessageID `json:"message_id,omitempty"`
+	// AlertSystemMessageID is the id of any system alert message posted as a
+	// result of this action.
+	AlertSystemMessageID discord.MessageID `json:"alert_system_message_id,omitempty"`
+	// Content is the user-generated text content. It is empty without the
+	// MessageContent intent.
+	Content string `json:"content"`
+	// MatchedKeyword is the word or phrase configured in the rule that
+	// triggered the rule.
+	MatchedKeyword string `json:"matched_keyword"`
+	// MatchedContent is the substring in the content that triggered the rule.
+	// It is empty without the MessageContent intent.
+	MatchedContent string `json:"matched_content"`
+}
+
+// RequestSoundboardSoundsCommand is a command for Op 31. It requests the
+// soundboard sounds of the given guilds, which are sent in
+// SoundboardSoundsEvents.
+//
+// https://discord.com/developers/docs/topics/gateway-events#request-soundboard-sounds
+type RequestSoundboardSoundsCommand struct {
+	GuildIDs []discord.GuildID `json:"guild_ids"`
+}
+
+// GuildSoundboardSoundCreateEvent is a dispatch event. It is sent when a
+// soundboard sound is created.
+//
+// https://discord.com/developers/docs/topics/gateway-events#guild-soundboard-sound-create
+type GuildSoundboardSoundCreateEvent struct {
+	discord.SoundboardSound
+}
+
+// GuildSoundboardSoundUpdateEvent is a dispatch event. It is sent when a
+// soundboard sound is updated.
+//
+// https://discord.com/developers/docs/topics/gateway-events#guild-soundboard-sound-update
+type GuildSoundboardSoundUpdateEvent struct {
+	discord.SoundboardSound
+}
+
+// GuildSoundboardSoundDeleteEvent is a dispatch event. It is sent when a
+// soundboard sound is deleted.
+//
+// https://discord.com/developers/docs/topics/gateway-events#guild-soundboard-sound-delete
+type GuildSoundboardSoundDeleteEvent struct {
+	SoundID discord.SoundID `json:"sound_id"`
+	GuildID discord.GuildID `json:"guild_id"`
+}
+
+// GuildSoundboardSoundsUpdateEvent is a dispatch event. It is sent when
+// multiple soundboard sounds are updated at once.
+//
+// https://discord.com/developers/docs/topics/gateway-events#guild-soundboard-sounds-update
+type GuildSoundboardSoundsUpdateEvent struct {
+	SoundboardSounds []discord.SoundboardSound `json:"soundboard_sounds"`
+	GuildID          discord.GuildID           `json:"guild_id"`
+}
+
+// SoundboardSoundsEvent is a dispatch event. It is sent in response to a
+// RequestSoundboardSoundsCommand, once for each guild.
+//
+// https://discord.com/developers/docs/topics/gateway-events#soundboard-sounds
+type SoundboardSoundsEvent struct {
+	SoundboardSounds []discord.SoundboardSound `json:"soundboard_sounds"`
+	GuildID          discord.GuildID           `json:"guild_id"`
+}
+
+// VoiceChannelEffectSendEvent is a dispatch event. It is sent when someone
+// sends an effect, such as an emoji reaction or a soundboard sound, in a voice
+// channel that the current user is connected to.
+//
+// https://discord.com/developers/docs/topics/gateway-events#voice-channel-effect-send
+type VoiceChannelEffectSendEvent struct {
+	ChannelID discord.ChannelID `json:"channel_id"`
+	GuildID   discord.GuildID   `json:"guild_id"`
+	UserID    discord.UserID    `json:"user_id"`
+	// Emoji is the emoji sent, for emoji reaction and soundboard effects.
+	Emoji *discord.Emoji `json:"emoji,omitempty"`
+	// AnimationType is the type of the emoji animation, for emoji reaction
+	// and soundboard effects.
+	AnimationType *VoiceChannelEffectAnimationType `json:"animation_type,omitempty"`
+	// AnimationID is the ID of the emoji animation, for emoji reaction and
+	// soundboard effects.
+	AnimationID int `json:"animation_id,omitempty"`
+	// SoundID is the ID of the soundboard sound, for soundboard effects.
+	SoundID discord.SoundID `json:"sound_id,omitempty"`
+	// SoundVolume is the volume of the soundboard sound, from 0 to 1, for
+	// soundboard effects.
+	SoundVolume float64 `json:"sound_volume,omitempty"`
+}
+
+// VoiceChannelEffectAnimationType is the type of the animation of a
+// VoiceChannelEffectSendEvent.
+type VoiceChannelEffectAnimationType uint8
+
+const (
+	// PremiumEffectAnimation is a fun animation sent by a Nitro subscriber.
+	PremiumEffectAnimation VoiceChannelEffectAnimationType = iota
+	// BasicEffectAnimation is the standard animation.
+	BasicEffectAnimation
+)
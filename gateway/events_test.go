@@ -87,3 +87,75 @@ func TestRequestGuildMembersCommand(t *testing.T) {
 		}
 	})
 }
+
+func TestEventRegistry(t *testing.T) {
+	info, ok := EventByName("MESSAGE_CREATE")
+	if !ok {
+		t.Fatal("MESSAGE_CREATE not found")
+	}
+
+	if info.Type != reflect.TypeOf((*MessageCreateEvent)(nil)) || !info.IsDispatch() {
+		t.Fatalf("unexpected event info %+v", info)
+	}
+
+	if _, ok := info.New().(*MessageCreateEvent); !ok {
+		t.Fatal("New returned the wrong type")
+	}
+
+	info, ok = EventByType(reflect.TypeOf((*GuildCreateEvent)(nil)))
+	if !ok || info.Name != "GUILD_CREATE" {
+		t.Fatalf("unexpected event info %+v", info)
+	}
+
+	info, ok = EventByType(reflect.TypeOf((*HelloEvent)(nil)))
+	if !ok || info.IsDispatch() || info.Name != "" {
+		t.Fatalf("unexpected event info %+v", info)
+	}
+
+	if name, ok := EventNameOf(&ReadyEvent{}); !ok || name != "READY" {
+		t.Fatalf("unexpected name %q", name)
+	}
+
+	if _, ok := EventNameOf(new(HeartbeatCommand)); ok {
+		t.Fatal("unexpected name for a command")
+	}
+
+	events := Events()
+	for i := 1; i < len(events); i++ {
+		a, b := events[i-1], events[i]
+		if a.Op > b.Op || (a.Op == b.Op && a.Name >= b.Name) {
+			t.Fatalf("events not sorted: %v before %v", a.Name, b.Name)
+		}
+	}
+
+	if _, ok := EventByName("NOT_AN_EVENT"); ok {
+		t.Fatal("unexpected event found")
+	}
+}
+
+func TestInferIntents(t *testing.T) {
+	intents, privileged := InferIntents(
+		reflect.TypeOf((*MessageCreateEvent)(nil)),
+		reflect.TypeOf((*GuildMemberAddEvent)(nil)),
+		reflect.TypeOf((*ReadyEvent)(nil)),
+	)
+
+	expect := IntentGuildMessages | IntentDirectMessages | IntentGuildMembers |
+		IntentMessageContent
+	if intents != expect {
+		t.Errorf("expected intents %b, got %b", expect, intents)
+	}
+
+	expectPrivileged := IntentGuildMembers | IntentMessageContent
+	if privileged != expectPrivileged {
+		t.Errorf("expected privileged intents %b, got %b", expectPrivileged, privileged)
+	}
+
+	intents, privileged = InferIntents(reflect.TypeOf((*MessageDeleteEvent)(nil)))
+	if intents != IntentGuildMessages|IntentDirectMessages {
+		t.Errorf("unexpected intents %b for MessageDeleteEvent", intents)
+	}
+	if privileged != 0 {
+		t.Errorf("unexpected privileged intents %b for MessageDeleteEvent", privileged)
+	}
+}
@@ -13,11 +13,13 @@ import (
 	"math/rand"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/internal/lazytime"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
+	"golang.org/x/time/rate"
 )
 
 var (
@@ -47,7 +49,7 @@ func URL(ctx context.Context) (string, error) {
 // BotURL fetches the Gateway URL along with extra metadata. The token
 // passed in will NOT be prefixed with Bot.
 func BotURL(ctx context.Context, token string) (*api.BotData, error) {
-	return api.NewClient(token).WithContext(ctx).BotURL()
+	return api.NewClient(token).WithCtx(ctx).BotURL()
 }
 
 // AddGatewayParams appends into the given URL string the gateway URL
@@ -71,6 +73,36 @@ type State struct {
 	Identifier Identifier
 	SessionID  string
 	Sequence   int64
+	// ResumeURL is the URL to connect to when resuming the session. It is
+	// given by Discord in the Ready event.
+	ResumeURL string
+}
+
+// ResumeState is the part of State that is needed to resume a session, such as
+// from a new process after restarting the bot. It can be marshaled to JSON to
+// be persisted.
+type ResumeState struct {
+	SessionID string `json:"session_id"`
+	ResumeURL string `json:"resume_url"`
+	Sequence  int64  `json:"sequence"`
+}
+
+// ResumeState returns the state needed to resume the current session.
+func (s State) ResumeState() ResumeState {
+	return ResumeState{
+		SessionID: s.SessionID,
+		ResumeURL: s.ResumeURL,
+		Sequence:  s.Sequence,
+	}
+}
+
+// WithResumeState returns a copy of s that resumes the session in the given
+// ResumeState once connected.
+func (s State) WithResumeState(rs ResumeState) State {
+	s.SessionID = rs.SessionID
+	s.ResumeURL = rs.ResumeURL
+	s.Sequence = rs.Sequence
+	return s
 }
 
 // Gateway describes an instance that handles the Discord gateway. It is
@@ -78,7 +110,14 @@ type State struct {
 // start connecting to the Discord gateway server.
 type Gateway struct {
 	gateway *ws.Gateway
+	ws      *ws.Websocket
+	conn    *ws.Conn
+	url     string
 	state   State
+	hooks   ws.GatewayHooks
+	proxied bool
+
+	keepSession uint32 // atomic
 
 	// non-mutex-guarded states
 	// TODO: make lastBeat part of ws.Gateway so it can keep track of whether or
@@ -154,6 +193,27 @@ var DefaultGatewayOpts = ws.GatewayOpts{
 	DialTimeout:           0,
 	ReconnectAttempt:      0,
 	AlwaysCloseGracefully: true,
+	// Heartbeats must never be delayed, and Identify and Resume are
+	// throttled by their own limiters, so they don't queue behind other
+	// commands.
+	PriorityOps: []ws.OpCode{heartbeatOp, identifyOp, resumeOp},
+	OpLimiters: map[ws.OpCode]func() *rate.Limiter{
+		statusUpdateOp: NewPresenceLimiter,
+	},
+}
+
+// PresenceBurst is the number of presence updates that can be sent at once
+// before being throttled to one every PresenceInterval.
+var PresenceBurst = 5
+
+// PresenceInterval is the interval that presence updates are throttled to once
+// PresenceBurst is used up.
+const PresenceInterval = time.Minute / 5
+
+// NewPresenceLimiter returns a rate limiter for throttling presence updates,
+// which Discord limits further than other gateway commands.
+func NewPresenceLimiter() *rate.Limiter {
+	return rate.NewLimiter(rate.Every(PresenceInterval), PresenceBurst)
 }
 
 // NewCustomWithIdentifier creates a new Gateway with a custom gateway URL and a
@@ -169,13 +229,64 @@ func NewFromState(gatewayURL string, state State, opts *ws.GatewayOpts) *Gateway
 		opts = &DefaultGatewayOpts
 	}
 
-	gw := ws.NewGateway(ws.NewWebsocket(ws.NewCodec(OpUnmarshalers), gatewayURL), opts)
+	if opts.Compression != ws.PayloadCompression {
+		// Payload compression is requested in the Identify command, so don't
+		// ask for it if the connection is compressed in another way.
+		state.Identifier.Compress = false
+	}
+
+	codec := ws.NewCodec(OpUnmarshalers)
+	codec.Encoding = opts.Encoding
+	if filter := opts.EventFilter; filter != nil {
+		// The gateway needs Ready and Resumed to keep track of its session,
+		// and Session waits for them when opening.
+		codec.Filter = func(t ws.EventType) bool {
+			return t == "READY" || t == "RESUMED" || filter(t)
+		}
+	}
+	conn := ws.NewConn(codec)
+	conn.Compression = opts.Compression
+	conn.ReadLimit = opts.ReadLimit
+	conn.MaxPayloadSize = opts.MaxPayloadSize
+	conn.ZstdDecompressor = opts.ZstdDecompressor
+
+	websocket := ws.NewCustomWebsocket(conn, gatewayURL)
+
 	return &Gateway{
-		gateway: gw,
+		gateway: ws.NewGateway(websocket, opts),
+		ws:      websocket,
+		conn:    conn,
+		url:     gatewayURL,
 		state:   state,
+		hooks:   opts.Hooks,
 	}
 }
 
+// NewProxied creates a new Gateway that connects to a gateway proxy at the
+// given URL instead of Discord. The proxy owns the session: the Gateway never
+// sends Identify or Resume, and it only heartbeats if the proxy sends a Hello.
+// This allows many processes to consume the events of the same sessions.
+//
+// Only the token and the shard of the state's Identifier may be used, such as
+// by the proxy's Hello handshake, and the state's session is never resumed.
+// If opts is nil, then DefaultGatewayOpts is used.
+func NewProxied(proxyURL string, state State, opts *ws.GatewayOpts) *Gateway {
+	g := NewFromState(proxyURL, state, opts)
+	g.proxied = true
+	return g
+}
+
+// Proxied returns true if the gateway was created using NewProxied.
+func (g *Gateway) Proxied() bool {
+	return g.proxied
+}
+
+// CompressionStats returns the number of bytes received by the gateway so far,
+// before and after decompression.
+func (g *Gateway) CompressionStats() ws.CompressionStats {
+	return g.conn.CompressionStats()
+}
+
 // Opts returns a copy of the gateway options that are being used.
 func (g *Gateway) Opts() *ws.GatewayOpts {
 	return g.gateway.Opts()
@@ -305,9 +416,32 @@ func (g *Gateway) Send(ctx context.Context, data ws.Event) error {
 //	    return gateway.LastError()
 //	}
 func (g *Gateway) Connect(ctx context.Context) <-chan ws.Op {
+	if !g.gateway.HasStarted() {
+		g.updateURL()
+	}
+
 	return g.gateway.Connect(ctx, &gatewayImpl{Gateway: g})
 }
 
+// SkipGracefulClose makes the gateway close without invalidating its session
+// the next time its context is done, so that the session can be resumed later
+// using the ResumeState of State. Refer to ws.Gateway's SkipGracefulClose.
+func (g *Gateway) SkipGracefulClose() {
+	atomic.StoreUint32(&g.keepSession, 1)
+	g.gateway.SkipGracefulClose()
+}
+
+// updateURL makes the gateway connect to the resume URL if there's a session to
+// resume, or the gateway URL otherwise. A proxied gateway always connects to the
+// proxy.
+func (g *Gateway) updateURL() {
+	if !g.proxied && g.state.SessionID != "" && g.state.ResumeURL != "" {
+		g.ws.SetAddr(AddGatewayParams(g.state.ResumeURL))
+	} else {
+		g.ws.SetAddr(g.url)
+	}
+}
+
 type gatewayImpl struct {
 	*Gateway
 	heartrate    time.Duration
@@ -317,11 +451,19 @@ type gatewayImpl struct {
 func (g *gatewayImpl) invalidate() {
 	g.state.SessionID = ""
 	g.state.Sequence = 0
+	g.state.ResumeURL = ""
+	g.updateURL()
 }
 
 // sendIdentify sends off the Identify command with the Gateway's IdentifyData
 // with the given context for timeout.
 func (g *gatewayImpl) sendIdentify(ctx context.Context) error {
+	// Warn the user if the daily identify budget is running low, since the
+	// Wait below may block for a very long time once it's exhausted.
+	if err := g.state.Identifier.budgetError(); err != nil {
+		g.gateway.SendError(err)
+	}
+
 	if err := g.state.Identifier.Wait(ctx); err != nil {
 		return fmt.Errorf("can't wait for identify(): %w", err)
 	}
@@ -369,6 +511,11 @@ func (g *gatewayImpl) OnOp(ctx context.Context, op ws.Op) bool {
 		g.lastSentBeat = now
 		g.beatMutex.Unlock()
 
+		if g.proxied {
+			// The proxy has already identified or resumed the session.
+			break
+		}
+
 		// Send Discord either the Identify packet (if it's a fresh
 		// connection), or a Resume packet (if it's a dead connection).
 		if !resumable || g.state.SessionID == "" || g.state.Sequence == 0 {
@@ -385,9 +532,18 @@ func (g *gatewayImpl) OnOp(ctx context.Context, op ws.Op) bool {
 		}
 
 	case *InvalidSessionEvent:
+		if g.proxied {
+			// The proxy owns the session, so it's up to it to identify again.
+			break
+		}
+
 		// Wipe the session state.
 		g.invalidate()
 
+		if g.hooks.OnInvalidSession != nil {
+			g.hooks.OnInvalidSession(bool(*data))
+		}
+
 		if !*data {
 			g.gateway.QueueReconnect()
 			break
@@ -420,10 +576,16 @@ func (g *gatewayImpl) OnOp(ctx context.Context, op ws.Op) bool {
 
 	case *ReadyEvent:
 		g.state.SessionID = data.SessionID
+		g.state.ResumeURL = data.ResumeGatewayURL
+		g.updateURL()
 		g.useLastSentBeat()
 
 	case *ResumedEvent:
 		g.useLastSentBeat()
+
+		if g.hooks.OnResumed != nil {
+			g.hooks.OnResumed()
+		}
 	}
 
 	return true
@@ -477,6 +639,10 @@ func (g *gatewayImpl) SendHeartbeat(ctx context.Context) {
 // Close closes the state.
 func (g *gatewayImpl) Close() error {
 	g.retryTimer.Stop()
-	g.invalidate()
+
+	if atomic.SwapUint32(&g.keepSession, 0) == 0 {
+		g.invalidate()
+	}
+
 	return nil
 }
@@ -2,7 +2,10 @@ package gateway
 
 import (
 	"context"
+	"encoding/json"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
@@ -11,6 +14,7 @@ import (
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/internal/testenv"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
+	"github.com/gorilla/websocket"
 )
 
 func doLog(t *testing.T) {
@@ -170,3 +174,82 @@ func gatewayOpenAndSpin(t *testing.T, ctx context.Context, g *Gateway) {
 		}
 	}
 }
+
+func TestProxied(t *testing.T) {
+	doLog(t)
+
+	sent := make(chan ws.OpCode, 10)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Error("failed to upgrade:", err)
+			return
+		}
+		defer conn.Close()
+
+		payloads := []string{
+			`{"op":10,"d":{"heartbeat_interval":50}}`,
+			`{"op":0,"t":"READY","s":1,"d":{"session_id":"a","resume_gateway_url":"wss://127.0.0.1:1"}}`,
+			`{"op":0,"t":"TYPING_START","s":2,"d":{"channel_id":"1","user_id":"2"}}`,
+		}
+		for _, payload := range payloads {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(payload)); err != nil {
+				t.Error("failed to write:", err)
+				return
+			}
+		}
+
+		for {
+			var op struct {
+				Code ws.OpCode `json:"op"`
+			}
+			_, b, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := json.Unmarshal(b, &op); err != nil {
+				t.Error("failed to unmarshal command:", err)
+				return
+			}
+			if op.Code == heartbeatOp {
+				conn.WriteMessage(websocket.TextMessage, []byte(`{"op":11}`))
+			}
+			sent <- op.Code
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	state := State{Identifier: DefaultIdentifier("Bot token")}
+	g := NewProxied(strings.Replace(srv.URL, "http", "ws", 1), state, nil)
+	if !g.Proxied() {
+		t.Fatal("gateway is not proxied")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	ch := g.Connect(ctx)
+	for op := range ch {
+		if _, ok := op.Data.(*TypingStartEvent); ok {
+			break
+		}
+	}
+
+	if seq := g.state.Sequence; seq != 2 {
+		t.Fatalf("expected sequence 2, got %d", seq)
+	}
+
+	select {
+	case code := <-sent:
+		if code != heartbeatOp {
+			t.Fatalf("expected only heartbeats to be sent, got op %d", code)
+		}
+	case <-ctx.Done():
+		t.Fatal("no heartbeat was sent")
+	}
+
+	cancel()
+	for range ch {
+	}
+}
@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"strings"
@@ -17,6 +18,34 @@ import (
 // Gateway.
 var DefaultPresence *UpdatePresenceCommand
 
+// ErrIdentifyBudgetExhausted is returned by Identifier.Wait if
+// FailOnExhaustedBudget is true and the daily session start limit has been
+// used up.
+var ErrIdentifyBudgetExhausted = errors.New("daily identify budget exhausted")
+
+// IdentifyBudgetError is sent as a background error by the gateway when it's
+// about to identify while the daily session start limit is running low, which
+// is when at most a tenth of the total budget is remaining. If Remaining is 0,
+// then the identify will block until ResetAt.
+type IdentifyBudgetError struct {
+	Remaining int
+	Total     int
+	ResetAt   time.Time
+}
+
+// Error implements error.
+func (err *IdentifyBudgetError) Error() string {
+	if err.Remaining < 1 {
+		return fmt.Sprintf(
+			"identify budget exhausted (%d total), resets at %s",
+			err.Total, err.ResetAt.Format(time.RFC3339))
+	}
+
+	return fmt.Sprintf(
+		"identify budget low: %d/%d remaining, resets at %s",
+		err.Remaining, err.Total, err.ResetAt.Format(time.RFC3339))
+}
+
 // Identifier is a wrapper around IdentifyCommand to add in appropriate rate
 // limiters.
 type Identifier struct {
@@ -24,6 +53,16 @@ type Identifier struct {
 
 	IdentifyShortLimit  *rate.Limiter `json:"-"` // optional
 	IdentifyGlobalLimit *rate.Limiter `json:"-"` // optional
+
+	// BotData is the cached /gateway/bot response that was last given to
+	// SetBotData, usually through QueryGateway. It is nil if the gateway has
+	// never been queried with a bot token.
+	BotData *api.BotData `json:"-"`
+	// FailOnExhaustedBudget makes Wait return ErrIdentifyBudgetExhausted
+	// instead of blocking until the daily session start limit resets.
+	FailOnExhaustedBudget bool `json:"-"`
+
+	botDataTime time.Time
 }
 
 // DefaultIdentifier creates a new default Identifier
@@ -51,6 +90,10 @@ func (id *Identifier) Wait(ctx context.Context) error {
 	}
 
 	if id.IdentifyGlobalLimit != nil {
+		if id.FailOnExhaustedBudget && id.IdentifyGlobalLimit.Tokens() < 1 {
+			return ErrIdentifyBudgetExhausted
+		}
+
 		if err := id.IdentifyGlobalLimit.Wait(ctx); err != nil {
 			return fmt.Errorf("can't wait for global limit: %w", err)
 		}
@@ -62,36 +105,97 @@ func (id *Identifier) Wait(ctx context.Context) error {
 // QueryGateway queries the gateway for the URL and updates the Identifier with
 // the appropriate information.
 func (id *Identifier) QueryGateway(ctx context.Context) (gatewayURL string, err error) {
-	var botData *api.BotData
-
-	if strings.HasPrefix(id.Token, "Bot ") {
-		botData, err = BotURL(ctx, id.Token)
-		if err != nil {
-			return "", fmt.Errorf("failed to get bot data: %w", err)
-		}
-		gatewayURL = botData.URL
-	} else {
+	if !strings.HasPrefix(id.Token, "Bot ") {
 		gatewayURL, err = URL(ctx)
 		if err != nil {
 			return "", fmt.Errorf("failed to get gateway endpoint: %w", err)
 		}
+		return gatewayURL, nil
+	}
+
+	botData, err := BotURL(ctx, id.Token)
+	if err != nil {
+		return "", fmt.Errorf("failed to get bot data: %w", err)
 	}
 
+	id.SetBotData(botData)
+	return botData.URL, nil
+}
+
+// SetBotData caches the given /gateway/bot response and updates the
+// Identifier's rate limiters to use its session start limit, if any.
+func (id *Identifier) SetBotData(botData *api.BotData) {
+	id.BotData = botData
+	id.botDataTime = time.Now()
+
 	// Use the supplied connect rate limit, if any.
-	if botData != nil && botData.StartLimit != nil {
-		resetAt := time.Now().Add(botData.StartLimit.ResetAfter.Duration())
+	if botData.StartLimit == nil {
+		return
+	}
+
+	if id.IdentifyGlobalLimit != nil {
+		resetAt := id.botDataTime.Add(botData.StartLimit.ResetAfter.Duration())
 		limiter := id.IdentifyGlobalLimit
 
 		// Update the burst to be the current given time and reset it back to
 		// the default when the given time is reached.
 		limiter.SetBurst(botData.StartLimit.Remaining)
 		limiter.SetBurstAt(resetAt, botData.StartLimit.Total)
+	}
 
+	if id.IdentifyShortLimit != nil {
 		// Update the maximum number of identify requests allowed per 5s.
 		id.IdentifyShortLimit.SetBurst(botData.StartLimit.MaxConcurrency)
 	}
+}
+
+// SessionStartLimit returns the session start limit from the cached BotData.
+// Remaining is estimated from the identify rate limiter, so identifies done
+// since the data was fetched are accounted for, and ResetAfter is relative to
+// now. False is returned if there is no cached session start limit.
+func (id *Identifier) SessionStartLimit() (api.SessionStartLimit, bool) {
+	if id.BotData == nil || id.BotData.StartLimit == nil {
+		return api.SessionStartLimit{}, false
+	}
+
+	limit := *id.BotData.StartLimit
+
+	resetAt := id.botDataTime.Add(limit.ResetAfter.Duration())
+	if resetAfter := time.Until(resetAt); resetAfter > 0 {
+		limit.ResetAfter = discord.DurationToMilliseconds(resetAfter)
+	} else {
+		// The limit has already been reset since we last fetched it.
+		limit.ResetAfter = 0
+		limit.Remaining = limit.Total
+	}
 
-	return
+	if id.IdentifyGlobalLimit != nil {
+		tokens := int(id.IdentifyGlobalLimit.Tokens())
+		if tokens < 0 {
+			tokens = 0
+		}
+		if tokens < limit.Remaining {
+			limit.Remaining = tokens
+		}
+	}
+
+	return limit, true
+}
+
+// budgetError returns an IdentifyBudgetError if the session start limit is
+// running low, or nil if it's not. A session start limit with a Total of 0 is
+// treated as unknown, since the field may be absent.
+func (id *Identifier) budgetError() error {
+	limit, ok := id.SessionStartLimit()
+	if !ok || limit.Total == 0 || limit.Remaining > limit.Total/10 {
+		return nil
+	}
+
+	return &IdentifyBudgetError{
+		Remaining: limit.Remaining,
+		Total:     limit.Total,
+		ResetAt:   time.Now().Add(limit.ResetAfter.Duration()),
+	}
 }
 
 // DefaultIdentity is used as the default identity when initializing a new
@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestIdentifierSessionStartLimit(t *testing.T) {
+	id := DefaultIdentifier("Bot token")
+
+	if _, ok := id.SessionStartLimit(); ok {
+		t.Fatal("unexpected session start limit without bot data")
+	}
+
+	id.SetBotData(&api.BotData{
+		URL: "wss://gateway.discord.gg",
+		StartLimit: &api.SessionStartLimit{
+			Total:          1000,
+			Remaining:      2,
+			ResetAfter:     discord.DurationToMilliseconds(time.Hour),
+			MaxConcurrency: 1,
+		},
+	})
+
+	limit, ok := id.SessionStartLimit()
+	if !ok {
+		t.Fatal("missing session start limit")
+	}
+	if limit.Remaining != 2 || limit.Total != 1000 {
+		t.Fatalf("unexpected limit %+v", limit)
+	}
+
+	if err := id.budgetError(); err == nil {
+		t.Fatal("expected low budget error")
+	}
+
+	unknown := DefaultIdentifier("Bot token")
+	unknown.SetBotData(&api.BotData{StartLimit: &api.SessionStartLimit{}})
+	if err := unknown.budgetError(); err != nil {
+		t.Fatal("unexpected budget error without a total:", err)
+	}
+
+	ctx := context.Background()
+	id.IdentifyShortLimit = nil
+	id.FailOnExhaustedBudget = true
+
+	for i := 0; i < 2; i++ {
+		if err := id.Wait(ctx); err != nil {
+			t.Fatalf("identify %d failed: %v", i, err)
+		}
+	}
+
+	if err := id.Wait(ctx); !errors.Is(err, ErrIdentifyBudgetExhausted) {
+		t.Fatalf("expected exhausted budget, got %v", err)
+	}
+
+	limit, _ = id.SessionStartLimit()
+	if limit.Remaining != 0 {
+		t.Fatalf("expected no remaining identifies, got %d", limit.Remaining)
+	}
+}
@@ -1,6 +1,9 @@
 package gateway
 
 import (
+	"reflect"
+	"sync"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
 )
@@ -29,6 +32,20 @@ const (
 	IntentGuildScheduledEvents
 )
 
+const (
+	// IntentAutoModerationConfiguration is the intent for changes to auto
+	// moderation rules.
+	IntentAutoModerationConfiguration Intents = 1 << 20
+	// IntentAutoModerationExecution is the intent for auto moderation actions
+	// being executed.
+	IntentAutoModerationExecution Intents = 1 << 21
+	// IntentGuildMessagePolls is the intent for poll votes in guilds.
+	IntentGuildMessagePolls Intents = 1 << 24
+	// IntentDirectMessagePolls is the intent for poll votes in direct
+	// messages.
+	IntentDirectMessagePolls Intents = 1 << 25
+)
+
 // IntentGuildBans is an alias to IntentGuildModeration.
 //
 // Deprecated: IntentGuildModeration is the more correct constant to use.
@@ -66,6 +83,10 @@ var EventIntents = map[ws.EventType]Intents{
 	"CHANNEL_DELETE":      IntentGuilds,
 	"CHANNEL_PINS_UPDATE": IntentGuilds | IntentDirectMessages,
 
+	"STAGE_INSTANCE_CREATE": IntentGuilds,
+	"STAGE_INSTANCE_UPDATE": IntentGuilds,
+	"STAGE_INSTANCE_DELETE": IntentGuilds,
+
 	"GUILD_MEMBER_ADD":    IntentGuildMembers,
 	"GUILD_MEMBER_REMOVE": IntentGuildMembers,
 	"GUILD_MEMBER_UPDATE": IntentGuildMembers,
@@ -74,16 +95,24 @@ var EventIntents = map[ws.EventType]Intents{
 	"GUILD_BAN_ADD":                IntentGuildModeration,
 	"GUILD_BAN_REMOVE":             IntentGuildModeration,
 
-	"GUILD_EMOJIS_UPDATE": IntentGuildEmojis,
+	"GUILD_EMOJIS_UPDATE":            IntentGuildEmojis,
+	"GUILD_SOUNDBOARD_SOUND_CREATE":  IntentGuildEmojis,
+	"GUILD_SOUNDBOARD_SOUND_UPDATE":  IntentGuildEmojis,
+	"GUILD_SOUNDBOARD_SOUND_DELETE":  IntentGuildEmojis,
+	"GUILD_SOUNDBOARD_SOUNDS_UPDATE": IntentGuildEmojis,
 
 	"GUILD_INTEGRATIONS_UPDATE": IntentGuildIntegrations,
+	"INTEGRATION_CREATE":        IntentGuildIntegrations,
+	"INTEGRATION_UPDATE":        IntentGuildIntegrations,
+	"INTEGRATION_DELETE":        IntentGuildIntegrations,
 
 	"WEBHOOKS_UPDATE": IntentGuildWebhooks,
 
 	"INVITE_CREATE": IntentGuildInvites,
 	"INVITE_DELETE": IntentGuildInvites,
 
-	"VOICE_STATE_UPDATE": IntentGuildVoiceStates,
+	"VOICE_STATE_UPDATE":        IntentGuildVoiceStates,
+	"VOICE_CHANNEL_EFFECT_SEND": IntentGuildVoiceStates,
 
 	"PRESENCE_UPDATE": IntentGuildPresences,
 
@@ -99,9 +128,76 @@ var EventIntents = map[ws.EventType]Intents{
 
 	"TYPING_START": IntentGuildMessageTyping | IntentDirectMessageTyping,
 
+	"MESSAGE_POLL_VOTE_ADD":    IntentGuildMessagePolls | IntentDirectMessagePolls,
+	"MESSAGE_POLL_VOTE_REMOVE": IntentGuildMessagePolls | IntentDirectMessagePolls,
+
 	"GUILD_SCHEDULED_EVENT_CREATE":      IntentGuildScheduledEvents,
 	"GUILD_SCHEDULED_EVENT_UPDATE":      IntentGuildScheduledEvents,
 	"GUILD_SCHEDULED_EVENT_DELETE":      IntentGuildScheduledEvents,
 	"GUILD_SCHEDULED_EVENT_USER_ADD":    IntentGuildScheduledEvents,
 	"GUILD_SCHEDULED_EVENT_USER_REMOVE": IntentGuildScheduledEvents,
+
+	"AUTO_MODERATION_RULE_CREATE": IntentAutoModerationConfiguration,
+	"AUTO_MODERATION_RULE_UPDATE": IntentAutoModerationConfiguration,
+	"AUTO_MODERATION_RULE_DELETE": IntentAutoModerationConfiguration,
+
+	"AUTO_MODERATION_ACTION_EXECUTION": IntentAutoModerationExecution,
+}
+
+var (
+	typeIntents     map[reflect.Type]Intents
+	typeIntentsOnce sync.Once
+)
+
+// IntentsForType returns the intents needed to receive the event with the given
+// Go type, such as reflect.TypeOf((*MessageCreateEvent)(nil)). It returns 0
+// for events that don't need any intent. It uses EventIntents and
+// OpUnmarshalers, which must not be changed after it's first called.
+func IntentsForType(t reflect.Type) Intents {
+	typeIntentsOnce.Do(func() {
+		typeIntents = map[reflect.Type]Intents{}
+		OpUnmarshalers.Each(func(_ ws.OpCode, t ws.EventType, f ws.OpFunc) bool {
+			if intent, ok := EventIntents[t]; ok {
+				typeIntents[reflect.TypeOf(f())] = intent
+			}
+			return false
+		})
+	})
+
+	return typeIntents[t]
+}
+
+// messageContentTypes are the events whose messages have empty contents,
+// embeds, attachments and components without IntentMessageContent.
+var messageContentTypes = []reflect.Type{
+	reflect.TypeOf((*MessageCreateEvent)(nil)),
+	reflect.TypeOf((*MessageUpdateEvent)(nil)),
+}
+
+// InferIntents returns the intents needed to receive the events with the given
+// Go types. privileged contains the privileged intents among them, which must
+// also be enabled in the Developer Portal.
+//
+// IntentMessageContent is included in both if there's a MessageCreateEvent or
+// MessageUpdateEvent, since handlers of those most likely read the message
+// content. It's also privileged, so drop it if the bot doesn't need it.
+func InferIntents(types ...reflect.Type) (intents, privileged Intents) {
+	for _, t := range types {
+		intents |= IntentsForType(t)
+
+		for _, contentType := range messageContentTypes {
+			if t == contentType {
+				intents |= IntentMessageContent
+				privileged |= IntentMessageContent
+			}
+		}
+	}
+
+	for _, intent := range PrivilegedIntents {
+		if intents.Has(intent) {
+			privileged |= intent
+		}
+	}
+
+	return intents, privileged
 }
@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// GuildMembers aggregates the GuildMembersChunkEvents that are sent in response
+// to a single RequestGuildMembersCommand. Its zero value is not usable; use
+// NewGuildMembers instead.
+type GuildMembers struct {
+	GuildID   discord.GuildID
+	Members   []discord.Member
+	Presences []discord.Presence
+	// NotFound contains the IDs of the requested users that weren't found.
+	NotFound []string
+	// Nonce is the nonce of the request. Only chunks with this nonce are
+	// added.
+	Nonce string
+
+	received []bool
+	left     int
+}
+
+// NewGuildMembers creates a new GuildMembers for the given command. If the
+// command doesn't have a nonce, a new one is generated and set.
+func NewGuildMembers(cmd *RequestGuildMembersCommand) *GuildMembers {
+	if cmd.Nonce == "" {
+		cmd.Nonce = api.NewNonce()
+	}
+
+	m := GuildMembers{Nonce: cmd.Nonce}
+	if len(cmd.GuildIDs) > 0 {
+		m.GuildID = cmd.GuildIDs[0]
+	}
+
+	return &m
+}
+
+// Add adds the members in the given chunk. Chunks that belong to other
+// requests or were already added are ignored. It returns true once all chunks
+// have been added.
+func (m *GuildMembers) Add(ev *GuildMembersChunkEvent) (done bool) {
+	if ev.Nonce != m.Nonce || m.Done() {
+		return m.Done()
+	}
+
+	if m.received == nil {
+		m.received = make([]bool, ev.ChunkCount)
+		m.left = ev.ChunkCount
+	}
+
+	if ev.ChunkIndex < 0 || ev.ChunkIndex >= len(m.received) || m.received[ev.ChunkIndex] {
+		return false
+	}
+
+	m.received[ev.ChunkIndex] = true
+	m.left--
+
+	m.GuildID = ev.GuildID
+	m.Members = append(m.Members, ev.Members...)
+	m.Presences = append(m.Presences, ev.Presences...)
+	m.NotFound = append(m.NotFound, ev.NotFound...)
+
+	return m.Done()
+}
+
+// Done returns true if all chunks have been added.
+func (m *GuildMembers) Done() bool {
+	return m.received != nil && m.left <= 0
+}
@@ -0,0 +1,47 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestGuildMembers(t *testing.T) {
+	cmd := RequestGuildMembersCommand{GuildIDs: []discord.GuildID{1}}
+	members := NewGuildMembers(&cmd)
+
+	if cmd.Nonce == "" || members.Nonce != cmd.Nonce {
+		t.Fatalf("unexpected nonce %q in command, %q in members", cmd.Nonce, members.Nonce)
+	}
+
+	chunk := func(index int, nonce string, userID discord.UserID) *GuildMembersChunkEvent {
+		return &GuildMembersChunkEvent{
+			GuildID:    1,
+			Members:    []discord.Member{{User: discord.User{ID: userID}}},
+			ChunkIndex: index,
+			ChunkCount: 3,
+			Nonce:      nonce,
+		}
+	}
+
+	steps := []struct {
+		ev   *GuildMembersChunkEvent
+		done bool
+	}{
+		{chunk(1, cmd.Nonce, 2), false},
+		{chunk(0, "other", 9), false},
+		{chunk(1, cmd.Nonce, 2), false},
+		{chunk(0, cmd.Nonce, 1), false},
+		{chunk(2, cmd.Nonce, 3), true},
+	}
+
+	for i, step := range steps {
+		if done := members.Add(step.ev); done != step.done {
+			t.Errorf("step %d: expected done=%v", i, step.done)
+		}
+	}
+
+	if len(members.Members) != 3 {
+		t.Fatalf("expected 3 members, got %d", len(members.Members))
+	}
+}
@@ -0,0 +1,68 @@
+package gateway
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// NewPresence creates a new UpdatePresenceCommand with the given status and
+// activities. The methods on the returned command can be chained:
+//
+//	p := gateway.NewPresence(discord.OnlineStatus,
+//		discord.NewActivity(discord.WatchingActivity, "the logs"),
+//	)
+//
+//	p = gateway.NewPresence(discord.IdleStatus).
+//		WithActivity(discord.NewCustomActivity("brb", nil)).
+//		WithIdleSince(time.Now())
+func NewPresence(status discord.Status, activities ...*discord.Activity) *UpdatePresenceCommand {
+	p := UpdatePresenceCommand{
+		Activities: make([]discord.Activity, 0, len(activities)),
+		Status:     status,
+	}
+
+	for _, activity := range activities {
+		p.Activities = append(p.Activities, *activity)
+	}
+
+	return &p
+}
+
+// WithActivity adds the given activity to the presence.
+func (p *UpdatePresenceCommand) WithActivity(a *discord.Activity) *UpdatePresenceCommand {
+	p.Activities = append(p.Activities, *a)
+	return p
+}
+
+// WithStatus sets the status of the presence.
+func (p *UpdatePresenceCommand) WithStatus(status discord.Status) *UpdatePresenceCommand {
+	p.Status = status
+	return p
+}
+
+// WithIdleSince marks the presence as AFK since the given time.
+func (p *UpdatePresenceCommand) WithIdleSince(t time.Time) *UpdatePresenceCommand {
+	p.Since = discord.TimeToMilliseconds(t)
+	p.AFK = true
+	return p
+}
+
+// Validate validates the presence and all of its activities.
+func (p *UpdatePresenceCommand) Validate() error {
+	switch p.Status {
+	case discord.OnlineStatus, discord.DoNotDisturbStatus, discord.IdleStatus,
+		discord.InvisibleStatus, discord.OfflineStatus:
+	default:
+		return fmt.Errorf("invalid status %q", p.Status)
+	}
+
+	for i := range p.Activities {
+		if err := p.Activities[i].Validate(); err != nil {
+			return fmt.Errorf("activity %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestNewPresence(t *testing.T) {
+	p := NewPresence(discord.IdleStatus,
+		discord.NewActivity(discord.WatchingActivity, "the logs"),
+	).
+		WithActivity(discord.NewCustomActivity("brb", nil)).
+		WithIdleSince(time.Unix(10, 0))
+
+	if err := p.Validate(); err != nil {
+		t.Fatal("unexpected invalid presence:", err)
+	}
+
+	if len(p.Activities) != 2 || !p.AFK || p.Since != 10000 {
+		t.Fatalf("unexpected presence %+v", p)
+	}
+
+	p.WithActivity(discord.NewStreamingActivity("live", "https://example.com"))
+	if err := p.Validate(); err == nil {
+		t.Error("expected invalid streaming URL error")
+	}
+
+	if err := NewPresence("away").Validate(); err == nil {
+		t.Error("expected invalid status error")
+	}
+}
@@ -0,0 +1,105 @@
+package gateway
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+// EventInfo describes an event registered in OpUnmarshalers.
+type EventInfo struct {
+	// Op is the event's opcode.
+	Op ws.OpCode
+	// Name is the event's name, such as "MESSAGE_CREATE". It is only set for
+	// dispatch events.
+	Name ws.EventType
+	// Type is the event's Go type, which is always a pointer type, such as
+	// *MessageCreateEvent. It is the type that handlers are called with.
+	Type reflect.Type
+	// New creates a new zero-value instance of the event.
+	New ws.OpFunc
+}
+
+// IsDispatch returns true if the event is a dispatch event.
+func (info EventInfo) IsDispatch() bool {
+	return info.Op == dispatchOp
+}
+
+// Events returns all events and commands registered in OpUnmarshalers,
+// including ones added by other packages. The list is sorted by opcode, then
+// by name.
+func Events() []EventInfo {
+	var events []EventInfo
+
+	OpUnmarshalers.Each(func(op ws.OpCode, name ws.EventType, fn ws.OpFunc) bool {
+		events = append(events, EventInfo{
+			Op:   op,
+			Name: name,
+			Type: reflect.TypeOf(fn()),
+			New:  fn,
+		})
+		return false
+	})
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Op != events[j].Op {
+			return events[i].Op < events[j].Op
+		}
+		return events[i].Name < events[j].Name
+	})
+
+	return events
+}
+
+// EventByName returns the dispatch event with the given name, such as
+// "MESSAGE_CREATE".
+func EventByName(name ws.EventType) (EventInfo, bool) {
+	fn := OpUnmarshalers.Lookup(dispatchOp, name)
+	if fn == nil {
+		return EventInfo{}, false
+	}
+
+	return EventInfo{
+		Op:   dispatchOp,
+		Name: name,
+		Type: reflect.TypeOf(fn()),
+		New:  fn,
+	}, true
+}
+
+// EventByType returns the event with the given Go type, such as
+// reflect.TypeOf((*MessageCreateEvent)(nil)).
+func EventByType(t reflect.Type) (EventInfo, bool) {
+	var info EventInfo
+	var found bool
+
+	OpUnmarshalers.Each(func(op ws.OpCode, name ws.EventType, fn ws.OpFunc) bool {
+		ev := fn()
+		if reflect.TypeOf(ev) != t {
+			return false
+		}
+
+		info = EventInfo{
+			Op:   op,
+			Name: name,
+			Type: t,
+			New:  fn,
+		}
+		found = true
+		return true
+	})
+
+	return info, found
+}
+
+// EventNameOf returns the dispatch event name of the given event, such as
+// "MESSAGE_CREATE" for *MessageCreateEvent. It is a shortcut for calling the
+// event's EventType method, except it also checks that the event is a
+// dispatch event.
+func EventNameOf(ev ws.Event) (ws.EventType, bool) {
+	if ev.Op() != dispatchOp {
+		return "", false
+	}
+	return ev.EventType(), true
+}
@@ -3,6 +3,7 @@ module github.com/diamondburned/arikawa/v3
 go 1.16
 
 require (
+	github.com/andybalholm/brotli v1.1.1
 	github.com/gorilla/schema v1.3.0
 	github.com/gorilla/websocket v1.5.1
 	golang.org/x/crypto v0.23.0
@@ -23,6 +23,16 @@ func (m *CtxMutex) Lock(ctx context.Context) error {
 	}
 }
 
+// TryLock returns true if the mutex has been locked without blocking.
+func (m *CtxMutex) TryLock() bool {
+	select {
+	case m.mut <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
 // TryUnlock returns true if the mutex has been unlocked.
 func (m *CtxMutex) TryUnlock() bool {
 	select {
@@ -0,0 +1,66 @@
+package session
+
+import (
+	"context"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+// RequestGuildMembers sends the given command over the gateway and waits until
+// all of the members chunks sent in response are received. A nonce is
+// generated if the command doesn't have one; only one guild can be requested
+// at once.
+//
+// ctx should have a timeout, since Discord doesn't respond if the request is
+// invalid. If ctx expires, the members received so far are returned along with
+// the context's error.
+func (s *Session) RequestGuildMembers(
+	ctx context.Context, cmd gateway.RequestGuildMembersCommand) (*gateway.GuildMembers, error) {
+
+	members := gateway.NewGuildMembers(&cmd)
+	done := make(chan struct{})
+
+	var mu sync.Mutex
+	var finished bool
+
+	rm := s.AddSyncHandler(func(ev *gateway.GuildMembersChunkEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !finished && members.Add(ev) {
+			finished = true
+			close(done)
+		}
+	})
+	defer rm()
+
+	if err := s.SendGateway(ctx, &cmd); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-done:
+		return members, nil
+	case <-ctx.Done():
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Stop the handler from adding more chunks.
+		if !finished {
+			finished = true
+			return members, ctx.Err()
+		}
+
+		return members, nil
+	}
+}
+
+// RequestGuildMembersAsync is the asynchronous variant of RequestGuildMembers.
+// f is called once in a new goroutine with what RequestGuildMembers returns.
+func (s *Session) RequestGuildMembersAsync(
+	ctx context.Context, cmd gateway.RequestGuildMembersCommand,
+	f func(*gateway.GuildMembers, error)) {
+
+	go func() { f(s.RequestGuildMembers(ctx, cmd)) }()
+}
@@ -0,0 +1,79 @@
+package session
+
+import (
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/handler"
+)
+
+// Option is an option for constructing a Session using New.
+type Option func(*options)
+
+type options struct {
+	id         gateway.Identifier
+	client     *api.Client
+	handler    *handler.Handler
+	pool       handler.Pool
+	gatewayURL string
+	proxied    bool
+}
+
+// WithIntents adds the given intents to the identifier.
+func WithIntents(intents ...gateway.Intents) Option {
+	return func(o *options) {
+		for _, intent := range intents {
+			o.id.AddIntents(intent)
+		}
+	}
+}
+
+// WithShard sets the shard of the Session to the given shard ID out of the
+// given total number of shards. For running many shards, consider using
+// package shard instead.
+func WithShard(id, total int) Option {
+	return func(o *options) {
+		o.id.Shard = &gateway.Shard{id, total}
+	}
+}
+
+// WithIdentifier calls fn with the identifier, allowing any of its fields to
+// be changed, such as the presence or the large threshold.
+func WithIdentifier(fn func(*gateway.Identifier)) Option {
+	return func(o *options) { fn(&o.id) }
+}
+
+// WithGatewayURL makes the Session connect to the given Gateway URL instead of
+// querying it from Discord when it is opened. The Gateway parameters are
+// added to the URL.
+func WithGatewayURL(url string) Option {
+	return func(o *options) { o.gatewayURL = url }
+}
+
+// WithGatewayProxy makes the Session connect to a gateway proxy at the given
+// URL, which owns the Discord sessions, instead of Discord itself. Refer to
+// gateway.NewProxied. The Gateway parameters are added to the URL.
+func WithGatewayProxy(url string) Option {
+	return func(o *options) {
+		o.gatewayURL = url
+		o.proxied = true
+	}
+}
+
+// WithClient makes the Session use the given API client instead of creating
+// one from the token.
+func WithClient(client *api.Client) Option {
+	return func(o *options) { o.client = client }
+}
+
+// WithHandler makes the Session use the given handler instead of creating a
+// new one.
+func WithHandler(h *handler.Handler) Option {
+	return func(o *options) { o.handler = h }
+}
+
+// WithHandlerPool makes the Session run its asynchronous event handlers in the
+// given pool, such as a handler.WorkerPool, instead of spawning a goroutine
+// for each handler call.
+func WithHandlerPool(pool handler.Pool) Option {
+	return func(o *options) { o.pool = pool }
+}
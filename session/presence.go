@@ -0,0 +1,56 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+// MinPresenceRotation is the shortest interval that RotatePresence switches
+// presences at. It is longer than gateway.PresenceInterval, so that a rotation
+// never uses up the presence rate limit and other presence updates still go
+// through right away.
+const MinPresenceRotation = 2 * gateway.PresenceInterval
+
+// RotatePresence cycles through the given presences, switching to the next one
+// every interval, until ctx is done. Intervals shorter than
+// MinPresenceRotation are raised to it. The first presence is set right away.
+//
+// RotatePresence blocks until ctx is done and returns its error, unless a
+// presence is invalid. Presences that fail to send, such as while the gateway
+// is reconnecting, are skipped.
+func (s *Session) RotatePresence(
+	ctx context.Context, interval time.Duration,
+	presences ...*gateway.UpdatePresenceCommand) error {
+
+	if len(presences) == 0 {
+		return errors.New("no presences to rotate")
+	}
+
+	for i, p := range presences {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("presence %d: %w", i, err)
+		}
+	}
+
+	if interval < MinPresenceRotation {
+		interval = MinPresenceRotation
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for i := 0; ; i = (i + 1) % len(presences) {
+		// The gateway waits for its presence rate limiter before sending.
+		s.SendGateway(ctx, presences[i])
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -9,12 +9,15 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/api/webhook"
+	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/utils/handler"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/diamondburned/arikawa/v3/utils/telemetry"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
 	"github.com/diamondburned/arikawa/v3/utils/ws/ophandler"
 )
@@ -45,8 +48,18 @@ type Session struct {
 	// this is true, then any event sent by Discord will unblock Open (usually
 	// HELLO).
 	DontWaitForReady bool // false
+
+	// OfflineOnClose, if true, makes Close set the presence to invisible and
+	// wait for it to be sent before closing the gateway. This makes the bot
+	// appear offline immediately instead of lingering until Discord expires
+	// the session. Waiting is bounded by OfflineTimeout.
+	OfflineOnClose bool // false
 }
 
+// OfflineTimeout is the maximum duration that Close waits for the invisible
+// presence to be sent if OfflineOnClose is true.
+var OfflineTimeout = 5 * time.Second
+
 type sessionState struct {
 	sync.Mutex
 	id      gateway.Identifier
@@ -55,6 +68,8 @@ type sessionState struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	doneCh <-chan struct{}
+
+	resume *gateway.ResumeState
 }
 
 // NewWithIntents is similar to New but adds the given intents in during
@@ -71,10 +86,39 @@ func NewWithIntents(token string, intents ...gateway.Intents) *Session {
 	return NewWithIdentifier(id)
 }
 
-// New creates a new session from a given token. Most bots should be using
-// NewWithIntents instead.
-func New(token string) *Session {
-	return NewWithIdentifier(gateway.DefaultIdentifier(token))
+// New creates a new session from a given token and options. Most bots should
+// at least give the intents that they need using WithIntents:
+//
+//	s := session.New("Bot "+token,
+//		session.WithIntents(gateway.IntentGuildMessages),
+//		session.WithShard(0, 2),
+//	)
+func New(token string, opts ...Option) *Session {
+	o := options{id: gateway.DefaultIdentifier(token)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.client == nil {
+		o.client = api.NewClient(o.id.Token)
+	}
+	if o.handler == nil {
+		o.handler = handler.New()
+	}
+	if o.pool != nil {
+		o.handler.SetPool(o.pool)
+	}
+
+	var g *gateway.Gateway
+	switch {
+	case o.proxied:
+		g = gateway.NewProxied(
+			gateway.AddGatewayParams(o.gatewayURL), gateway.State{Identifier: o.id}, nil)
+	case o.gatewayURL != "":
+		g = gateway.NewCustomWithIdentifier(gateway.AddGatewayParams(o.gatewayURL), o.id, nil)
+	}
+
+	return newCustom(o.id, o.client, o.handler, g)
 }
 
 // Login tries to log in as a normal user account; MFA is optional.
@@ -146,6 +190,16 @@ func newCustom(
 	}
 }
 
+// InferIntents returns the intents needed to receive the events that the
+// Session has handlers for. privileged contains the privileged intents among
+// them, which must also be enabled in the Developer Portal; it's a good idea
+// to warn about them. Handlers that take an interface, such as interface{},
+// aren't considered. The returned intents can be given to AddIntents. See
+// gateway.InferIntents for when IntentMessageContent is included.
+func (s *Session) InferIntents() (intents, privileged gateway.Intents) {
+	return gateway.InferIntents(s.Handler.EventTypes()...)
+}
+
 // AddIntents adds the given intents into the gateway. Calling it after Open has
 // already been called will result in a panic.
 func (s *Session) AddIntents(intents gateway.Intents) {
@@ -168,6 +222,26 @@ func (s *Session) HasIntents(intents gateway.Intents) bool {
 	return s.state.id.HasIntents(intents)
 }
 
+// BotData returns the /gateway/bot response cached by the session when it
+// was first opened. Nil is returned if the session has never been opened or if
+// it's not using a bot token.
+func (s *Session) BotData() *api.BotData {
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	return s.state.id.BotData
+}
+
+// SessionStartLimit returns the current session start limit, that is, the
+// daily budget of identifies. Refer to gateway.Identifier's SessionStartLimit
+// for more information.
+func (s *Session) SessionStartLimit() (api.SessionStartLimit, bool) {
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	return s.state.id.SessionStartLimit()
+}
+
 // Gateway returns the current session's gateway. If Open has never been called
 // or Session was never constructed with a gateway, then nil is returned.
 func (s *Session) Gateway() *gateway.Gateway {
@@ -271,7 +345,9 @@ func (s *Session) Connect(ctx context.Context) error {
 }
 
 // Open opens the Discord gateway and its handler, then waits until either the
-// Ready or Resumed event gets through. Prefer using Connect instead of Open.
+// Ready or Resumed event gets through. If the gateway is proxied, then any event
+// unblocks Open, since the proxy may not send either. Prefer using Connect
+// instead of Open.
 func (s *Session) Open(ctx context.Context) error {
 	evCh := make(chan interface{})
 
@@ -285,11 +361,20 @@ func (s *Session) Open(ctx context.Context) error {
 	}
 
 	if s.state.gateway == nil {
-		g, err := gateway.NewWithIdentifier(ctx, s.state.id)
+		// Query the gateway using our own identifier, so that the /gateway/bot
+		// response is cached within the session.
+		url, err := s.state.id.QueryGateway(ctx)
 		if err != nil {
 			return err
 		}
-		s.state.gateway = g
+		s.state.gateway = gateway.NewCustomWithIdentifier(
+			gateway.AddGatewayParams(url), s.state.id, nil)
+	}
+
+	if s.state.resume != nil {
+		state := s.state.gateway.State()
+		s.state.gateway.SetState(state.WithResumeState(*s.state.resume))
+		s.state.resume = nil
 	}
 
 	// Make a context that's stored in state so this can be used throughout.
@@ -300,7 +385,11 @@ func (s *Session) Open(ctx context.Context) error {
 	defer rm()
 
 	opCh := s.state.gateway.Connect(s.state.ctx)
-	s.state.doneCh = ophandler.Loop(opCh, s.Handler)
+	if s.Telemetry.Enabled() {
+		s.state.doneCh = s.instrumentedLoop(s.state.ctx, opCh)
+	} else {
+		s.state.doneCh = ophandler.Loop(opCh, s.Handler)
+	}
 
 	for {
 		select {
@@ -317,7 +406,7 @@ func (s *Session) Open(ctx context.Context) error {
 			return s.state.gateway.LastError()
 
 		case ev := <-evCh:
-			if s.DontWaitForReady {
+			if s.DontWaitForReady || s.state.gateway.Proxied() {
 				return nil
 			}
 
@@ -329,6 +418,31 @@ func (s *Session) Open(ctx context.Context) error {
 	}
 }
 
+// instrumentedLoop is ophandler.Loop, except every event is reported to the
+// client's telemetry provider.
+func (s *Session) instrumentedLoop(ctx context.Context, src <-chan ws.Op) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		for op := range src {
+			attrs := []telemetry.Attribute{
+				telemetry.Int(telemetry.KeyOp, int(op.Code)),
+				telemetry.String(telemetry.KeyEvent, string(op.Type)),
+			}
+
+			start := time.Now()
+			ctx, span := s.Telemetry.Start(ctx, telemetry.SpanGatewayEvent, attrs...)
+			s.Telemetry.Add(ctx, telemetry.MetricGatewayEvents, 1, attrs...)
+
+			s.Handler.Call(op.Data)
+
+			span.End()
+			s.Telemetry.RecordDuration(ctx, telemetry.MetricGatewayDispatch, start, attrs...)
+		}
+		close(done)
+	}()
+	return done
+}
+
 // Wait blocks until either ctx is done or the gateway stumbles on an
 // unrecoverable error.
 func (s *Session) Wait(ctx context.Context) error {
@@ -405,10 +519,26 @@ func (s *Session) SendGateway(ctx context.Context, m ws.Event) error {
 	return s.Gateway().Send(ctx, m)
 }
 
+// GoOffline sets the presence to invisible and waits until it is sent. The
+// previous presence is not restored; use Gateway().Send with an
+// UpdatePresenceCommand to go online again.
+func (s *Session) GoOffline(ctx context.Context) error {
+	return s.SendGateway(ctx, offlinePresence())
+}
+
+func offlinePresence() *gateway.UpdatePresenceCommand {
+	return &gateway.UpdatePresenceCommand{
+		Activities: []discord.Activity{},
+		Status:     discord.InvisibleStatus,
+	}
+}
+
 // Close closes the underlying Websocket connection, invalidating the session
 // ID. It will send a closing frame before ending the connection, closing it
 // gracefully. This will cause the bot to appear as offline instantly. To
 // prevent this behavior, change Gateway.AlwaysCloseGracefully.
+//
+// If OfflineOnClose is true, the presence is set to invisible first.
 func (s *Session) Close() error {
 	s.state.Lock()
 	defer s.state.Unlock()
@@ -416,11 +546,52 @@ func (s *Session) Close() error {
 	return s.close()
 }
 
+// CloseResumable closes the gateway like Close, except the session is kept by
+// Discord and the state needed to resume it is returned. OfflineOnClose is
+// ignored. Passing the returned state to Resume, such as in a new process after
+// restarting the bot, resumes the session without missing any events, as long
+// as it's done before Discord expires the session.
+func (s *Session) CloseResumable() (gateway.ResumeState, error) {
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	if s.state.cancel == nil {
+		return gateway.ResumeState{}, ErrClosed
+	}
+
+	s.state.gateway.SkipGracefulClose()
+
+	s.state.cancel()
+	s.state.cancel = nil
+	s.state.ctx = nil
+
+	<-s.state.doneCh
+	s.state.doneCh = nil
+
+	return s.state.gateway.State().ResumeState(), s.state.gateway.LastError()
+}
+
+// Resume makes the next call to Open or Connect resume the session in the
+// given state instead of starting a new one. If Discord refuses to resume it,
+// a new session is started.
+func (s *Session) Resume(state gateway.ResumeState) {
+	s.state.Lock()
+	s.state.resume = &state
+	s.state.Unlock()
+}
+
 func (s *Session) close() error {
 	if s.state.cancel == nil {
 		return ErrClosed
 	}
 
+	if s.OfflineOnClose && s.gatewayIsAlive() {
+		ctx, cancel := context.WithTimeout(s.state.ctx, OfflineTimeout)
+		// Ignore the error, since the gateway is closed either way.
+		s.state.gateway.Send(ctx, offlinePresence())
+		cancel()
+	}
+
 	s.state.cancel()
 	s.state.cancel = nil
 	s.state.ctx = nil
@@ -2,11 +2,16 @@ package session
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/internal/testenv"
+	"github.com/gorilla/websocket"
 )
 
 func TestSession(t *testing.T) {
@@ -92,3 +97,173 @@ func TestSessionConnect(t *testing.T) {
 		time.Sleep(time.Second)
 	}
 }
+
+// resumeCommand is the first command sent by the client to the fake gateway.
+type resumeCommand struct {
+	Op   int    // 2 for IDENTIFY or 6 for RESUME
+	Path string // path of the connection
+	Data gateway.ResumeCommand
+}
+
+// newResumeServer creates a fake gateway. A client that identifies gets the
+// session "abc" with a TYPING_START event of sequence 2, and a client that
+// resumes gets RESUMED. The first command of each connection is sent to cmds.
+// The identified session must not be closed gracefully, since that ends it.
+func newResumeServer(t *testing.T, cmds chan<- resumeCommand) (gatewayURL, resumeURL string) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Error("failed to upgrade:", err)
+			return
+		}
+		defer conn.Close()
+
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"op":10,"d":{"heartbeat_interval":45000}}`))
+
+		var cmd struct {
+			Op   int             `json:"op"`
+			Data json.RawMessage `json:"d"`
+		}
+		for {
+			if err := conn.ReadJSON(&cmd); err != nil {
+				t.Error("failed to read command:", err)
+				return
+			}
+			if cmd.Op != 1 { // skip heartbeats
+				break
+			}
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"op":11}`))
+		}
+
+		sent := resumeCommand{Op: cmd.Op, Path: r.URL.Path}
+		var payloads []string
+
+		switch cmd.Op {
+		case 2:
+			payloads = []string{
+				`{"op":0,"t":"READY","s":1,"d":{"session_id":"abc","resume_gateway_url":"` +
+					strings.Replace(srv.URL, "http", "ws", 1) + `/resume"}}`,
+				`{"op":0,"t":"TYPING_START","s":2,"d":{"channel_id":"1","user_id":"2"}}`,
+			}
+		case 6:
+			if err := json.Unmarshal(cmd.Data, &sent.Data); err != nil {
+				t.Error("failed to unmarshal resume:", err)
+			}
+			payloads = []string{`{"op":0,"t":"RESUMED","s":3,"d":null}`}
+		}
+
+		cmds <- sent
+
+		for _, payload := range payloads {
+			conn.WriteMessage(websocket.TextMessage, []byte(payload))
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				if sent.Op == 2 && websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					t.Error("session was invalidated by a graceful close")
+				}
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	gatewayURL = strings.Replace(srv.URL, "http", "ws", 1)
+	return gatewayURL, gatewayURL + "/resume"
+}
+
+func TestSessionResume(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	cmds := make(chan resumeCommand, 1)
+	gatewayURL, resumeURL := newResumeServer(t, cmds)
+
+	typingCh := make(chan *gateway.TypingStartEvent, 1)
+
+	s := New("Bot token", WithGatewayURL(gatewayURL))
+	s.AddHandler(typingCh)
+
+	if err := s.Open(ctx); err != nil {
+		t.Fatal("failed to open:", err)
+	}
+
+	if cmd := <-cmds; cmd.Op != 2 {
+		t.Fatalf("expected IDENTIFY, got op %d", cmd.Op)
+	}
+
+	select {
+	case <-typingCh:
+	case <-ctx.Done():
+		t.Fatal("TYPING_START not received")
+	}
+
+	state, err := s.CloseResumable()
+	if err != nil {
+		t.Fatal("failed to close:", err)
+	}
+
+	expect := gateway.ResumeState{
+		SessionID: "abc",
+		ResumeURL: resumeURL,
+		Sequence:  2,
+	}
+	if state != expect {
+		t.Fatalf("unexpected resume state %+v", state)
+	}
+
+	// Persist the state like a bot restarting would.
+	b, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal("failed to marshal:", err)
+	}
+
+	var restored gateway.ResumeState
+	if err := json.Unmarshal(b, &restored); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+	if restored != state {
+		t.Fatalf("state %+v changed after JSON round trip: %+v", state, restored)
+	}
+
+	s = New("Bot token", WithGatewayURL(gatewayURL))
+	s.Resume(restored)
+
+	if err := s.Open(ctx); err != nil {
+		t.Fatal("failed to reopen:", err)
+	}
+	defer s.Close()
+
+	cmd := <-cmds
+	if cmd.Op != 6 {
+		t.Fatalf("expected RESUME, got op %d", cmd.Op)
+	}
+	if cmd.Path != "/resume" {
+		t.Fatalf("expected to resume using the resume URL, got path %q", cmd.Path)
+	}
+	if cmd.Data.SessionID != "abc" || cmd.Data.Sequence != 2 {
+		t.Fatalf("unexpected RESUME %+v", cmd.Data)
+	}
+}
+
+func TestSessionInferIntents(t *testing.T) {
+	s := New("Bot token")
+	s.AddHandler(func(*gateway.MessageCreateEvent) {})
+	s.AddHandler(func(*gateway.GuildMemberAddEvent) {})
+	s.AddHandler(func(interface{}) {})
+
+	intents, privileged := s.InferIntents()
+
+	expect := gateway.IntentGuildMessages | gateway.IntentDirectMessages |
+		gateway.IntentGuildMembers | gateway.IntentMessageContent
+	if intents != expect {
+		t.Errorf("expected intents %b, got %b", expect, intents)
+	}
+
+	expectPrivileged := gateway.IntentGuildMembers | gateway.IntentMessageContent
+	if privileged != expectPrivileged {
+		t.Errorf("expected privileged intents %b, got %b", expectPrivileged, privileged)
+	}
+}
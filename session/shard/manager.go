@@ -2,6 +2,7 @@ package shard
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -10,10 +11,11 @@ import (
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/internal/backoff"
+	"github.com/diamondburned/arikawa/v3/session"
 )
 
 func updateIdentifier(ctx context.Context, id *gateway.Identifier) (url string, err error) {
-	botData, err := api.NewClient(id.Token).WithContext(ctx).BotURL()
+	botData, err := api.NewClient(id.Token).WithCtx(ctx).BotURL()
 	if err != nil {
 		return "", err
 	}
@@ -23,15 +25,7 @@ func updateIdentifier(ctx context.Context, id *gateway.Identifier) (url string,
 	}
 
 	id.Shard = &gateway.Shard{0, botData.Shards}
-
-	// Update the burst to be the current given time and reset it back to
-	// the default when the given time is reached.
-	id.IdentifyGlobalLimit.SetBurst(botData.StartLimit.Remaining)
-	resetAt := time.Now().Add(botData.StartLimit.ResetAfter.Duration())
-	id.IdentifyGlobalLimit.SetBurstAt(resetAt, botData.StartLimit.Total)
-
-	// Update the maximum number of identify requests allowed per 5s.
-	id.IdentifyShortLimit.SetBurst(botData.StartLimit.MaxConcurrency)
+	id.SetBotData(botData)
 
 	return botData.URL, nil
 }
@@ -48,6 +42,21 @@ type Manager struct {
 
 	rescaling *rescalingState // nil unless rescaling
 
+	statusMu sync.Mutex
+	statuses []ShardStatus
+	onStatus func(shardID int, status ShardStatus)
+
+	eventMu sync.RWMutex
+	onEvent func(shardID int, event interface{})
+
+	// watchCtx is canceled when the Manager is closed, which stops shards
+	// from being restarted automatically.
+	watchMu     sync.Mutex
+	watchCtx    context.Context
+	stopWatches context.CancelFunc
+
+	autoRestart bool
+
 	new NewShardFunc
 }
 
@@ -101,31 +110,40 @@ func NewIdentifiedManagerWithURL(
 
 	m := Manager{
 		gatewayURL: gateway.AddGatewayParams(url),
-		shards:     make([]ShardState, id.Shard.NumShards()),
+		statuses:   make([]ShardStatus, id.Shard.NumShards()),
 		new:        fn,
 	}
 
-	var err error
+	shards, err := m.newShards(id, id.Shard.NumShards())
+	if err != nil {
+		return nil, err
+	}
 
-	for i := range m.shards {
-		data := id.IdentifyCommand
-		data.Shard = &gateway.Shard{i, len(m.shards)}
+	m.shards = shards
+	return &m, nil
+}
 
-		m.shards[i] = ShardState{
-			ID: gateway.Identifier{
-				IdentifyCommand:     data,
-				IdentifyShortLimit:  id.IdentifyShortLimit,
-				IdentifyGlobalLimit: id.IdentifyGlobalLimit,
-			},
-		}
+// newShards creates numShards shards using the given Identifier. The shards are
+// not opened.
+func (m *Manager) newShards(id gateway.Identifier, numShards int) ([]ShardState, error) {
+	shards := make([]ShardState, numShards)
+
+	for i := range shards {
+		shardID := id
+		shardID.Shard = &gateway.Shard{i, numShards}
+
+		shards[i] = ShardState{ID: shardID}
 
-		m.shards[i].Shard, err = fn(&m, &m.shards[i].ID)
+		shard, err := m.new(m, &shards[i].ID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create shard %d/%d: %w", i, len(m.shards)-1, err)
+			return nil, fmt.Errorf("failed to create shard %d/%d: %w", i, numShards-1, err)
 		}
+
+		shards[i].Shard = shard
+		m.addEventHandler(shard, i)
 	}
 
-	return &m, nil
+	return shards, nil
 }
 
 // GatewayURL returns the URL to the gateway. The URL will always have the
@@ -137,6 +155,33 @@ func (m *Manager) GatewayURL() string {
 	return m.gatewayURL
 }
 
+// BotData returns the /gateway/bot response that was last fetched by the
+// Manager, or nil if the Manager was never given one.
+func (m *Manager) BotData() *api.BotData {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if len(m.shards) == 0 {
+		return nil
+	}
+
+	return m.shards[0].ID.BotData
+}
+
+// SessionStartLimit returns the current session start limit shared by all
+// shards. Refer to gateway.Identifier's SessionStartLimit for more
+// information.
+func (m *Manager) SessionStartLimit() (api.SessionStartLimit, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if len(m.shards) == 0 {
+		return api.SessionStartLimit{}, false
+	}
+
+	return m.shards[0].ID.SessionStartLimit()
+}
+
 // NumShards returns the total number of shards. It is OK for the caller to rely
 // on NumShards while they're inside ForEach.
 func (m *Manager) NumShards() int {
@@ -180,35 +225,447 @@ func (m *Manager) ForEach(f func(shard Shard)) {
 	}
 }
 
+// OnStatusChange sets the function that is called every time a shard changes
+// its status, including when the whole Manager is opened, closed or rescaled.
+// The function is called synchronously and must not call any of the Manager's
+// methods other than Status. Calling OnStatusChange again replaces the
+// previous function; a nil function removes it.
+func (m *Manager) OnStatusChange(f func(shardID int, status ShardStatus)) {
+	m.statusMu.Lock()
+	m.onStatus = f
+	m.statusMu.Unlock()
+}
+
+// Status returns the current status of the shard with the given ID. It returns
+// ShardClosed if the shard doesn't exist. Status never blocks on the Manager
+// opening, closing or restarting shards.
+func (m *Manager) Status(ix int) ShardStatus {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	if ix < 0 || ix >= len(m.statuses) {
+		return ShardClosed
+	}
+
+	return m.statuses[ix]
+}
+
+func (m *Manager) setStatus(ix int, status ShardStatus) {
+	m.statusMu.Lock()
+
+	if ix < 0 || ix >= len(m.statuses) || m.statuses[ix] == status {
+		m.statusMu.Unlock()
+		return
+	}
+
+	m.statuses[ix] = status
+	f := m.onStatus
+
+	m.statusMu.Unlock()
+
+	if f != nil {
+		f(ix, status)
+	}
+}
+
+// EventShard is a Shard that can have event handlers added to it. It is
+// implemented by Session and State.
+type EventShard interface {
+	Shard
+	AddHandler(handler interface{}) (rm func())
+}
+
+var _ EventShard = (*session.Session)(nil)
+
+// OnShardEvent sets the function that is called for every event received by
+// any shard that implements EventShard, along with the ID of the shard that
+// received it. Shards created by a later rescale are covered as well. Like
+// handlers added to the shards themselves, the function may be called
+// concurrently. A nil function removes it.
+func (m *Manager) OnShardEvent(f func(shardID int, event interface{})) {
+	m.eventMu.Lock()
+	m.onEvent = f
+	m.eventMu.Unlock()
+}
+
+func (m *Manager) addEventHandler(shard Shard, shardID int) {
+	eventShard, ok := shard.(EventShard)
+	if !ok {
+		return
+	}
+
+	eventShard.AddHandler(func(ev interface{}) {
+		m.eventMu.RLock()
+		f := m.onEvent
+		m.eventMu.RUnlock()
+
+		if f != nil {
+			f(shardID, ev)
+		}
+	})
+}
+
+func (m *Manager) setShardStatus(shard *ShardState, status ShardStatus) {
+	// Detached shards aren't managed yet (or anymore), so their statuses would
+	// overwrite the ones of the managed shards with the same IDs.
+	if !shard.detached {
+		m.setStatus(shard.ShardID(), status)
+	}
+}
+
+func (m *Manager) resetStatuses(numShards int) {
+	m.statusMu.Lock()
+	m.statuses = make([]ShardStatus, numShards)
+	m.statusMu.Unlock()
+}
+
+// openShards behaves like OpenShards, except the shard statuses are updated.
+// Shards are opened concurrently in groups of max_concurrency, so that each
+// identify rate limit bucket is used once at a time.
+func (m *Manager) openShards(ctx context.Context, shards []ShardState) error {
+	if len(shards) == 0 {
+		return nil
+	}
+
+	concurrency := 1
+	if botData := shards[0].ID.BotData; botData != nil && botData.StartLimit != nil {
+		if botData.StartLimit.MaxConcurrency > 1 {
+			concurrency = botData.StartLimit.MaxConcurrency
+		}
+	}
+
+	errs := make([]error, len(shards))
+
+	for start := 0; start < len(shards); start += concurrency {
+		end := start + concurrency
+		if end > len(shards) {
+			end = len(shards)
+		}
+
+		var wg sync.WaitGroup
+
+		for i := start; i < end; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = m.openShard(ctx, &shards[i])
+			}(i)
+		}
+
+		wg.Wait()
+
+		for i := start; i < end; i++ {
+			if errs[i] != nil {
+				m.closeShards(shards)
+				return fmt.Errorf("failed to open shard %d/%d: %w", i, len(shards)-1, errs[i])
+			}
+		}
+	}
+
+	return nil
+}
+
+// closeShards behaves like CloseShards, except the shard statuses are updated.
+func (m *Manager) closeShards(shards []ShardState) error {
+	var lastError error
+
+	for i := range shards {
+		if err := m.closeShard(&shards[i]); err != nil {
+			lastError = err
+		}
+	}
+
+	return lastError
+}
+
+func (m *Manager) openShard(ctx context.Context, shard *ShardState) error {
+	m.setShardStatus(shard, ShardOpening)
+
+	if err := shard.Shard.Open(ctx); err != nil {
+		m.setShardStatus(shard, ShardClosed)
+		return err
+	}
+
+	// Mark as opened so we can close it.
+	shard.Opened = true
+	shard.generation++
+	m.setShardStatus(shard, ShardOpened)
+
+	if waitShard, ok := shard.Shard.(WaitShard); ok {
+		go m.watchShard(m.watchContext(), shard, waitShard, shard.generation)
+	}
+
+	return nil
+}
+
+func (m *Manager) closeShard(shard *ShardState) error {
+	// Bump the generation even if the shard isn't opened, so that a shard
+	// that is waiting to be restarted automatically stays closed.
+	shard.generation++
+
+	if !shard.Opened {
+		return nil
+	}
+
+	m.setShardStatus(shard, ShardClosing)
+
+	err := shard.Shard.Close()
+	shard.Opened = false
+
+	m.setShardStatus(shard, ShardClosed)
+	return err
+}
+
 // Open opens all gateways handled by this Manager. If an error occurs, Open
 // will attempt to close all previously opened gateways before returning.
 func (m *Manager) Open(ctx context.Context) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	return OpenShards(ctx, m.shards)
+	m.watchMu.Lock()
+	if m.watchCtx == nil || m.watchCtx.Err() != nil {
+		m.watchCtx, m.stopWatches = context.WithCancel(context.Background())
+	}
+	m.watchMu.Unlock()
+
+	return m.openShards(ctx, m.shards)
+}
+
+// WaitShard is a Shard that can block until its gateway dies from an error it
+// cannot recover from. It is implemented by Session and State.
+type WaitShard interface {
+	Shard
+	Wait(ctx context.Context) error
+}
+
+var _ WaitShard = (*session.Session)(nil)
+
+// AutoRestart sets whether shards that implement WaitShard are restarted
+// automatically once their gateway dies. A shard that fails to restart is
+// retried with an exponential backoff until it opens or the Manager is closed.
+// Automatic restarts are disabled by default.
+func (m *Manager) AutoRestart(enable bool) {
+	m.mutex.Lock()
+	m.autoRestart = enable
+	m.mutex.Unlock()
+}
+
+func (m *Manager) watchContext() context.Context {
+	m.watchMu.Lock()
+	defer m.watchMu.Unlock()
+
+	if m.watchCtx == nil {
+		m.watchCtx, m.stopWatches = context.WithCancel(context.Background())
+	}
+
+	return m.watchCtx
+}
+
+func (m *Manager) stopWatching() {
+	m.watchMu.Lock()
+	defer m.watchMu.Unlock()
+
+	if m.stopWatches != nil {
+		m.stopWatches()
+	}
+}
+
+// watchShard waits until the given shard's gateway dies, then restarts it if
+// the shard wasn't closed or restarted by the Manager in the meantime.
+func (m *Manager) watchShard(
+	ctx context.Context, shard *ShardState, w WaitShard, generation uint64) {
+
+	w.Wait(context.Background())
+
+	backoffT := backoff.NewTimer(time.Second, 5*time.Minute)
+	defer backoffT.Stop()
+
+	for {
+		var retry bool
+
+		generation, retry = m.restartDeadShard(ctx, shard, generation)
+		if !retry {
+			return
+		}
+
+		select {
+		case <-backoffT.Next():
+			continue
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// restartDeadShard restarts the given shard if it's still the same one that
+// was watched. It returns true if the restart failed and should be retried.
+func (m *Manager) restartDeadShard(
+	ctx context.Context, shard *ShardState, generation uint64) (uint64, bool) {
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ix := shard.ShardID()
+
+	// The shard might've been replaced by a rescale. Only look at it after
+	// we know that it's still managed, since other shards aren't guarded by
+	// the mutex.
+	if !m.autoRestart || ctx.Err() != nil || ix >= len(m.shards) || &m.shards[ix] != shard {
+		return 0, false
+	}
+
+	if shard.generation != generation {
+		return 0, false
+	}
+
+	m.closeShard(shard)
+
+	if err := m.openShard(ctx, shard); err != nil {
+		return shard.generation, true
+	}
+
+	return 0, false
+}
+
+// Restart closes and reopens the shard with the given ID while leaving all
+// other shards running. Whether the shard resumes or re-identifies depends on
+// the Shard implementation. If the shard fails to open again, it is left
+// closed and the error is returned; calling Restart again will retry.
+//
+// Restart is safe to call while handling events from other shards, but it
+// holds the Manager's lock, so it will block Open, Close, Rescale and methods
+// such as Shard and ForEach until it's done.
+func (m *Manager) Restart(ctx context.Context, ix int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if ix < 0 || ix >= len(m.shards) {
+		return fmt.Errorf("unknown shard %d", ix)
+	}
+
+	shard := &m.shards[ix]
+
+	// Ignore the close error: it's usually the gateway's last error, which
+	// doesn't mean that the shard failed to close.
+	m.closeShard(shard)
+
+	if err := m.openShard(ctx, shard); err != nil {
+		return fmt.Errorf("failed to reopen shard %d/%d: %w", ix, len(m.shards)-1, err)
+	}
+
+	return nil
+}
+
+// RestartAll does a rolling restart of all shards: each shard is restarted one
+// after another using Restart, so that at most one shard is down at any given
+// time. The first error stops the rolling restart and is returned.
+func (m *Manager) RestartAll(ctx context.Context) error {
+	for i := 0; i < m.NumShards(); i++ {
+		if err := m.Restart(ctx, i); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Close closes all gateways handled by this Manager; it will stop rescaling if
 // the manager is currently being rescaled. If an error occurs, Close will
 // attempt to close all remaining gateways first, before returning.
 func (m *Manager) Close() error {
+	m.haltRescale()
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	if m.rescaling != nil {
-		m.rescaling.haltRescale()
-		m.rescaling.rescaleDone.Wait()
+	return m.closeShards(m.shards)
+}
+
+// haltRescale stops rescaling and stops shards from being restarted
+// automatically. It must be called without holding the mutex, since rescaling
+// acquires it before finishing.
+func (m *Manager) haltRescale() {
+	m.stopWatching()
+
+	m.mutex.Lock()
+	rescaling := m.rescaling
+	m.mutex.Unlock()
 
-		m.rescaling = nil
+	if rescaling != nil {
+		rescaling.haltRescale()
+		rescaling.rescaleDone.Wait()
 	}
+}
 
-	return CloseShards(m.shards)
+// OfflineShard is a Shard that can set its presence to invisible. It is
+// implemented by Session and State.
+type OfflineShard interface {
+	Shard
+	GoOffline(ctx context.Context) error
+}
+
+var _ OfflineShard = (*session.Session)(nil)
+
+// GoOffline sets the presence of all opened shards that implement
+// OfflineShard to invisible, and waits until all of them are sent. The updates
+// are sent concurrently. The first error encountered is returned.
+func (m *Manager) GoOffline(ctx context.Context) error {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.goOffline(ctx)
+}
+
+func (m *Manager) goOffline(ctx context.Context) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(m.shards))
+
+	for i, shard := range m.shards {
+		offline, ok := shard.Shard.(OfflineShard)
+		if !ok || !shard.Opened {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := offline.GoOffline(ctx); err != nil {
+				errs[i] = fmt.Errorf("shard %d: %w", i, err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CloseOffline is like Close, except all shards are first set to appear
+// offline using GoOffline, so that the bot visibly goes offline immediately.
+// Errors from GoOffline are ignored, since the shards are closed either way.
+func (m *Manager) CloseOffline(ctx context.Context) error {
+	m.haltRescale()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.goOffline(ctx)
+	return m.closeShards(m.shards)
 }
 
 // Rescale rescales the manager asynchronously. The caller MUST NOT call Rescale
 // in the constructor function; doing so WILL cause the state to be inconsistent
 // and eventually crash and burn and destroy us all.
+//
+// Rescale closes all shards before opening the new ones, since it's meant to
+// be called once Discord refuses the current number of shards. To change the
+// number of shards without any downtime, use Reshard.
 func (m *Manager) Rescale() {
 	go m.rescale()
 }
@@ -239,13 +696,15 @@ func (m *Manager) rescale() {
 	// Close the shards outside the lock. This should be fairly quickly, but it
 	// allows the caller to halt rescaling while we're closing or opening the
 	// shards.
-	CloseShards(oldShards)
+	m.closeShards(oldShards)
+
+	data := oldShards[0].ID.IdentifyCommand
 
 	backoffT := backoff.NewTimer(time.Second, 15*time.Minute)
 	defer backoffT.Stop()
 
 	for {
-		if m.tryRescale(ctx) {
+		if m.tryRescale(ctx, data) {
 			return
 		}
 
@@ -260,53 +719,196 @@ func (m *Manager) rescale() {
 
 // tryRescale attempts once to rescale. It assumes the mutex is unlocked and
 // will unlock the mutex itself.
-func (m *Manager) tryRescale(ctx context.Context) bool {
-	m.mutex.Lock()
-
-	data := m.shards[0].ID.IdentifyCommand
+func (m *Manager) tryRescale(ctx context.Context, data gateway.IdentifyCommand) bool {
 	newID := gateway.NewIdentifier(data)
 
 	url, err := updateIdentifier(ctx, &newID)
 	if err != nil {
-		m.mutex.Unlock()
 		return false
 	}
 
 	numShards := newID.Shard.NumShards()
-	m.gatewayURL = url
 
-	// Release the mutex early.
+	// Create the shards slice to set after we reacquire the mutex.
+	newShards, err := m.newShards(newID, numShards)
+	if err != nil {
+		return false
+	}
+
+	m.resetStatuses(numShards)
+
+	if err := m.openShards(ctx, newShards); err != nil {
+		return false
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Don't install the new shards if rescaling was halted while they were
+	// being opened.
+	if ctx.Err() != nil {
+		m.closeShards(newShards)
+		return true
+	}
+
+	m.gatewayURL = gateway.AddGatewayParams(url)
+	m.shards = newShards
+	m.rescaling = nil
+
+	return true
+}
+
+// Reshard changes the number of shards without any downtime: a new set of
+// shards is created and opened while the current shards keep running, and the
+// current shards are only closed once all new shards are opened. If numShards
+// is 0, then the number of shards recommended by Discord is queried and used. If any new
+// shard fails to open, all new shards are closed and the current shards are
+// kept.
+//
+// Events may be received twice while both sets of shards are running, and the
+// new shards use new Shard instances created by the NewShardFunc. Reshard
+// returns an error if the Manager is already rescaling.
+func (m *Manager) Reshard(ctx context.Context, numShards int) error {
+	m.mutex.Lock()
+
+	if m.rescaling != nil {
+		m.mutex.Unlock()
+		return errors.New("manager is already rescaling")
+	}
+
+	if len(m.shards) == 0 {
+		m.mutex.Unlock()
+		return errors.New("manager has no shards")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	m.rescaling = &rescalingState{haltRescale: cancel}
+	m.rescaling.rescaleDone.Add(1)
+	defer m.rescaling.rescaleDone.Done()
+
+	id := m.shards[0].ID
+	opened := m.shards[0].Opened
+
 	m.mutex.Unlock()
 
-	// Create the shards slice to set after we reacquire the mutex.
-	newShards := make([]ShardState, numShards)
-
-	for i := 0; i < numShards; i++ {
-		data := newID.IdentifyCommand
-		data.Shard = &gateway.Shard{i, len(m.shards)}
-
-		newShards[i] = ShardState{
-			ID: gateway.Identifier{
-				IdentifyCommand:     data,
-				IdentifyShortLimit:  newID.IdentifyShortLimit,
-				IdentifyGlobalLimit: newID.IdentifyGlobalLimit,
-			},
-		}
+	err := m.reshard(ctx, id, numShards, opened)
+
+	m.mutex.Lock()
+	m.rescaling = nil
+	m.mutex.Unlock()
+
+	return err
+}
+
+func (m *Manager) reshard(
+	ctx context.Context, id gateway.Identifier, numShards int, open bool) error {
 
-		newShards[i].Shard, err = m.new(m, &newShards[i].ID)
+	newID := gateway.NewIdentifier(id.IdentifyCommand)
+
+	var url string
+
+	// Only ask Discord if we don't know how many shards to use; otherwise,
+	// keep using the last known gateway information.
+	if numShards < 1 {
+		var err error
+
+		url, err = updateIdentifier(ctx, &newID)
 		if err != nil {
-			return false
+			return fmt.Errorf("failed to get gateway info: %w", err)
 		}
+
+		numShards = newID.Shard.NumShards()
+	} else if id.BotData != nil {
+		newID.SetBotData(id.BotData)
 	}
 
-	if err := OpenShards(ctx, newShards); err != nil {
-		return false
+	newShards, err := m.newShards(newID, numShards)
+	if err != nil {
+		return err
+	}
+
+	for i := range newShards {
+		newShards[i].detached = true
+	}
+
+	if open {
+		if err := m.openShards(ctx, newShards); err != nil {
+			return err
+		}
 	}
 
 	m.mutex.Lock()
+
+	if err := ctx.Err(); err != nil {
+		m.mutex.Unlock()
+		m.closeShards(newShards)
+		return err
+	}
+
+	oldShards := m.shards
+
+	if url != "" {
+		m.gatewayURL = gateway.AddGatewayParams(url)
+	}
 	m.shards = newShards
-	m.rescaling = nil
+
+	for i := range oldShards {
+		oldShards[i].detached = true
+	}
+
+	for i := range newShards {
+		newShards[i].detached = false
+	}
+
+	m.resetStatuses(numShards)
+
+	if open {
+		for i := range newShards {
+			m.setStatus(i, ShardOpened)
+		}
+	}
+
 	m.mutex.Unlock()
 
-	return true
+	// The old shards are no longer reachable through the Manager, so they can
+	// be closed outside the lock.
+	m.closeShards(oldShards)
+
+	return nil
+}
+
+// AutoReshard checks the number of shards recommended by Discord every given
+// interval and calls Reshard when it differs from the current number of
+// shards. It blocks until ctx is done and returns its error. Failed checks are
+// ignored and retried on the next interval.
+func (m *Manager) AutoReshard(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		m.mutex.RLock()
+		if len(m.shards) == 0 || m.rescaling != nil {
+			m.mutex.RUnlock()
+			continue
+		}
+		id := gateway.NewIdentifier(m.shards[0].ID.IdentifyCommand)
+		current := len(m.shards)
+		m.mutex.RUnlock()
+
+		if _, err := updateIdentifier(ctx, &id); err != nil {
+			continue
+		}
+
+		if recommended := id.Shard.NumShards(); recommended != current {
+			m.Reshard(ctx, recommended)
+		}
+	}
 }
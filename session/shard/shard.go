@@ -36,6 +36,37 @@ func NewSessionShard(f func(m *Manager, s *session.Session)) NewShardFunc {
 	}
 }
 
+// ShardStatus is the status of a shard managed by a Manager.
+type ShardStatus uint8
+
+const (
+	// ShardClosed means the shard is not connected. This is the initial
+	// status.
+	ShardClosed ShardStatus = iota
+	// ShardOpening means the shard is being opened.
+	ShardOpening
+	// ShardOpened means the shard has been opened successfully.
+	ShardOpened
+	// ShardClosing means the shard is being closed.
+	ShardClosing
+)
+
+// String returns the name of the shard status.
+func (s ShardStatus) String() string {
+	switch s {
+	case ShardClosed:
+		return "closed"
+	case ShardOpening:
+		return "opening"
+	case ShardOpened:
+		return "opened"
+	case ShardClosing:
+		return "closing"
+	default:
+		return fmt.Sprintf("ShardStatus(%d)", s)
+	}
+}
+
 // ShardState wraps around the Gateway interface to provide additional state.
 type ShardState struct {
 	Shard Shard
@@ -45,6 +76,12 @@ type ShardState struct {
 	// are consistent.
 	ID     gateway.Identifier
 	Opened bool
+
+	// generation is bumped every time the Manager opens or closes the shard.
+	generation uint64
+	// detached is true if the shard isn't managed by the Manager, such as
+	// while it's being resharded.
+	detached bool
 }
 
 // ShardID returns the shard state's shard ID.
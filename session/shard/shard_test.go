@@ -2,6 +2,8 @@ package shard_test
 
 import (
 	"context"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -72,3 +74,356 @@ shardLoop:
 		t.Error("failed to close:", err)
 	}
 }
+
+type mockShard struct {
+	opened bool
+	opens  int
+}
+
+func (s *mockShard) Open(context.Context) error {
+	s.opened = true
+	s.opens++
+	return nil
+}
+
+func (s *mockShard) Close() error {
+	s.opened = false
+	return nil
+}
+
+func TestRollingRestart(t *testing.T) {
+	id := gateway.DefaultIdentifier("Bot token")
+	id.Shard = &gateway.Shard{0, 3}
+
+	m, err := shard.NewIdentifiedManagerWithURL("wss://localhost", id,
+		func(m *shard.Manager, id *gateway.Identifier) (shard.Shard, error) {
+			return &mockShard{}, nil
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to make shard manager:", err)
+	}
+
+	type transition struct {
+		id     int
+		status shard.ShardStatus
+	}
+
+	var transitions []transition
+	m.OnStatusChange(func(id int, status shard.ShardStatus) {
+		transitions = append(transitions, transition{id, status})
+	})
+
+	if err := m.Open(context.Background()); err != nil {
+		t.Fatal("failed to open:", err)
+	}
+
+	transitions = nil
+
+	if err := m.RestartAll(context.Background()); err != nil {
+		t.Fatal("failed to restart:", err)
+	}
+
+	var expect []transition
+	for i := 0; i < 3; i++ {
+		expect = append(expect,
+			transition{i, shard.ShardClosing},
+			transition{i, shard.ShardClosed},
+			transition{i, shard.ShardOpening},
+			transition{i, shard.ShardOpened},
+		)
+	}
+
+	if len(transitions) != len(expect) {
+		t.Fatalf("expected %d transitions, got %v", len(expect), transitions)
+	}
+
+	for i := range expect {
+		if transitions[i] != expect[i] {
+			t.Fatalf("transition %d: expected %v, got %v", i, expect[i], transitions[i])
+		}
+	}
+
+	for i := 0; i < m.NumShards(); i++ {
+		s := m.Shard(i).(*mockShard)
+		if !s.opened || s.opens != 2 {
+			t.Errorf("shard %d: opened=%v opens=%d", i, s.opened, s.opens)
+		}
+		if status := m.Status(i); status != shard.ShardOpened {
+			t.Errorf("shard %d: unexpected status %v", i, status)
+		}
+	}
+
+	if err := m.Restart(context.Background(), 3); err == nil {
+		t.Error("expected error restarting unknown shard")
+	}
+
+	if err := m.Close(); err != nil {
+		t.Fatal("failed to close:", err)
+	}
+
+	if status := m.Status(0); status != shard.ShardClosed {
+		t.Errorf("unexpected status %v after close", status)
+	}
+}
+
+type mockOfflineShard struct {
+	mockShard
+	events *[]string
+	mu     *sync.Mutex
+}
+
+func (s *mockOfflineShard) GoOffline(context.Context) error {
+	s.mu.Lock()
+	*s.events = append(*s.events, "offline")
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *mockOfflineShard) Close() error {
+	s.mu.Lock()
+	*s.events = append(*s.events, "close")
+	s.mu.Unlock()
+	return s.mockShard.Close()
+}
+
+func TestCloseOffline(t *testing.T) {
+	id := gateway.DefaultIdentifier("Bot token")
+	id.Shard = &gateway.Shard{0, 2}
+
+	var events []string
+	var mu sync.Mutex
+
+	m, err := shard.NewIdentifiedManagerWithURL("wss://localhost", id,
+		func(m *shard.Manager, id *gateway.Identifier) (shard.Shard, error) {
+			return &mockOfflineShard{events: &events, mu: &mu}, nil
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to make shard manager:", err)
+	}
+
+	if err := m.Open(context.Background()); err != nil {
+		t.Fatal("failed to open:", err)
+	}
+
+	if err := m.CloseOffline(context.Background()); err != nil {
+		t.Fatal("failed to close:", err)
+	}
+
+	expect := []string{"offline", "offline", "close", "close"}
+	if !reflect.DeepEqual(events, expect) {
+		t.Fatalf("expected events %q, got %q", expect, events)
+	}
+}
+
+func TestRestartGoOffline(t *testing.T) {
+	id := gateway.DefaultIdentifier("Bot token")
+	id.Shard = &gateway.Shard{0, 2}
+
+	var events []string
+	var mu sync.Mutex
+
+	m, err := shard.NewIdentifiedManagerWithURL("wss://localhost", id,
+		func(m *shard.Manager, id *gateway.Identifier) (shard.Shard, error) {
+			return &mockOfflineShard{events: &events, mu: &mu}, nil
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to make shard manager:", err)
+	}
+
+	if err := m.Open(context.Background()); err != nil {
+		t.Fatal("failed to open:", err)
+	}
+
+	// Restart changes whether the shard is opened, which GoOffline reads, so
+	// the two must not race. Run with -race to check.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			if err := m.Restart(context.Background(), 1); err != nil {
+				t.Error("failed to restart:", err)
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			if err := m.GoOffline(context.Background()); err != nil {
+				t.Error("failed to go offline:", err)
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if err := m.Close(); err != nil {
+		t.Fatal("failed to close:", err)
+	}
+}
+
+type mockWaitShard struct {
+	mu     sync.Mutex
+	opens  int
+	dead   chan struct{}
+	events []func(interface{})
+}
+
+func (s *mockWaitShard) Open(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.opens++
+	s.dead = make(chan struct{})
+	return nil
+}
+
+func (s *mockWaitShard) Close() error {
+	s.die()
+	return nil
+}
+
+func (s *mockWaitShard) die() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.dead:
+	default:
+		close(s.dead)
+	}
+}
+
+func (s *mockWaitShard) Wait(ctx context.Context) error {
+	s.mu.Lock()
+	dead := s.dead
+	s.mu.Unlock()
+
+	<-dead
+	return nil
+}
+
+func (s *mockWaitShard) AddHandler(fn interface{}) func() {
+	s.events = append(s.events, fn.(func(interface{})))
+	return func() {}
+}
+
+func (s *mockWaitShard) numOpens() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.opens
+}
+
+func TestAutoRestart(t *testing.T) {
+	id := gateway.DefaultIdentifier("Bot token")
+	id.Shard = &gateway.Shard{0, 2}
+
+	m, err := shard.NewIdentifiedManagerWithURL("wss://localhost", id,
+		func(m *shard.Manager, id *gateway.Identifier) (shard.Shard, error) {
+			return &mockWaitShard{}, nil
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to make shard manager:", err)
+	}
+
+	m.AutoRestart(true)
+
+	if err := m.Open(context.Background()); err != nil {
+		t.Fatal("failed to open:", err)
+	}
+	defer m.Close()
+
+	s := m.Shard(1).(*mockWaitShard)
+	s.die()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for s.numOpens() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("shard was not restarted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if opens := m.Shard(0).(*mockWaitShard).numOpens(); opens != 1 {
+		t.Errorf("shard 0 was opened %d times", opens)
+	}
+
+	if err := m.Close(); err != nil {
+		t.Fatal("failed to close:", err)
+	}
+
+	// Closing must not trigger a restart.
+	time.Sleep(50 * time.Millisecond)
+	if opens := s.numOpens(); opens != 2 {
+		t.Errorf("shard 1 was opened %d times after close", opens)
+	}
+}
+
+func TestReshard(t *testing.T) {
+	id := gateway.DefaultIdentifier("Bot token")
+	id.Shard = &gateway.Shard{0, 2}
+
+	m, err := shard.NewIdentifiedManagerWithURL("wss://localhost", id,
+		func(m *shard.Manager, id *gateway.Identifier) (shard.Shard, error) {
+			return &mockWaitShard{}, nil
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to make shard manager:", err)
+	}
+
+	var mu sync.Mutex
+	var events []int
+	m.OnShardEvent(func(shardID int, ev interface{}) {
+		mu.Lock()
+		events = append(events, shardID)
+		mu.Unlock()
+	})
+
+	if err := m.Open(context.Background()); err != nil {
+		t.Fatal("failed to open:", err)
+	}
+	defer m.Close()
+
+	oldShard := m.Shard(1).(*mockWaitShard)
+
+	if err := m.Reshard(context.Background(), 3); err != nil {
+		t.Fatal("failed to reshard:", err)
+	}
+
+	if n := m.NumShards(); n != 3 {
+		t.Fatalf("expected 3 shards, got %d", n)
+	}
+
+	select {
+	case <-oldShard.dead:
+	default:
+		t.Error("old shard was not closed")
+	}
+
+	for i := 0; i < 3; i++ {
+		s := m.Shard(i).(*mockWaitShard)
+		if s.numOpens() != 1 {
+			t.Errorf("shard %d: opened %d times", i, s.numOpens())
+		}
+		if status := m.Status(i); status != shard.ShardOpened {
+			t.Errorf("shard %d: unexpected status %v", i, status)
+		}
+		for _, fn := range s.events {
+			fn("event")
+		}
+	}
+
+	if !reflect.DeepEqual(events, []int{0, 1, 2}) {
+		t.Errorf("unexpected event shard IDs %v", events)
+	}
+}
@@ -8,9 +8,14 @@ func (s *State) handleReady(ev *gateway.ReadyEvent) {
 	s.guildMutex.Lock()
 	defer s.guildMutex.Unlock()
 
+	s.fewMutex.Lock()
 	for chID := range s.fewMessages {
 		delete(s.fewMessages, chID)
 	}
+	for chID := range s.backfilled {
+		delete(s.backfilled, chID)
+	}
+	s.fewMutex.Unlock()
 
 	for _, g := range ev.Guilds {
 		s.unreadyGuilds[g.ID] = struct{}{}
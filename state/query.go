@@ -0,0 +1,270 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state/store"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+)
+
+// This file contains queries computed over the State. They are only as
+// complete as the data that they're computed from, so the caveats of each
+// query should be kept in mind.
+
+// MembersWithRole returns the members in the guild that have the given role.
+// The members are fetched using (*State).Members, so without the
+// GuildMembers intent, at most MaxFetchMembers members are fetched from the
+// API. With the intent, only the members in the cache are considered, which
+// may not be every member in large guilds unless they have been requested
+// using the Gateway.
+func (s *State) MembersWithRole(
+	guildID discord.GuildID, roleID discord.RoleID) ([]discord.Member, error) {
+
+	members, err := s.Members(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Everyone has the @everyone role, which has the same ID as the guild.
+	if discord.GuildID(roleID) == guildID {
+		return members, nil
+	}
+
+	filtered := members[:0]
+	for _, member := range members {
+		if hasRole(member.RoleIDs, roleID) {
+			filtered = append(filtered, member)
+		}
+	}
+
+	return filtered, nil
+}
+
+func hasRole(roleIDs []discord.RoleID, roleID discord.RoleID) bool {
+	for _, id := range roleIDs {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// ChannelsByType returns the channels in the guild that are of any of the
+// given types. The channels are fetched using (*State).Channels, so threads
+// are not included.
+func (s *State) ChannelsByType(
+	guildID discord.GuildID, types ...discord.ChannelType) ([]discord.Channel, error) {
+
+	channels, err := s.Channels(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := channels[:0]
+	for _, ch := range channels {
+		for _, t := range types {
+			if ch.Type == t {
+				filtered = append(filtered, ch)
+				break
+			}
+		}
+	}
+
+	return filtered, nil
+}
+
+// TextChannelsSorted returns the text and announcement channels in the guild
+// in the order that Discord shows them in the client: channels without a
+// category come first, followed by the channels in each category in the order
+// of the categories.
+func (s *State) TextChannelsSorted(guildID discord.GuildID) ([]discord.Channel, error) {
+	channels, err := s.Channels(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[discord.ChannelID]discord.Channel)
+	for _, ch := range channels {
+		if ch.Type == discord.GuildCategory {
+			categories[ch.ID] = ch
+		}
+	}
+
+	text := channels[:0]
+	for _, ch := range channels {
+		if ch.Type == discord.GuildText || ch.Type == discord.GuildAnnouncement {
+			text = append(text, ch)
+		}
+	}
+
+	// categoryKey returns the position and ID of the channel's category, with
+	// uncategorized channels sorted before any category.
+	categoryKey := func(ch discord.Channel) (int, discord.ChannelID) {
+		if cat, ok := categories[ch.ParentID]; ok {
+			return cat.Position, cat.ID
+		}
+		return -1, 0
+	}
+
+	sort.SliceStable(text, func(i, j int) bool {
+		ipos, iid := categoryKey(text[i])
+		jpos, jid := categoryKey(text[j])
+		if ipos != jpos {
+			return ipos < jpos
+		}
+		if iid != jid {
+			return iid < jid
+		}
+		if text[i].Position != text[j].Position {
+			return text[i].Position < text[j].Position
+		}
+		return text[i].ID < text[j].ID
+	})
+
+	return text, nil
+}
+
+// MutualGuilds returns the guilds in the cache that the given user is a member
+// of. It is computed entirely over the cache without making any API calls, so
+// a guild is only returned if the user's member is cached in it. This is
+// usually only complete with the GuildMembers intent and after the members
+// of each guild have been requested using the Gateway. If no guilds are cached,
+// then an empty slice is returned.
+func (s *State) MutualGuilds(userID discord.UserID) ([]discord.Guild, error) {
+	guilds, err := s.Cabinet.Guilds()
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get guilds: %w", err)
+	}
+
+	mutual := guilds[:0]
+	for _, guild := range guilds {
+		_, err := s.Cabinet.Member(guild.ID, userID)
+		if err == nil {
+			mutual = append(mutual, guild)
+			continue
+		}
+		if !errors.Is(err, store.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get member in guild %d: %w", guild.ID, err)
+		}
+	}
+
+	return mutual, nil
+}
+
+// FindMember finds a member in the guild using a query, which may be a user ID,
+// a user mention, or the start of a username, global display name or nickname.
+// Names are matched case-insensitively, and exact matches are preferred. Since
+// names may be all digits too, a query that looks like an ID but isn't one of
+// a member is searched as a name.
+//
+// The cached members are searched first. If there's no match, then the members
+// are searched using the API, which doesn't require the GuildMembers intent.
+// Note that the API only matches usernames and nicknames. If no member is
+// found, then an error wrapping store.ErrNotFound is returned.
+func (s *State) FindMember(guildID discord.GuildID, query string) (*discord.Member, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("empty member query: %w", store.ErrNotFound)
+	}
+
+	if id, mention, ok := parseUserQuery(query); ok {
+		m, err := s.Member(guildID, id)
+		if mention || !isNotFound(err) {
+			return m, err
+		}
+	}
+
+	cached, err := s.Cabinet.Members(guildID)
+	if err == nil {
+		if m := bestMemberMatch(cached, query); m != nil {
+			return m, nil
+		}
+	} else if !errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get cached members: %w", err)
+	}
+
+	found, err := s.Session.SearchGuildMembers(guildID, query, 10)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search members: %w", err)
+	}
+
+	m := bestMemberMatch(found, query)
+	if m == nil {
+		return nil, fmt.Errorf("no member matching %q: %w", query, store.ErrNotFound)
+	}
+
+	if s.HasIntents(gateway.IntentGuildMembers) {
+		s.Cabinet.MemberSet(guildID, m, false)
+	}
+
+	return m, nil
+}
+
+// minSnowflakeDigits is the number of digits of the shortest user IDs. Shorter
+// numbers are only searched as names.
+const minSnowflakeDigits = 17
+
+// parseUserQuery parses a user ID or mention. mention is true if the query is a
+// mention, which can't be a name.
+func parseUserQuery(query string) (id discord.UserID, mention, ok bool) {
+	if strings.HasPrefix(query, "<@") && strings.HasSuffix(query, ">") {
+		query = strings.TrimPrefix(query[2:len(query)-1], "!")
+		mention = true
+	} else if len(query) < minSnowflakeDigits {
+		return 0, false, false
+	}
+
+	for _, r := range query {
+		if r < '0' || r > '9' {
+			return 0, false, false
+		}
+	}
+
+	sf, err := discord.ParseSnowflake(query)
+	if err != nil || !sf.IsValid() {
+		return 0, false, false
+	}
+
+	return discord.UserID(sf), mention, true
+}
+
+// isNotFound returns true if err is a store.ErrNotFound or a 404 from the API.
+func isNotFound(err error) bool {
+	var httpErr *httputil.HTTPError
+	return errors.Is(err, store.ErrNotFound) ||
+		(errors.As(err, &httpErr) && httpErr.Status == http.StatusNotFound)
+}
+
+// bestMemberMatch returns the first member whose name is the query, or
+// otherwise the first member whose name starts with the query.
+func bestMemberMatch(members []discord.Member, query string) *discord.Member {
+	query = strings.ToLower(query)
+
+	var prefix *discord.Member
+	for i, m := range members {
+		for _, name := range []string{m.User.Username, m.User.DisplayName, m.Nick} {
+			if name == "" {
+				continue
+			}
+
+			name = strings.ToLower(name)
+			if name == query {
+				return &members[i]
+			}
+			if prefix == nil && strings.HasPrefix(name, query) {
+				prefix = &members[i]
+			}
+		}
+	}
+
+	return prefix
+}
@@ -0,0 +1,284 @@
+package state
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state/store"
+)
+
+func newQueryState(t *testing.T) *State {
+	t.Helper()
+
+	s := NewWithIntents("Bot token", gateway.IntentGuilds, gateway.IntentGuildMembers)
+
+	for _, guild := range []discord.Guild{{ID: 1}, {ID: 2}, {ID: 3}} {
+		if err := s.Cabinet.GuildSet(&guild, false); err != nil {
+			t.Fatal("failed to set guild:", err)
+		}
+	}
+
+	members := map[discord.GuildID][]discord.Member{
+		1: {
+			{User: discord.User{ID: 10}, RoleIDs: []discord.RoleID{100}},
+			{User: discord.User{ID: 11}, RoleIDs: []discord.RoleID{100, 101}},
+			{User: discord.User{ID: 12}},
+		},
+		2: {
+			{User: discord.User{ID: 10}},
+		},
+		3: {
+			{User: discord.User{ID: 12}},
+		},
+	}
+	for guildID, members := range members {
+		for i := range members {
+			if err := s.Cabinet.MemberSet(guildID, &members[i], false); err != nil {
+				t.Fatal("failed to set member:", err)
+			}
+		}
+	}
+
+	channels := []discord.Channel{
+		{ID: 20, GuildID: 1, Type: discord.GuildCategory, Position: 1},
+		{ID: 21, GuildID: 1, Type: discord.GuildCategory, Position: 0},
+		{ID: 30, GuildID: 1, Type: discord.GuildText, ParentID: 20, Position: 0},
+		{ID: 31, GuildID: 1, Type: discord.GuildText, ParentID: 21, Position: 1},
+		{ID: 32, GuildID: 1, Type: discord.GuildAnnouncement, ParentID: 21, Position: 0},
+		{ID: 33, GuildID: 1, Type: discord.GuildText, Position: 5},
+		{ID: 34, GuildID: 1, Type: discord.GuildVoice, ParentID: 21, Position: 2},
+	}
+	for i := range channels {
+		if err := s.Cabinet.ChannelSet(&channels[i], false); err != nil {
+			t.Fatal("failed to set channel:", err)
+		}
+	}
+
+	return s
+}
+
+func TestMutualGuilds(t *testing.T) {
+	s := NewWithIntents("Bot token", gateway.IntentGuilds)
+
+	guilds, err := s.MutualGuilds(10)
+	if err != nil {
+		t.Fatal("unexpected error without guilds:", err)
+	}
+	if len(guilds) != 0 {
+		t.Fatalf("expected no guilds, got %v", guilds)
+	}
+
+	s = newQueryState(t)
+
+	guilds, err = s.MutualGuilds(10)
+	if err != nil {
+		t.Fatal("failed to get mutual guilds:", err)
+	}
+
+	var ids []discord.GuildID
+	for _, guild := range guilds {
+		ids = append(ids, guild.ID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	if expect := []discord.GuildID{1, 2}; !reflect.DeepEqual(ids, expect) {
+		t.Fatalf("expected guilds %v, got %v", expect, ids)
+	}
+
+	guilds, err = s.MutualGuilds(99)
+	if err != nil {
+		t.Fatal("failed to get mutual guilds:", err)
+	}
+	if len(guilds) != 0 {
+		t.Fatalf("expected no guilds for a stranger, got %v", guilds)
+	}
+}
+
+func TestMembersWithRole(t *testing.T) {
+	s := newQueryState(t)
+
+	userIDs := func(roleID discord.RoleID) []discord.UserID {
+		t.Helper()
+
+		members, err := s.MembersWithRole(1, roleID)
+		if err != nil {
+			t.Fatal("failed to get members with role:", err)
+		}
+
+		ids := make([]discord.UserID, len(members))
+		for i, member := range members {
+			ids[i] = member.User.ID
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		return ids
+	}
+
+	tests := []struct {
+		name   string
+		role   discord.RoleID
+		expect []discord.UserID
+	}{
+		{"shared role", 100, []discord.UserID{10, 11}},
+		{"single member", 101, []discord.UserID{11}},
+		{"everyone", 1, []discord.UserID{10, 11, 12}},
+		{"nobody", 102, []discord.UserID{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if ids := userIDs(test.role); !reflect.DeepEqual(ids, test.expect) {
+				t.Fatalf("expected members %v, got %v", test.expect, ids)
+			}
+		})
+	}
+}
+
+func TestChannelsByType(t *testing.T) {
+	s := newQueryState(t)
+
+	channels, err := s.ChannelsByType(1, discord.GuildText, discord.GuildVoice)
+	if err != nil {
+		t.Fatal("failed to get channels:", err)
+	}
+
+	var ids []discord.ChannelID
+	for _, ch := range channels {
+		ids = append(ids, ch.ID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	if expect := []discord.ChannelID{30, 31, 33, 34}; !reflect.DeepEqual(ids, expect) {
+		t.Fatalf("expected channels %v, got %v", expect, ids)
+	}
+}
+
+func TestTextChannelsSorted(t *testing.T) {
+	s := newQueryState(t)
+
+	channels, err := s.TextChannelsSorted(1)
+	if err != nil {
+		t.Fatal("failed to get channels:", err)
+	}
+
+	ids := make([]discord.ChannelID, len(channels))
+	for i, ch := range channels {
+		ids[i] = ch.ID
+	}
+
+	// Uncategorized first, then category 21 (position 0), then category 20.
+	if expect := []discord.ChannelID{33, 32, 31, 30}; !reflect.DeepEqual(ids, expect) {
+		t.Fatalf("expected channels %v, got %v", expect, ids)
+	}
+}
+
+func TestParseUserQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		id      discord.UserID
+		mention bool
+		ok      bool
+	}{
+		{"170132746042081280", 170132746042081280, false, true},
+		{"<@170132746042081280>", 170132746042081280, true, true},
+		{"<@!170132746042081280>", 170132746042081280, true, true},
+		{"<@1234>", 1234, true, true},
+		{"1234", 0, false, false}, // too short to be an ID, so it's a name
+		{"17013274604208128a", 0, false, false},
+		{"<@abc>", 0, false, false},
+		{"diamondburned", 0, false, false},
+	}
+
+	for _, test := range tests {
+		id, mention, ok := parseUserQuery(test.query)
+		if id != test.id || mention != test.mention || ok != test.ok {
+			t.Errorf("parseUserQuery(%q) = (%d, %v, %v), expected (%d, %v, %v)",
+				test.query, id, mention, ok, test.id, test.mention, test.ok)
+		}
+	}
+}
+
+func TestBestMemberMatch(t *testing.T) {
+	members := []discord.Member{
+		{User: discord.User{ID: 1, Username: "alexander"}},
+		{User: discord.User{ID: 2, Username: "al", DisplayName: "Big Al"}},
+		{User: discord.User{ID: 3, Username: "bob"}, Nick: "Alex"},
+		{User: discord.User{ID: 4, Username: "1234"}},
+	}
+
+	tests := []struct {
+		query  string
+		expect discord.UserID
+	}{
+		{"ALEX", 3},   // exact nickname beats the earlier prefix
+		{"alexa", 1},  // prefix
+		{"big al", 2}, // display name
+		{"1234", 4},
+		{"carol", 0},
+	}
+
+	for _, test := range tests {
+		var got discord.UserID
+		if m := bestMemberMatch(members, test.query); m != nil {
+			got = m.User.ID
+		}
+		if got != test.expect {
+			t.Errorf("bestMemberMatch(%q) = %d, expected %d", test.query, got, test.expect)
+		}
+	}
+}
+
+func TestFindMember(t *testing.T) {
+	var paths []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+
+		if !strings.HasSuffix(r.URL.Path, "/members/search") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":10007,"message":"Unknown Member"}`))
+			return
+		}
+
+		json.NewEncoder(w).Encode([]discord.Member{
+			{User: discord.User{ID: 5, Username: r.URL.Query().Get("query")}},
+		})
+	}))
+	defer srv.Close()
+
+	s := NewWithStore("Bot token", store.NoopCabinet)
+	s.Client = s.Client.WithBaseURL(srv.URL)
+
+	// Numbers that look like IDs but aren't members are searched as names.
+	for _, query := range []string{"1234", "123456789012345678"} {
+		m, err := s.FindMember(1, query)
+		if err != nil {
+			t.Fatalf("failed to find member %q: %v", query, err)
+		}
+		if m.User.Username != query {
+			t.Fatalf("unexpected member %+v for %q", m.User, query)
+		}
+	}
+
+	// Mentions can't be names, so they aren't searched.
+	if _, err := s.FindMember(1, "<@123456789012345678>"); err == nil {
+		t.Fatal("expected a mention of a missing member to fail")
+	}
+
+	expect := []string{
+		api.Path + "/guilds/1/members/search",
+		api.Path + "/guilds/1/members/123456789012345678",
+		api.Path + "/guilds/1/members/search",
+		api.Path + "/guilds/1/members/123456789012345678",
+	}
+	if !reflect.DeepEqual(paths, expect) {
+		t.Fatalf("expected requests %q, got %q", expect, paths)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
 
 	"github.com/diamondburned/arikawa/v3/api"
@@ -16,6 +17,7 @@ import (
 	"github.com/diamondburned/arikawa/v3/state/store"
 	"github.com/diamondburned/arikawa/v3/state/store/defaultstore"
 	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/telemetry"
 )
 
 var (
@@ -87,8 +89,9 @@ type State struct {
 
 	// PreHandler is the manual hook that is executed before the State handler
 	// is. This should only be used for low-level operations.
-	// It's recommended to set Synchronous to true if you mutate the events.
-	PreHandler *handler.Handler // default nil
+	// It's recommended to add synchronous handlers if you mutate the events.
+	// Refer to AddPreHandler and AddSyncPreHandler.
+	PreHandler *handler.Handler
 
 	// Command handler with inherited methods. Ran after PreHandler. You should
 	// most of the time use this instead of Session's, to avoid race conditions
@@ -99,6 +102,9 @@ type State struct {
 	// again.
 	fewMessages map[discord.ChannelID]struct{}
 	fewMutex    *sync.Mutex
+	// List of channels that were backfilled using BackfillMessages. It is
+	// also guarded by fewMutex.
+	backfilled map[discord.ChannelID]struct{}
 
 	// unavailableGuilds is a set of discord.GuildIDs of guilds that became
 	// unavailable after connecting to the gateway, i.e. they were sent in a
@@ -140,19 +146,42 @@ func NewFromSession(s *session.Session, cabinet *store.Cabinet) *State {
 	state := &State{
 		Session:           s,
 		Cabinet:           cabinet,
+		PreHandler:        handler.New(),
 		Handler:           handler.New(),
 		StateLog:          func(err error) {},
 		readyMu:           new(sync.Mutex),
 		fewMessages:       map[discord.ChannelID]struct{}{},
 		fewMutex:          new(sync.Mutex),
+		backfilled:        map[discord.ChannelID]struct{}{},
 		unavailableGuilds: make(map[discord.GuildID]struct{}),
 		unreadyGuilds:     make(map[discord.GuildID]struct{}),
 		guildMutex:        new(sync.Mutex),
 	}
 	state.hookSession()
+	state.Client.Session.CachedUploadLimit = state.cachedUploadLimit
 	return state
 }
 
+// cachedUploadLimit returns the upload limit of the channel using only the
+// cached channel and guild.
+func (s *State) cachedUploadLimit(channelID discord.ChannelID) (int64, bool) {
+	ch, err := s.Cabinet.Channel(channelID)
+	if err != nil {
+		return 0, false
+	}
+
+	if !ch.GuildID.IsValid() {
+		return discord.DefaultUploadLimit, true
+	}
+
+	g, err := s.Cabinet.Guild(ch.GuildID)
+	if err != nil {
+		return 0, false
+	}
+
+	return g.NitroBoost.UploadLimit(), true
+}
+
 // NewAPIOnlyState creates a new State that only has API functions and no
 // gateway (or state caches). Use this as a drop-in for InteractionServer usage.
 //
@@ -161,13 +190,48 @@ func NewFromSession(s *session.Session, cabinet *store.Cabinet) *State {
 // work, which is expected.
 func NewAPIOnlyState(token string, h *handler.Handler) *State {
 	return &State{
-		Session:  session.NewCustom(gateway.DefaultIdentifier(token), api.NewClient(token), h),
-		Handler:  h,
-		Cabinet:  store.NoopCabinet,
-		StateLog: func(err error) {},
+		Session:    session.NewCustom(gateway.DefaultIdentifier(token), api.NewClient(token), h),
+		PreHandler: handler.New(),
+		Handler:    h,
+		Cabinet:    store.NoopCabinet,
+		StateLog:   func(err error) {},
 	}
 }
 
+// AddPreHandler adds a handler into PreHandler, which is called before the
+// State updates its cache. The handler is called asynchronously, so it is not
+// guaranteed to see the cache before the update; use AddSyncPreHandler for
+// that. The returned function removes the handler.
+//
+// For handlers that should see the cache after the update, use AddHandler or
+// AddSyncHandler instead.
+func (s *State) AddPreHandler(handler interface{}) (rm func()) {
+	return s.PreHandler.AddHandler(handler)
+}
+
+// AddSyncPreHandler adds a synchronous handler into PreHandler. The State
+// won't update its cache until the handler returns, so the handler can use the
+// cache to look up what the event is about to change, such as the content of a
+// message before it's deleted. The handler should not block for long, since it
+// blocks all other events.
+func (s *State) AddSyncPreHandler(handler interface{}) (rm func()) {
+	return s.PreHandler.AddSyncHandler(handler)
+}
+
+// InferIntents returns the intents needed to receive the events that the State
+// has handlers and pre-handlers for, including the handlers added to the
+// Session. IntentGuilds is always included, since the State needs it to cache
+// anything. Refer to Session's InferIntents for more information.
+func (s *State) InferIntents() (intents, privileged gateway.Intents) {
+	var types []reflect.Type
+	types = append(types, s.Session.Handler.EventTypes()...)
+	types = append(types, s.PreHandler.EventTypes()...)
+	types = append(types, s.Handler.EventTypes()...)
+
+	intents, privileged = gateway.InferIntents(types...)
+	return intents | gateway.IntentGuilds, privileged
+}
+
 // WithContext returns a shallow copy of State with the context replaced in the
 // API client. All methods called on the State will use this given context. This
 // method is thread-safe.
@@ -194,37 +258,37 @@ func (s *State) Ready() gateway.ReadyEvent {
 
 //// Helper methods
 
+// AuthorDisplayName returns the name that the message's author is displayed
+// as. It follows the same fallback as Member.DisplayName.
 func (s *State) AuthorDisplayName(message *gateway.MessageCreateEvent) string {
 	if !message.GuildID.IsValid() {
-		return message.Author.Username
+		return message.Author.DisplayOrUsername()
 	}
 
 	if message.Member != nil {
 		if message.Member.Nick != "" {
 			return message.Member.Nick
 		}
-		return message.Author.Username
+		return message.Author.DisplayOrUsername()
 	}
 
 	n, err := s.MemberDisplayName(message.GuildID, message.Author.ID)
 	if err != nil {
-		return message.Author.Username
+		return message.Author.DisplayOrUsername()
 	}
 
 	return n
 }
 
+// MemberDisplayName returns the name that the member is displayed as in the
+// guild. Refer to Member.DisplayName.
 func (s *State) MemberDisplayName(guildID discord.GuildID, userID discord.UserID) (string, error) {
 	member, err := s.Member(guildID, userID)
 	if err != nil {
 		return "", err
 	}
 
-	if member.Nick == "" {
-		return member.User.Username, nil
-	}
-
-	return member.Nick, nil
+	return member.DisplayName(), nil
 }
 
 // AuthorColor is a variant of MemberColor that possibly uses the existing
@@ -413,6 +477,7 @@ func (s *State) Permissions(
 
 func (s *State) Me() (*discord.User, error) {
 	u, err := s.Cabinet.Me()
+	s.cacheLookup("me", err == nil)
 	if err == nil {
 		return u, nil
 	}
@@ -429,8 +494,63 @@ func (s *State) Me() (*discord.User, error) {
 
 ////
 
+func (s *State) AutoModerationRule(
+	guildID discord.GuildID, ruleID discord.AutoModerationRuleID) (*discord.AutoModerationRule, error) {
+
+	if s.HasIntents(gateway.IntentAutoModerationConfiguration) {
+		r, err := s.Cabinet.AutoModerationRule(guildID, ruleID)
+		s.cacheLookup("auto_moderation_rule", err == nil)
+		if err == nil {
+			return r, nil
+		}
+	} else { // Fast path
+		return s.Session.AutoModerationRule(guildID, ruleID)
+	}
+
+	rs, err := s.fetchAutoModerationRules(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range rs {
+		if r.ID == ruleID {
+			return &r, nil
+		}
+	}
+
+	return nil, store.ErrNotFound
+}
+
+func (s *State) AutoModerationRules(guildID discord.GuildID) ([]discord.AutoModerationRule, error) {
+	if s.HasIntents(gateway.IntentAutoModerationConfiguration) {
+		rs, err := s.Cabinet.AutoModerationRules(guildID)
+		s.cacheLookup("auto_moderation_rules", err == nil)
+		if err == nil {
+			return rs, nil
+		}
+	}
+
+	return s.fetchAutoModerationRules(guildID)
+}
+
+func (s *State) fetchAutoModerationRules(
+	guildID discord.GuildID) (rs []discord.AutoModerationRule, err error) {
+
+	rs, err = s.Session.AutoModerationRules(guildID)
+	if err == nil && s.HasIntents(gateway.IntentAutoModerationConfiguration) {
+		for i := range rs {
+			s.Cabinet.AutoModerationRuleSet(guildID, &rs[i], false)
+		}
+	}
+
+	return
+}
+
+////
+
 func (s *State) Channel(id discord.ChannelID) (c *discord.Channel, err error) {
 	c, err = s.Cabinet.Channel(id)
+	s.cacheLookup("channel", err == nil && s.tracksChannel(c))
 	if err == nil && s.tracksChannel(c) {
 		return
 	}
@@ -450,6 +570,7 @@ func (s *State) Channel(id discord.ChannelID) (c *discord.Channel, err error) {
 func (s *State) Channels(guildID discord.GuildID) (cs []discord.Channel, err error) {
 	if s.HasIntents(gateway.IntentGuilds) {
 		cs, err = s.Cabinet.Channels(guildID)
+		s.cacheLookup("channels", err == nil)
 		if err == nil {
 			return
 		}
@@ -512,6 +633,7 @@ func (s *State) Emoji(
 
 	if s.HasIntents(gateway.IntentGuildEmojis) {
 		e, err = s.Cabinet.Emoji(guildID, emojiID)
+		s.cacheLookup("emoji", err == nil)
 		if err == nil {
 			return
 		}
@@ -538,6 +660,7 @@ func (s *State) Emoji(
 func (s *State) Emojis(guildID discord.GuildID) (es []discord.Emoji, err error) {
 	if s.HasIntents(gateway.IntentGuildEmojis) {
 		es, err = s.Cabinet.Emojis(guildID)
+		s.cacheLookup("emojis", err == nil)
 		if err == nil {
 			return
 		}
@@ -560,6 +683,7 @@ func (s *State) Emojis(guildID discord.GuildID) (es []discord.Emoji, err error)
 func (s *State) Guild(id discord.GuildID) (*discord.Guild, error) {
 	if s.HasIntents(gateway.IntentGuilds) {
 		c, err := s.Cabinet.Guild(id)
+		s.cacheLookup("guild", err == nil)
 		if err == nil {
 			return c, nil
 		}
@@ -572,6 +696,7 @@ func (s *State) Guild(id discord.GuildID) (*discord.Guild, error) {
 func (s *State) Guilds() (gs []discord.Guild, err error) {
 	if s.HasIntents(gateway.IntentGuilds) {
 		gs, err = s.Cabinet.Guilds()
+		s.cacheLookup("guilds", err == nil)
 		if err == nil {
 			return
 		}
@@ -596,6 +721,7 @@ func (s *State) Guilds() (gs []discord.Guild, err error) {
 func (s *State) Member(guildID discord.GuildID, userID discord.UserID) (*discord.Member, error) {
 	if s.HasIntents(gateway.IntentGuildMembers) {
 		m, err := s.Cabinet.Member(guildID, userID)
+		s.cacheLookup("member", err == nil)
 		if err == nil {
 			return m, nil
 		}
@@ -607,6 +733,7 @@ func (s *State) Member(guildID discord.GuildID, userID discord.UserID) (*discord
 func (s *State) Members(guildID discord.GuildID) (ms []discord.Member, err error) {
 	if s.HasIntents(gateway.IntentGuildMembers) {
 		ms, err = s.Cabinet.Members(guildID)
+		s.cacheLookup("members", err == nil)
 		if err == nil {
 			return
 		}
@@ -632,6 +759,7 @@ func (s *State) Message(
 	channelID discord.ChannelID, messageID discord.MessageID) (*discord.Message, error) {
 
 	m, err := s.Cabinet.Message(channelID, messageID)
+	s.cacheLookup("message", err == nil && s.tracksMessage(m))
 	if err == nil && s.tracksMessage(m) {
 		return m, nil
 	}
@@ -694,6 +822,15 @@ func (s *State) Messages(channelID discord.ChannelID, limit uint) ([]discord.Mes
 			return storeMessages, nil
 		}
 
+		// Was the channel backfilled?
+		if _, ok := s.backfilled[channelID]; ok {
+			s.fewMutex.Unlock()
+			if limit > 0 && len(storeMessages) > int(limit) {
+				return storeMessages[:limit], nil
+			}
+			return storeMessages, nil
+		}
+
 		// No, fetch from the API.
 		s.fewMutex.Unlock()
 	} else {
@@ -783,6 +920,69 @@ func (s *State) Messages(channelID discord.ChannelID, limit uint) ([]discord.Mes
 	return msgs, nil
 }
 
+// BackfillMessages fetches the latest n messages of the channel with the given
+// ID and adds them into the message store, so that they can be used for
+// lookups, such as in Message Delete handlers. If n is 0, then the store's
+// MaxMessages is used, and nothing is fetched if that is 0 too. At most
+// MaxMessages messages are kept, since the store can't hold more.
+//
+// Once backfilled, the channel is marked so that Messages returns the cached
+// messages instead of fetching them again. The store is kept up to date by
+// gateway events from then on. The mark is cleared on a new Ready event.
+//
+// The returned slice is sorted from latest to oldest.
+func (s *State) BackfillMessages(
+	ctx context.Context, channelID discord.ChannelID, n uint) ([]discord.Message, error) {
+
+	if n == 0 {
+		n = uint(s.MaxMessages())
+		if n == 0 {
+			// Fetching with a limit of 0 would page through the whole channel.
+			return nil, nil
+		}
+	}
+
+	c, err := s.WithContext(ctx).Channel(channelID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch channel: %w", err)
+	}
+
+	msgs, err := s.Session.WithCtx(ctx).MessagesBefore(channelID, 0, n)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range msgs {
+		// Messages fetched from the API don't have GuildID filled.
+		msgs[i].GuildID = c.GuildID
+	}
+
+	// Mirror what the channel would look like if the messages came from the
+	// gateway: don't cache messages we wouldn't receive updates for.
+	if len(msgs) > 0 && !s.tracksMessage(&msgs[0]) {
+		return msgs, nil
+	}
+
+	// Add the messages from latest to oldest, so that the store keeps the
+	// latest ones if it can't hold all of them.
+	for i := range msgs {
+		if i >= s.MaxMessages() {
+			break
+		}
+		s.Cabinet.MessageSet(&msgs[i], false)
+	}
+
+	s.fewMutex.Lock()
+	s.backfilled[channelID] = struct{}{}
+	if len(msgs) < int(n) {
+		// We've reached the start of the channel.
+		s.fewMessages[channelID] = struct{}{}
+	}
+	s.fewMutex.Unlock()
+
+	return msgs, nil
+}
+
 ////
 
 // Presence checks the state for user presences. If no guildID is given, it
@@ -820,6 +1020,7 @@ func (s *State) Presence(gID discord.GuildID, uID discord.UserID) (*discord.Pres
 func (s *State) Role(guildID discord.GuildID, roleID discord.RoleID) (target *discord.Role, err error) {
 	if s.HasIntents(gateway.IntentGuilds) {
 		target, err = s.Cabinet.Role(guildID, roleID)
+		s.cacheLookup("role", err == nil)
 		if err == nil {
 			return
 		}
@@ -851,6 +1052,7 @@ func (s *State) Role(guildID discord.GuildID, roleID discord.RoleID) (target *di
 func (s *State) Roles(guildID discord.GuildID) ([]discord.Role, error) {
 	if s.HasIntents(gateway.IntentGuilds) {
 		rs, err := s.Cabinet.Roles(guildID)
+		s.cacheLookup("roles", err == nil)
 		if err == nil {
 			return rs, nil
 		}
@@ -859,6 +1061,57 @@ func (s *State) Roles(guildID discord.GuildID) ([]discord.Role, error) {
 	return s.fetchRoles(guildID)
 }
 
+func (s *State) ScheduledEvent(
+	guildID discord.GuildID, eventID discord.EventID) (*discord.GuildScheduledEvent, error) {
+
+	if s.HasIntents(gateway.IntentGuildScheduledEvents) {
+		e, err := s.Cabinet.ScheduledEvent(guildID, eventID)
+		s.cacheLookup("scheduled_event", err == nil)
+		if err == nil {
+			return e, nil
+		}
+	}
+
+	e, err := s.Session.ScheduledEvent(guildID, eventID, false)
+	if err == nil && s.HasIntents(gateway.IntentGuildScheduledEvents) {
+		s.Cabinet.ScheduledEventSet(guildID, e, false)
+	}
+
+	return e, err
+}
+
+func (s *State) ScheduledEvents(guildID discord.GuildID) ([]discord.GuildScheduledEvent, error) {
+	if s.HasIntents(gateway.IntentGuildScheduledEvents) {
+		es, err := s.Cabinet.ScheduledEvents(guildID)
+		s.cacheLookup("scheduled_events", err == nil)
+		if err == nil {
+			return es, nil
+		}
+	}
+
+	es, err := s.Session.ListScheduledEvents(guildID, false)
+	if err == nil && s.HasIntents(gateway.IntentGuildScheduledEvents) {
+		for i := range es {
+			s.Cabinet.ScheduledEventSet(guildID, &es[i], false)
+		}
+	}
+
+	return es, err
+}
+
+// cacheLookup reports a cache lookup of the given kind to the client's
+// telemetry provider.
+func (s *State) cacheLookup(kind string, hit bool) {
+	if !s.Telemetry.Enabled() {
+		return
+	}
+
+	s.Telemetry.Add(s.Context(), telemetry.MetricCacheLookups, 1,
+		telemetry.String(telemetry.KeyKind, kind),
+		telemetry.Bool(telemetry.KeyHit, hit),
+	)
+}
+
 func (s *State) fetchGuild(id discord.GuildID) (g *discord.Guild, err error) {
 	g, err = s.Session.Guild(id)
 	if err == nil && s.HasIntents(gateway.IntentGuilds) {
@@ -191,6 +191,43 @@ func (s *State) onEvent(iface interface{}) {
 			s.stateErr(err, "failed to remove a role in state")
 		}
 
+	// Rules are not sent in Guild Create events, so they're only cached once
+	// all of them have been fetched. Adding a rule before that would make the
+	// cached rules look complete when they aren't.
+	case *gateway.AutoModerationRuleCreateEvent:
+		if _, err := s.Cabinet.AutoModerationRules(ev.GuildID); err == nil {
+			if err := s.Cabinet.AutoModerationRuleSet(ev.GuildID, &ev.AutoModerationRule, false); err != nil {
+				s.stateErr(err, "failed to add an auto moderation rule in state")
+			}
+		}
+
+	case *gateway.AutoModerationRuleUpdateEvent:
+		if _, err := s.Cabinet.AutoModerationRules(ev.GuildID); err == nil {
+			if err := s.Cabinet.AutoModerationRuleSet(ev.GuildID, &ev.AutoModerationRule, true); err != nil {
+				s.stateErr(err, "failed to update an auto moderation rule in state")
+			}
+		}
+
+	case *gateway.AutoModerationRuleDeleteEvent:
+		if err := s.Cabinet.AutoModerationRuleRemove(ev.GuildID, ev.ID); err != nil {
+			s.stateErr(err, "failed to remove an auto moderation rule in state")
+		}
+
+	case *gateway.GuildScheduledEventCreateEvent:
+		if err := s.Cabinet.ScheduledEventSet(ev.GuildID, &ev.GuildScheduledEvent, false); err != nil {
+			s.stateErr(err, "failed to add a scheduled event in state")
+		}
+
+	case *gateway.GuildScheduledEventUpdateEvent:
+		if err := s.Cabinet.ScheduledEventSet(ev.GuildID, &ev.GuildScheduledEvent, true); err != nil {
+			s.stateErr(err, "failed to update a scheduled event in state")
+		}
+
+	case *gateway.GuildScheduledEventDeleteEvent:
+		if err := s.Cabinet.ScheduledEventRemove(ev.GuildID, ev.ID); err != nil {
+			s.stateErr(err, "failed to remove a scheduled event in state")
+		}
+
 	case *gateway.GuildEmojisUpdateEvent:
 		if err := s.Cabinet.EmojiSet(ev.GuildID, ev.Emojis, true); err != nil {
 			s.stateErr(err, "failed to update emojis in state")
@@ -267,21 +304,31 @@ func (s *State) onEvent(iface interface{}) {
 		}
 
 		s.editMessage(ev.ChannelID, ev.MessageID, func(m *discord.Message) bool {
-			if i := findReaction(m.Reactions, ev.Emoji); i > -1 {
+			i := findReaction(m.Reactions, ev.Emoji)
+			if i > -1 {
 				// Copy the reactions slice so it's not racy.
 				m.Reactions = append([]discord.Reaction(nil), m.Reactions...)
-				m.Reactions[i].Count++
-				m.Reactions[i].Me = m.Reactions[i].Me || me
 			} else {
 				old := m.Reactions
 				m.Reactions = make([]discord.Reaction, 0, len(old)+1)
 				m.Reactions = append(m.Reactions, old...)
-				m.Reactions = append(m.Reactions, discord.Reaction{
-					Count: 1,
-					Me:    me,
-					Emoji: ev.Emoji,
-				})
+				m.Reactions = append(m.Reactions, discord.Reaction{Emoji: ev.Emoji})
+				i = len(m.Reactions) - 1
 			}
+
+			r := &m.Reactions[i]
+			r.Count++
+			if ev.Burst {
+				r.CountDetails.Burst++
+				r.MeBurst = r.MeBurst || me
+				if len(ev.BurstColors) > 0 {
+					r.BurstColors = ev.BurstColors
+				}
+			} else {
+				r.CountDetails.Normal++
+				r.Me = r.Me || me
+			}
+
 			return true
 		})
 
@@ -301,10 +348,22 @@ func (s *State) onEvent(iface interface{}) {
 				copy(m.Reactions[i:], old[i+1:])
 			} else {
 				r.Count--
-				if r.Me { // If reaction removal is the user's
+				if ev.Burst {
+					if r.CountDetails.Burst > 0 {
+						r.CountDetails.Burst--
+					}
+				} else if r.CountDetails.Normal > 0 {
+					r.CountDetails.Normal--
+				}
+
+				if r.Me || r.MeBurst { // If reaction removal is the user's
 					u, err := s.Cabinet.Me()
 					if err == nil && ev.UserID == u.ID {
-						r.Me = false
+						if ev.Burst {
+							r.MeBurst = false
+						} else {
+							r.Me = false
+						}
 					}
 				}
 			}
@@ -488,6 +547,13 @@ func storeGuildCreate(cab *store.Cabinet, guild *gateway.GuildCreateEvent) []err
 		}
 	}
 
+	// Handle guild scheduled events
+	for i := range guild.ScheduledEvents {
+		if err := cab.ScheduledEventSet(guild.ID, &guild.ScheduledEvents[i], false); err != nil {
+			errs(err, "failed to set scheduled event in Ready")
+		}
+	}
+
 	return *stack
 }
 
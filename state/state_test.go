@@ -0,0 +1,71 @@
+package state
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state/store"
+)
+
+func TestBackfillMessagesNoStore(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	// The noop store holds no messages, so MaxMessages is 0.
+	s := NewWithStore("Bot token", store.NoopCabinet)
+	s.Client = s.Client.WithBaseURL(srv.URL)
+
+	msgs, err := s.BackfillMessages(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(msgs) != 0 {
+		t.Fatalf("expected no messages, got %d", len(msgs))
+	}
+}
+
+func TestCacheLookupNoTelemetry(t *testing.T) {
+	s := NewWithStore("Bot token", store.NoopCabinet)
+
+	allocs := testing.AllocsPerRun(100, func() { s.cacheLookup("guild", true) })
+	if allocs != 0 {
+		t.Fatalf("expected no allocations without telemetry, got %v", allocs)
+	}
+}
+
+func TestStateInferIntents(t *testing.T) {
+	s := NewWithStore("Bot token", store.NoopCabinet)
+
+	intents, privileged := s.InferIntents()
+	if intents != gateway.IntentGuilds {
+		t.Errorf("expected only IntentGuilds without handlers, got %b", intents)
+	}
+	if privileged != 0 {
+		t.Errorf("expected no privileged intents, got %b", privileged)
+	}
+
+	s.Session.AddHandler(func(*gateway.TypingStartEvent) {})
+	s.PreHandler.AddHandler(func(*gateway.MessageUpdateEvent) {})
+	s.AddHandler(func(*gateway.PresenceUpdateEvent) {})
+
+	intents, privileged = s.InferIntents()
+
+	expect := gateway.IntentGuilds |
+		gateway.IntentGuildMessageTyping | gateway.IntentDirectMessageTyping |
+		gateway.IntentGuildMessages | gateway.IntentDirectMessages |
+		gateway.IntentMessageContent | gateway.IntentGuildPresences
+	if intents != expect {
+		t.Errorf("expected intents %b, got %b", expect, intents)
+	}
+
+	expectPrivileged := gateway.IntentMessageContent | gateway.IntentGuildPresences
+	if privileged != expectPrivileged {
+		t.Errorf("expected privileged intents %b, got %b", expectPrivileged, privileged)
+	}
+}
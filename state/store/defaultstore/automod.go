@@ -0,0 +1,109 @@
+package defaultstore
+
+import (
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/internal/moreatomic"
+	"github.com/diamondburned/arikawa/v3/state/store"
+)
+
+type AutoModerationRule struct {
+	guilds moreatomic.Map
+}
+
+var _ store.AutoModerationRuleStore = (*AutoModerationRule)(nil)
+
+type autoModRules struct {
+	mut   sync.RWMutex
+	rules map[discord.AutoModerationRuleID]discord.AutoModerationRule
+}
+
+func NewAutoModerationRule() *AutoModerationRule {
+	return &AutoModerationRule{
+		guilds: *moreatomic.NewMap(func() interface{} {
+			return &autoModRules{
+				rules: make(map[discord.AutoModerationRuleID]discord.AutoModerationRule, 1),
+			}
+		}),
+	}
+}
+
+func (s *AutoModerationRule) Reset() error {
+	return s.guilds.Reset()
+}
+
+func (s *AutoModerationRule) AutoModerationRule(
+	guildID discord.GuildID, ruleID discord.AutoModerationRuleID) (*discord.AutoModerationRule, error) {
+
+	iv, ok := s.guilds.Load(guildID)
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	rs := iv.(*autoModRules)
+
+	rs.mut.RLock()
+	defer rs.mut.RUnlock()
+
+	r, ok := rs.rules[ruleID]
+	if ok {
+		return &r, nil
+	}
+
+	return nil, store.ErrNotFound
+}
+
+func (s *AutoModerationRule) AutoModerationRules(
+	guildID discord.GuildID) ([]discord.AutoModerationRule, error) {
+
+	iv, ok := s.guilds.Load(guildID)
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	rs := iv.(*autoModRules)
+
+	rs.mut.RLock()
+	defer rs.mut.RUnlock()
+
+	var rules = make([]discord.AutoModerationRule, 0, len(rs.rules))
+	for _, rule := range rs.rules {
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (s *AutoModerationRule) AutoModerationRuleSet(
+	guildID discord.GuildID, rule *discord.AutoModerationRule, update bool) error {
+
+	iv, _ := s.guilds.LoadOrStore(guildID)
+
+	rs := iv.(*autoModRules)
+
+	rs.mut.Lock()
+	if _, ok := rs.rules[rule.ID]; !ok || update {
+		rs.rules[rule.ID] = *rule
+	}
+	rs.mut.Unlock()
+
+	return nil
+}
+
+func (s *AutoModerationRule) AutoModerationRuleRemove(
+	guildID discord.GuildID, ruleID discord.AutoModerationRuleID) error {
+
+	iv, ok := s.guilds.Load(guildID)
+	if !ok {
+		return nil
+	}
+
+	rs := iv.(*autoModRules)
+
+	rs.mut.Lock()
+	delete(rs.rules, ruleID)
+	rs.mut.Unlock()
+
+	return nil
+}
@@ -8,14 +8,16 @@ import "github.com/diamondburned/arikawa/v3/state/store"
 // Message store with a limit of 100 messages.
 func New() *store.Cabinet {
 	return &store.Cabinet{
-		MeStore:         NewMe(),
-		ChannelStore:    NewChannel(),
-		EmojiStore:      NewEmoji(),
-		GuildStore:      NewGuild(),
-		MemberStore:     NewMember(),
-		MessageStore:    NewMessage(100),
-		PresenceStore:   NewPresence(),
-		RoleStore:       NewRole(),
-		VoiceStateStore: NewVoiceState(),
+		MeStore:                 NewMe(),
+		AutoModerationRuleStore: NewAutoModerationRule(),
+		ChannelStore:            NewChannel(),
+		EmojiStore:              NewEmoji(),
+		GuildStore:              NewGuild(),
+		MemberStore:             NewMember(),
+		MessageStore:            NewMessage(100),
+		PresenceStore:           NewPresence(),
+		RoleStore:               NewRole(),
+		ScheduledEventStore:     NewScheduledEvent(),
+		VoiceStateStore:         NewVoiceState(),
 	}
 }
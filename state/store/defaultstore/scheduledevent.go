@@ -0,0 +1,109 @@
+package defaultstore
+
+import (
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/internal/moreatomic"
+	"github.com/diamondburned/arikawa/v3/state/store"
+)
+
+type ScheduledEvent struct {
+	guilds moreatomic.Map
+}
+
+var _ store.ScheduledEventStore = (*ScheduledEvent)(nil)
+
+type scheduledEvents struct {
+	mut    sync.RWMutex
+	events map[discord.EventID]discord.GuildScheduledEvent
+}
+
+func NewScheduledEvent() *ScheduledEvent {
+	return &ScheduledEvent{
+		guilds: *moreatomic.NewMap(func() interface{} {
+			return &scheduledEvents{
+				events: make(map[discord.EventID]discord.GuildScheduledEvent, 1),
+			}
+		}),
+	}
+}
+
+func (s *ScheduledEvent) Reset() error {
+	return s.guilds.Reset()
+}
+
+func (s *ScheduledEvent) ScheduledEvent(
+	guildID discord.GuildID, eventID discord.EventID) (*discord.GuildScheduledEvent, error) {
+
+	iv, ok := s.guilds.Load(guildID)
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	es := iv.(*scheduledEvents)
+
+	es.mut.RLock()
+	defer es.mut.RUnlock()
+
+	e, ok := es.events[eventID]
+	if ok {
+		return &e, nil
+	}
+
+	return nil, store.ErrNotFound
+}
+
+func (s *ScheduledEvent) ScheduledEvents(
+	guildID discord.GuildID) ([]discord.GuildScheduledEvent, error) {
+
+	iv, ok := s.guilds.Load(guildID)
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	es := iv.(*scheduledEvents)
+
+	es.mut.RLock()
+	defer es.mut.RUnlock()
+
+	var events = make([]discord.GuildScheduledEvent, 0, len(es.events))
+	for _, event := range es.events {
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (s *ScheduledEvent) ScheduledEventSet(
+	guildID discord.GuildID, event *discord.GuildScheduledEvent, update bool) error {
+
+	iv, _ := s.guilds.LoadOrStore(guildID)
+
+	es := iv.(*scheduledEvents)
+
+	es.mut.Lock()
+	if _, ok := es.events[event.ID]; !ok || update {
+		es.events[event.ID] = *event
+	}
+	es.mut.Unlock()
+
+	return nil
+}
+
+func (s *ScheduledEvent) ScheduledEventRemove(
+	guildID discord.GuildID, eventID discord.EventID) error {
+
+	iv, ok := s.guilds.Load(guildID)
+	if !ok {
+		return nil
+	}
+
+	es := iv.(*scheduledEvents)
+
+	es.mut.Lock()
+	delete(es.events, eventID)
+	es.mut.Unlock()
+
+	return nil
+}
@@ -50,6 +50,7 @@ var ErrNotFound = errors.New("item not found in store")
 // be copied around.
 type Cabinet struct {
 	MeStore
+	AutoModerationRuleStore
 	ChannelStore
 	EmojiStore
 	GuildStore
@@ -57,6 +58,7 @@ type Cabinet struct {
 	MessageStore
 	PresenceStore
 	RoleStore
+	ScheduledEventStore
 	VoiceStateStore
 }
 
@@ -64,6 +66,7 @@ type Cabinet struct {
 func (sc *Cabinet) Reset() error {
 	errors := []error{
 		sc.MeStore.Reset(),
+		sc.AutoModerationRuleStore.Reset(),
 		sc.ChannelStore.Reset(),
 		sc.EmojiStore.Reset(),
 		sc.GuildStore.Reset(),
@@ -71,6 +74,7 @@ func (sc *Cabinet) Reset() error {
 		sc.MessageStore.Reset(),
 		sc.PresenceStore.Reset(),
 		sc.RoleStore.Reset(),
+		sc.ScheduledEventStore.Reset(),
 		sc.VoiceStateStore.Reset(),
 	}
 
@@ -123,15 +127,17 @@ type NoopStore = noop
 // NoopCabinet is a store cabinet with all store methods set to the Noop
 // implementations.
 var NoopCabinet = &Cabinet{
-	MeStore:         Noop,
-	ChannelStore:    Noop,
-	EmojiStore:      Noop,
-	GuildStore:      Noop,
-	MemberStore:     Noop,
-	MessageStore:    Noop,
-	PresenceStore:   Noop,
-	RoleStore:       Noop,
-	VoiceStateStore: Noop,
+	MeStore:                 Noop,
+	AutoModerationRuleStore: Noop,
+	ChannelStore:            Noop,
+	EmojiStore:              Noop,
+	GuildStore:              Noop,
+	MemberStore:             Noop,
+	MessageStore:            Noop,
+	PresenceStore:           Noop,
+	RoleStore:               Noop,
+	ScheduledEventStore:     Noop,
+	VoiceStateStore:         Noop,
 }
 
 // noop is the Noop type that implements methods.
@@ -344,6 +350,36 @@ func (noop) PresenceRemove(discord.GuildID, discord.UserID) error {
 	return nil
 }
 
+// AutoModerationRuleStore is the store interface for all auto moderation
+// rules.
+type AutoModerationRuleStore interface {
+	Resetter
+
+	AutoModerationRule(
+		discord.GuildID, discord.AutoModerationRuleID) (*discord.AutoModerationRule, error)
+	AutoModerationRules(discord.GuildID) ([]discord.AutoModerationRule, error)
+
+	AutoModerationRuleSet(
+		guildID discord.GuildID, r *discord.AutoModerationRule, update bool) error
+	AutoModerationRuleRemove(discord.GuildID, discord.AutoModerationRuleID) error
+}
+
+var _ AutoModerationRuleStore = (*noop)(nil)
+
+func (noop) AutoModerationRule(
+	discord.GuildID, discord.AutoModerationRuleID) (*discord.AutoModerationRule, error) {
+	return nil, ErrNotFound
+}
+func (noop) AutoModerationRules(discord.GuildID) ([]discord.AutoModerationRule, error) {
+	return nil, ErrNotFound
+}
+func (noop) AutoModerationRuleSet(discord.GuildID, *discord.AutoModerationRule, bool) error {
+	return nil
+}
+func (noop) AutoModerationRuleRemove(discord.GuildID, discord.AutoModerationRuleID) error {
+	return nil
+}
+
 // RoleStore is the store interface for all member roles.
 type RoleStore interface {
 	Resetter
@@ -362,6 +398,32 @@ func (noop) Roles(discord.GuildID) ([]discord.Role, error)               { retur
 func (noop) RoleSet(discord.GuildID, *discord.Role, bool) error          { return nil }
 func (noop) RoleRemove(discord.GuildID, discord.RoleID) error            { return nil }
 
+// ScheduledEventStore is the store interface for all guild scheduled events.
+type ScheduledEventStore interface {
+	Resetter
+
+	ScheduledEvent(discord.GuildID, discord.EventID) (*discord.GuildScheduledEvent, error)
+	ScheduledEvents(discord.GuildID) ([]discord.GuildScheduledEvent, error)
+
+	ScheduledEventSet(guildID discord.GuildID, e *discord.GuildScheduledEvent, update bool) error
+	ScheduledEventRemove(discord.GuildID, discord.EventID) error
+}
+
+var _ ScheduledEventStore = (*noop)(nil)
+
+func (noop) ScheduledEvent(discord.GuildID, discord.EventID) (*discord.GuildScheduledEvent, error) {
+	return nil, ErrNotFound
+}
+func (noop) ScheduledEvents(discord.GuildID) ([]discord.GuildScheduledEvent, error) {
+	return nil, ErrNotFound
+}
+func (noop) ScheduledEventSet(discord.GuildID, *discord.GuildScheduledEvent, bool) error {
+	return nil
+}
+func (noop) ScheduledEventRemove(discord.GuildID, discord.EventID) error {
+	return nil
+}
+
 // VoiceStateStore is the store interface for all voice states.
 type VoiceStateStore interface {
 	Resetter
@@ -2,31 +2,10 @@ package bot
 
 import (
 	"reflect"
-	"sync"
 
 	"github.com/diamondburned/arikawa/v3/gateway"
-	"github.com/diamondburned/arikawa/v3/utils/ws"
 )
 
-var (
-	// eventIntents maps event pointer types to intents.
-	eventIntents     map[reflect.Type]gateway.Intents
-	eventIntentsOnce sync.Once
-)
-
-func ensureEventIntents() {
-	eventIntentsOnce.Do(func() {
-		eventIntents = map[reflect.Type]gateway.Intents{}
-		gateway.OpUnmarshalers.Each(func(_ ws.OpCode, t ws.EventType, f ws.OpFunc) bool {
-			intent, ok := gateway.EventIntents[t]
-			if ok {
-				eventIntents[reflect.TypeOf(f())] = intent
-			}
-			return false
-		})
-	})
-}
-
 type command struct {
 	value       reflect.Value // Func
 	event       reflect.Type
@@ -51,13 +30,7 @@ func (c *command) call(arg0 interface{}, argv ...reflect.Value) (interface{}, er
 
 // intents returns the command's intents from the event.
 func (c *command) intents() gateway.Intents {
-	ensureEventIntents()
-
-	intents, ok := eventIntents[c.event]
-	if !ok {
-		return 0
-	}
-	return intents
+	return gateway.IntentsForType(c.event)
 }
 
 func callWith(caller reflect.Value, arg0 interface{}, argv ...reflect.Value) (interface{}, error) {
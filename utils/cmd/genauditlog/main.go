@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"flag"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+type data struct {
+	Package string
+	Keys    []changeKey
+}
+
+type changeKey struct {
+	ConstName string
+	Key       string
+	TypeName  string
+	// IsString is true if the values are encoded as JSON strings.
+	IsString bool
+}
+
+//go:embed template.tmpl
+var packageTmpl string
+
+var tmpl = template.Must(template.New("").Parse(packageTmpl))
+
+// typePrefix is the prefix of the doc comment line that documents the type of
+// a change key's values.
+const typePrefix = "Type: "
+
+func main() {
+	var pkg string
+	var out string
+	var strs string
+
+	log.SetFlags(0)
+
+	flag.Usage = func() {
+		log.Printf("usage: %s [-p package] [-s types] <files...>", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+
+	flag.StringVar(&out, "o", "", "output, empty for stdout")
+	flag.StringVar(&pkg, "p", "discord", "package name")
+	flag.StringVar(&strs, "s", "", "comma-separated types whose values are encoded as JSON strings")
+	flag.Parse()
+
+	if len(flag.Args()) == 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	stringTypes := map[string]bool{}
+	for _, typ := range strings.Split(strs, ",") {
+		stringTypes[typ] = true
+	}
+
+	d := data{Package: pkg}
+
+	// types maps each key to the type of its values. Keys that are documented
+	// with different types are ambiguous, so they're mapped to an empty
+	// string and skipped.
+	types := map[string]string{}
+	var keys []changeKey
+
+	fset := token.NewFileSet()
+
+	for _, path := range flag.Args() {
+		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			log.Fatalln("failed to parse file:", err)
+		}
+
+		for _, key := range crawlFile(f) {
+			key.IsString = stringTypes[key.TypeName]
+
+			if typ, ok := types[key.Key]; ok && typ != key.TypeName {
+				types[key.Key] = ""
+				continue
+			}
+
+			types[key.Key] = key.TypeName
+			keys = append(keys, key)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, key := range keys {
+		if types[key.Key] == "" || seen[key.Key] {
+			continue
+		}
+		seen[key.Key] = true
+		d.Keys = append(d.Keys, key)
+	}
+
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, d); err != nil {
+		log.Fatalln("failed to execute template:", err)
+	}
+
+	b, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalln("failed to fmt:", err)
+	}
+
+	outFile := os.Stdout
+
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			log.Fatalln("failed to create output file:", err)
+		}
+		defer f.Close()
+
+		outFile = f
+	}
+
+	if _, err := outFile.Write(b); err != nil {
+		log.Fatalln("failed to write to file:", err)
+	}
+}
+
+// crawlFile returns all AuditLogChangeKey constants in the file that have their
+// type documented.
+func crawlFile(f *ast.File) []changeKey {
+	var keys []changeKey
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			v := spec.(*ast.ValueSpec)
+
+			ident, ok := v.Type.(*ast.Ident)
+			if !ok || ident.Name != "AuditLogChangeKey" || v.Doc == nil {
+				continue
+			}
+
+			typ := docType(v.Doc)
+			if typ == "" {
+				continue
+			}
+
+			for i, name := range v.Names {
+				lit, ok := v.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+
+				keys = append(keys, changeKey{
+					ConstName: name.Name,
+					Key:       strings.Trim(lit.Value, "\"`"),
+					TypeName:  typ,
+				})
+			}
+		}
+	}
+
+	return keys
+}
+
+func docType(doc *ast.CommentGroup) string {
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		if strings.HasPrefix(line, typePrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, typePrefix))
+		}
+	}
+	return ""
+}
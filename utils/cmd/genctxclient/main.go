@@ -0,0 +1,237 @@
+// Command genctxclient generates a method on *Client for every exported method
+// on CtxClient, which calls it with the client's context.
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var (
+	pkg  = "api"
+	out  = "-"
+	recv = "CtxClient"
+	to   = "Client"
+)
+
+type registry struct {
+	PackageName string
+	Recv        string
+	To          string
+	StdImports  []string
+	Imports     []string
+	Methods     []Method
+}
+
+type Method struct {
+	// Doc is the doc comment of the method, including the comment markers.
+	Doc     string
+	Name    string
+	Params  string
+	Args    string
+	Results string
+}
+
+//go:embed template.tmpl
+var packageTmpl string
+
+var tmpl = template.Must(template.New("").Parse(packageTmpl))
+
+func main() {
+	flag.StringVar(&pkg, "p", pkg, "the package name to use")
+	flag.StringVar(&out, "o", out, "output file, - for stdout")
+	flag.StringVar(&recv, "r", recv, "the type whose methods are wrapped")
+	flag.StringVar(&to, "t", to, "the type that the methods are generated for")
+	flag.Parse()
+
+	r := registry{
+		PackageName: pkg,
+		Recv:        recv,
+		To:          to,
+	}
+
+	files, err := os.ReadDir(".")
+	if err != nil {
+		log.Fatalln("failed to read current directory:", err)
+	}
+
+	imports := make(map[string]struct{})
+
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, ".go") ||
+			strings.HasSuffix(name, "_test.go") || name == out {
+			continue
+		}
+		if err := r.CrawlFile(name, imports); err != nil {
+			log.Fatalf("failed to crawl file %s: %v", name, err)
+		}
+	}
+
+	for path := range imports {
+		// The standard library goes first, then the other packages.
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			r.Imports = append(r.Imports, path)
+		} else {
+			r.StdImports = append(r.StdImports, path)
+		}
+	}
+	sort.Strings(r.StdImports)
+	sort.Strings(r.Imports)
+
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, &r); err != nil {
+		log.Fatalln("failed to execute template:", err)
+	}
+
+	b, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalln("failed to fmt:", err)
+	}
+
+	output := os.Stdout
+	if out != "-" {
+		f, err := os.Create(out)
+		if err != nil {
+			log.Fatalln("failed to create output:", err)
+		}
+		defer f.Close()
+
+		output = f
+	}
+
+	if _, err := output.Write(b); err != nil {
+		log.Fatalln("failed to write rendered:", err)
+	}
+}
+
+// CrawlFile adds the exported methods of the receiver type in the given file,
+// along with the imports that their signatures use. Files that declare the
+// receiver type are skipped, since they only have its plumbing.
+func (r *registry) CrawlFile(name string, imports map[string]struct{}) error {
+	fset := token.NewFileSet()
+
+	f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	if f.Scope.Lookup(r.Recv) != nil {
+		return nil
+	}
+
+	fileImports := make(map[string]string, len(f.Imports))
+	for _, spec := range f.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		fileImports[name] = path
+	}
+
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || !fn.Name.IsExported() {
+			continue
+		}
+		if ident, ok := fn.Recv.List[0].Type.(*ast.Ident); !ok || ident.Name != r.Recv {
+			continue
+		}
+
+		// Add the imports of the packages used in the signature.
+		var importErr error
+		ast.Inspect(fn.Type, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if x, ok := sel.X.(*ast.Ident); ok {
+				path, ok := fileImports[x.Name]
+				if !ok {
+					importErr = fmt.Errorf("%s: unknown package %s", fn.Name.Name, x.Name)
+				}
+				imports[path] = struct{}{}
+			}
+			return false
+		})
+		if importErr != nil {
+			return importErr
+		}
+
+		m := Method{Name: fn.Name.Name}
+		if fn.Doc != nil {
+			lines := make([]string, len(fn.Doc.List))
+			for i, comment := range fn.Doc.List {
+				lines[i] = comment.Text
+			}
+			m.Doc = strings.Join(lines, "\n")
+		}
+
+		var params, args []string
+		for _, field := range fn.Type.Params.List {
+			if len(field.Names) == 0 {
+				return fmt.Errorf("%s: unnamed parameter", fn.Name.Name)
+			}
+
+			typ := nodeString(fset, field.Type)
+			for _, name := range field.Names {
+				params = append(params, name.Name+" "+typ)
+				if _, ok := field.Type.(*ast.Ellipsis); ok {
+					args = append(args, name.Name+"...")
+				} else {
+					args = append(args, name.Name)
+				}
+			}
+		}
+		m.Params = strings.Join(params, ", ")
+		m.Args = strings.Join(args, ", ")
+
+		if fn.Type.Results != nil {
+			var results []string
+			var named bool
+			for _, field := range fn.Type.Results.List {
+				typ := nodeString(fset, field.Type)
+				if len(field.Names) == 0 {
+					results = append(results, typ)
+					continue
+				}
+
+				named = true
+				for _, name := range field.Names {
+					results = append(results, name.Name+" "+typ)
+				}
+			}
+
+			m.Results = strings.Join(results, ", ")
+			if named || len(results) > 1 {
+				m.Results = "(" + m.Results + ")"
+			}
+		}
+
+		r.Methods = append(r.Methods, m)
+	}
+
+	return nil
+}
+
+func nodeString(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, node); err != nil {
+		log.Fatalln("failed to print node:", err)
+	}
+	return buf.String()
+}
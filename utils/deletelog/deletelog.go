@@ -0,0 +1,232 @@
+// Package deletelog provides a best-effort way to find out who deleted a
+// message.
+//
+// Discord's Message Delete events don't say who deleted the message. The only
+// way to find out is to look for a matching Message Delete audit log entry,
+// which has a few quirks:
+//
+//   - Users deleting their own messages don't create any audit log entries.
+//     Neither do bots deleting messages, in some cases.
+//   - Consecutive deletions of messages from the same author in the same
+//     channel by the same user are merged into a single entry, whose count is
+//     incremented instead.
+//   - Audit log entries may arrive slightly after the Message Delete event.
+//
+// Attributor handles all of the above by keeping track of the entries and
+// their counts. Since Message Delete events don't contain the message's
+// author, the author must be obtained elsewhere, usually from the State's
+// message cache using a synchronous pre-handler.
+package deletelog
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+// DefaultWindow is the default window within which a new audit log entry is
+// considered to be about a deletion.
+const DefaultWindow = 10 * time.Second
+
+// AuditLogFetcher fetches a guild's audit log. It is implemented by
+// *api.Client.
+type AuditLogFetcher interface {
+	AuditLog(discord.GuildID, api.AuditLogData) (*discord.AuditLog, error)
+}
+
+// Attributor attributes deleted messages to the users who deleted them. An
+// Attributor is thread-safe.
+type Attributor struct {
+	// Window is the duration within which a newly seen audit log entry is
+	// considered to match a deletion. It defaults to DefaultWindow.
+	Window time.Duration
+	// Delay is how long Deleter waits before fetching the audit log using
+	// the REST API, which gives Discord some time to create the entry. It
+	// defaults to 0.
+	Delay time.Duration
+
+	client AuditLogFetcher
+
+	mutex   sync.Mutex
+	pending map[discord.GuildID][]discord.AuditLogEntry
+	counts  map[discord.AuditLogEntryID]int
+}
+
+// NewAttributor creates a new Attributor. If client is nil, then the REST API
+// is never used, and only the entries given to AddEntry are used.
+func NewAttributor(client AuditLogFetcher) *Attributor {
+	return &Attributor{
+		Window:  DefaultWindow,
+		client:  client,
+		pending: make(map[discord.GuildID][]discord.AuditLogEntry),
+		counts:  make(map[discord.AuditLogEntryID]int),
+	}
+}
+
+// HandleAuditLogEntry is a handler that adds the entry of the given event.
+// It can be directly added as a handler:
+//
+//	s.AddHandler(attributor.HandleAuditLogEntry)
+func (a *Attributor) HandleAuditLogEntry(ev *gateway.GuildAuditLogEntryCreateEvent) {
+	a.AddEntry(ev.GuildID, ev.AuditLogEntry)
+}
+
+// AddEntry adds an audit log entry received from the gateway. Entries that
+// are not Message Delete entries are ignored.
+func (a *Attributor) AddEntry(guildID discord.GuildID, entry discord.AuditLogEntry) {
+	if entry.ActionType != discord.MessageDelete {
+		return
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.prune()
+	a.pending[guildID] = append(a.pending[guildID], entry)
+}
+
+// Deleter returns the ID of the user who most likely deleted the message of
+// the given event, which was authored by authorID. If no matching audit log
+// entry is found, then the author most likely deleted the message themselves,
+// so authorID is returned along with false.
+func (a *Attributor) Deleter(
+	ev *gateway.MessageDeleteEvent, authorID discord.UserID) (discord.UserID, bool, error) {
+
+	if !ev.GuildID.IsValid() {
+		// Only the author can delete messages in DMs.
+		return authorID, false, nil
+	}
+
+	if entry := a.matchPending(ev, authorID); entry != nil {
+		return entry.UserID, true, nil
+	}
+
+	if a.client == nil {
+		return authorID, false, nil
+	}
+
+	if a.Delay > 0 {
+		time.Sleep(a.Delay)
+	}
+
+	log, err := a.client.AuditLog(ev.GuildID, api.AuditLogData{
+		ActionType: discord.MessageDelete,
+		Limit:      25,
+	})
+	if err != nil {
+		return authorID, false, err
+	}
+
+	if entry := a.matchFetched(ev, authorID, log.Entries); entry != nil {
+		return entry.UserID, true, nil
+	}
+
+	return authorID, false, nil
+}
+
+func (a *Attributor) matchPending(
+	ev *gateway.MessageDeleteEvent, authorID discord.UserID) *discord.AuditLogEntry {
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.prune()
+
+	pending := a.pending[ev.GuildID]
+
+	for i, entry := range pending {
+		if !matches(entry, ev, authorID) {
+			continue
+		}
+
+		a.pending[ev.GuildID] = append(pending[:i:i], pending[i+1:]...)
+		a.counts[entry.ID] = entryCount(entry)
+
+		return &entry
+	}
+
+	return nil
+}
+
+func (a *Attributor) matchFetched(
+	ev *gateway.MessageDeleteEvent, authorID discord.UserID,
+	entries []discord.AuditLogEntry) *discord.AuditLogEntry {
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	now := time.Now()
+
+	for i, entry := range entries {
+		if !matches(entry, ev, authorID) {
+			continue
+		}
+
+		count := entryCount(entry)
+		seen, ok := a.counts[entry.ID]
+
+		// Either the entry is new, or it's an old entry whose count has been
+		// incremented since we last saw it.
+		if (!ok && now.Sub(entry.CreatedAt()) <= a.window()) || (ok && count > seen) {
+			a.counts[entry.ID] = count
+			return &entries[i]
+		}
+	}
+
+	return nil
+}
+
+func (a *Attributor) window() time.Duration {
+	if a.Window > 0 {
+		return a.Window
+	}
+	return DefaultWindow
+}
+
+// countsTTL is how long entry counts are kept. Discord merges deletions into
+// the same entry for a while, so this must be longer than that.
+const countsTTL = time.Hour
+
+// prune removes stale entries. The mutex must be held.
+func (a *Attributor) prune() {
+	now := time.Now()
+
+	for guildID, entries := range a.pending {
+		fresh := entries[:0]
+		for _, entry := range entries {
+			if now.Sub(entry.CreatedAt()) <= a.window() {
+				fresh = append(fresh, entry)
+			}
+		}
+
+		if len(fresh) == 0 {
+			delete(a.pending, guildID)
+		} else {
+			a.pending[guildID] = fresh
+		}
+	}
+
+	for id := range a.counts {
+		if now.Sub(id.Time()) > countsTTL {
+			delete(a.counts, id)
+		}
+	}
+}
+
+func matches(entry discord.AuditLogEntry, ev *gateway.MessageDeleteEvent, authorID discord.UserID) bool {
+	return entry.ActionType == discord.MessageDelete &&
+		entry.Options.ChannelID == ev.ChannelID &&
+		discord.UserID(entry.TargetID) == authorID
+}
+
+func entryCount(entry discord.AuditLogEntry) int {
+	count, err := strconv.Atoi(entry.Options.Count)
+	if err != nil {
+		return 1
+	}
+	return count
+}
@@ -0,0 +1,88 @@
+package deletelog
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+type mockFetcher struct {
+	entries []discord.AuditLogEntry
+}
+
+func (f *mockFetcher) AuditLog(discord.GuildID, api.AuditLogData) (*discord.AuditLog, error) {
+	return &discord.AuditLog{Entries: f.entries}, nil
+}
+
+func newEntry(created time.Time, modID, authorID discord.UserID, count int) discord.AuditLogEntry {
+	return discord.AuditLogEntry{
+		ID:         discord.AuditLogEntryID(discord.NewSnowflake(created)),
+		TargetID:   discord.Snowflake(authorID),
+		UserID:     modID,
+		ActionType: discord.MessageDelete,
+		Options: discord.AuditEntryInfo{
+			ChannelID: 3,
+			Count:     strconv.Itoa(count),
+		},
+	}
+}
+
+func TestAttributor(t *testing.T) {
+	const (
+		guildID  discord.GuildID = 1
+		modID    discord.UserID  = 10
+		authorID discord.UserID  = 20
+	)
+
+	ev := &gateway.MessageDeleteEvent{ID: 2, ChannelID: 3, GuildID: guildID}
+
+	fetcher := &mockFetcher{}
+	a := NewAttributor(fetcher)
+
+	assertDeleter := func(expect discord.UserID, expectOK bool) {
+		t.Helper()
+
+		deleter, ok, err := a.Deleter(ev, authorID)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if deleter != expect || ok != expectOK {
+			t.Fatalf("expected (%v, %v), got (%v, %v)", expect, expectOK, deleter, ok)
+		}
+	}
+
+	// No entries: the author deleted the message themselves.
+	assertDeleter(authorID, false)
+
+	// An entry from the gateway.
+	a.HandleAuditLogEntry(&gateway.GuildAuditLogEntryCreateEvent{
+		AuditLogEntry: newEntry(time.Now(), modID, authorID, 1),
+		GuildID:       guildID,
+	})
+	assertDeleter(modID, true)
+
+	// The entry is consumed, and fetching returns the same entry with the
+	// same count, so it doesn't match again.
+	entry := newEntry(time.Now().Add(-time.Minute), modID, authorID, 1)
+	a.counts[entry.ID] = 1
+	fetcher.entries = []discord.AuditLogEntry{entry}
+	assertDeleter(authorID, false)
+
+	// The moderator deletes another message, so the count is incremented.
+	fetcher.entries[0].Options.Count = "2"
+	assertDeleter(modID, true)
+
+	// Old entries that were never seen don't match.
+	fetcher.entries = []discord.AuditLogEntry{
+		newEntry(time.Now().Add(-time.Minute), modID+1, authorID, 1),
+	}
+	assertDeleter(authorID, false)
+
+	// DMs are never attributed.
+	ev.GuildID = 0
+	assertDeleter(authorID, false)
+}
@@ -29,6 +29,7 @@ import (
 type Handler struct {
 	mutex  sync.RWMutex
 	events map[reflect.Type]slab // nil type for interfaces
+	pool   Pool
 }
 
 func New() *Handler {
@@ -41,11 +42,38 @@ func (h *Handler) Call(ev interface{}) {
 	v := reflect.ValueOf(ev)
 	t := reflect.TypeOf(ev)
 
+	h.mutex.RLock()
+	pool := h.pool
+	h.mutex.RUnlock()
+
+	// Asynchronous handlers are queued into the pool after the handlers are
+	// unlocked, since the pool may block until a handler that wants to add
+	// or remove handlers returns.
+	var queued []slabEntry
+
 	all := h.AllCallersForType(t)
 	all(func(caller Caller) bool {
+		if entry, ok := caller.(slabEntry); ok && pool != nil && !entry.isSync {
+			queued = append(queued, entry)
+			return true
+		}
 		caller.Call(v)
 		return true
 	})
+
+	for _, entry := range queued {
+		entry := entry
+		pool.Go(func() { entry.call(v) })
+	}
+}
+
+// SetPool sets the Pool that asynchronous handlers are run in. If p is nil,
+// each asynchronous handler is run in its own goroutine, which is the default.
+// Synchronous handlers are unaffected.
+func (h *Handler) SetPool(p Pool) {
+	h.mutex.Lock()
+	h.pool = p
+	h.mutex.Unlock()
 }
 
 // AllCallersForType returns all callers for the given event type. This is an
@@ -83,6 +111,31 @@ func (h *Handler) AllCallersForType(t reflect.Type) func(yield func(Caller) bool
 	}
 }
 
+// EventTypes returns the event types that have handlers, such as
+// *gateway.MessageCreateEvent. Handlers that take an interface, such as
+// interface{}, are not included, since they aren't for any specific event.
+func (h *Handler) EventTypes() []reflect.Type {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	var types []reflect.Type
+
+	for t, slab := range h.events {
+		if t == nil {
+			continue
+		}
+
+		for _, entry := range slab.Entries {
+			if !entry.isInvalid() {
+				types = append(types, t)
+				break
+			}
+		}
+	}
+
+	return types
+}
+
 // WaitFor blocks until there's an event. It's advised to use ChanFor instead,
 // as WaitFor may skip some events if it's not ran fast enough after the event
 // arrived.
@@ -297,3 +297,35 @@ func BenchmarkReflect(b *testing.B) {
 		h.call(msgV)
 	}
 }
+
+func TestHandlerPool(t *testing.T) {
+	pool := NewWorkerPool(2, 0)
+	defer pool.Close()
+
+	h := &Handler{}
+	h.SetPool(pool)
+
+	const n = 10
+	results := make(chan string, n)
+
+	h.AddHandler(func(m *gateway.MessageCreateEvent) {
+		// Adding a handler from within a handler must not deadlock.
+		h.AddHandler(func(*gateway.MessageDeleteEvent) {})
+		results <- m.Content
+	})
+
+	for i := 0; i < n; i++ {
+		h.Call(newMessage("hime arikawa"))
+	}
+
+	for i := 0; i < n; i++ {
+		select {
+		case r := <-results:
+			if r != "hime arikawa" {
+				t.Fatal("unexpected content:", r)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for handler")
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import "sync"
+
+// Pool runs asynchronous handlers. By default, each asynchronous handler is
+// called in its own goroutine, which may spawn an unbounded number of
+// goroutines during event bursts. Setting a Pool on the Handler bounds that.
+type Pool interface {
+	// Go runs f, usually in another goroutine. It may block until there is
+	// room to run f.
+	Go(f func())
+}
+
+// WorkerPool is a Pool that runs functions in a fixed number of goroutines.
+// Go blocks once all workers are busy and the queue is full, which applies
+// backpressure onto the event loop.
+type WorkerPool struct {
+	queue chan func()
+	wg    sync.WaitGroup
+	once  sync.Once
+}
+
+var _ Pool = (*WorkerPool)(nil)
+
+// NewWorkerPool creates a new WorkerPool with the given number of workers and
+// queue size. If workers is less than 1, then 1 worker is used.
+func NewWorkerPool(workers, queue int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queue < 0 {
+		queue = 0
+	}
+
+	p := &WorkerPool{queue: make(chan func(), queue)}
+	p.wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer p.wg.Done()
+			for f := range p.queue {
+				f()
+			}
+		}()
+	}
+
+	return p
+}
+
+// Go queues f to be run by a worker. It must not be called after Close.
+func (p *WorkerPool) Go(f func()) {
+	p.queue <- f
+}
+
+// Close stops the workers after all queued functions have been run and waits
+// for them to exit.
+func (p *WorkerPool) Close() {
+	p.once.Do(func() { close(p.queue) })
+	p.wg.Wait()
+}
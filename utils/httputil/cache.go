@@ -0,0 +1,230 @@
+package httputil
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+)
+
+// DefaultCacheEntries is the number of responses kept by a ResponseCache if
+// MaxEntries is 0.
+const DefaultCacheEntries = 1000
+
+// ResponseCache is a conditional request cache. It keeps the bodies of GET
+// responses that have an ETag or Last-Modified header, and sends their
+// validators along with later requests to the same URL. If the server replies
+// with 304 Not Modified, then the cached body is returned instead, so that it
+// doesn't have to be downloaded again.
+//
+// Every request is still sent, so the returned bodies are never stale, and
+// rate limits apply as usual. Responses are keyed by their URL and
+// Authorization header, so clients with different tokens can share a cache.
+//
+// ResponseCache is used as a middleware:
+//
+//	cache := httputil.NewResponseCache(0)
+//	client.Middlewares = append(client.Middlewares, cache.Middleware)
+//
+// It only works with drivers whose requests have a ToHTTPRequest method, such
+// as the default driver; requests of other drivers are sent as-is. It is safe
+// for concurrent use.
+type ResponseCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element // -> *cacheEntry
+	lru     *list.List                 // most recently used first
+}
+
+type cacheKey struct {
+	url  string
+	auth string
+}
+
+type cacheEntry struct {
+	key          cacheKey
+	etag         string
+	lastModified string
+	status       int
+	header       http.Header
+	body         []byte
+}
+
+// NewResponseCache creates a new ResponseCache that keeps up to maxEntries
+// responses, dropping the least recently used ones. If maxEntries is 0, then
+// DefaultCacheEntries is used.
+func NewResponseCache(maxEntries int) *ResponseCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheEntries
+	}
+
+	return &ResponseCache{
+		maxEntries: maxEntries,
+		entries:    make(map[cacheKey]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Len returns the number of cached responses.
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// Clear removes all cached responses.
+func (c *ResponseCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[cacheKey]*list.Element)
+	c.lru.Init()
+}
+
+// Middleware is the Middleware that caches responses. Refer to ResponseCache.
+func (c *ResponseCache) Middleware(next Transport) Transport {
+	return func(r httpdriver.Request) (httpdriver.Response, error) {
+		hr, ok := r.(interface{ ToHTTPRequest() *http.Request })
+		if !ok {
+			return next(r)
+		}
+
+		req := hr.ToHTTPRequest()
+		if req.Method != "GET" {
+			return next(r)
+		}
+
+		key := cacheKey{
+			url:  req.URL.String(),
+			auth: req.Header.Get("Authorization"),
+		}
+
+		entry := c.get(key)
+		if entry != nil {
+			header := http.Header{}
+			if entry.etag != "" {
+				header.Set("If-None-Match", entry.etag)
+			}
+			if entry.lastModified != "" {
+				header.Set("If-Modified-Since", entry.lastModified)
+			}
+			r.AddHeader(header)
+		}
+
+		resp, err := next(r)
+		if err != nil {
+			return resp, err
+		}
+
+		switch status := resp.GetStatus(); {
+		case status == http.StatusNotModified && entry != nil:
+			resp.GetBody().Close()
+
+			// Keep the headers of the new response, since they have the
+			// current rate limits.
+			header := entry.header.Clone()
+			for k, v := range resp.GetHeader() {
+				header[k] = v
+			}
+
+			return &cachedResponse{
+				status: entry.status,
+				header: header,
+				body:   io.NopCloser(bytes.NewReader(entry.body)),
+			}, nil
+
+		case status == http.StatusOK:
+			header := resp.GetHeader()
+
+			etag := header.Get("ETag")
+			lastModified := header.Get("Last-Modified")
+			if etag == "" && lastModified == "" {
+				c.remove(key)
+				return resp, nil
+			}
+
+			body := resp.GetBody()
+			b, err := io.ReadAll(body)
+			body.Close()
+			if err != nil {
+				return nil, err
+			}
+
+			c.put(&cacheEntry{
+				key:          key,
+				etag:         etag,
+				lastModified: lastModified,
+				status:       status,
+				header:       header.Clone(),
+				body:         b,
+			})
+
+			return &cachedResponse{
+				status: status,
+				header: header,
+				body:   io.NopCloser(bytes.NewReader(b)),
+			}, nil
+
+		default:
+			return resp, nil
+		}
+	}
+}
+
+func (c *ResponseCache) get(key cacheKey) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*cacheEntry)
+}
+
+func (c *ResponseCache) put(entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[entry.key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[entry.key] = c.lru.PushFront(entry)
+
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (c *ResponseCache) remove(key cacheKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// cachedResponse is a response with a body that was read into memory.
+type cachedResponse struct {
+	status int
+	header http.Header
+	body   io.ReadCloser
+}
+
+func (r *cachedResponse) GetStatus() int         { return r.status }
+func (r *cachedResponse) GetHeader() http.Header { return r.header }
+func (r *cachedResponse) GetBody() io.ReadCloser { return r.body }
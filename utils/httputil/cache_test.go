@@ -0,0 +1,43 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResponseCache(t *testing.T) {
+	var sent int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		sent++
+		w.Write([]byte(`{"name":"guild"}`))
+	}))
+	defer srv.Close()
+
+	cache := NewResponseCache(0)
+
+	client := NewClient()
+	client.Middlewares = append(client.Middlewares, cache.Middleware)
+
+	for i := 0; i < 3; i++ {
+		var v struct{ Name string }
+		if err := client.RequestJSON(&v, "GET", srv.URL+"/guilds/1"); err != nil {
+			t.Fatal("failed to request:", err)
+		}
+		if v.Name != "guild" {
+			t.Fatalf("unexpected body on request %d: %+v", i, v)
+		}
+	}
+
+	if sent != 1 {
+		t.Fatalf("expected the body to be sent once, got %d", sent)
+	}
+	if cache.Len() != 1 {
+		t.Fatalf("expected 1 cached response, got %d", cache.Len())
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/url"
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
 	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/telemetry"
 )
 
 // StatusTooManyRequests is the HTTP status code discord sends on rate-limiting.
@@ -32,13 +34,39 @@ type Client struct {
 	// errors out. The error returned will override Do's if it's not nil.
 	OnResponse []ResponseFunc
 
+	// Middlewares wrap the sending of every request, in order from the
+	// outermost. Refer to Middleware.
+	Middlewares []Middleware
+
 	// Timeout is the maximum amount of time the client will wait for a request
 	// to finish. If this is 0 or smaller the Client won't time out. Otherwise,
 	// the timeout will be used as deadline for context of every request.
 	Timeout time.Duration
 
-	// Default to the global Retries variable (5).
+	// Default to the global Retries variable (5). It is only used if
+	// RetryPolicy is nil, in which case requests are retried immediately
+	// unless the response has a Retry-After header, which is then waited for.
 	Retries uint
+	// RetryPolicy, if not nil, decides which failed requests are retried and
+	// how long to wait between attempts. It overrides Retries.
+	RetryPolicy *RetryPolicy
+
+	// Decompress, if true, makes the client request compressed responses
+	// using all encodings in Decompressors and transparently decompress them.
+	// This reduces bandwidth for endpoints that return large payloads, such
+	// as member lists and audit logs.
+	Decompress bool
+	// Decompressors is the list of content encodings that the client can
+	// decompress, in order of preference. It defaults to DefaultDecompressors.
+	Decompressors []Decompressor
+
+	// Telemetry is the provider that every request is reported to. It reports
+	// nothing by default.
+	Telemetry telemetry.Provider
+	// TelemetryRoute returns the route of the given request URL, which is used
+	// as the route attribute when reporting requests. If nil, the URL path is
+	// used.
+	TelemetryRoute func(url string) string
 
 	context context.Context
 }
@@ -48,18 +76,27 @@ func NewClient() *Client {
 		Client:        httpdriver.NewClient(),
 		SchemaEncoder: &DefaultSchema{},
 		Retries:       Retries,
+		Decompressors: DefaultDecompressors,
 		context:       context.Background(),
 	}
 }
 
-// Copy returns a shallow copy of the client.
+// Copy returns a shallow copy of the client. Appending to the OnRequest,
+// OnResponse and Middlewares of the copy doesn't affect the client or its other copies.
 func (c *Client) Copy() *Client {
 	cl := new(Client)
 	*cl = *c
+	// Cap the slices to their length, so that appending to them reallocates
+	// instead of writing into the array shared with the other copies.
+	cl.OnRequest = c.OnRequest[:len(c.OnRequest):len(c.OnRequest)]
+	cl.OnResponse = c.OnResponse[:len(c.OnResponse):len(c.OnResponse)]
+	cl.Middlewares = c.Middlewares[:len(c.Middlewares):len(c.Middlewares)]
 	return cl
 }
 
-// WithContext returns a client copy of the client with the given context.
+// WithContext returns a client copy of the client with the given context. To
+// give a single request its own context, use RequestCtx or one of the other
+// Ctx methods instead, which don't copy the client.
 func (c *Client) WithContext(ctx context.Context) *Client {
 	c = c.Copy()
 	c.context = ctx
@@ -72,6 +109,19 @@ func (c *Client) Context() context.Context {
 	return c.context
 }
 
+func (c *Client) route(rawURL string) string {
+	if c.TelemetryRoute != nil {
+		return c.TelemetryRoute(rawURL)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	return u.Path
+}
+
 // applyOptions tries to apply all options. It does not halt if a single option
 // fails, and the error returned is the latest error.
 func (c *Client) applyOptions(r httpdriver.Request, extra []RequestOption) (e error) {
@@ -103,6 +153,15 @@ func (c *Client) MeanwhileMultipart(
 	writer MultipartWriter,
 	method, url string, opts ...RequestOption) (httpdriver.Response, error) {
 
+	return c.MeanwhileMultipartCtx(c.context, writer, method, url, opts...)
+}
+
+// MeanwhileMultipartCtx is the same as MeanwhileMultipart, but it uses the
+// given context instead of the client's.
+func (c *Client) MeanwhileMultipartCtx(
+	ctx context.Context, writer MultipartWriter,
+	method, url string, opts ...RequestOption) (httpdriver.Response, error) {
+
 	r, w := io.Pipe()
 	body := multipart.NewWriter(w)
 
@@ -116,6 +175,20 @@ func (c *Client) MeanwhileMultipart(
 		w.CloseWithError(err)
 	}()
 
+	// Stop writing the body as soon as the context is done, since not every
+	// driver closes the request body when the request is canceled, which
+	// would leave a large upload hanging.
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			r.CloseWithError(ctx.Err())
+		case <-done:
+		}
+	}()
+
 	// Prepend the multipart writer and the correct Content-Type header options.
 	opts = PrependOptions(
 		opts,
@@ -123,13 +196,18 @@ func (c *Client) MeanwhileMultipart(
 		WithContentType(body.FormDataContentType()),
 	)
 
-	// Request with the current client and our own context:
-	return c.Request(method, url, opts...)
+	return c.RequestCtx(ctx, method, url, opts...)
 }
 
 // FastRequest performs a request without waiting for the body.
 func (c *Client) FastRequest(method, url string, opts ...RequestOption) error {
-	r, err := c.Request(method, url, opts...)
+	return c.FastRequestCtx(c.context, method, url, opts...)
+}
+
+// FastRequestCtx is the same as FastRequest, but it uses the given context
+// instead of the client's.
+func (c *Client) FastRequestCtx(ctx context.Context, method, url string, opts ...RequestOption) error {
+	r, err := c.RequestCtx(ctx, method, url, opts...)
 	if err != nil {
 		return err
 	}
@@ -139,7 +217,15 @@ func (c *Client) FastRequest(method, url string, opts ...RequestOption) error {
 
 // RequestJSON performs a request and unmarshals the JSON body into "to".
 func (c *Client) RequestJSON(to interface{}, method, url string, opts ...RequestOption) error {
-	r, err := c.Request(method, url, opts...)
+	return c.RequestJSONCtx(c.context, to, method, url, opts...)
+}
+
+// RequestJSONCtx is the same as RequestJSON, but it uses the given context
+// instead of the client's.
+func (c *Client) RequestJSONCtx(
+	ctx context.Context, to interface{}, method, url string, opts ...RequestOption) error {
+
+	r, err := c.RequestCtx(ctx, method, url, opts...)
 	if err != nil {
 		return err
 	}
@@ -166,7 +252,16 @@ func (c *Client) RequestJSON(to interface{}, method, url string, opts ...Request
 // Request performs a request and returns a response with an unread body. The
 // caller must close it manually.
 func (c *Client) Request(method, url string, opts ...RequestOption) (httpdriver.Response, error) {
-	response, cancel, err := c.request(method, url, opts)
+	return c.RequestCtx(c.context, method, url, opts...)
+}
+
+// RequestCtx is the same as Request, but it uses the given context instead of
+// the client's. Unlike WithContext, it doesn't copy the client, so it's the
+// cheaper way to give a single request its own context.
+func (c *Client) RequestCtx(
+	ctx context.Context, method, url string, opts ...RequestOption) (httpdriver.Response, error) {
+
+	response, cancel, err := c.request(ctx, method, url, opts)
 	if err != nil {
 		if cancel != nil {
 			cancel()
@@ -182,7 +277,7 @@ func (c *Client) Request(method, url string, opts ...RequestOption) (httpdriver.
 }
 
 func (c *Client) request(
-	method, url string,
+	ctx context.Context, method, url string,
 	opts []RequestOption) (r httpdriver.Response, cancel context.CancelFunc, doErr error) {
 
 	// Error that represents the latest error in the chain.
@@ -190,15 +285,43 @@ func (c *Client) request(
 
 	var status int
 
-	ctx := c.context
-
 	if c.Timeout > 0 {
 		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
 	}
 
-	// The c.Retries < 1 check ensures that we retry forever if that field is
-	// less than 1.
-	for i := uint(0); c.Retries < 1 || i < c.Retries; i++ {
+	var attrs []telemetry.Attribute
+
+	if c.Telemetry.Enabled() {
+		start := time.Now()
+		attrs = []telemetry.Attribute{
+			telemetry.String(telemetry.KeyMethod, method),
+			telemetry.String(telemetry.KeyRoute, c.route(url)),
+		}
+
+		var span telemetry.Span
+		ctx, span = c.Telemetry.Start(ctx, telemetry.SpanRESTRequest, attrs...)
+
+		defer func() {
+			attrs = append(attrs, telemetry.Int(telemetry.KeyStatus, status))
+			span.SetAttributes(attrs[2:]...)
+			if doErr != nil {
+				span.RecordError(doErr)
+			}
+			span.End()
+
+			c.Telemetry.RecordDuration(ctx, telemetry.MetricRESTDuration, start, attrs...)
+			c.Telemetry.Add(ctx, telemetry.MetricRESTRequests, 1, attrs...)
+		}()
+	}
+
+	if c.Decompress && len(c.Decompressors) > 0 {
+		opts = PrependOptions(opts, WithAcceptEncoding(c.acceptEncoding()))
+	}
+
+	policy := c.retryPolicy()
+	transport := c.transport()
+
+	for attempt := uint(1); ; attempt++ {
 		q, err := c.Client.NewRequest(ctx, method, url)
 		if err != nil {
 			doErr = RequestError{err}
@@ -216,9 +339,10 @@ func (c *Client) request(
 			return
 		}
 
-		r, doErr = c.Client.Do(q)
+		r, doErr = transport(q)
 
 		// Call OnResponse() even if the request failed.
+		onRespErr = nil
 		for _, fn := range c.OnResponse {
 			// Be sure to call ALL OnResponse handlers.
 			if err := fn(q, r); err != nil {
@@ -226,15 +350,40 @@ func (c *Client) request(
 			}
 		}
 
-		if onRespErr != nil || doErr != nil {
-			continue
+		var resp httpdriver.Response
+		if doErr == nil {
+			resp = r
+			status = r.GetStatus()
 		}
 
-		if status = r.GetStatus(); status == StatusTooManyRequests || status >= 500 {
-			continue
+		var retry bool
+		if onRespErr != nil {
+			retry = policy.MaxAttempts < 1 || attempt < policy.MaxAttempts
+		} else {
+			retry = policy.shouldRetry(attempt, resp, doErr)
 		}
 
-		break
+		if !retry {
+			break
+		}
+
+		wait := policy.backoff(attempt, resp)
+
+		if attrs != nil {
+			c.Telemetry.Add(ctx, telemetry.MetricRESTRetries, 1, attrs[:2]...)
+		}
+
+		// The response is discarded, so its body must be closed.
+		if resp != nil {
+			resp.GetBody().Close()
+		}
+		r = nil
+
+		if err := sleep(ctx, wait); err != nil {
+			onRespErr = nil
+			doErr = err
+			break
+		}
 	}
 
 	if onRespErr != nil {
@@ -248,6 +397,13 @@ func (c *Client) request(
 		return
 	}
 
+	if c.Decompress {
+		if r, doErr = c.decompressResponse(r); doErr != nil {
+			doErr = RequestError{doErr}
+			return
+		}
+	}
+
 	// Response received, but with a failure status code:
 	if status < 200 || status > 299 {
 		// Try and parse the body.
@@ -0,0 +1,177 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"github.com/diamondburned/arikawa/v3/utils/telemetry"
+)
+
+type testSpan struct {
+	name  string
+	attrs map[string]interface{}
+	ended bool
+}
+
+func (s *testSpan) SetAttributes(attrs ...telemetry.Attribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *testSpan) RecordError(err error) {}
+func (s *testSpan) End()                  { s.ended = true }
+
+type testTracer struct{ spans []*testSpan }
+
+func (t *testTracer) Start(ctx context.Context, name string, attrs ...telemetry.Attribute) (context.Context, telemetry.Span) {
+	span := &testSpan{name: name, attrs: map[string]interface{}{}}
+	span.SetAttributes(attrs...)
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestClientTelemetry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	tracer := &testTracer{}
+
+	client := NewClient()
+	client.Telemetry = telemetry.Provider{Tracer: tracer}
+	client.TelemetryRoute = func(string) string { return "/test" }
+
+	if err := client.FastRequest("DELETE", srv.URL+"/test/123"); err != nil {
+		t.Fatal("failed to request:", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(tracer.spans))
+	}
+
+	span := tracer.spans[0]
+	if !span.ended {
+		t.Fatal("span was not ended")
+	}
+
+	expect := map[string]interface{}{
+		telemetry.KeyMethod: "DELETE",
+		telemetry.KeyRoute:  "/test",
+		telemetry.KeyStatus: int64(http.StatusNoContent),
+	}
+
+	for k, v := range expect {
+		if span.attrs[k] != v {
+			t.Errorf("expected attribute %s = %v, got %v", k, v, span.attrs[k])
+		}
+	}
+}
+
+func TestClientCopy(t *testing.T) {
+	c := NewClient()
+	c.OnRequest = make([]RequestOption, 0, 4)
+
+	var called []string
+	hook := func(name string) RequestOption {
+		return func(httpdriver.Request) error {
+			called = append(called, name)
+			return nil
+		}
+	}
+
+	a := c.Copy()
+	a.OnRequest = append(a.OnRequest, hook("a"))
+
+	b := c.WithContext(context.Background())
+	b.OnRequest = append(b.OnRequest, hook("b"))
+
+	if err := a.applyOptions(nil, nil); err != nil {
+		t.Fatal("failed to apply options:", err)
+	}
+
+	if len(called) != 1 || called[0] != "a" {
+		t.Fatalf("expected only hook a to be called, got %q", called)
+	}
+}
+
+func TestClientMiddlewares(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`"` + r.Header.Get("X-Test") + `"`))
+	}))
+	defer srv.Close()
+
+	var called []string
+	logger := func(name string) Middleware {
+		return func(next Transport) Transport {
+			return func(r httpdriver.Request) (httpdriver.Response, error) {
+				called = append(called, name)
+				return next(r)
+			}
+		}
+	}
+
+	client := NewClient()
+	client.Middlewares = []Middleware{
+		logger("a"),
+		logger("b"),
+		func(next Transport) Transport {
+			return func(r httpdriver.Request) (httpdriver.Response, error) {
+				if r.GetPath() == "/cached" {
+					return httpdriver.NewMockResponse(http.StatusOK, nil, "cached"), nil
+				}
+				r.AddHeader(http.Header{"X-Test": {"injected"}})
+				return next(r)
+			}
+		},
+	}
+
+	var got string
+	if err := client.RequestJSON(&got, "GET", srv.URL+"/test"); err != nil {
+		t.Fatal("failed to request:", err)
+	}
+	if got != "injected" {
+		t.Fatalf("expected injected header, got %q", got)
+	}
+
+	if err := client.RequestJSON(&got, "GET", srv.URL+"/cached"); err != nil {
+		t.Fatal("failed to request:", err)
+	}
+	if got != "cached" {
+		t.Fatalf("expected cached response, got %q", got)
+	}
+
+	if strings.Join(called, ",") != "a,b,a,b" {
+		t.Fatalf("unexpected middleware calls %q", called)
+	}
+}
+
+func TestClientRequestCtx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := c.FastRequestCtx(ctx, "GET", srv.URL); !errors.Is(err, context.Canceled) {
+		t.Fatal("expected the given context to be used, got", err)
+	}
+	if err := c.RequestJSONCtx(ctx, nil, "GET", srv.URL); !errors.Is(err, context.Canceled) {
+		t.Fatal("expected the given context to be used, got", err)
+	}
+
+	// The client's own context is unaffected.
+	if err := c.FastRequest("GET", srv.URL); err != nil {
+		t.Fatal("unexpected error from the client:", err)
+	}
+}
@@ -0,0 +1,152 @@
+package httputil
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+)
+
+// Decompressor describes a content encoding that the Client can decompress.
+type Decompressor struct {
+	// Encoding is the name of the content encoding, such as "gzip" or "br".
+	Encoding string
+	// NewReader wraps the given compressed reader into a reader that reads
+	// decompressed data.
+	NewReader func(io.Reader) (io.ReadCloser, error)
+}
+
+// DefaultDecompressors contains the decompressors that are supported out of
+// the box, in order of preference. Other encodings can be supported by adding
+// them to a Client's Decompressors. For example, to add Zstandard using
+// github.com/klauspost/compress/zstd:
+//
+//	client.Decompressors = append([]httputil.Decompressor{{
+//	    Encoding: "zstd",
+//	    NewReader: func(r io.Reader) (io.ReadCloser, error) {
+//	        d, err := zstd.NewReader(r)
+//	        if err != nil {
+//	            return nil, err
+//	        }
+//	        return d.IOReadCloser(), nil
+//	    },
+//	}}, client.Decompressors...)
+var DefaultDecompressors = []Decompressor{
+	{
+		Encoding: "br",
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			return io.NopCloser(brotli.NewReader(r)), nil
+		},
+	},
+	{
+		Encoding: "gzip",
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+	},
+	{
+		Encoding: "deflate",
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			return zlib.NewReader(r)
+		},
+	},
+}
+
+// acceptEncoding returns the Accept-Encoding header value for the client's
+// decompressors.
+func (c *Client) acceptEncoding() string {
+	encodings := make([]string, len(c.Decompressors))
+	for i, d := range c.Decompressors {
+		encodings[i] = d.Encoding
+	}
+	return strings.Join(encodings, ", ")
+}
+
+func (c *Client) decompressor(encoding string) *Decompressor {
+	for i, d := range c.Decompressors {
+		if strings.EqualFold(d.Encoding, encoding) {
+			return &c.Decompressors[i]
+		}
+	}
+	return nil
+}
+
+// decompressResponse wraps the response's body to decompress it according to
+// its Content-Encoding header. The response is returned as-is if it's not
+// compressed.
+func (c *Client) decompressResponse(r httpdriver.Response) (httpdriver.Response, error) {
+	header := r.GetHeader().Get("Content-Encoding")
+	if header == "" {
+		return r, nil
+	}
+
+	body := r.GetBody()
+	closers := []io.Closer{body}
+
+	var reader io.Reader = body
+
+	// Encodings are listed in the order they're applied, so they have to be
+	// undone in reverse.
+	encodings := strings.Split(header, ",")
+	for i := len(encodings) - 1; i >= 0; i-- {
+		encoding := strings.TrimSpace(encodings[i])
+		if encoding == "" || strings.EqualFold(encoding, "identity") {
+			continue
+		}
+
+		d := c.decompressor(encoding)
+		if d == nil {
+			closeAll(closers)
+			return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+		}
+
+		rc, err := d.NewReader(reader)
+		if err != nil {
+			closeAll(closers)
+			return nil, fmt.Errorf("failed to decompress %s body: %w", encoding, err)
+		}
+
+		closers = append(closers, rc)
+		reader = rc
+	}
+
+	return httpResponse{
+		Response: r,
+		body:     decompressedBody{reader, closers},
+	}, nil
+}
+
+// WithAcceptEncoding returns a RequestOption that sets the Accept-Encoding
+// header. It is used by the Client if Decompress is true.
+func WithAcceptEncoding(encoding string) RequestOption {
+	return func(r httpdriver.Request) error {
+		r.AddHeader(http.Header{"Accept-Encoding": {encoding}})
+		return nil
+	}
+}
+
+type decompressedBody struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (body decompressedBody) Close() error {
+	return closeAll(body.closers)
+}
+
+// closeAll closes the given closers from last to first and returns the first
+// error.
+func closeAll(closers []io.Closer) error {
+	var err error
+	for i := len(closers) - 1; i >= 0; i-- {
+		if cerr := closers[i].Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
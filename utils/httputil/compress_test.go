@@ -0,0 +1,70 @@
+package httputil
+
+import (
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestClientDecompress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "br, gzip, deflate" {
+			t.Errorf("unexpected Accept-Encoding %q", r.Header.Get("Accept-Encoding"))
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Content-Type", "application/json")
+
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"hello":"world"}`))
+		gz.Close()
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.Decompress = true
+
+	var body struct {
+		Hello string `json:"hello"`
+	}
+
+	if err := client.RequestJSON(&body, "GET", srv.URL); err != nil {
+		t.Fatal("failed to request:", err)
+	}
+
+	if body.Hello != "world" {
+		t.Fatalf("unexpected body %#v", body)
+	}
+}
+
+func TestClientDecompressBrotli(t *testing.T) {
+	const payload = `{"hello":"brotli"}`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "br")
+		w.Header().Set("Content-Type", "application/json")
+
+		br := brotli.NewWriter(w)
+		br.Write([]byte(payload))
+		br.Close()
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.Decompress = true
+
+	var body struct {
+		Hello string `json:"hello"`
+	}
+
+	if err := client.RequestJSON(&body, "GET", srv.URL); err != nil {
+		t.Fatal("failed to request:", err)
+	}
+
+	if body.Hello != "brotli" {
+		t.Fatalf("unexpected body %#v", body)
+	}
+}
@@ -53,6 +53,11 @@ type DefaultRequest http.Request
 
 var _ Request = (*DefaultRequest)(nil)
 
+// ToHTTPRequest returns the underlying stdlib Request.
+func (r *DefaultRequest) ToHTTPRequest() *http.Request {
+	return (*http.Request)(r)
+}
+
 func (r *DefaultRequest) GetPath() string {
 	return r.URL.Path
 }
@@ -0,0 +1,40 @@
+package httputil
+
+import "github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+
+// Transport sends a request and returns its response. The innermost Transport
+// of a Client is the Do method of its httpdriver.Client.
+type Transport func(r httpdriver.Request) (httpdriver.Response, error)
+
+// Middleware wraps the next Transport of a Client. A middleware can change the
+// request before calling next, such as by adding headers, inspect or replace
+// the response and error that next returns, or return a response without
+// calling next at all, such as when it is cached:
+//
+//	client.Middlewares = append(client.Middlewares, func(next httputil.Transport) httputil.Transport {
+//		return func(r httpdriver.Request) (httpdriver.Response, error) {
+//			start := time.Now()
+//			resp, err := next(r)
+//			log.Println(r.GetPath(), "took", time.Since(start), "error:", err)
+//			return resp, err
+//		}
+//	})
+//
+// Middlewares run once per attempt, after the OnRequest options are applied
+// and before the OnResponse functions are called with the response that they
+// return. A response body that a middleware replaces must be closed by it.
+type Middleware func(next Transport) Transport
+
+// Chain wraps the Transport with the middlewares. The first middleware is the
+// outermost, meaning that it sees the request first and the response last.
+func Chain(t Transport, middlewares ...Middleware) Transport {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		t = middlewares[i](t)
+	}
+	return t
+}
+
+// transport returns the Transport that sends requests made by the client.
+func (c *Client) transport() Transport {
+	return Chain(c.Client.Do, c.Middlewares...)
+}
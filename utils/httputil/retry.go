@@ -0,0 +1,136 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+)
+
+// RetryPolicy decides which failed requests are retried, how many times and
+// how long to wait between attempts.
+//
+// The wait before each retry doubles with each attempt, starting at
+// MinBackoff and capped at MaxBackoff, and a random jitter of up to half of it
+// is subtracted so that many clients don't retry at the same time. If the
+// response has a Retry-After header, then that duration is waited instead.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first
+	// one. If it's 0, then requests are retried forever.
+	MaxAttempts uint
+	// Retryable returns true if a request that got the given response or
+	// error should be retried. Only one of them is non-nil. If Retryable is
+	// nil, then DefaultRetryable is used.
+	Retryable func(httpdriver.Response, error) bool
+	// MinBackoff is the wait before the first retry. If it's 0, then requests
+	// are retried immediately unless the response has a Retry-After header.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum wait before a retry. If it's 0, then the wait
+	// is not capped.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryable returns true for network errors, responses with status
+// 429 Too Many Requests and responses with 5xx statuses. Errors caused by the
+// request's context being canceled are not retried.
+func DefaultRetryable(resp httpdriver.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	status := resp.GetStatus()
+	return status == StatusTooManyRequests || status >= 500
+}
+
+// retryPolicy returns the client's retry policy. If it has none, then the
+// policy made from Retries only waits between attempts for Retry-After.
+func (c *Client) retryPolicy() RetryPolicy {
+	if c.RetryPolicy != nil {
+		return *c.RetryPolicy
+	}
+	return RetryPolicy{MaxAttempts: c.Retries}
+}
+
+func (p RetryPolicy) shouldRetry(attempt uint, resp httpdriver.Response, err error) bool {
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if p.Retryable != nil {
+		return p.Retryable(resp, err)
+	}
+
+	return DefaultRetryable(resp, err)
+}
+
+// backoff returns the wait before retrying after the given attempt, which
+// starts at 1.
+func (p RetryPolicy) backoff(attempt uint, resp httpdriver.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.GetHeader()); ok {
+			return wait
+		}
+	}
+
+	wait := p.MinBackoff
+	for i := uint(1); i < attempt && wait > 0; i++ {
+		wait *= 2
+		if p.MaxBackoff > 0 && wait >= p.MaxBackoff {
+			break
+		}
+	}
+
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	if wait > 1 {
+		wait -= time.Duration(rand.Int63n(int64(wait / 2)))
+	}
+
+	return wait
+}
+
+// retryAfter parses the Retry-After header, which is either a number of
+// seconds or a date.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.ParseFloat(v, 64); err == nil && secs >= 0 {
+		return time.Duration(secs * float64(time.Second)), true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// sleep waits for the given duration or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
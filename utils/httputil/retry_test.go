@@ -0,0 +1,77 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientRetryPolicy(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+	c.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+	}
+
+	var body struct {
+		OK bool `json:"ok"`
+	}
+
+	if err := c.RequestJSON(&body, "GET", srv.URL); err != nil {
+		t.Fatal("request failed:", err)
+	}
+
+	if attempts != 3 || !body.OK {
+		t.Fatalf("expected success after 3 attempts, got %d attempts", attempts)
+	}
+
+	attempts = 0
+	c.RetryPolicy.MaxAttempts = 1
+
+	err := c.RequestJSON(&body, "GET", srv.URL)
+	if httpErr, ok := err.(*HTTPError); !ok || httpErr.Status != http.StatusBadGateway {
+		t.Fatalf("expected 502 error, got %v", err)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{
+		MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: time.Second,
+	}
+
+	tests := []struct {
+		attempt uint
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{10, time.Second},
+	}
+
+	for _, test := range tests {
+		wait := p.backoff(test.attempt, nil)
+		if wait > test.max || wait < test.max/2 {
+			t.Errorf("attempt %d: expected wait between %v and %v, got %v",
+				test.attempt, test.max/2, test.max, wait)
+		}
+	}
+}
@@ -1,20 +1,95 @@
 package sendpart
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"io"
+	"mime"
 	"mime/multipart"
+	"net/http"
+	"net/textproto"
 	"net/url"
+	"os"
+	"path"
 	"strconv"
+	"strings"
 
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/json"
 )
 
+// SpoilerPrefix is the filename prefix that makes Discord show a file as a
+// spoiler.
+const SpoilerPrefix = "SPOILER_"
+
 // File represents a file to be uploaded to Discord.
 type File struct {
 	Name   string
 	Reader io.Reader
+
+	// Size is the size of the file in bytes, if known. If it's 0, then it's
+	// taken from the Reader if it has a Len or Stat method, such as a
+	// *bytes.Reader, *strings.Reader or *os.File. Refer to Len.
+	Size int64
+	// Progress, if not nil, is called as the file is uploaded with the number
+	// of bytes uploaded so far and the total from Len, which is -1 if it's
+	// unknown. It's called from the goroutine writing the request body, and it
+	// must not block.
+	Progress func(uploaded, total int64)
+
+	// Description is the description (alt text) of the file. It is a maximum
+	// of 1024 characters long.
+	Description string
+	// ContentType is the media type of the file. If empty, it is guessed from
+	// the file extension, and then from the first 512 bytes of the file.
+	ContentType string
+	// IsSpoiler, if true, marks the file as a spoiler by adding SpoilerPrefix
+	// to its name.
+	IsSpoiler bool
+
+	// DurationSecs is the duration of the audio file. It is required for
+	// voice messages.
+	DurationSecs float64
+	// Waveform is the base64-encoded waveform of the audio file. It is
+	// required for voice messages.
+	Waveform string
+}
+
+// Filename returns the name of the file as it is uploaded, which has
+// SpoilerPrefix added if the file is a spoiler.
+func (f File) Filename() string {
+	if f.IsSpoiler && !strings.HasPrefix(f.Name, SpoilerPrefix) {
+		return SpoilerPrefix + f.Name
+	}
+	return f.Name
+}
+
+// Len returns the size of the file in bytes, or -1 if it's unknown. Refer to
+// Size.
+func (f File) Len() int64 {
+	if f.Size > 0 {
+		return f.Size
+	}
+
+	switch r := f.Reader.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len())
+	case interface{ Stat() (os.FileInfo, error) }:
+		info, err := r.Stat()
+		if err == nil && info.Mode().IsRegular() {
+			// The file may have been read from already, so only the rest of
+			// it is uploaded.
+			if seeker, ok := r.(io.Seeker); ok {
+				if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+					return info.Size() - offset
+				}
+			}
+			return info.Size()
+		}
+	}
+
+	return -1
 }
 
 // AttachmentURI returns the file encoded using the attachment URI required for
@@ -22,11 +97,17 @@ type File struct {
 func (f File) AttachmentURI() string {
 	u := url.URL{
 		Scheme: "attachment",
-		Path:   f.Name,
+		Path:   f.Filename(),
 	}
 	return u.String()
 }
 
+// hasMetadata returns true if the file has metadata that must be sent in the
+// attachments field of the JSON payload.
+func (f File) hasMetadata() bool {
+	return f.Description != "" || f.DurationSecs > 0 || f.Waveform != ""
+}
+
 // DataMultipartWriter is a MultipartWriter that also contains data that's
 // JSON-marshalable.
 type DataMultipartWriter interface {
@@ -39,13 +120,26 @@ type DataMultipartWriter interface {
 
 // Do sends an HTTP request using client to the given URL and unmarshals the
 // body into v if it's not nil. It will only send using multipart if needed.
-func Do(c *httputil.Client, method string, data DataMultipartWriter, v interface{}, url string) error {
+func Do(
+	c *httputil.Client, method string, data DataMultipartWriter,
+	v interface{}, url string, opts ...httputil.RequestOption) error {
+
+	return DoCtx(c.Context(), c, method, data, v, url, opts...)
+}
+
+// DoCtx is the same as Do, but it uses the given context instead of the
+// client's.
+func DoCtx(
+	ctx context.Context, c *httputil.Client, method string, data DataMultipartWriter,
+	v interface{}, url string, opts ...httputil.RequestOption) error {
+
 	if !data.NeedsMultipart() {
 		// No files, so no need for streaming.
-		return c.RequestJSON(v, method, url, httputil.WithJSONBody(data))
+		opts = append([]httputil.RequestOption{httputil.WithJSONBody(data)}, opts...)
+		return c.RequestJSONCtx(ctx, v, method, url, opts...)
 	}
 
-	resp, err := c.MeanwhileMultipart(data, method, url)
+	resp, err := c.MeanwhileMultipartCtx(ctx, data, method, url, opts...)
 	if err != nil {
 		return err
 	}
@@ -63,26 +157,84 @@ func Do(c *httputil.Client, method string, data DataMultipartWriter, v interface
 // PATCH sends a PATCH request using client to the given URL and unmarshals the
 // body into v if it's not nil. It will only send using multipart if needed.
 // It is equivalent to calling Do with "POST"
-func POST(c *httputil.Client, data DataMultipartWriter, v interface{}, url string) error {
-	return Do(c, "POST", data, v, url)
+func POST(
+	c *httputil.Client, data DataMultipartWriter,
+	v interface{}, url string, opts ...httputil.RequestOption) error {
+
+	return Do(c, "POST", data, v, url, opts...)
 }
 
 // PATCH sends a PATCH request using client to the given URL and unmarshals the
 // body into v if it's not nil. It will only send using multipart if needed.
 // It is equivalent to calling Do with "PATCH"
-func PATCH(c *httputil.Client, data DataMultipartWriter, v interface{}, url string) error {
-	return Do(c, "PATCH", data, v, url)
+func PATCH(
+	c *httputil.Client, data DataMultipartWriter,
+	v interface{}, url string, opts ...httputil.RequestOption) error {
+
+	return Do(c, "PATCH", data, v, url, opts...)
 }
 
 // Write writes the item into payload_json and the list of files into the
 // multipart writer. Write does not close the body.
+//
+// If any of the files has metadata, such as a description, or the item's JSON
+// already has an attachments field, then an attachment object for each file is
+// added into the attachments field of the item's JSON, after any attachments
+// that the item already has.
 func Write(body *multipart.Writer, item interface{}, files []File) error {
+	return WriteInto(body, item, "", files)
+}
+
+// WriteInto is similar to Write, except the attachment objects are added into
+// the object in the given field of the item's JSON instead of the item itself.
+// This is used for payloads that nest the message, such as when starting a
+// thread in a forum channel.
+func WriteInto(body *multipart.Writer, item interface{}, field string, files []File) error {
 	// Encode the JSON body first
 	w, err := body.CreateFormField("payload_json")
 	if err != nil {
 		return fmt.Errorf("failed to create bodypart for JSON: %w", err)
 	}
 
+	// Resolve the content types up front, so that the attachment objects in
+	// the JSON match the types of the parts.
+	resolved := make([]File, len(files))
+	for i, file := range files {
+		if file.ContentType == "" {
+			// Sniffing wraps the reader, which hides its length.
+			if n := file.Len(); n > 0 {
+				file.Size = n
+			}
+			file.Reader, file.ContentType = detectContentType(file)
+		}
+		resolved[i] = file
+	}
+	files = resolved
+
+	needsAttachments := false
+	for _, file := range files {
+		if file.hasMetadata() {
+			needsAttachments = true
+			break
+		}
+	}
+
+	// Discord treats an attachments field as the complete list of attachments
+	// to keep, so new files must be in it too.
+	if !needsAttachments && len(files) > 0 {
+		needsAttachments, err = hasAttachments(item, field)
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+	}
+
+	if needsAttachments {
+		item, err = withAttachments(item, field, files)
+		if err != nil {
+			return fmt.Errorf("failed to add attachments to JSON: %w", err)
+		}
+	}
+
 	if err := json.EncodeStream(w, item); err != nil {
 		return fmt.Errorf("failed to encode JSON: %w", err)
 	}
@@ -90,15 +242,154 @@ func Write(body *multipart.Writer, item interface{}, files []File) error {
 	for i, file := range files {
 		num := strconv.Itoa(i)
 
-		w, err := body.CreateFormFile("file"+num, file.Name)
+		r := file.Reader
+		if file.Progress != nil {
+			r = &progressReader{r: r, total: file.Len(), progress: file.Progress}
+		}
+
+		w, err := body.CreatePart(fileHeader("file"+num, file.Filename(), file.ContentType))
 		if err != nil {
 			return fmt.Errorf("failed to create bodypart for %q: %w", num, err)
 		}
 
-		if _, err := io.Copy(w, file.Reader); err != nil {
+		if _, err := io.Copy(w, r); err != nil {
 			return fmt.Errorf("failed to write for file %q: %w", num, err)
 		}
 	}
 
 	return nil
 }
+
+// hasAttachments returns true if the JSON of item, or of the object in the
+// given field if it's not empty, has a non-null attachments field.
+func hasAttachments(item interface{}, field string) (bool, error) {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return false, err
+	}
+
+	var fields map[string]json.Raw
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return false, err
+	}
+
+	if field != "" {
+		return hasAttachments(fields[field], "")
+	}
+
+	raw, ok := fields["attachments"]
+	return ok && string(raw) != "null", nil
+}
+
+type attachment struct {
+	ID           int     `json:"id"`
+	Filename     string  `json:"filename"`
+	Description  string  `json:"description,omitempty"`
+	ContentType  string  `json:"content_type,omitempty"`
+	DurationSecs float64 `json:"duration_secs,omitempty"`
+	Waveform     string  `json:"waveform,omitempty"`
+}
+
+// withAttachments returns the JSON of item with an attachment for each file
+// appended into its attachments field, or the attachments field of the object
+// in the given field if it's not empty. The ID of each attachment is the index
+// of its file, which is how Discord matches them.
+func withAttachments(item interface{}, field string, files []File) (json.Raw, error) {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.Raw
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = make(map[string]json.Raw, 1)
+	}
+
+	if field != "" {
+		nested, err := withAttachments(fields[field], "", files)
+		if err != nil {
+			return nil, fmt.Errorf("in field %q: %w", field, err)
+		}
+		fields[field] = nested
+		return json.Marshal(fields)
+	}
+
+	var attachments []json.Raw
+	if raw, ok := fields["attachments"]; ok {
+		if err := json.Unmarshal(raw, &attachments); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, file := range files {
+		b, err := json.Marshal(attachment{
+			ID:           i,
+			Filename:     file.Filename(),
+			Description:  file.Description,
+			ContentType:  file.ContentType,
+			DurationSecs: file.DurationSecs,
+			Waveform:     file.Waveform,
+		})
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, b)
+	}
+
+	raw, err := json.Marshal(attachments)
+	if err != nil {
+		return nil, err
+	}
+	fields["attachments"] = raw
+
+	return json.Marshal(fields)
+}
+
+// detectContentType guesses the content type of the file from its extension,
+// falling back to sniffing its first 512 bytes. The returned reader must be
+// used in place of the file's reader.
+func detectContentType(file File) (io.Reader, string) {
+	if t := mime.TypeByExtension(path.Ext(file.Name)); t != "" {
+		return file.Reader, t
+	}
+
+	r := bufio.NewReaderSize(file.Reader, 512)
+	// Peek returns what it can read along with an error if there is less than
+	// 512 bytes, which is fine.
+	head, _ := r.Peek(512)
+	return r, http.DetectContentType(head)
+}
+
+// progressReader reports the number of bytes read from r.
+type progressReader struct {
+	r        io.Reader
+	read     int64
+	total    int64
+	progress func(uploaded, total int64)
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	if n > 0 {
+		r.read += int64(n)
+		r.progress(r.read, r.total)
+	}
+	return n, err
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// fileHeader creates the header of a file part. It is similar to the one
+// created by multipart.Writer.CreateFormFile, except with the given content
+// type.
+func fileHeader(field, filename, contentType string) textproto.MIMEHeader {
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(
+		`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(field), quoteEscaper.Replace(filename)))
+	h.Set("Content-Type", contentType)
+	return h
+}
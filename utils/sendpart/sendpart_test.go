@@ -0,0 +1,184 @@
+package sendpart
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestWrite(t *testing.T) {
+	type item struct {
+		Content     string     `json:"content"`
+		Attachments []json.Raw `json:"attachments,omitempty"`
+	}
+
+	files := []File{
+		{Name: "cat.png", Reader: strings.NewReader("meow"), Description: "a cat"},
+		{Name: "secret", Reader: strings.NewReader("%PDF-1.4"), IsSpoiler: true},
+		{Name: "data", Reader: strings.NewReader("{}"), ContentType: "application/json"},
+	}
+
+	var buf bytes.Buffer
+	body := multipart.NewWriter(&buf)
+
+	in := item{
+		Content:     "hi",
+		Attachments: []json.Raw{json.Raw(`{"id":"1234"}`)},
+	}
+	if err := Write(body, in, files); err != nil {
+		t.Fatal("failed to write:", err)
+	}
+	body.Close()
+
+	r := multipart.NewReader(&buf, body.Boundary())
+
+	part, err := r.NextPart()
+	if err != nil {
+		t.Fatal("failed to read payload_json:", err)
+	}
+
+	var out struct {
+		Content     string `json:"content"`
+		Attachments []struct {
+			ID          interface{} `json:"id"`
+			Filename    string      `json:"filename"`
+			Description string      `json:"description"`
+			ContentType string      `json:"content_type"`
+		} `json:"attachments"`
+	}
+	if err := json.DecodeStream(part, &out); err != nil {
+		t.Fatal("failed to decode payload_json:", err)
+	}
+
+	if out.Content != "hi" {
+		t.Fatalf("unexpected content %q", out.Content)
+	}
+	if len(out.Attachments) != 4 {
+		t.Fatalf("expected 4 attachments, got %d", len(out.Attachments))
+	}
+	if out.Attachments[0].ID != "1234" {
+		t.Fatalf("existing attachment was not kept: %v", out.Attachments[0].ID)
+	}
+	if a := out.Attachments[1]; a.Filename != "cat.png" || a.Description != "a cat" || a.ContentType != "image/png" {
+		t.Fatalf("unexpected attachment %+v", a)
+	}
+	if a := out.Attachments[2]; a.Filename != "SPOILER_secret" || a.ContentType != "application/pdf" {
+		t.Fatalf("unexpected attachment %+v", a)
+	}
+	if a := out.Attachments[3]; a.Filename != "data" || a.ContentType != "application/json" {
+		t.Fatalf("unexpected attachment %+v", a)
+	}
+
+	expect := []struct {
+		filename    string
+		contentType string
+		data        string
+	}{
+		{"cat.png", "image/png", "meow"},
+		{"SPOILER_secret", "application/pdf", "%PDF-1.4"},
+		{"data", "application/json", "{}"},
+	}
+
+	for i, expect := range expect {
+		part, err := r.NextPart()
+		if err != nil {
+			t.Fatalf("failed to read file %d: %v", i, err)
+		}
+
+		if name := part.FileName(); name != expect.filename {
+			t.Errorf("file %d: expected name %q, got %q", i, expect.filename, name)
+		}
+		if ct := part.Header.Get("Content-Type"); ct != expect.contentType {
+			t.Errorf("file %d: expected type %q, got %q", i, expect.contentType, ct)
+		}
+
+		data, _ := io.ReadAll(part)
+		if string(data) != expect.data {
+			t.Errorf("file %d: expected data %q, got %q", i, expect.data, data)
+		}
+	}
+}
+
+func TestWriteInto(t *testing.T) {
+	type message struct {
+		Content string `json:"content"`
+	}
+	type item struct {
+		Name    string  `json:"name"`
+		Message message `json:"message"`
+	}
+
+	files := []File{
+		{Name: "cat.png", Reader: strings.NewReader("meow"), Description: "a cat"},
+	}
+
+	var buf bytes.Buffer
+	body := multipart.NewWriter(&buf)
+
+	if err := WriteInto(body, item{Name: "thread"}, "message", files); err != nil {
+		t.Fatal("failed to write:", err)
+	}
+	body.Close()
+
+	part, err := multipart.NewReader(&buf, body.Boundary()).NextPart()
+	if err != nil {
+		t.Fatal("failed to read payload_json:", err)
+	}
+
+	var out struct {
+		Name        string     `json:"name"`
+		Attachments []json.Raw `json:"attachments"`
+		Message     struct {
+			Attachments []struct {
+				Filename    string `json:"filename"`
+				Description string `json:"description"`
+			} `json:"attachments"`
+		} `json:"message"`
+	}
+	if err := json.DecodeStream(part, &out); err != nil {
+		t.Fatal("failed to decode payload_json:", err)
+	}
+
+	if out.Name != "thread" {
+		t.Fatalf("unexpected name %q", out.Name)
+	}
+	if len(out.Attachments) != 0 {
+		t.Fatal("unexpected top-level attachments")
+	}
+	if len(out.Message.Attachments) != 1 || out.Message.Attachments[0].Description != "a cat" {
+		t.Fatalf("unexpected message attachments %+v", out.Message.Attachments)
+	}
+}
+
+func TestFileProgress(t *testing.T) {
+	content := strings.Repeat("a", 100000)
+
+	var calls int
+	var last, total int64
+
+	files := []File{{
+		Name:   "a.txt",
+		Reader: strings.NewReader(content),
+		Progress: func(uploaded, t int64) {
+			calls++
+			last, total = uploaded, t
+		},
+	}}
+
+	body := multipart.NewWriter(io.Discard)
+	if err := Write(body, struct{}{}, files); err != nil {
+		t.Fatal("failed to write:", err)
+	}
+
+	if calls == 0 || last != int64(len(content)) || total != int64(len(content)) {
+		t.Fatalf("unexpected progress: %d calls, last %d/%d", calls, last, total)
+	}
+
+	if n := (File{Reader: io.MultiReader()}).Len(); n != -1 {
+		t.Fatalf("expected unknown length, got %d", n)
+	}
+}
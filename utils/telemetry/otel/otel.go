@@ -0,0 +1,167 @@
+// Package otel adapts OpenTelemetry tracers and meters to the telemetry
+// interfaces used by arikawa. It lives in its own module, so that programs
+// that don't use OpenTelemetry don't depend on it.
+//
+//	import (
+//		arikawaotel "github.com/diamondburned/arikawa/v3/utils/telemetry/otel"
+//		"go.opentelemetry.io/otel"
+//	)
+//
+//	s := state.New(token)
+//	s.Telemetry = arikawaotel.NewProvider(otel.GetTracerProvider(), otel.GetMeterProvider())
+//
+// Metric and attribute names are reported as-is. Histograms are recorded in
+// seconds, since arikawa only records durations.
+package otel
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/utils/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer and meter created by
+// NewProvider.
+const InstrumentationName = "github.com/diamondburned/arikawa/v3"
+
+// NewProvider creates a telemetry.Provider that reports to the given
+// OpenTelemetry providers. Either may be nil, in which case nothing is
+// reported for it.
+func NewProvider(tp trace.TracerProvider, mp metric.MeterProvider) telemetry.Provider {
+	var p telemetry.Provider
+	if tp != nil {
+		p.Tracer = NewTracer(tp.Tracer(InstrumentationName))
+	}
+	if mp != nil {
+		p.Meter = NewMeter(mp.Meter(InstrumentationName))
+	}
+	return p
+}
+
+// Tracer is a telemetry.Tracer that starts OpenTelemetry spans.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var _ telemetry.Tracer = (*Tracer)(nil)
+
+// NewTracer creates a new Tracer that starts spans using the given tracer.
+func NewTracer(tracer trace.Tracer) *Tracer {
+	return &Tracer{tracer: tracer}
+}
+
+// Start implements telemetry.Tracer.
+func (t *Tracer) Start(ctx context.Context, name string, attrs ...telemetry.Attribute) (context.Context, telemetry.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(convertAttrs(attrs)...))
+	return ctx, otelSpan{span}
+}
+
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s otelSpan) SetAttributes(attrs ...telemetry.Attribute) {
+	s.span.SetAttributes(convertAttrs(attrs)...)
+}
+
+func (s otelSpan) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s otelSpan) End() {
+	s.span.End()
+}
+
+// Meter is a telemetry.Meter that records to OpenTelemetry instruments. The
+// instruments are created when a metric is first recorded. It is safe for
+// concurrent use.
+type Meter struct {
+	meter metric.Meter
+
+	mu         sync.Mutex
+	counters   map[string]metric.Int64Counter
+	histograms map[string]metric.Float64Histogram
+}
+
+var _ telemetry.Meter = (*Meter)(nil)
+
+// NewMeter creates a new Meter that creates its instruments using the given
+// meter.
+func NewMeter(meter metric.Meter) *Meter {
+	return &Meter{
+		meter:      meter,
+		counters:   make(map[string]metric.Int64Counter),
+		histograms: make(map[string]metric.Float64Histogram),
+	}
+}
+
+// Add implements telemetry.Meter. Errors creating the counter are reported to
+// the global OpenTelemetry error handler.
+func (m *Meter) Add(ctx context.Context, name string, n int64, attrs ...telemetry.Attribute) {
+	m.mu.Lock()
+	counter, ok := m.counters[name]
+	if !ok {
+		var err error
+		counter, err = m.meter.Int64Counter(name)
+		if err != nil {
+			m.mu.Unlock()
+			otel.Handle(err)
+			return
+		}
+		m.counters[name] = counter
+	}
+	m.mu.Unlock()
+
+	counter.Add(ctx, n, metric.WithAttributes(convertAttrs(attrs)...))
+}
+
+// Record implements telemetry.Meter. Errors creating the histogram are
+// reported to the global OpenTelemetry error handler.
+func (m *Meter) Record(ctx context.Context, name string, v float64, attrs ...telemetry.Attribute) {
+	m.mu.Lock()
+	histogram, ok := m.histograms[name]
+	if !ok {
+		var err error
+		histogram, err = m.meter.Float64Histogram(name, metric.WithUnit("s"))
+		if err != nil {
+			m.mu.Unlock()
+			otel.Handle(err)
+			return
+		}
+		m.histograms[name] = histogram
+	}
+	m.mu.Unlock()
+
+	histogram.Record(ctx, v, metric.WithAttributes(convertAttrs(attrs)...))
+}
+
+func convertAttrs(attrs []telemetry.Attribute) []attribute.KeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	kvs := make([]attribute.KeyValue, len(attrs))
+	for i, attr := range attrs {
+		switch v := attr.Value.(type) {
+		case string:
+			kvs[i] = attribute.String(attr.Key, v)
+		case int64:
+			kvs[i] = attribute.Int64(attr.Key, v)
+		case float64:
+			kvs[i] = attribute.Float64(attr.Key, v)
+		case bool:
+			kvs[i] = attribute.Bool(attr.Key, v)
+		default:
+			kvs[i] = attribute.String(attr.Key, fmt.Sprint(v))
+		}
+	}
+	return kvs
+}
@@ -0,0 +1,112 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	p := NewProvider(tp, nil)
+	if p.Meter != nil {
+		t.Fatal("unexpected Meter without a MeterProvider")
+	}
+
+	_, span := p.Start(context.Background(), telemetry.SpanRESTRequest,
+		telemetry.String(telemetry.KeyMethod, "GET"))
+	span.SetAttributes(telemetry.Int(telemetry.KeyStatus, 500), telemetry.Bool("retried", true))
+	span.RecordError(errors.New("internal server error"))
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+
+	s := spans[0]
+	if s.Name() != telemetry.SpanRESTRequest {
+		t.Fatalf("unexpected span name %q", s.Name())
+	}
+	if s.Status().Code != codes.Error {
+		t.Fatalf("expected error status, got %v", s.Status())
+	}
+
+	expect := []attribute.KeyValue{
+		attribute.String(telemetry.KeyMethod, "GET"),
+		attribute.Int64(telemetry.KeyStatus, 500),
+		attribute.Bool("retried", true),
+	}
+	attrs := s.Attributes()
+	if len(attrs) != len(expect) {
+		t.Fatalf("expected attributes %v, got %v", expect, attrs)
+	}
+	for i := range expect {
+		if attrs[i] != expect[i] {
+			t.Fatalf("expected attributes %v, got %v", expect, attrs)
+		}
+	}
+}
+
+func TestMeter(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	p := NewProvider(nil, mp)
+	if p.Tracer != nil {
+		t.Fatal("unexpected Tracer without a TracerProvider")
+	}
+
+	ctx := context.Background()
+	attr := telemetry.String(telemetry.KeyRoute, "/channels")
+
+	p.Add(ctx, telemetry.MetricRESTRequests, 1, attr)
+	p.Add(ctx, telemetry.MetricRESTRequests, 2, attr)
+	p.Record(ctx, telemetry.MetricRESTDuration, 0.5, attr)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal("failed to collect:", err)
+	}
+
+	if len(rm.ScopeMetrics) != 1 {
+		t.Fatalf("expected 1 scope, got %d", len(rm.ScopeMetrics))
+	}
+
+	scope := rm.ScopeMetrics[0]
+	if scope.Scope.Name != InstrumentationName {
+		t.Fatalf("unexpected scope %q", scope.Scope.Name)
+	}
+
+	metrics := make(map[string]metricdata.Metrics)
+	for _, m := range scope.Metrics {
+		metrics[m.Name] = m
+	}
+
+	sum, ok := metrics[telemetry.MetricRESTRequests].Data.(metricdata.Sum[int64])
+	if !ok || len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 3 {
+		t.Fatalf("unexpected counter %#v", metrics[telemetry.MetricRESTRequests].Data)
+	}
+	if v, _ := sum.DataPoints[0].Attributes.Value(telemetry.KeyRoute); v.AsString() != "/channels" {
+		t.Fatalf("unexpected counter attributes %v", sum.DataPoints[0].Attributes)
+	}
+
+	duration := metrics[telemetry.MetricRESTDuration]
+	if duration.Unit != "s" {
+		t.Fatalf("unexpected histogram unit %q", duration.Unit)
+	}
+	hist, ok := duration.Data.(metricdata.Histogram[float64])
+	if !ok || len(hist.DataPoints) != 1 || hist.DataPoints[0].Sum != 0.5 {
+		t.Fatalf("unexpected histogram %#v", duration.Data)
+	}
+}
@@ -0,0 +1,285 @@
+// Package prometheus provides a telemetry.Meter that keeps the metrics reported
+// by arikawa in memory and serves them in the Prometheus text format, so that
+// they can be scraped without depending on the Prometheus client library.
+//
+//	meter := prometheus.NewMeter()
+//
+//	s := state.New(token)
+//	s.Telemetry = telemetry.Provider{Meter: meter}
+//
+//	http.Handle("/metrics", meter)
+//
+// Metric and attribute names are converted by replacing every character that
+// Prometheus doesn't allow with an underscore, so telemetry.MetricRESTRequests
+// is served as discord_rest_requests_total. Counters get the _total suffix.
+package prometheus
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/utils/telemetry"
+)
+
+// DefaultBuckets are the upper bounds of the histogram buckets used if
+// Meter.Buckets is empty. They suit durations in seconds, which is what
+// arikawa records.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// ContentType is the content type of the metrics served by Meter.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type metricType string
+
+const (
+	counterType   metricType = "counter"
+	histogramType metricType = "histogram"
+)
+
+// Meter is a telemetry.Meter that serves the recorded metrics over HTTP. It is
+// safe for concurrent use.
+type Meter struct {
+	// Buckets are the upper bounds of the histogram buckets in increasing
+	// order. If it's empty, then DefaultBuckets is used. It must not be
+	// changed once the Meter is used.
+	Buckets []float64
+
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+var (
+	_ telemetry.Meter = (*Meter)(nil)
+	_ http.Handler    = (*Meter)(nil)
+)
+
+type family struct {
+	typ    metricType
+	series map[string]*series // labels -> series
+}
+
+type series struct {
+	value  float64  // counters
+	counts []uint64 // histograms, not cumulative
+	sum    float64
+	count  uint64
+}
+
+// NewMeter creates a new Meter with DefaultBuckets.
+func NewMeter() *Meter {
+	return &Meter{}
+}
+
+// Add implements telemetry.Meter.
+func (m *Meter) Add(ctx context.Context, name string, n int64, attrs ...telemetry.Attribute) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if s := m.series(counterType, name+"_total", attrs); s != nil {
+		s.value += float64(n)
+	}
+}
+
+// Record implements telemetry.Meter.
+func (m *Meter) Record(ctx context.Context, name string, v float64, attrs ...telemetry.Attribute) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.series(histogramType, name, attrs)
+	if s == nil {
+		return
+	}
+
+	buckets := m.buckets()
+	if s.counts == nil {
+		s.counts = make([]uint64, len(buckets))
+	}
+
+	if i := sort.SearchFloat64s(buckets, v); i < len(buckets) {
+		s.counts[i]++
+	}
+	s.sum += v
+	s.count++
+}
+
+// series returns the series with the given labels, creating it if needed. Nil
+// is returned if the name is already used by a metric of another type.
+func (m *Meter) series(typ metricType, name string, attrs []telemetry.Attribute) *series {
+	name = sanitizeName(name)
+
+	if m.families == nil {
+		m.families = make(map[string]*family)
+	}
+
+	f, ok := m.families[name]
+	if !ok {
+		f = &family{typ: typ, series: make(map[string]*series)}
+		m.families[name] = f
+	}
+	if f.typ != typ {
+		return nil
+	}
+
+	labels := formatLabels(attrs)
+
+	s, ok := f.series[labels]
+	if !ok {
+		s = &series{}
+		f.series[labels] = s
+	}
+
+	return s
+}
+
+func (m *Meter) buckets() []float64 {
+	if len(m.Buckets) > 0 {
+		return m.Buckets
+	}
+	return DefaultBuckets
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format.
+func (m *Meter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format to w. Metrics and
+// their series are sorted by name and labels.
+func (m *Meter) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	names := make([]string, 0, len(m.families))
+	for name := range m.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := m.families[name]
+		fmt.Fprintf(bw, "# TYPE %s %s\n", name, f.typ)
+
+		labels := make([]string, 0, len(f.series))
+		for l := range f.series {
+			labels = append(labels, l)
+		}
+		sort.Strings(labels)
+
+		for _, l := range labels {
+			s := f.series[l]
+
+			switch f.typ {
+			case counterType:
+				fmt.Fprintf(bw, "%s%s %s\n", name, wrapLabels(l), formatFloat(s.value))
+
+			case histogramType:
+				var cumulative uint64
+				for i, bound := range m.buckets() {
+					cumulative += s.counts[i]
+					fmt.Fprintf(bw, "%s_bucket%s %d\n",
+						name, wrapLabels(l, `le="`+formatFloat(bound)+`"`), cumulative)
+				}
+				fmt.Fprintf(bw, "%s_bucket%s %d\n", name, wrapLabels(l, `le="+Inf"`), s.count)
+				fmt.Fprintf(bw, "%s_sum%s %s\n", name, wrapLabels(l), formatFloat(s.sum))
+				fmt.Fprintf(bw, "%s_count%s %d\n", name, wrapLabels(l), s.count)
+			}
+		}
+	}
+
+	err := bw.Flush()
+	return cw.n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	n, err := w.w.Write(b)
+	w.n += int64(n)
+	return n, err
+}
+
+// formatLabels formats the attributes as comma-separated label pairs sorted by
+// key, which is also used to identify the series.
+func formatLabels(attrs []telemetry.Attribute) string {
+	pairs := make([]string, len(attrs))
+	for i, attr := range attrs {
+		pairs[i] = sanitizeName(attr.Key) + `="` + escapeLabel(formatValue(attr.Value)) + `"`
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+func wrapLabels(labels ...string) string {
+	nonEmpty := labels[:0:0]
+	for _, l := range labels {
+		if l != "" {
+			nonEmpty = append(nonEmpty, l)
+		}
+	}
+
+	if len(nonEmpty) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(nonEmpty, ",") + "}"
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return formatFloat(v)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, +1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+// sanitizeName replaces the characters that aren't allowed in Prometheus
+// metric and label names with underscores.
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
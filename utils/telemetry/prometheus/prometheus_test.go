@@ -0,0 +1,49 @@
+package prometheus
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/telemetry"
+)
+
+func TestMeter(t *testing.T) {
+	meter := &Meter{Buckets: []float64{0.1, 1}}
+	ctx := context.Background()
+
+	attrs := []telemetry.Attribute{
+		telemetry.String(telemetry.KeyRoute, "/channels/{id}"),
+		telemetry.String(telemetry.KeyMethod, "GET"),
+	}
+
+	meter.Add(ctx, telemetry.MetricRESTRequests, 1, attrs...)
+	meter.Add(ctx, telemetry.MetricRESTRequests, 2, attrs...)
+	meter.Record(ctx, telemetry.MetricRESTDuration, 0.05, attrs...)
+	meter.Record(ctx, telemetry.MetricRESTDuration, 0.5, attrs...)
+	meter.Record(ctx, telemetry.MetricRESTDuration, 5, attrs...)
+	// Mismatching types are ignored.
+	meter.Record(ctx, telemetry.MetricRESTRequests+"_total", 1)
+
+	var out strings.Builder
+	if _, err := meter.WriteTo(&out); err != nil {
+		t.Fatal("failed to write metrics:", err)
+	}
+
+	const labels = `http_method="GET",http_route="/channels/{id}"`
+	expected := strings.Join([]string{
+		`# TYPE discord_rest_duration histogram`,
+		`discord_rest_duration_bucket{` + labels + `,le="0.1"} 1`,
+		`discord_rest_duration_bucket{` + labels + `,le="1"} 2`,
+		`discord_rest_duration_bucket{` + labels + `,le="+Inf"} 3`,
+		`discord_rest_duration_sum{` + labels + `} 5.55`,
+		`discord_rest_duration_count{` + labels + `} 3`,
+		`# TYPE discord_rest_requests_total counter`,
+		`discord_rest_requests_total{` + labels + `} 3`,
+		``,
+	}, "\n")
+
+	if out.String() != expected {
+		t.Fatalf("unexpected metrics:\n%s\nexpected:\n%s", out.String(), expected)
+	}
+}
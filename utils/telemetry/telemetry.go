@@ -0,0 +1,177 @@
+// Package telemetry provides optional tracing and metrics hooks for REST
+// requests, gateway events and State cache operations.
+//
+// The package deliberately does not depend on any telemetry library. Instead,
+// it defines the small Tracer and Meter interfaces that the rest of arikawa
+// reports to. Adapters for a concrete library live in their own module so that
+// the dependency is only pulled in by programs that need it. For OpenTelemetry,
+// the github.com/diamondburned/arikawa/v3/utils/telemetry/otel module provides
+// a Tracer and a Meter.
+//
+// A Provider is then set on the client, which is shared by Session and State:
+//
+//	s := state.New(token)
+//	s.Telemetry = telemetry.Provider{Tracer: tracer, Meter: meter}
+//
+// For Prometheus, the prometheus subpackage provides a Meter that can be
+// scraped directly, without depending on the Prometheus client library.
+package telemetry
+
+import (
+	"context"
+	"time"
+)
+
+// Span names reported by arikawa.
+const (
+	SpanRESTRequest  = "discord.rest.request"
+	SpanGatewayEvent = "discord.gateway.event"
+)
+
+// Metric names reported by arikawa. Durations are recorded in seconds.
+const (
+	// MetricRESTDuration is the duration of a REST request, including all
+	// retries. It has the method, route and status attributes.
+	MetricRESTDuration = "discord.rest.duration"
+	// MetricRESTRequests counts the REST requests made, counting retries of
+	// the same request once. It has the method, route and status attributes.
+	// The status is 0 if no response was received.
+	MetricRESTRequests = "discord.rest.requests"
+	// MetricRESTRetries counts the retries of REST requests. It has the method
+	// and route attributes.
+	MetricRESTRetries = "discord.rest.retries"
+	// MetricRateLimitWait is the time spent waiting for a rate limit bucket
+	// before a REST request is sent. It has the route attribute.
+	MetricRateLimitWait = "discord.rest.ratelimit.wait"
+	// MetricGatewayEvents counts the gateway events received. It has the op
+	// and event attributes.
+	MetricGatewayEvents = "discord.gateway.events"
+	// MetricGatewayDispatch is the time taken to dispatch a gateway event to
+	// all synchronous handlers. It has the op and event attributes.
+	MetricGatewayDispatch = "discord.gateway.dispatch"
+	// MetricCacheLookups counts State cache lookups. It has the kind and hit
+	// attributes.
+	MetricCacheLookups = "discord.state.cache.lookups"
+)
+
+// Attribute keys reported by arikawa.
+const (
+	KeyMethod = "http.method"
+	KeyRoute  = "http.route"
+	KeyStatus = "http.status_code"
+	KeyOp     = "discord.gateway.op"
+	KeyEvent  = "discord.gateway.event"
+	KeyKind   = "discord.cache.kind"
+	KeyHit    = "discord.cache.hit"
+	// KeyRateLimitWait is the time in seconds that a REST request waited for
+	// its rate limit bucket. It is only set on spans.
+	KeyRateLimitWait = "discord.ratelimit.wait"
+)
+
+// Attribute is a key-value pair attached to spans and measurements. Value is
+// always a string, int64, float64 or bool.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String creates a new string Attribute.
+func String(k, v string) Attribute { return Attribute{k, v} }
+
+// Int creates a new integer Attribute.
+func Int(k string, v int) Attribute { return Attribute{k, int64(v)} }
+
+// Float creates a new floating-point Attribute.
+func Float(k string, v float64) Attribute { return Attribute{k, v} }
+
+// Bool creates a new boolean Attribute.
+func Bool(k string, v bool) Attribute { return Attribute{k, v} }
+
+// Tracer starts spans.
+type Tracer interface {
+	// Start starts a new span as a child of the span in ctx, if any. The
+	// returned context carries the new span.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a single traced operation.
+type Span interface {
+	// SetAttributes adds the given attributes to the span.
+	SetAttributes(attrs ...Attribute)
+	// RecordError records the given error and marks the span as failed.
+	RecordError(err error)
+	// End ends the span.
+	End()
+}
+
+// Meter records measurements.
+type Meter interface {
+	// Add adds n to the counter with the given name.
+	Add(ctx context.Context, name string, n int64, attrs ...Attribute)
+	// Record records v in the histogram with the given name.
+	Record(ctx context.Context, name string, v float64, attrs ...Attribute)
+}
+
+// Provider holds the Tracer and Meter to report to. Either may be nil, in
+// which case nothing is reported for it. The zero value is a valid Provider
+// that reports nothing.
+type Provider struct {
+	Tracer Tracer
+	Meter  Meter
+}
+
+// Enabled returns true if either the Tracer or the Meter is set.
+func (p Provider) Enabled() bool {
+	return p.Tracer != nil || p.Meter != nil
+}
+
+// Start starts a new span using the Tracer. A no-op span is returned if there
+// is no Tracer.
+func (p Provider) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	if p.Tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	ctx, span := p.Tracer.Start(ctx, name, attrs...)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// Add adds n to the counter with the given name using the Meter, if any.
+func (p Provider) Add(ctx context.Context, name string, n int64, attrs ...Attribute) {
+	if p.Meter != nil {
+		p.Meter.Add(ctx, name, n, attrs...)
+	}
+}
+
+// Record records v in the histogram with the given name using the Meter, if
+// any.
+func (p Provider) Record(ctx context.Context, name string, v float64, attrs ...Attribute) {
+	if p.Meter != nil {
+		p.Meter.Record(ctx, name, v, attrs...)
+	}
+}
+
+// RecordDuration records the time since start in seconds in the histogram with
+// the given name using the Meter, if any.
+func (p Provider) RecordDuration(ctx context.Context, name string, start time.Time, attrs ...Attribute) {
+	if p.Meter != nil {
+		p.Meter.Record(ctx, name, time.Since(start).Seconds(), attrs...)
+	}
+}
+
+type spanKey struct{}
+
+// SpanFromContext returns the span started by Provider.Start that is stored in
+// ctx. A no-op span is returned if there is none.
+func SpanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		return span
+	}
+	return noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) RecordError(error)          {}
+func (noopSpan) End()                       {}
@@ -1,6 +1,7 @@
 package ws
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -15,6 +16,27 @@ import (
 type Codec struct {
 	Unmarshalers OpUnmarshalers
 	Headers      http.Header
+	// Filter, if not nil, is used to drop dispatch events before their data is
+	// decoded.
+	Filter EventFilter
+	// Encoding, if not nil, is the encoding of the payloads. Payloads are
+	// converted into JSON before being decoded. If it's nil, then payloads
+	// are JSON.
+	Encoding Encoding
+}
+
+// Encoding is an encoding of gateway payloads other than JSON, such as ETF.
+// Payloads are converted from and to JSON, so that events can be decoded the
+// same way for all encodings. Refer to package etf for an implementation.
+type Encoding interface {
+	// Name returns the name of the encoding that is given to the gateway
+	// using the encoding query parameter.
+	Name() string
+	// AppendJSON reads exactly one payload from r and appends it to dst as
+	// JSON.
+	AppendJSON(dst []byte, r io.Reader) ([]byte, error)
+	// FromJSON converts a JSON payload into the encoding.
+	FromJSON(b []byte) ([]byte, error)
 }
 
 // NewCodec creates a new default Codec instance.
@@ -37,7 +59,8 @@ const maxSharedBufferSize = 1 << 15 // 32KB
 // DecodeBuffer boxes a byte slice to provide a shared and thread-unsafe buffer.
 // It is used internally and should only be handled around as an opaque thing.
 type DecodeBuffer struct {
-	buf []byte
+	buf  []byte
+	json []byte // for Encoding
 }
 
 // NewDecodeBuffer creates a new preallocated DecodeBuffer.
@@ -55,6 +78,24 @@ func NewDecodeBuffer(cap int) DecodeBuffer {
 //
 // buf is optional.
 func (c Codec) DecodeInto(ctx context.Context, r io.Reader, buf *DecodeBuffer, out chan<- Op) error {
+	if c.Encoding != nil {
+		b, err := c.Encoding.AppendJSON(buf.json[:0], r)
+		if err != nil {
+			return c.send(ctx, out, newErrOp(err, "cannot read "+c.Encoding.Name()+" payload"))
+		}
+
+		if cap(b) < maxSharedBufferSize {
+			buf.json = b[:0]
+		}
+
+		r = bytes.NewReader(b)
+	}
+
+	return c.decodeJSON(ctx, r, buf, out)
+}
+
+// decodeJSON is DecodeInto for payloads that are already JSON.
+func (c Codec) decodeJSON(ctx context.Context, r io.Reader, buf *DecodeBuffer, out chan<- Op) error {
 	var op codecOp
 	op.Data = json.Raw(buf.buf)
 
@@ -62,6 +103,16 @@ func (c Codec) DecodeInto(ctx context.Context, r io.Reader, buf *DecodeBuffer, o
 		return c.send(ctx, out, newErrOp(err, "cannot read JSON stream"))
 	}
 
+	if op.Type != "" && c.Filter != nil && !c.Filter(op.Type) {
+		if cap(op.Data) < maxSharedBufferSize {
+			buf.buf = op.Data[:0]
+		}
+
+		op := op.Op
+		op.Data = &filteredEvent{code: op.Code, typ: op.Type}
+		return c.send(ctx, out, op)
+	}
+
 	if EnableRawEvents {
 		dt := op.Data
 		op := op.Op
@@ -0,0 +1,44 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCodecFilter(t *testing.T) {
+	codec := NewCodec(NewOpUnmarshalers())
+	codec.Filter = DenyEvents("TYPING_START")
+
+	out := make(chan Op, 1)
+	buf := NewDecodeBuffer(0)
+
+	decode := func(payload string) Op {
+		t.Helper()
+
+		err := codec.DecodeInto(context.Background(), strings.NewReader(payload), &buf, out)
+		if err != nil {
+			t.Fatal("failed to decode:", err)
+		}
+
+		return <-out
+	}
+
+	op := decode(`{"op":0,"t":"TYPING_START","s":5,"d":{"channel_id":"1"}}`)
+	if _, ok := op.Data.(*filteredEvent); !ok {
+		t.Fatalf("expected filtered event, got %T", op.Data)
+	}
+	if op.Sequence != 5 || op.Type != "TYPING_START" {
+		t.Errorf("unexpected filtered op %+v", op)
+	}
+
+	// Allowed events are still decoded, which fails here since there are no
+	// unmarshalers.
+	op = decode(`{"op":0,"t":"MESSAGE_CREATE","s":6,"d":{}}`)
+
+	var unknownErr UnknownEventError
+	if !errors.As(op.Data.(*BackgroundErrorEvent), &unknownErr) {
+		t.Fatalf("expected unknown event error, got %v", op.Data)
+	}
+}
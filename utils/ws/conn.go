@@ -1,6 +1,7 @@
 package ws
 
 import (
+	"bytes"
 	"compress/zlib"
 	"context"
 	"errors"
@@ -8,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -15,9 +17,17 @@ import (
 
 const rwBufferSize = 1 << 15 // 32KB
 
+// zlibHeader is the first byte of zlib payloads that use the deflate method
+// with the default window size, which Discord's are.
+const zlibHeader = 0x78
+
 // ErrWebsocketClosed is returned if the websocket is already closed.
 var ErrWebsocketClosed = errors.New("websocket is closed")
 
+// ErrNoZstdDecompressor is returned by Dial if the compression mode is
+// ZstdStreamCompression, but the Conn has no ZstdDecompressor.
+var ErrNoZstdDecompressor = errors.New("zstd-stream compression needs a ZstdDecompressor")
+
 // Connection is an interface that abstracts around a generic Websocket driver.
 // This connection expects the driver to handle compression by itself, including
 // modifying the connection URL. The implementation doesn't have to be safe for
@@ -38,11 +48,62 @@ type Connection interface {
 	Close(gracefully bool) error
 }
 
+// CompressionMode describes how a websocket connection compresses its
+// payloads.
+type CompressionMode uint8
+
+const (
+	// PayloadCompression makes the server compress large payloads using zlib
+	// before sending them as binary messages. This is the default.
+	PayloadCompression CompressionMode = iota
+	// PerMessageDeflate negotiates the standard permessage-deflate websocket
+	// extension (RFC 7692) instead of using payload compression. Discord
+	// itself doesn't support this, but some gateway proxies prefer it.
+	PerMessageDeflate
+	// NoCompression disables compression entirely.
+	NoCompression
+	// ZlibStreamCompression compresses all payloads of a connection as a
+	// single zlib stream (compress=zlib-stream). It compresses better than
+	// PayloadCompression, since the payloads share one compression context.
+	ZlibStreamCompression
+	// ZstdStreamCompression is like ZlibStreamCompression, but uses Zstandard
+	// (compress=zstd-stream), which Discord recommends. The standard library
+	// has no Zstandard decoder, so one must be set as the ZstdDecompressor of
+	// the Conn, otherwise Dial returns ErrNoZstdDecompressor.
+	ZstdStreamCompression
+	// PreferZstdStreamCompression is ZstdStreamCompression if the Conn has a
+	// ZstdDecompressor, or ZlibStreamCompression otherwise.
+	PreferZstdStreamCompression
+)
+
+// String returns the name of the compression mode.
+func (m CompressionMode) String() string {
+	switch m {
+	case PayloadCompression:
+		return "payload"
+	case PerMessageDeflate:
+		return "permessage-deflate"
+	case NoCompression:
+		return "none"
+	case ZlibStreamCompression:
+		return "zlib-stream"
+	case ZstdStreamCompression:
+		return "zstd-stream"
+	case PreferZstdStreamCompression:
+		return "prefer-zstd-stream"
+	default:
+		return fmt.Sprintf("CompressionMode(%d)", m)
+	}
+}
+
 // Conn is the default Websocket connection. It tries to compresses all payloads
 // using zlib.
 type Conn struct {
 	dialer websocket.Dialer
 	codec  Codec
+	// customDialer is true if the dialer was given by the user, in which case
+	// its EnableCompression is only changed for PerMessageDeflate.
+	customDialer bool
 
 	// conn is used for synchronizing the conn instance itself. Any use of conn
 	// must copy conn out.
@@ -52,6 +113,91 @@ type Conn struct {
 
 	// CloseTimeout is the timeout for graceful closing. It's defaulted to 5s.
 	CloseTimeout time.Duration
+
+	// Compression is the compression mode used for new connections. It's
+	// defaulted to PayloadCompression. PerMessageDeflate always enables the
+	// dialer's EnableCompression field; the other modes only disable it if the
+	// dialer wasn't given using NewConnWithDialer.
+	Compression CompressionMode
+
+	// ReadLimit is the maximum size in bytes of a single message read from
+	// the websocket, before it is decompressed. MaxPayloadSize is the maximum
+	// size in bytes of a payload after it is decompressed. A payload that
+	// exceeds either limit is dropped, and a *PayloadTooLargeError is sent as
+	// a BackgroundErrorEvent. Zero means no limit, which is the default.
+	//
+	// These protect the memory of the program from pathological payloads,
+	// such as the GUILD_CREATE of a huge guild or a misbehaving proxy. Note
+	// that a dropped payload is lost, so the limits should be set well above
+	// the largest payload that is expected.
+	ReadLimit      int64
+	MaxPayloadSize int64
+
+	// ZstdDecompressor creates the decoder used by ZstdStreamCompression and
+	// PreferZstdStreamCompression. Package utils/ws/zstd, which is its own
+	// module, implements one:
+	//
+	//	conn.ZstdDecompressor = zstd.StreamDecompressor
+	ZstdDecompressor StreamDecompressor
+
+	stats *CompressionStats
+}
+
+// PayloadTooLargeError is returned if a payload exceeds either the ReadLimit
+// or the MaxPayloadSize of a Conn.
+type PayloadTooLargeError struct {
+	// Limit is the limit that was exceeded.
+	Limit int64
+	// Decompressed is true if the limit is MaxPayloadSize, or false if the
+	// limit is ReadLimit.
+	Decompressed bool
+}
+
+// Error implements error.
+func (err *PayloadTooLargeError) Error() string {
+	if err.Decompressed {
+		return fmt.Sprintf("decompressed payload exceeds the limit of %d bytes", err.Limit)
+	}
+	return fmt.Sprintf("websocket message exceeds the limit of %d bytes", err.Limit)
+}
+
+// limitReader is similar to io.LimitedReader, except it returns a
+// *PayloadTooLargeError once more than n bytes are read.
+type limitReader struct {
+	r   io.Reader
+	n   int64
+	err *PayloadTooLargeError
+}
+
+func newLimitReader(r io.Reader, limit int64, decompressed bool) io.Reader {
+	if limit <= 0 {
+		return r
+	}
+	return &limitReader{
+		r:   r,
+		n:   limit,
+		err: &PayloadTooLargeError{Limit: limit, Decompressed: decompressed},
+	}
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, l.err
+	}
+
+	// Read one more byte than the limit so that a payload of exactly the
+	// limit is allowed.
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, l.err
+	}
+
+	return n, err
 }
 
 type connMutex struct {
@@ -64,23 +210,53 @@ var _ Connection = (*Conn)(nil)
 
 // NewConn creates a new default websocket connection with a default dialer.
 func NewConn(codec Codec) *Conn {
-	return NewConnWithDialer(codec, websocket.Dialer{
+	c := NewConnWithDialer(codec, websocket.Dialer{
 		Proxy:             http.ProxyFromEnvironment,
 		HandshakeTimeout:  10 * time.Second,
 		ReadBufferSize:    rwBufferSize,
 		WriteBufferSize:   rwBufferSize,
 		EnableCompression: true,
 	})
+	c.customDialer = false
+	return c
 }
 
 // NewConnWithDialer creates a new default websocket connection with a custom
-// dialer.
+// dialer. The dialer's EnableCompression field is kept as-is unless
+// Compression is PerMessageDeflate.
 func NewConnWithDialer(codec Codec, dialer websocket.Dialer) *Conn {
 	return &Conn{
 		dialer:       dialer,
 		codec:        codec,
+		customDialer: true,
 		CloseTimeout: 5 * time.Second,
+		stats:        &CompressionStats{},
+	}
+}
+
+// CompressionStats returns the number of bytes received by the connection so
+// far, before and after decompression.
+func (c *Conn) CompressionStats() CompressionStats {
+	return CompressionStats{
+		WireBytes:    atomic.LoadInt64(&c.stats.WireBytes),
+		DecodedBytes: atomic.LoadInt64(&c.stats.DecodedBytes),
+	}
+}
+
+// compression returns the compression mode that is actually used.
+func (c *Conn) compression() (CompressionMode, error) {
+	switch c.Compression {
+	case ZstdStreamCompression:
+		if c.ZstdDecompressor == nil {
+			return 0, ErrNoZstdDecompressor
+		}
+	case PreferZstdStreamCompression:
+		if c.ZstdDecompressor == nil {
+			return ZlibStreamCompression, nil
+		}
+		return ZstdStreamCompression, nil
 	}
+	return c.Compression, nil
 }
 
 // Dial starts a new connection and returns the listening channel for it. If the
@@ -89,6 +265,11 @@ func (c *Conn) Dial(ctx context.Context, addr string) (<-chan Op, error) {
 	// BUG which prevents stream compression.
 	// See https://github.com/golang/go/issues/31514.
 
+	mode, err := c.compression()
+	if err != nil {
+		return nil, err
+	}
+
 	c.mut.Lock()
 	defer c.mut.Unlock()
 
@@ -97,7 +278,41 @@ func (c *Conn) Dial(ctx context.Context, addr string) (<-chan Op, error) {
 		c.conn.close(c.CloseTimeout, false)
 	}
 
-	conn, _, err := c.dialer.DialContext(ctx, addr, c.codec.Headers)
+	dialer := c.dialer
+	if !c.customDialer || mode == PerMessageDeflate {
+		dialer.EnableCompression = mode == PerMessageDeflate
+	}
+
+	var stream StreamDecompressor
+	switch mode {
+	case ZlibStreamCompression:
+		stream = ZlibStreamDecompressor
+	case ZstdStreamCompression:
+		stream = c.ZstdDecompressor
+	}
+
+	if stream != nil {
+		var err error
+		if addr, err = withQueryParam(addr, "compress", mode.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.codec.Encoding != nil {
+		var err error
+		if addr, err = withQueryParam(addr, "encoding", c.codec.Encoding.Name()); err != nil {
+			return nil, err
+		}
+	}
+
+	headers := c.codec.Headers
+	if mode != PayloadCompression && headers.Get("Accept-Encoding") != "" {
+		// Don't ask for zlib payloads if we're not using them.
+		headers = headers.Clone()
+		headers.Del("Accept-Encoding")
+	}
+
+	conn, _, err := dialer.DialContext(ctx, addr, headers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial WS: %w", err)
 	}
@@ -105,7 +320,12 @@ func (c *Conn) Dial(ctx context.Context, addr string) (<-chan Op, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	events := make(chan Op, 1)
-	go readLoop(ctx, conn, c.codec, events)
+	go readLoop(ctx, conn, loopState{
+		codec:          c.codec,
+		readLimit:      c.ReadLimit,
+		maxPayloadSize: c.MaxPayloadSize,
+		stats:          c.stats,
+	}, stream, events)
 
 	c.conn = &connMutex{
 		wrmut:  make(chan struct{}, 1),
@@ -192,6 +412,15 @@ func (c *Conn) Send(ctx context.Context, b []byte) error {
 		return ErrWebsocketClosed
 	}
 
+	msgType := websocket.TextMessage
+	if c.codec.Encoding != nil {
+		var err error
+		if b, err = c.codec.Encoding.FromJSON(b); err != nil {
+			return fmt.Errorf("failed to encode payload as %s: %w", c.codec.Encoding.Name(), err)
+		}
+		msgType = websocket.BinaryMessage
+	}
+
 	select {
 	case conn.wrmut <- struct{}{}:
 		defer func() { <-conn.wrmut }()
@@ -204,7 +433,7 @@ func (c *Conn) Send(ctx context.Context, b []byte) error {
 			}
 		}
 
-		return conn.WriteMessage(websocket.TextMessage, b)
+		return conn.WriteMessage(msgType, b)
 	case <-ctx.Done():
 		return ctx.Err()
 	}
@@ -218,23 +447,40 @@ type loopState struct {
 	codec Codec
 	zlib  io.ReadCloser
 	buf   DecodeBuffer
+
+	readLimit      int64
+	maxPayloadSize int64
+	stats          *CompressionStats
+
+	// stream is the decoder of the transport compression stream, if any.
+	stream *streamDecoder
 }
 
-func readLoop(ctx context.Context, conn *websocket.Conn, codec Codec, opCh chan<- Op) {
+func readLoop(
+	ctx context.Context, conn *websocket.Conn, state loopState,
+	stream StreamDecompressor, opCh chan<- Op) {
+
 	// Clean up the events channel in the end.
 	defer close(opCh)
 
 	// Allocate the read loop its own private resources.
-	state := loopState{
-		conn:  conn,
-		codec: codec,
-		buf:   NewDecodeBuffer(1 << 14), // 16KB
+	state.conn = conn
+	state.buf = NewDecodeBuffer(1 << 14) // 16KB
+
+	if stream != nil {
+		state.stream = startStreamDecoder(ctx, stream, state, opCh)
 	}
 
 	for {
 		if err := state.handle(ctx, opCh); err != nil {
 			WSDebug("Conn: fatal Conn error:", err)
 
+			// Stop decoding the stream before the close event, so that no
+			// events are sent after it.
+			if state.stream != nil {
+				state.stream.stop(err)
+			}
+
 			closeEv := &CloseEvent{
 				Err:  err,
 				Code: -1,
@@ -264,6 +510,31 @@ func (state *loopState) handle(ctx context.Context, opCh chan<- Op) error {
 		return err
 	}
 
+	r = newLimitReader(countingReader{r, &state.stats.WireBytes}, state.readLimit, false)
+
+	if t == websocket.BinaryMessage && state.stream != nil {
+		// The stream can't continue without the whole message, so an error
+		// here, including one from the ReadLimit, requires a reconnect.
+		if _, err := io.Copy(state.stream, r); err != nil {
+			return fmt.Errorf("failed to decompress stream: %w", err)
+		}
+		return nil
+	}
+
+	if t == websocket.BinaryMessage && state.codec.Encoding != nil {
+		// Payloads of binary encodings are binary messages too, so only the
+		// ones starting with a zlib header are compressed.
+		var b [1]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return fmt.Errorf("failed to read payload: %w", err)
+		}
+
+		r = io.MultiReader(bytes.NewReader(b[:]), r)
+		if b[0] != zlibHeader {
+			t = websocket.TextMessage
+		}
+	}
+
 	if t == websocket.BinaryMessage {
 		// Probably a zlib payload.
 
@@ -283,6 +554,8 @@ func (state *loopState) handle(ctx context.Context, opCh chan<- Op) error {
 		r = state.zlib
 	}
 
+	r = newLimitReader(countingReader{r, &state.stats.DecodedBytes}, state.maxPayloadSize, true)
+
 	if err := state.codec.DecodeInto(ctx, r, &state.buf, opCh); err != nil {
 		return fmt.Errorf("error distributing event: %w", err)
 	}
@@ -0,0 +1,218 @@
+package ws
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestLimitReader(t *testing.T) {
+	t.Run("exact", func(t *testing.T) {
+		r := newLimitReader(strings.NewReader("hello"), 5, false)
+		b, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if string(b) != "hello" {
+			t.Fatalf("unexpected data %q", b)
+		}
+	})
+
+	t.Run("exceeded", func(t *testing.T) {
+		r := newLimitReader(strings.NewReader("hello world"), 5, true)
+		_, err := io.ReadAll(r)
+
+		var tooLarge *PayloadTooLargeError
+		if !errors.As(err, &tooLarge) {
+			t.Fatal("expected PayloadTooLargeError, got", err)
+		}
+		if tooLarge.Limit != 5 || !tooLarge.Decompressed {
+			t.Fatalf("unexpected error %+v", tooLarge)
+		}
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		src := strings.NewReader("hello")
+		if r := newLimitReader(src, 0, false); r != io.Reader(src) {
+			t.Fatal("expected reader to be returned as-is")
+		}
+	})
+}
+
+type testStreamEvent struct {
+	N int `json:"n"`
+}
+
+func (e *testStreamEvent) Op() OpCode           { return 1 }
+func (e *testStreamEvent) EventType() EventType { return "TEST" }
+
+func TestConnZlibStream(t *testing.T) {
+	var compressed bytes.Buffer
+	z := zlib.NewWriter(&compressed)
+	var boundaries []int
+	var decoded int
+	for i := 1; i <= 3; i++ {
+		n, _ := fmt.Fprintf(z, `{"op":1,"t":"TEST","d":{"n":%d}}`, i)
+		decoded += n
+		z.Flush()
+		boundaries = append(boundaries, compressed.Len())
+	}
+
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Error("failed to upgrade:", err)
+			return
+		}
+		defer conn.Close()
+
+		// Split the second payload across two messages, and send the rest
+		// in one message.
+		b := compressed.Bytes()
+		middle := (boundaries[0] + boundaries[1]) / 2
+		for _, msg := range [][]byte{b[:boundaries[0]], b[boundaries[0]:middle], b[middle:]} {
+			conn.WriteMessage(websocket.BinaryMessage, msg)
+		}
+
+		conn.ReadMessage()
+	}))
+	defer srv.Close()
+
+	conn := NewConn(Codec{
+		Unmarshalers: NewOpUnmarshalers(func() Event { return new(testStreamEvent) }),
+	})
+	conn.Compression = PreferZstdStreamCompression // falls back to zlib-stream
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ops, err := conn.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/?v=10")
+	if err != nil {
+		t.Fatal("failed to dial:", err)
+	}
+	defer conn.Close(false)
+
+	for i := 1; i <= 3; i++ {
+		op, err := ReadOp(ctx, ops)
+		if err != nil {
+			t.Fatal("failed to read op:", err)
+		}
+		ev, ok := op.Data.(*testStreamEvent)
+		if !ok || ev.N != i {
+			t.Fatalf("unexpected op %d: %#v", i, op.Data)
+		}
+	}
+
+	if query != "compress=zlib-stream&v=10" {
+		t.Fatalf("unexpected query %q", query)
+	}
+
+	stats := conn.CompressionStats()
+	if stats.WireBytes != int64(compressed.Len()) || stats.DecodedBytes != int64(decoded) {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestConnNoZstdDecompressor(t *testing.T) {
+	conn := NewConn(Codec{})
+	conn.Compression = ZstdStreamCompression
+
+	// The address is never dialed.
+	_, err := conn.Dial(context.Background(), "ws://127.0.0.1:0/?v=10")
+	if !errors.Is(err, ErrNoZstdDecompressor) {
+		t.Fatal("expected ErrNoZstdDecompressor, got", err)
+	}
+}
+
+func TestConnPreferZstd(t *testing.T) {
+	decompressor := func(r io.Reader) (io.ReadCloser, error) { return io.NopCloser(r), nil }
+
+	tests := []struct {
+		name         string
+		mode         CompressionMode
+		decompressor StreamDecompressor
+		expect       CompressionMode
+	}{
+		{"zstd", ZstdStreamCompression, decompressor, ZstdStreamCompression},
+		{"prefer zstd", PreferZstdStreamCompression, decompressor, ZstdStreamCompression},
+		{"prefer zstd without decoder", PreferZstdStreamCompression, nil, ZlibStreamCompression},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conn := NewConn(Codec{})
+			conn.Compression = test.mode
+			conn.ZstdDecompressor = test.decompressor
+
+			mode, err := conn.compression()
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if mode != test.expect {
+				t.Fatalf("expected %v, got %v", test.expect, mode)
+			}
+		})
+	}
+}
+
+func TestConnDialerCompression(t *testing.T) {
+	extensions := make(chan string, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		extensions <- r.Header.Get("Sec-Websocket-Extensions")
+
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Error("failed to upgrade:", err)
+			return
+		}
+		defer conn.Close()
+
+		conn.ReadMessage()
+	}))
+	defer srv.Close()
+
+	dial := func(conn *Conn) string {
+		t.Helper()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if _, err := conn.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")); err != nil {
+			t.Fatal("failed to dial:", err)
+		}
+		defer conn.Close(false)
+
+		return <-extensions
+	}
+
+	codec := NewCodec(NewOpUnmarshalers())
+
+	if ext := dial(NewConn(codec)); ext != "" {
+		t.Errorf("default dialer negotiated %q with payload compression", ext)
+	}
+
+	custom := NewConnWithDialer(codec, websocket.Dialer{EnableCompression: true})
+	if ext := dial(custom); !strings.Contains(ext, "permessage-deflate") {
+		t.Errorf("custom dialer's EnableCompression was overridden, got %q", ext)
+	}
+
+	custom = NewConnWithDialer(codec, websocket.Dialer{})
+	custom.Compression = PerMessageDeflate
+	if ext := dial(custom); !strings.Contains(ext, "permessage-deflate") {
+		t.Errorf("PerMessageDeflate didn't enable compression, got %q", ext)
+	}
+}
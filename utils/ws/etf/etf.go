@@ -0,0 +1,611 @@
+// Package etf implements the Erlang External Term Format as a gateway payload
+// encoding. Payloads are converted from and to JSON, so all events are decoded
+// the same way as with the default JSON encoding.
+//
+// Since every payload is converted before it's decoded, ETF always costs more
+// CPU and memory than JSON, roughly 40% more time per event in BenchmarkDecode.
+// It doesn't make payloads smaller either, since strings carry a larger header
+// than their JSON quotes. It's a wire format option for when payloads must be
+// ETF, not an optimization.
+//
+// To use it, set the Encoding field of the gateway options:
+//
+//	opts := gateway.DefaultGatewayOpts
+//	opts.Encoding = etf.Encoding{}
+package etf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+const (
+	version = 131
+
+	tagNewFloat      = 70
+	tagCompressed    = 80
+	tagSmallInteger  = 97
+	tagInteger       = 98
+	tagFloat         = 99
+	tagAtom          = 100
+	tagSmallTuple    = 104
+	tagLargeTuple    = 105
+	tagNil           = 106
+	tagString        = 107
+	tagList          = 108
+	tagBinary        = 109
+	tagSmallBig      = 110
+	tagLargeBig      = 111
+	tagSmallAtom     = 115
+	tagMap           = 116
+	tagAtomUTF8      = 118
+	tagSmallAtomUTF8 = 119
+)
+
+// maxDepth is the maximum nesting depth of a term.
+const maxDepth = 512
+
+// Encoding is the ETF gateway encoding.
+type Encoding struct{}
+
+var _ ws.Encoding = Encoding{}
+
+// Name implements ws.Encoding. It returns "etf".
+func (Encoding) Name() string { return "etf" }
+
+// AppendJSON implements ws.Encoding. Atoms are converted to strings, except
+// for nil, true and false; tuples and lists are converted to arrays; and
+// integers of any size are kept as JSON numbers.
+func (Encoding) AppendJSON(dst []byte, r io.Reader) ([]byte, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+		r = br.(io.Reader)
+	}
+
+	d := decoder{r: r, br: br}
+
+	v, err := br.ReadByte()
+	if err != nil {
+		return dst, err
+	}
+	if v != version {
+		return dst, fmt.Errorf("unknown ETF version %d", v)
+	}
+
+	return d.term(dst, 0)
+}
+
+type decoder struct {
+	r   io.Reader
+	br  io.ByteReader
+	buf [8]byte
+	// chunk is used to read strings and atoms. It's kept here, since a local
+	// array would escape to the heap for every string.
+	chunk [512]byte
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	_, err := io.ReadFull(d.r, d.buf[:n])
+	return d.buf[:n], noEOF(err)
+}
+
+func (d *decoder) uint8() (int, error) {
+	b, err := d.br.ReadByte()
+	return int(b), noEOF(err)
+}
+
+func (d *decoder) uint16() (int, error) {
+	b, err := d.read(2)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(b)), nil
+}
+
+func (d *decoder) uint32() (int, error) {
+	b, err := d.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint32(b)), nil
+}
+
+// noEOF turns io.EOF into io.ErrUnexpectedEOF, since a term never ends before
+// it's complete.
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (d *decoder) term(dst []byte, depth int) ([]byte, error) {
+	if depth > maxDepth {
+		return dst, errors.New("term is nested too deeply")
+	}
+
+	tag, err := d.uint8()
+	if err != nil {
+		return dst, err
+	}
+
+	switch tag {
+	case tagSmallInteger:
+		n, err := d.uint8()
+		return strconv.AppendInt(dst, int64(n), 10), err
+
+	case tagInteger:
+		b, err := d.read(4)
+		if err != nil {
+			return dst, err
+		}
+		return strconv.AppendInt(dst, int64(int32(binary.BigEndian.Uint32(b))), 10), nil
+
+	case tagNewFloat:
+		b, err := d.read(8)
+		if err != nil {
+			return dst, err
+		}
+		return appendFloat(dst, math.Float64frombits(binary.BigEndian.Uint64(b)))
+
+	case tagFloat:
+		b := make([]byte, 31)
+		if _, err := io.ReadFull(d.r, b); err != nil {
+			return dst, noEOF(err)
+		}
+		f, err := strconv.ParseFloat(string(bytes.TrimRight(b, "\x00")), 64)
+		if err != nil {
+			return dst, fmt.Errorf("invalid float: %w", err)
+		}
+		return appendFloat(dst, f)
+
+	case tagSmallAtom, tagSmallAtomUTF8:
+		n, err := d.uint8()
+		if err != nil {
+			return dst, err
+		}
+		return d.atom(dst, n, tag == tagSmallAtomUTF8)
+
+	case tagAtom, tagAtomUTF8:
+		n, err := d.uint16()
+		if err != nil {
+			return dst, err
+		}
+		return d.atom(dst, n, tag == tagAtomUTF8)
+
+	case tagSmallTuple:
+		n, err := d.uint8()
+		if err != nil {
+			return dst, err
+		}
+		return d.array(dst, n, depth)
+
+	case tagLargeTuple:
+		n, err := d.uint32()
+		if err != nil {
+			return dst, err
+		}
+		return d.array(dst, n, depth)
+
+	case tagNil:
+		return append(dst, "[]"...), nil
+
+	case tagString:
+		n, err := d.uint16()
+		if err != nil {
+			return dst, err
+		}
+		// String terms are lists of bytes, which are Latin-1 characters.
+		return d.string(dst, n, true)
+
+	case tagList:
+		n, err := d.uint32()
+		if err != nil {
+			return dst, err
+		}
+		if dst, err = d.array(dst, n, depth); err != nil {
+			return dst, err
+		}
+		// Only proper lists, which end with an empty list, are supported.
+		tail, err := d.uint8()
+		if err != nil {
+			return dst, err
+		}
+		if tail != tagNil {
+			return dst, errors.New("improper lists are not supported")
+		}
+		return dst, nil
+
+	case tagBinary:
+		n, err := d.uint32()
+		if err != nil {
+			return dst, err
+		}
+		return d.string(dst, n, false)
+
+	case tagSmallBig:
+		n, err := d.uint8()
+		if err != nil {
+			return dst, err
+		}
+		return d.bigInt(dst, n)
+
+	case tagLargeBig:
+		n, err := d.uint32()
+		if err != nil {
+			return dst, err
+		}
+		return d.bigInt(dst, n)
+
+	case tagMap:
+		n, err := d.uint32()
+		if err != nil {
+			return dst, err
+		}
+		return d.object(dst, n, depth)
+
+	case tagCompressed:
+		size, err := d.uint32()
+		if err != nil {
+			return dst, err
+		}
+		return d.compressed(dst, size, depth)
+
+	default:
+		return dst, fmt.Errorf("unsupported ETF tag %d", tag)
+	}
+}
+
+func (d *decoder) atom(dst []byte, n int, isUTF8 bool) ([]byte, error) {
+	if n > len(d.chunk) {
+		// Atoms are at most 255 characters, so this is a bogus length.
+		return d.string(dst, n, !isUTF8)
+	}
+
+	b := d.chunk[:n]
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		return dst, noEOF(err)
+	}
+
+	switch string(b) {
+	case "nil", "null":
+		return append(dst, "null"...), nil
+	case "true", "false":
+		return append(dst, b...), nil
+	}
+
+	return appendString(dst, b, !isUTF8), nil
+}
+
+// string reads a string of n bytes in chunks, so that a bogus length can't
+// make it allocate more than what's actually sent.
+func (d *decoder) string(dst []byte, n int, latin1 bool) ([]byte, error) {
+	dst = append(dst, '"')
+
+	for n > 0 {
+		size := n
+		if size > len(d.chunk) {
+			size = len(d.chunk)
+		}
+
+		if _, err := io.ReadFull(d.r, d.chunk[:size]); err != nil {
+			return dst, noEOF(err)
+		}
+
+		dst = appendEscaped(dst, d.chunk[:size], latin1)
+		n -= size
+	}
+
+	return append(dst, '"'), nil
+}
+
+func (d *decoder) array(dst []byte, n, depth int) ([]byte, error) {
+	var err error
+
+	dst = append(dst, '[')
+
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		if dst, err = d.term(dst, depth+1); err != nil {
+			return dst, err
+		}
+	}
+
+	return append(dst, ']'), nil
+}
+
+func (d *decoder) object(dst []byte, n, depth int) ([]byte, error) {
+	var err error
+
+	dst = append(dst, '{')
+
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+
+		start := len(dst)
+		if dst, err = d.term(dst, depth+1); err != nil {
+			return dst, err
+		}
+
+		// JSON keys must be strings, so quote keys that aren't, such as
+		// integers.
+		if dst[start] != '"' {
+			key := string(dst[start:])
+			dst = appendString(dst[:start], []byte(key), false)
+		}
+
+		dst = append(dst, ':')
+		if dst, err = d.term(dst, depth+1); err != nil {
+			return dst, err
+		}
+	}
+
+	return append(dst, '}'), nil
+}
+
+func (d *decoder) bigInt(dst []byte, n int) ([]byte, error) {
+	sign, err := d.uint8()
+	if err != nil {
+		return dst, err
+	}
+
+	if n <= 8 {
+		b, err := d.read(n)
+		if err != nil {
+			return dst, err
+		}
+
+		var u uint64
+		for i := n - 1; i >= 0; i-- {
+			u = u<<8 | uint64(b[i])
+		}
+
+		if sign != 0 {
+			dst = append(dst, '-')
+		}
+		return strconv.AppendUint(dst, u, 10), nil
+	}
+
+	// Use a limited reader instead of allocating n bytes right away, in case n
+	// is bogus.
+	b, err := io.ReadAll(io.LimitReader(d.r, int64(n)))
+	if err != nil {
+		return dst, err
+	}
+	if len(b) < n {
+		return dst, io.ErrUnexpectedEOF
+	}
+
+	// Convert from little endian.
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+
+	i := new(big.Int).SetBytes(b)
+	if sign != 0 {
+		i.Neg(i)
+	}
+
+	return i.Append(dst, 10), nil
+}
+
+func (d *decoder) compressed(dst []byte, size, depth int) ([]byte, error) {
+	z, err := zlib.NewReader(d.r)
+	if err != nil {
+		return dst, fmt.Errorf("invalid compressed term: %w", err)
+	}
+	defer z.Close()
+
+	b, err := io.ReadAll(io.LimitReader(z, int64(size)))
+	if err != nil {
+		return dst, fmt.Errorf("invalid compressed term: %w", err)
+	}
+	if len(b) != size {
+		return dst, errors.New("compressed term has the wrong size")
+	}
+
+	r := bytes.NewReader(b)
+	inner := decoder{r: r, br: r}
+	return inner.term(dst, depth)
+}
+
+func appendFloat(dst []byte, f float64) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return dst, fmt.Errorf("unsupported float %v", f)
+	}
+	return strconv.AppendFloat(dst, f, 'g', -1, 64), nil
+}
+
+func appendString(dst, b []byte, latin1 bool) []byte {
+	dst = append(dst, '"')
+	dst = appendEscaped(dst, b, latin1)
+	return append(dst, '"')
+}
+
+const hex = "0123456789abcdef"
+
+func appendEscaped(dst, b []byte, latin1 bool) []byte {
+	// Bytes that don't need escaping are appended in runs, since most
+	// strings don't have any that do.
+	start := 0
+	for i, c := range b {
+		if c >= 0x20 && c != '"' && c != '\\' && (c < utf8.RuneSelf || !latin1) {
+			continue
+		}
+
+		dst = append(dst, b[start:i]...)
+		start = i + 1
+
+		switch {
+		case c == '"' || c == '\\':
+			dst = append(dst, '\\', c)
+		case c == '\n':
+			dst = append(dst, '\\', 'n')
+		case c == '\r':
+			dst = append(dst, '\\', 'r')
+		case c == '\t':
+			dst = append(dst, '\\', 't')
+		case c < 0x20:
+			dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+		case c >= utf8.RuneSelf && latin1:
+			// Latin-1 maps directly to the first 256 code points.
+			dst = append(dst, 0xC0|c>>6, 0x80|c&0x3F)
+		}
+	}
+	return append(dst, b[start:]...)
+}
+
+// FromJSON implements ws.Encoding. Strings are encoded as binaries, arrays as
+// lists, objects as maps with binary keys, and null, true and false as atoms.
+func (Encoding) FromJSON(b []byte) ([]byte, error) {
+	dec := stdjson.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(b))
+	out = append(out, version)
+
+	return appendTerm(out, v)
+}
+
+func appendTerm(dst []byte, v interface{}) ([]byte, error) {
+	var err error
+
+	switch v := v.(type) {
+	case nil:
+		return appendAtom(dst, "nil"), nil
+
+	case bool:
+		if v {
+			return appendAtom(dst, "true"), nil
+		}
+		return appendAtom(dst, "false"), nil
+
+	case string:
+		dst = append(dst, tagBinary)
+		dst = appendUint32(dst, uint32(len(v)))
+		return append(dst, v...), nil
+
+	case stdjson.Number:
+		return appendNumber(dst, v)
+
+	case []interface{}:
+		if len(v) == 0 {
+			return append(dst, tagNil), nil
+		}
+
+		dst = append(dst, tagList)
+		dst = appendUint32(dst, uint32(len(v)))
+		for _, elem := range v {
+			if dst, err = appendTerm(dst, elem); err != nil {
+				return dst, err
+			}
+		}
+		return append(dst, tagNil), nil
+
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		dst = append(dst, tagMap)
+		dst = appendUint32(dst, uint32(len(v)))
+		for _, k := range keys {
+			if dst, err = appendTerm(dst, k); err != nil {
+				return dst, err
+			}
+			if dst, err = appendTerm(dst, v[k]); err != nil {
+				return dst, err
+			}
+		}
+		return dst, nil
+
+	default:
+		return dst, fmt.Errorf("unexpected JSON value %T", v)
+	}
+}
+
+func appendAtom(dst []byte, atom string) []byte {
+	dst = append(dst, tagSmallAtomUTF8, byte(len(atom)))
+	return append(dst, atom...)
+}
+
+func appendNumber(dst []byte, n stdjson.Number) ([]byte, error) {
+	if i, err := n.Int64(); err == nil {
+		switch {
+		case i >= 0 && i <= math.MaxUint8:
+			return append(dst, tagSmallInteger, byte(i)), nil
+		case i >= math.MinInt32 && i <= math.MaxInt32:
+			dst = append(dst, tagInteger)
+			return appendUint32(dst, uint32(int32(i))), nil
+		case i == math.MinInt64:
+			// -i would overflow, so its magnitude is written out.
+			return appendBig(dst, 1<<63, true), nil
+		case i < 0:
+			return appendBig(dst, uint64(-i), true), nil
+		default:
+			return appendBig(dst, uint64(i), false), nil
+		}
+	}
+
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return appendBig(dst, u, false), nil
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return dst, fmt.Errorf("invalid number %q: %w", n, err)
+	}
+
+	dst = append(dst, tagNewFloat)
+	return appendUint64(dst, math.Float64bits(f)), nil
+}
+
+func appendBig(dst []byte, u uint64, negative bool) []byte {
+	var digits [8]byte
+	n := 0
+	for ; u > 0; u >>= 8 {
+		digits[n] = byte(u)
+		n++
+	}
+
+	var sign byte
+	if negative {
+		sign = 1
+	}
+
+	dst = append(dst, tagSmallBig, byte(n), sign)
+	return append(dst, digits[:n]...)
+}
+
+func appendUint32(dst []byte, u uint32) []byte {
+	return append(dst, byte(u>>24), byte(u>>16), byte(u>>8), byte(u))
+}
+
+func appendUint64(dst []byte, u uint64) []byte {
+	return appendUint32(appendUint32(dst, uint32(u>>32)), uint32(u))
+}
@@ -0,0 +1,148 @@
+package etf
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestRoundTrip(t *testing.T) {
+	// Objects are encoded with their keys sorted.
+	tests := []string{
+		`{"d":null,"op":1}`,
+		`{"d":{"intents":513,"properties":{"os":"linux"},"token":"a\"b\\c\n\t\u0001d"},"op":2}`,
+		`{"d":{"id":"123","ids":[1,-2,300,-70000,9223372036854775807,-9223372036854775808,18446744073709551615]},"op":0}`,
+		`{"d":{"activities":[],"afk":false,"since":1.5,"x":true},"op":3}`,
+	}
+
+	for _, test := range tests {
+		b, err := Encoding{}.FromJSON([]byte(test))
+		if err != nil {
+			t.Fatalf("failed to encode %s: %v", test, err)
+		}
+
+		j, err := Encoding{}.AppendJSON(nil, bytes.NewReader(b))
+		if err != nil {
+			t.Fatalf("failed to decode %s: %v", test, err)
+		}
+
+		if string(j) != test {
+			t.Errorf("round trip mismatch:\nexpected %s\ngot      %s", test, j)
+		}
+	}
+}
+
+func TestAppendJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		term []byte
+		json string
+	}{{
+		name: "atoms",
+		term: []byte{
+			version, tagSmallTuple, 3,
+			tagAtom, 0, 3, 'n', 'i', 'l',
+			tagSmallAtomUTF8, 4, 't', 'r', 'u', 'e',
+			tagSmallAtom, 5, 'R', 'E', 'A', 'D', 'Y',
+		},
+		json: `[null,true,"READY"]`,
+	}, {
+		name: "latin1 string",
+		term: []byte{version, tagString, 0, 2, 'a', 0xE9},
+		json: `"aé"`,
+	}, {
+		name: "big integer",
+		term: []byte{version, tagSmallBig, 9, 1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		json: `-18446744073709551616`,
+	}, {
+		name: "map with atom keys",
+		term: []byte{
+			version, tagMap, 0, 0, 0, 1,
+			tagSmallAtomUTF8, 2, 'o', 'p',
+			tagSmallInteger, 11,
+		},
+		json: `{"op":11}`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			j, err := Encoding{}.AppendJSON(nil, bytes.NewReader(test.term))
+			if err != nil {
+				t.Fatal("failed to decode:", err)
+			}
+
+			if string(j) != test.json {
+				t.Errorf("expected %s, got %s", test.json, j)
+			}
+		})
+	}
+}
+
+// BenchmarkDecode compares decoding a MESSAGE_CREATE event from ETF, which is
+// converted to JSON first, with decoding it from JSON. etf-to-json is the cost
+// of only the conversion.
+func BenchmarkDecode(b *testing.B) {
+	payload := []byte(`{
+		"id": "1097372584512454656", "channel_id": "1097369788434202675",
+		"guild_id": "1097369787641475112", "type": 0, "content": "Hello, world!",
+		"timestamp": "2023-04-16T17:12:47.115000+00:00", "edited_timestamp": null,
+		"tts": false, "mention_everyone": false, "pinned": false, "flags": 0,
+		"mentions": [], "mention_roles": [], "attachments": [], "embeds": [],
+		"components": [], "nonce": "1097372583434252288",
+		"author": {
+			"id": "170132746042081280", "username": "diamondburned",
+			"discriminator": "0", "avatar": "9fb9cf6d3b6f2e8d5c3f58d2a4ba1c2e",
+			"public_flags": 64
+		},
+		"member": {
+			"roles": ["1097369787641475113"], "joined_at": "2023-04-16T17:01:40.370000+00:00",
+			"deaf": false, "mute": false, "flags": 0
+		}
+	}`)
+
+	etf, err := Encoding{}.FromJSON(payload)
+	if err != nil {
+		b.Fatal("failed to encode:", err)
+	}
+
+	b.Run("json", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(payload)))
+		for i := 0; i < b.N; i++ {
+			var ev gateway.MessageCreateEvent
+			if err := json.Unmarshal(payload, &ev); err != nil {
+				b.Fatal("failed to decode:", err)
+			}
+		}
+	})
+
+	b.Run("etf-to-json", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(etf)))
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf, err = Encoding{}.AppendJSON(buf[:0], bytes.NewReader(etf))
+			if err != nil {
+				b.Fatal("failed to convert:", err)
+			}
+		}
+	})
+
+	b.Run("etf", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(etf)))
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf, err = Encoding{}.AppendJSON(buf[:0], bytes.NewReader(etf))
+			if err != nil {
+				b.Fatal("failed to convert:", err)
+			}
+			var ev gateway.MessageCreateEvent
+			if err := json.Unmarshal(buf, &ev); err != nil {
+				b.Fatal("failed to decode:", err)
+			}
+		}
+	})
+}
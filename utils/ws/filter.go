@@ -0,0 +1,44 @@
+package ws
+
+// EventFilter decides whether the dispatch event with the given type is
+// decoded. Events that are filtered out are dropped after reading their
+// envelope, which skips decoding their data entirely. Ops without an event
+// type are never filtered.
+type EventFilter func(EventType) bool
+
+// AllowEvents returns an EventFilter that only allows the given event types.
+func AllowEvents(types ...EventType) EventFilter {
+	set := makeEventSet(types)
+	return func(t EventType) bool {
+		_, ok := set[t]
+		return ok
+	}
+}
+
+// DenyEvents returns an EventFilter that allows all but the given event types.
+func DenyEvents(types ...EventType) EventFilter {
+	set := makeEventSet(types)
+	return func(t EventType) bool {
+		_, ok := set[t]
+		return !ok
+	}
+}
+
+func makeEventSet(types []EventType) map[EventType]struct{} {
+	set := make(map[EventType]struct{}, len(types))
+	for _, t := range types {
+		set[t] = struct{}{}
+	}
+	return set
+}
+
+// filteredEvent is the data of an Op that was dropped by an EventFilter. The
+// Op is still given to the Gateway's Handler so that it can keep track of the
+// sequence, but it's never sent to the caller.
+type filteredEvent struct {
+	code OpCode
+	typ  EventType
+}
+
+func (ev *filteredEvent) Op() OpCode           { return ev.code }
+func (ev *filteredEvent) EventType() EventType { return ev.typ }
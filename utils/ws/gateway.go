@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/internal/lazytime"
 	"github.com/diamondburned/arikawa/v3/utils/json"
+	"golang.org/x/time/rate"
 )
 
 // ConnectionError is given to the user if the gateway fails to connect to the
@@ -72,10 +74,55 @@ type GatewayOpts struct {
 	// will be made. Default is 0.
 	ReconnectAttempt int
 
+	// ReconnectStrategy, if not nil, decides how the gateway reconnects and
+	// which close codes are fatal. It takes precedence over ReconnectDelay,
+	// ReconnectAttempt and FatalCloseCodes. Refer to BackoffStrategy.
+	ReconnectStrategy ReconnectStrategy
+
+	// Hooks are the callbacks called as the connection changes.
+	Hooks GatewayHooks
+
 	// AlwaysCloseGracefully, if true, will always make the Gateway close
 	// gracefully once the context given to Open is cancelled. It governs the
 	// Close behavior. The default is true.
 	AlwaysCloseGracefully bool
+
+	// Compression is the compression mode used by the gateway's connection.
+	// It is only used by constructors that create their own Websocket, such
+	// as gateway.NewFromState. The default is PayloadCompression.
+	Compression CompressionMode
+	// PriorityOps are the op codes of the commands that are sent right away
+	// instead of waiting for the send rate limiter, such as heartbeats. They
+	// still count towards the rate limit.
+	PriorityOps []OpCode
+	// OpLimiters creates the additional rate limiters of the commands with
+	// certain op codes, which are waited for before the send rate limiter.
+	// Unlike the send rate limiter, they are kept across reconnects.
+	OpLimiters map[OpCode]func() *rate.Limiter
+
+	// EventFilter, if not nil, decides which dispatch events are decoded; the
+	// rest are dropped without decoding their data. Refer to AllowEvents and
+	// DenyEvents. Like Compression, it is only used by constructors that
+	// create their own Websocket.
+	EventFilter EventFilter
+	// Encoding is the encoding of the gateway's payloads, such as etf.Encoding
+	// from package etf. If it's nil, then payloads are JSON, which is the
+	// default and the fastest to decode. Like Compression, it is only used by
+	// constructors that create their own Websocket.
+	Encoding Encoding
+
+	// ZstdDecompressor is the Zstandard decoder used if Compression is
+	// ZstdStreamCompression or PreferZstdStreamCompression, such as
+	// zstd.StreamDecompressor from package utils/ws/zstd. If it's nil, then
+	// the former fails to dial, and the latter uses zlib-stream instead.
+	ZstdDecompressor StreamDecompressor
+
+	// ReadLimit and MaxPayloadSize limit the size of each payload received by
+	// the gateway's connection. Refer to Conn for more information. Like
+	// Compression, they are only used by constructors that create their own
+	// Websocket. The default is 0, which means no limit.
+	ReadLimit      int64
+	MaxPayloadSize int64
 }
 
 // DefaultGatewayOpts is the default event loop options.
@@ -90,20 +137,25 @@ var DefaultGatewayOpts = GatewayOpts{
 }
 
 // ErrorIsFatalClose returns true if the error is a fatal close error. It uses
-// opts.FatalCloseCodes to check for the codes.
+// opts.ReconnectStrategy to check for the codes, or opts.FatalCloseCodes if
+// there's none.
 func (opts GatewayOpts) ErrorIsFatalClose(err error) bool {
 	var closeErr *CloseEvent
 	if !errors.As(err, &closeErr) {
 		return false
 	}
 
-	for _, code := range opts.FatalCloseCodes {
-		if code == closeErr.Code {
-			return true
-		}
-	}
+	return opts.Strategy().IsFatalClose(closeErr.Code)
+}
 
-	return false
+// Strategy returns opts.ReconnectStrategy. If it's nil, then a strategy using
+// the ReconnectDelay, ReconnectAttempt and FatalCloseCodes fields is
+// returned.
+func (opts GatewayOpts) Strategy() ReconnectStrategy {
+	if opts.ReconnectStrategy != nil {
+		return opts.ReconnectStrategy
+	}
+	return optsStrategy{&opts}
 }
 
 // Gateway describes an instance that handles the Discord gateway. It is
@@ -119,6 +171,10 @@ type Gateway struct {
 	lastError error
 
 	opts GatewayOpts
+
+	opLimiters map[OpCode]*rate.Limiter
+
+	skipGracefulClose uint32 // atomic
 }
 
 // outerState holds gateway state that the caller may change concurrently. As
@@ -153,9 +209,15 @@ func NewGateway(ws *Websocket, opts *GatewayOpts) *Gateway {
 		opts = &DefaultGatewayOpts
 	}
 
+	opLimiters := make(map[OpCode]*rate.Limiter, len(opts.OpLimiters))
+	for code, newLimiter := range opts.OpLimiters {
+		opLimiters[code] = newLimiter()
+	}
+
 	return &Gateway{
-		ws:   ws,
-		opts: *opts,
+		ws:         ws,
+		opts:       *opts,
+		opLimiters: opLimiters,
 	}
 }
 
@@ -166,7 +228,9 @@ func (g *Gateway) Opts() *GatewayOpts {
 	return &cpy
 }
 
-// Send is a function to send an Op payload to the Gateway.
+// Send is a function to send an Op payload to the Gateway. It waits for the
+// rate limiters of the command unless its op code is one of the PriorityOps,
+// or until ctx is done.
 func (g *Gateway) Send(ctx context.Context, data Event) error {
 	op := Op{
 		Code: data.Op(),
@@ -181,6 +245,18 @@ func (g *Gateway) Send(ctx context.Context, data Event) error {
 		return fmt.Errorf("failed to encode payload: %w", err)
 	}
 
+	for _, code := range g.opts.PriorityOps {
+		if code == op.Code {
+			return g.ws.SendNow(ctx, b)
+		}
+	}
+
+	if limiter := g.opLimiters[op.Code]; limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("failed to wait for op %d rate limiter: %w", op.Code, err)
+		}
+	}
+
 	// WS should already be thread-safe.
 	return g.ws.Send(ctx, b)
 }
@@ -248,11 +324,21 @@ func (g *Gateway) LastError() error {
 	return g.lastError
 }
 
+// SkipGracefulClose makes the gateway close its connection without a close
+// frame the next time it's closed, even if AlwaysCloseGracefully is true. The
+// server then keeps the session, so it can be resumed later, possibly by
+// another process.
+func (g *Gateway) SkipGracefulClose() {
+	atomic.StoreUint32(&g.skipGracefulClose, 1)
+}
+
 // finalize closes the gateway permanently.
 func (g *Gateway) finalize(h Handler) {
 	var err error
 
-	if g.opts.AlwaysCloseGracefully {
+	skipGraceful := atomic.SwapUint32(&g.skipGracefulClose, 0) == 1
+
+	if g.opts.AlwaysCloseGracefully && !skipGraceful {
 		err = g.ws.CloseGracefully()
 	} else {
 		err = g.ws.Close()
@@ -317,6 +403,8 @@ func (g *Gateway) spin(ctx context.Context, h Handler) {
 	g.reconnect = make(chan struct{}, 1)
 	g.reconnect <- struct{}{}
 
+	strategy := g.opts.Strategy()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -340,7 +428,9 @@ func (g *Gateway) spin(ctx context.Context, h Handler) {
 			}
 
 			ok = h.OnOp(ctx, op)
-			g.outer.ch <- op
+			if _, filtered := op.Data.(*filteredEvent); !filtered {
+				g.outer.ch <- op
+			}
 			if !ok {
 				return
 			}
@@ -365,9 +455,16 @@ func (g *Gateway) spin(ctx context.Context, h Handler) {
 			var err error
 
 		retryLoop:
-			for try := 0; g.opts.ReconnectAttempt == 0 || try < g.opts.ReconnectAttempt; try++ {
+			for try := 0; ; try++ {
+				if g.opts.Hooks.OnConnecting != nil {
+					g.opts.Hooks.OnConnecting(try)
+				}
+
 				g.srcOp, err = g.ws.Dial(ctx)
 				if err == nil {
+					if g.opts.Hooks.OnConnected != nil {
+						g.opts.Hooks.OnConnected()
+					}
 					break
 				}
 
@@ -382,7 +479,12 @@ func (g *Gateway) spin(ctx context.Context, h Handler) {
 				// Signal an error before retrying.
 				g.SendError(ConnectionError{err})
 
-				retryTimer.Reset(g.opts.ReconnectDelay(try))
+				delay, retry := strategy.ReconnectDelay(try, err)
+				if !retry {
+					break
+				}
+
+				retryTimer.Reset(delay)
 				if err := retryTimer.Wait(ctx); err != nil {
 					g.SendError(ConnectionError{ctx.Err()})
 					return
@@ -0,0 +1,112 @@
+package ws
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type sentConn struct {
+	sent int
+}
+
+func (c *sentConn) Dial(context.Context, string) (<-chan Op, error) { return nil, nil }
+func (c *sentConn) Send(context.Context, []byte) error              { c.sent++; return nil }
+func (c *sentConn) Close(bool) error                                { return nil }
+
+type testCommand struct{ op OpCode }
+
+func (c *testCommand) Op() OpCode           { return c.op }
+func (c *testCommand) EventType() EventType { return "" }
+
+func TestGatewaySendLimits(t *testing.T) {
+	conn := &sentConn{}
+	g := NewGateway(NewCustomWebsocket(conn, ""), &GatewayOpts{
+		PriorityOps: []OpCode{1},
+		OpLimiters: map[OpCode]func() *rate.Limiter{
+			3: func() *rate.Limiter { return rate.NewLimiter(rate.Every(time.Hour), 1) },
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Use up the send rate limiter's burst.
+	for i := 0; i < SendBurst; i++ {
+		if err := g.Send(ctx, &testCommand{op: 8}); err != nil {
+			t.Fatal("failed to send command:", err)
+		}
+	}
+
+	// Priority commands are sent even though the burst is used up.
+	if err := g.Send(ctx, &testCommand{op: 1}); err != nil {
+		t.Fatal("failed to send priority command:", err)
+	}
+
+	if err := g.Send(ctx, &testCommand{op: 8}); err == nil {
+		t.Fatal("expected command to be rate limited")
+	}
+
+	g.ws.sendLimiter = NewSendLimiter()
+
+	if err := g.Send(ctx, &testCommand{op: 3}); err != nil {
+		t.Fatal("failed to send limited command:", err)
+	}
+	if err := g.Send(ctx, &testCommand{op: 3}); err == nil {
+		t.Fatal("expected limited command to be rate limited")
+	}
+
+	if conn.sent != SendBurst+2 {
+		t.Fatalf("expected %d commands to be sent, got %d", SendBurst+2, conn.sent)
+	}
+}
+
+func TestBackoffStrategy(t *testing.T) {
+	s := &BackoffStrategy{
+		MinDelay:        time.Second,
+		MaxDelay:        5 * time.Second,
+		MaxAttempts:     5,
+		FatalCloseCodes: []int{4004},
+	}
+
+	expect := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for try, delay := range expect {
+		got, ok := s.ReconnectDelay(try, nil)
+		if !ok || got != delay {
+			t.Errorf("try %d: expected %v, got %v (retry %v)", try, delay, got, ok)
+		}
+	}
+
+	if _, ok := s.ReconnectDelay(4, nil); ok {
+		t.Error("expected no retry after max attempts")
+	}
+
+	s.Jitter = true
+	for try := 0; try < 4; try++ {
+		got, _ := s.ReconnectDelay(try, nil)
+		if got < expect[try]/2 || got > expect[try] {
+			t.Errorf("try %d: jittered delay %v out of range", try, got)
+		}
+	}
+
+	if !s.IsFatalClose(4004) || s.IsFatalClose(4000) {
+		t.Error("unexpected fatal close codes")
+	}
+
+	// Without MaxDelay, the delay keeps growing, but it must not overflow.
+	uncapped := &BackoffStrategy{MinDelay: time.Second}
+	last := time.Duration(0)
+	for try := 0; try < 100; try++ {
+		got, ok := uncapped.ReconnectDelay(try, nil)
+		if !ok || got < last {
+			t.Fatalf("try %d: delay %v after %v (retry %v)", try, got, last, ok)
+		}
+		last = got
+	}
+	if last != math.MaxInt64 {
+		t.Errorf("expected the delay to stop at the largest duration, got %v", last)
+	}
+}
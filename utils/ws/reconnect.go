@@ -0,0 +1,126 @@
+package ws
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ReconnectStrategy decides how a Gateway reconnects after losing its
+// connection. It replaces the ReconnectDelay, ReconnectAttempt and
+// FatalCloseCodes fields of GatewayOpts when set.
+type ReconnectStrategy interface {
+	// ReconnectDelay returns the duration to wait after the given failed
+	// attempt before trying again. try starts at 0, and err is the error that
+	// made the attempt fail. If false is returned, the Gateway gives up and
+	// exits with err.
+	ReconnectDelay(try int, err error) (time.Duration, bool)
+	// IsFatalClose returns true if the Gateway should exit instead of
+	// reconnecting after being closed with the given close code.
+	IsFatalClose(code int) bool
+}
+
+// BackoffStrategy is a ReconnectStrategy that waits exponentially longer after
+// each failed attempt.
+type BackoffStrategy struct {
+	// MinDelay is the delay after the first failed attempt. It is doubled after
+	// each attempt until MaxDelay is reached.
+	MinDelay time.Duration
+	// MaxDelay is the maximum delay between attempts. If it's 0, then the
+	// delay is only capped to the largest time.Duration.
+	MaxDelay time.Duration
+	// Jitter, if true, randomizes each delay to be between half and all of
+	// it, so that many gateways disconnected at once don't reconnect at once.
+	Jitter bool
+	// MaxAttempts is the maximum number of attempts before giving up. If it's
+	// 0, unlimited attempts will be made.
+	MaxAttempts int
+	// FatalCloseCodes is the list of close codes that the Gateway cannot
+	// recover from.
+	FatalCloseCodes []int
+}
+
+var _ ReconnectStrategy = (*BackoffStrategy)(nil)
+
+// ReconnectDelay implements ReconnectStrategy.
+func (s *BackoffStrategy) ReconnectDelay(try int, err error) (time.Duration, bool) {
+	if s.MaxAttempts > 0 && try+1 >= s.MaxAttempts {
+		return 0, false
+	}
+
+	maxDelay := s.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = math.MaxInt64
+	}
+
+	delay := s.MinDelay
+	for i := 0; i < try && delay < maxDelay; i++ {
+		// Check before doubling, since doubling could overflow.
+		if delay > maxDelay/2 {
+			delay = maxDelay
+			break
+		}
+		delay *= 2
+	}
+
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	if s.Jitter && delay > 1 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+
+	return delay, true
+}
+
+// IsFatalClose implements ReconnectStrategy.
+func (s *BackoffStrategy) IsFatalClose(code int) bool {
+	for _, fatal := range s.FatalCloseCodes {
+		if fatal == code {
+			return true
+		}
+	}
+	return false
+}
+
+// optsStrategy is the ReconnectStrategy made from the ReconnectDelay,
+// ReconnectAttempt and FatalCloseCodes fields of GatewayOpts.
+type optsStrategy struct {
+	opts *GatewayOpts
+}
+
+func (s optsStrategy) ReconnectDelay(try int, err error) (time.Duration, bool) {
+	if s.opts.ReconnectAttempt > 0 && try+1 >= s.opts.ReconnectAttempt {
+		return 0, false
+	}
+	return s.opts.ReconnectDelay(try), true
+}
+
+func (s optsStrategy) IsFatalClose(code int) bool {
+	for _, fatal := range s.opts.FatalCloseCodes {
+		if fatal == code {
+			return true
+		}
+	}
+	return false
+}
+
+// GatewayHooks are optional callbacks that are called as the state of a
+// Gateway's connection changes, which is useful for logging and alerting on
+// connection churn. They are called synchronously from the event loop, so
+// they must not block.
+type GatewayHooks struct {
+	// OnConnecting is called before each attempt to connect. try starts at 0
+	// for every reconnection.
+	OnConnecting func(try int)
+	// OnConnected is called once the websocket is connected, before the
+	// session is identified or resumed.
+	OnConnected func()
+	// OnResumed is called once the session has been resumed. It is called by
+	// the Handler, such as package gateway's.
+	OnResumed func()
+	// OnInvalidSession is called when the session is invalidated by the
+	// server. It is called by the Handler, such as package gateway's.
+	OnInvalidSession func(resumable bool)
+}
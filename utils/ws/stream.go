@@ -0,0 +1,161 @@
+package ws
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"context"
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sync/atomic"
+)
+
+// StreamDecompressor creates a reader that decompresses a transport
+// compression stream, which spans every message received on a connection.
+type StreamDecompressor func(r io.Reader) (io.ReadCloser, error)
+
+// ZlibStreamDecompressor is the StreamDecompressor used by
+// ZlibStreamCompression.
+func ZlibStreamDecompressor(r io.Reader) (io.ReadCloser, error) {
+	return zlib.NewReader(r)
+}
+
+// CompressionStats counts the bytes received by a Conn over all of its
+// connections. It can be used to monitor how well payloads are compressed.
+type CompressionStats struct {
+	// WireBytes is the number of bytes received over the websocket before
+	// they are decompressed. With PerMessageDeflate, the websocket library
+	// decompresses messages by itself, so this is the same as DecodedBytes.
+	WireBytes int64
+	// DecodedBytes is the number of bytes of the decompressed payloads.
+	DecodedBytes int64
+}
+
+// Ratio returns how many times smaller the payloads were on the wire, or 0 if
+// nothing was received.
+func (s CompressionStats) Ratio() float64 {
+	if s.WireBytes == 0 {
+		return 0
+	}
+	return float64(s.DecodedBytes) / float64(s.WireBytes)
+}
+
+// countingReader atomically adds the number of bytes read to n.
+type countingReader struct {
+	r io.Reader
+	n *int64
+}
+
+func (r countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	atomic.AddInt64(r.n, int64(n))
+	return n, err
+}
+
+// withQueryParam sets a query parameter of the gateway URL, such as compress.
+func withQueryParam(addr, key, value string) (string, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse gateway URL: %w", err)
+	}
+
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// streamDecoder decompresses a transport compression stream in the background.
+// The messages of the websocket are written to it as they're received, and the
+// payloads are split from the decompressed stream, since they may not line up
+// with the messages.
+type streamDecoder struct {
+	w    *io.PipeWriter
+	done chan struct{}
+}
+
+func startStreamDecoder(
+	ctx context.Context, newReader StreamDecompressor, state loopState, opCh chan<- Op) *streamDecoder {
+
+	r, w := io.Pipe()
+	d := &streamDecoder{
+		w:    w,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(d.done)
+		r.CloseWithError(state.decodeStream(ctx, newReader, r, opCh))
+	}()
+
+	return d
+}
+
+// Write writes the next message of the stream. If decoding the stream failed,
+// then the error is returned.
+func (d *streamDecoder) Write(b []byte) (int, error) {
+	return d.w.Write(b)
+}
+
+// stop stops the decoder and waits for it to exit.
+func (d *streamDecoder) stop(err error) {
+	d.w.CloseWithError(err)
+	<-d.done
+}
+
+func (state loopState) decodeStream(
+	ctx context.Context, newReader StreamDecompressor, r io.Reader, opCh chan<- Op) error {
+
+	z, err := newReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to create a stream decompressor: %w", err)
+	}
+	defer z.Close()
+
+	zr := countingReader{z, &state.stats.DecodedBytes}
+	buf := NewDecodeBuffer(1 << 14) // 16KB
+
+	// next splits the next payload from the stream as JSON.
+	var next func() ([]byte, error)
+
+	if encoding := state.codec.Encoding; encoding != nil {
+		br := bufio.NewReader(zr)
+		var b []byte
+
+		next = func() ([]byte, error) {
+			var err error
+			b, err = encoding.AppendJSON(b[:0], br)
+			return b, err
+		}
+	} else {
+		dec := stdjson.NewDecoder(zr)
+
+		next = func() ([]byte, error) {
+			var raw stdjson.RawMessage
+			err := dec.Decode(&raw)
+			return raw, err
+		}
+	}
+
+	for {
+		raw, err := next()
+		if err != nil {
+			return fmt.Errorf("failed to decompress stream: %w", err)
+		}
+
+		if state.maxPayloadSize > 0 && int64(len(raw)) > state.maxPayloadSize {
+			err := &PayloadTooLargeError{Limit: state.maxPayloadSize, Decompressed: true}
+			if err := state.codec.send(ctx, opCh, newErrOp(err, "cannot read JSON stream")); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := state.codec.decodeJSON(ctx, bytes.NewReader(raw), &buf, opCh); err != nil {
+			return fmt.Errorf("error distributing event: %w", err)
+		}
+	}
+}
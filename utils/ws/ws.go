@@ -39,6 +39,14 @@ func NewWebsocket(c Codec, addr string) *Websocket {
 	return NewCustomWebsocket(NewConn(c), addr)
 }
 
+// NewWebsocketWithCompression creates a default Websocket with the given
+// address and compression mode.
+func NewWebsocketWithCompression(c Codec, addr string, mode CompressionMode) *Websocket {
+	conn := NewConn(c)
+	conn.Compression = mode
+	return NewCustomWebsocket(conn, addr)
+}
+
 // NewCustomWebsocket creates a new undialed Websocket.
 func NewCustomWebsocket(conn Connection, addr string) *Websocket {
 	return &Websocket{
@@ -50,6 +58,13 @@ func NewCustomWebsocket(conn Connection, addr string) *Websocket {
 	}
 }
 
+// SetAddr sets the address that the next Dial connects to.
+func (ws *Websocket) SetAddr(addr string) {
+	ws.mutex.Lock()
+	ws.addr = addr
+	ws.mutex.Unlock()
+}
+
 // Dial waits until the rate limiter allows then dials the websocket.
 func (ws *Websocket) Dial(ctx context.Context) (<-chan Op, error) {
 	if err := ws.dialLimiter.Wait(ctx); err != nil {
@@ -89,6 +104,21 @@ func (ws *Websocket) Send(ctx context.Context, b []byte) error {
 	return conn.Send(ctx, b)
 }
 
+// SendNow sends b over the Websocket without waiting for the send rate
+// limiter. The command still counts towards the rate limit, so commands sent
+// using Send afterwards wait longer instead. It is meant for commands that
+// must not be delayed, such as heartbeats.
+func (ws *Websocket) SendNow(ctx context.Context, b []byte) error {
+	ws.mutex.Lock()
+	sendLimiter := ws.sendLimiter
+	conn := ws.conn
+	ws.mutex.Unlock()
+
+	sendLimiter.Reserve()
+
+	return conn.Send(ctx, b)
+}
+
 // Close closes the websocket connection. It assumes that the Websocket is
 // closed even when it returns an error. If the Websocket was already closed
 // before, ErrWebsocketClosed will be returned.
@@ -0,0 +1,16 @@
+module github.com/diamondburned/arikawa/v3/utils/ws/zstd
+
+go 1.22
+
+require (
+	github.com/diamondburned/arikawa/v3 v3.6.0
+	github.com/gorilla/websocket v1.5.1
+	github.com/klauspost/compress v1.18.0
+)
+
+require (
+	golang.org/x/net v0.24.0 // indirect
+	golang.org/x/time v0.5.0 // indirect
+)
+
+replace github.com/diamondburned/arikawa/v3 => ../../..
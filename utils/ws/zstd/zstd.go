@@ -0,0 +1,29 @@
+// Package zstd implements the Zstandard decoder needed by
+// ws.ZstdStreamCompression using github.com/klauspost/compress/zstd. It lives
+// in its own module, so that programs that don't use it don't depend on
+// klauspost/compress.
+//
+//	opts := gateway.DefaultGatewayOpts
+//	opts.Compression = ws.ZstdStreamCompression
+//	opts.ZstdDecompressor = zstd.StreamDecompressor
+package zstd
+
+import (
+	"io"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+	"github.com/klauspost/compress/zstd"
+)
+
+var _ ws.StreamDecompressor = StreamDecompressor
+
+// StreamDecompressor implements ws.StreamDecompressor. The decoder doesn't
+// decode concurrently, since it must return each payload as soon as its
+// message is read, and not after more of the stream has arrived.
+func StreamDecompressor(r io.Reader) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return d.IOReadCloser(), nil
+}
@@ -0,0 +1,113 @@
+package zstd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+	"github.com/gorilla/websocket"
+)
+
+// testdata/payloads.zst was compressed using the zstd command from payloads.
+const payloads = `{"op":1,"t":"TEST","d":{"n":1}}` +
+	`{"op":1,"t":"TEST","d":{"n":2}}` +
+	`{"op":1,"t":"TEST","d":{"n":3}}`
+
+func TestStreamDecompressor(t *testing.T) {
+	f, err := os.Open("testdata/payloads.zst")
+	if err != nil {
+		t.Fatal("failed to open frame:", err)
+	}
+	defer f.Close()
+
+	r, err := StreamDecompressor(f)
+	if err != nil {
+		t.Fatal("failed to create decoder:", err)
+	}
+	defer r.Close()
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal("failed to decode:", err)
+	}
+
+	if string(b) != payloads {
+		t.Fatalf("unexpected payloads %s", b)
+	}
+}
+
+type testEvent struct {
+	N int `json:"n"`
+}
+
+func (e *testEvent) Op() ws.OpCode           { return 1 }
+func (e *testEvent) EventType() ws.EventType { return "TEST" }
+
+func TestConn(t *testing.T) {
+	frame, err := os.ReadFile("testdata/payloads.zst")
+	if err != nil {
+		t.Fatal("failed to read frame:", err)
+	}
+
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Error("failed to upgrade:", err)
+			return
+		}
+		defer conn.Close()
+
+		// Split the frame across messages, like Discord does with a stream.
+		third := len(frame) / 3
+		for _, msg := range [][]byte{frame[:third], frame[third : 2*third], frame[2*third:]} {
+			conn.WriteMessage(websocket.BinaryMessage, msg)
+		}
+
+		conn.ReadMessage()
+	}))
+	defer srv.Close()
+
+	conn := ws.NewConn(ws.Codec{
+		Unmarshalers: ws.NewOpUnmarshalers(func() ws.Event { return new(testEvent) }),
+	})
+	conn.Compression = ws.ZstdStreamCompression
+	conn.ZstdDecompressor = StreamDecompressor
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ops, err := conn.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/?v=10")
+	if err != nil {
+		t.Fatal("failed to dial:", err)
+	}
+	defer conn.Close(false)
+
+	for i := 1; i <= 3; i++ {
+		op, err := ws.ReadOp(ctx, ops)
+		if err != nil {
+			t.Fatal("failed to read op:", err)
+		}
+		ev, ok := op.Data.(*testEvent)
+		if !ok || ev.N != i {
+			t.Fatalf("unexpected op %d: %#v", i, op.Data)
+		}
+	}
+
+	if query != "compress=zstd-stream&v=10" {
+		t.Fatalf("unexpected query %q", query)
+	}
+
+	stats := conn.CompressionStats()
+	if stats.WireBytes != int64(len(frame)) || stats.DecodedBytes != int64(len(payloads)) {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
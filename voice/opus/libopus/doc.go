@@ -0,0 +1,11 @@
+// Package libopus implements opus.Codec using the C libopus library through
+// cgo.
+//
+// This package requires cgo and the libopus development files, which are
+// found using pkg-config. Since not every environment has libopus installed,
+// this package must be built with the libopus build tag:
+//
+//	go build -tags libopus
+//
+// Without the tag, this package is empty.
+package libopus
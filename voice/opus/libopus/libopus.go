@@ -0,0 +1,182 @@
+//go:build libopus
+// +build libopus
+
+package libopus
+
+/*
+#cgo pkg-config: opus
+#include <opus.h>
+
+static int libopus_set_bitrate(OpusEncoder *enc, opus_int32 bitrate) {
+	return opus_encoder_ctl(enc, OPUS_SET_BITRATE(bitrate));
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime"
+	"unsafe"
+
+	"github.com/diamondburned/arikawa/v3/voice/opus"
+)
+
+// Application is the intended application of an encoder.
+type Application int
+
+const (
+	// Audio is the default application, which is best for music and mixed
+	// content.
+	Audio Application = C.OPUS_APPLICATION_AUDIO
+	// VoIP is best for speech.
+	VoIP Application = C.OPUS_APPLICATION_VOIP
+	// RestrictedLowDelay minimizes the coding delay.
+	RestrictedLowDelay Application = C.OPUS_APPLICATION_RESTRICTED_LOWDELAY
+)
+
+// Error is an error returned by libopus.
+type Error int
+
+// Error implements error.
+func (err Error) Error() string {
+	return "libopus: " + C.GoString(C.opus_strerror(C.int(err)))
+}
+
+// Codec is an opus.Codec that uses libopus.
+type Codec struct {
+	// Application is the application used for new encoders. The default is
+	// Audio.
+	Application Application
+	// Bitrate is the bitrate in bits per second used for new encoders. If 0,
+	// then libopus picks one automatically.
+	Bitrate int
+}
+
+var _ opus.Codec = Codec{}
+
+// NewEncoder implements opus.Codec.
+func (c Codec) NewEncoder(sampleRate, channels int) (opus.Encoder, error) {
+	app := c.Application
+	if app == 0 {
+		app = Audio
+	}
+
+	var cerr C.int
+	p := C.opus_encoder_create(C.opus_int32(sampleRate), C.int(channels), C.int(app), &cerr)
+	if cerr != C.OPUS_OK {
+		return nil, Error(cerr)
+	}
+
+	enc := &Encoder{p: p, channels: channels}
+	runtime.SetFinalizer(enc, (*Encoder).Close)
+
+	if c.Bitrate > 0 {
+		if cerr := C.libopus_set_bitrate(p, C.opus_int32(c.Bitrate)); cerr != C.OPUS_OK {
+			enc.Close()
+			return nil, Error(cerr)
+		}
+	}
+
+	return enc, nil
+}
+
+// NewDecoder implements opus.Codec.
+func (c Codec) NewDecoder(sampleRate, channels int) (opus.Decoder, error) {
+	var cerr C.int
+	p := C.opus_decoder_create(C.opus_int32(sampleRate), C.int(channels), &cerr)
+	if cerr != C.OPUS_OK {
+		return nil, Error(cerr)
+	}
+
+	dec := &Decoder{p: p, channels: channels}
+	runtime.SetFinalizer(dec, (*Decoder).Close)
+
+	return dec, nil
+}
+
+var errClosed = errors.New("libopus: use of closed encoder or decoder")
+
+// Encoder is an opus.Encoder that uses libopus.
+type Encoder struct {
+	p        *C.OpusEncoder
+	channels int
+}
+
+// Encode implements opus.Encoder.
+func (e *Encoder) Encode(pcm []int16, data []byte) (int, error) {
+	if e.p == nil {
+		return 0, errClosed
+	}
+	if len(pcm) == 0 || len(data) == 0 {
+		return 0, Error(C.OPUS_BAD_ARG)
+	}
+
+	n := C.opus_encode(
+		e.p,
+		(*C.opus_int16)(unsafe.Pointer(&pcm[0])), C.int(len(pcm)/e.channels),
+		(*C.uchar)(unsafe.Pointer(&data[0])), C.opus_int32(len(data)),
+	)
+	runtime.KeepAlive(e)
+
+	if n < 0 {
+		return 0, Error(n)
+	}
+
+	return int(n), nil
+}
+
+// Close frees the encoder. It is also called when the encoder is garbage
+// collected.
+func (e *Encoder) Close() error {
+	if e.p != nil {
+		C.opus_encoder_destroy(e.p)
+		e.p = nil
+	}
+	return nil
+}
+
+// Decoder is an opus.Decoder that uses libopus.
+type Decoder struct {
+	p        *C.OpusDecoder
+	channels int
+}
+
+// Decode implements opus.Decoder. An empty packet makes libopus conceal the
+// packet loss.
+func (d *Decoder) Decode(data []byte, pcm []int16) (int, error) {
+	if d.p == nil {
+		return 0, errClosed
+	}
+	if len(pcm) == 0 {
+		return 0, Error(C.OPUS_BAD_ARG)
+	}
+
+	var dataPtr *C.uchar
+	if len(data) > 0 {
+		dataPtr = (*C.uchar)(unsafe.Pointer(&data[0]))
+	}
+
+	n := C.opus_decode(
+		d.p,
+		dataPtr, C.opus_int32(len(data)),
+		(*C.opus_int16)(unsafe.Pointer(&pcm[0])), C.int(len(pcm)/d.channels),
+		0,
+	)
+	runtime.KeepAlive(d)
+
+	if n < 0 {
+		return 0, Error(n)
+	}
+
+	return int(n), nil
+}
+
+// Close frees the decoder. It is also called when the decoder is garbage
+// collected.
+func (d *Decoder) Close() error {
+	if d.p != nil {
+		C.opus_decoder_destroy(d.p)
+		d.p = nil
+	}
+	return nil
+}
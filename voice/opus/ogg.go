@@ -0,0 +1,378 @@
+package opus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// SilenceFrame is a 20ms Opus frame of silence. Discord sends a few of these
+// when a user stops speaking, and they can be used to fill gaps in audio.
+var SilenceFrame = []byte{0xF8, 0xFF, 0xFE}
+
+// PacketSamples returns the number of samples per channel at 48kHz in the
+// given Opus packet, as described by its TOC byte. It returns 0 if the packet
+// is invalid.
+//
+// https://datatracker.ietf.org/doc/html/rfc6716#section-3.1
+func PacketSamples(packet []byte) int {
+	if len(packet) < 1 {
+		return 0
+	}
+
+	toc := packet[0]
+	config := toc >> 3
+
+	var frameSize int
+	switch {
+	case config < 12: // SILK
+		frameSize = []int{480, 960, 1920, 2880}[config%4]
+	case config < 16: // Hybrid
+		frameSize = []int{480, 960}[config%2]
+	default: // CELT
+		frameSize = []int{120, 240, 480, 960}[config%4]
+	}
+
+	var frames int
+	switch toc & 0x3 {
+	case 0:
+		frames = 1
+	case 1, 2:
+		frames = 2
+	case 3:
+		if len(packet) < 2 {
+			return 0
+		}
+		frames = int(packet[1] & 0x3F)
+	}
+
+	return frameSize * frames
+}
+
+// OggWriter writes Opus packets into an Ogg Opus stream, which can be played
+// or imported by most audio software. Packets are written as-is, so no
+// encoding or decoding is done.
+//
+// An OggWriter is not thread-safe.
+//
+// https://datatracker.ietf.org/doc/html/rfc7845
+type OggWriter struct {
+	w       io.Writer
+	serial  uint32
+	seq     uint32
+	granule uint64
+
+	// last is the last packet, which is held back so it can be written with
+	// the end-of-stream flag on Close.
+	last    []byte
+	hasLast bool
+	closed  bool
+	page    []byte
+}
+
+// NewOggWriter creates a new OggWriter and writes the Ogg Opus headers into w.
+// The comments are "KEY=value" pairs written into the header as-is, such as
+// "DATE=2006-01-02T15:04:05Z".
+func NewOggWriter(w io.Writer, channels int, comments ...string) (*OggWriter, error) {
+	o := &OggWriter{
+		w:      w,
+		serial: rand.Uint32(),
+	}
+
+	head := make([]byte, 19)
+	copy(head, "OpusHead")
+	head[8] = 1 // version
+	head[9] = byte(channels)
+	binary.LittleEndian.PutUint16(head[10:], 0) // pre-skip
+	binary.LittleEndian.PutUint32(head[12:], SampleRate)
+	binary.LittleEndian.PutUint16(head[16:], 0) // output gain
+	head[18] = 0                                // channel mapping family
+
+	if err := o.writePage(head, 0x02, 0); err != nil {
+		return nil, err
+	}
+
+	const vendor = "arikawa"
+
+	tags := make([]byte, 0, 64)
+	tags = append(tags, "OpusTags"...)
+	tags = appendUint32(tags, uint32(len(vendor)))
+	tags = append(tags, vendor...)
+	tags = appendUint32(tags, uint32(len(comments)))
+	for _, comment := range comments {
+		tags = appendUint32(tags, uint32(len(comment)))
+		tags = append(tags, comment...)
+	}
+
+	if err := o.writePage(tags, 0, 0); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// Granule returns the number of samples per channel written so far.
+func (o *OggWriter) Granule() uint64 {
+	if o.hasLast {
+		return o.granule + uint64(PacketSamples(o.last))
+	}
+	return o.granule
+}
+
+// WritePacket writes a single Opus packet.
+func (o *OggWriter) WritePacket(packet []byte) error {
+	if o.closed {
+		return errors.New("ogg writer is closed")
+	}
+
+	if o.hasLast {
+		o.granule += uint64(PacketSamples(o.last))
+		if err := o.writePage(o.last, 0, o.granule); err != nil {
+			return err
+		}
+	}
+
+	o.last = append(o.last[:0], packet...)
+	o.hasLast = true
+	return nil
+}
+
+// Close writes the last packet with the end-of-stream flag set. It does not
+// close the underlying writer.
+func (o *OggWriter) Close() error {
+	if o.closed {
+		return nil
+	}
+	o.closed = true
+
+	if o.hasLast {
+		o.granule += uint64(PacketSamples(o.last))
+		o.hasLast = false
+	} else {
+		o.last = o.last[:0]
+	}
+
+	return o.writePage(o.last, 0x04, o.granule)
+}
+
+// writePage writes packet as a single page.
+func (o *OggWriter) writePage(packet []byte, flags byte, granule uint64) error {
+	nsegs := len(packet)/255 + 1
+	if nsegs > 255 {
+		return errors.New("packet too large for an ogg page")
+	}
+
+	page := o.page[:0]
+	page = append(page, "OggS"...)
+	page = append(page, 0, flags)
+	page = appendUint64(page, granule)
+	page = appendUint32(page, o.serial)
+	page = appendUint32(page, o.seq)
+	page = appendUint32(page, 0) // checksum
+	page = append(page, byte(nsegs))
+	for i := 0; i < nsegs-1; i++ {
+		page = append(page, 255)
+	}
+	page = append(page, byte(len(packet)%255))
+	page = append(page, packet...)
+
+	binary.LittleEndian.PutUint32(page[22:], oggChecksum(page))
+
+	o.page = page
+	o.seq++
+
+	_, err := o.w.Write(page)
+	return err
+}
+
+// OggReader reads Opus packets from an Ogg Opus stream, such as one written by
+// OggWriter. Only the first logical stream is read, and packets are returned
+// as-is, so no decoding is done.
+//
+// An OggReader is not thread-safe.
+type OggReader struct {
+	r io.Reader
+
+	// Channels is the number of channels of the stream.
+	Channels int
+	// PreSkip is the number of samples per channel that should be discarded
+	// from the start of the decoded audio.
+	PreSkip int
+
+	serial  uint32
+	started bool
+	granule uint64
+	eos     bool
+
+	header  [27]byte
+	lacing  [255]byte
+	page    []byte
+	segs    []byte
+	packet  []byte
+	partial bool
+}
+
+// NewOggReader creates a new OggReader and reads the Ogg Opus headers from r.
+func NewOggReader(r io.Reader) (*OggReader, error) {
+	o := &OggReader{r: r}
+
+	head, err := o.ReadPacket()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpusHead: %w", err)
+	}
+	if len(head) < 19 || string(head[:8]) != "OpusHead" {
+		return nil, errors.New("stream is not an ogg opus stream")
+	}
+
+	o.Channels = int(head[9])
+	o.PreSkip = int(binary.LittleEndian.Uint16(head[10:]))
+
+	tags, err := o.ReadPacket()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpusTags: %w", err)
+	}
+	if len(tags) < 8 || string(tags[:8]) != "OpusTags" {
+		return nil, errors.New("missing OpusTags header")
+	}
+
+	return o, nil
+}
+
+// Granule returns the granule position of the last page read, which is the
+// number of samples per channel in the stream up to the end of that page,
+// including PreSkip.
+func (o *OggReader) Granule() uint64 {
+	return o.granule
+}
+
+// ReadPacket reads a single Opus packet. The returned slice is only valid
+// until the next call to ReadPacket. io.EOF is returned once the end of the
+// stream is reached.
+func (o *OggReader) ReadPacket() ([]byte, error) {
+	o.packet = o.packet[:0]
+
+	for {
+		for len(o.segs) > 0 {
+			n := int(o.segs[0])
+			o.segs = o.segs[1:]
+
+			if len(o.page) < n {
+				return nil, errors.New("ogg page is truncated")
+			}
+
+			o.packet = append(o.packet, o.page[:n]...)
+			o.page = o.page[n:]
+
+			if n < 255 {
+				o.partial = false
+				return o.packet, nil
+			}
+			o.partial = true
+		}
+
+		if o.eos {
+			return nil, io.EOF
+		}
+
+		if err := o.readPage(); err != nil {
+			if err == io.EOF && (o.partial || len(o.packet) > 0) {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+	}
+}
+
+// readPage reads the next page of the stream into o.page and o.segs, skipping
+// pages of other logical streams.
+func (o *OggReader) readPage() error {
+	for {
+		if _, err := io.ReadFull(o.r, o.header[:]); err != nil {
+			return err
+		}
+
+		if string(o.header[:4]) != "OggS" {
+			return errors.New("invalid ogg page capture pattern")
+		}
+
+		flags := o.header[5]
+		serial := binary.LittleEndian.Uint32(o.header[14:])
+		nsegs := int(o.header[26])
+
+		segs := o.lacing[:nsegs]
+		if _, err := io.ReadFull(o.r, segs); err != nil {
+			return unexpectedEOF(err)
+		}
+
+		var size int
+		for _, seg := range segs {
+			size += int(seg)
+		}
+
+		if cap(o.page) < size {
+			o.page = make([]byte, size)
+		}
+		o.page = o.page[:size]
+
+		if _, err := io.ReadFull(o.r, o.page); err != nil {
+			return unexpectedEOF(err)
+		}
+
+		if !o.started {
+			o.serial = serial
+			o.started = true
+		}
+		if serial != o.serial {
+			continue
+		}
+
+		// A granule position of -1 means that no packet ends on this page.
+		if granule := binary.LittleEndian.Uint64(o.header[6:]); granule != ^uint64(0) {
+			o.granule = granule
+		}
+
+		o.segs = segs
+		o.eos = flags&0x04 != 0
+		return nil
+	}
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	return appendUint32(appendUint32(b, uint32(v)), uint32(v>>32))
+}
+
+var oggCRCTable = func() (table [256]uint32) {
+	for i := range table {
+		r := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if r&0x80000000 != 0 {
+				r = r<<1 ^ 0x04C11DB7
+			} else {
+				r <<= 1
+			}
+		}
+		table[i] = r
+	}
+	return
+}()
+
+func oggChecksum(page []byte) uint32 {
+	var crc uint32
+	for _, b := range page {
+		crc = crc<<8 ^ oggCRCTable[byte(crc>>24)^b]
+	}
+	return crc
+}
@@ -0,0 +1,119 @@
+// Package opus defines the Opus encoder and decoder interfaces used to convert
+// between PCM audio and the Opus packets sent over a voice connection.
+//
+// This package does not implement Opus itself. Instead, a Codec is given to
+// the helpers in this package, so bots can choose the implementation that
+// suits them:
+//
+//   - Package voice/opus/libopus binds the C libopus library using cgo. It is
+//     the fastest and most complete implementation, but it requires libopus
+//     to be installed and must be built with the libopus build tag.
+//   - The voice/opus/pionopus module decodes using the pure Go
+//     github.com/pion/opus, which allows CGO_ENABLED=0 builds. It cannot
+//     encode, since pion/opus only implements decoding.
+//   - Other pure Go implementations can be plugged in using Funcs. Refer to
+//     Funcs for an example.
+//
+// Discord voice always uses 48kHz stereo audio in 20ms frames. Since Session
+// implements both io.Writer and PacketReader, a Writer and Reader can be
+// created directly on top of it:
+//
+//	w := opus.NewWriter(session, enc, opus.Channels)
+//	r := opus.NewReader(session, codec)
+package opus
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// SampleRate is the sample rate used by Discord voice.
+	SampleRate = 48000
+	// Channels is the number of channels used by Discord voice.
+	Channels = 2
+	// FrameDuration is the duration of a single Opus frame.
+	FrameDuration = 20 * time.Millisecond
+	// FrameSize is the number of samples per channel in a single frame.
+	FrameSize = SampleRate / int(time.Second/FrameDuration)
+	// MaxPacketSize is the recommended size of the buffer given to
+	// Encoder.Encode.
+	MaxPacketSize = 4000
+)
+
+// ErrUnsupported is returned by a Codec that doesn't support encoding or
+// decoding, such as decode-only pure Go implementations.
+var ErrUnsupported = errors.New("opus: operation not supported by codec")
+
+// Encoder encodes PCM audio into Opus packets. An Encoder is stateful, so one
+// must only be used for a single audio stream.
+type Encoder interface {
+	// Encode encodes a single frame of interleaved PCM samples into data and
+	// returns the number of bytes written. The number of samples must be a
+	// valid Opus frame size multiplied by the number of channels.
+	Encode(pcm []int16, data []byte) (int, error)
+}
+
+// Decoder decodes Opus packets into PCM audio. A Decoder is stateful, so one
+// must only be used for a single audio stream.
+type Decoder interface {
+	// Decode decodes a single Opus packet into interleaved PCM samples and
+	// returns the number of samples decoded per channel. An empty packet
+	// indicates packet loss, which the Decoder may conceal.
+	Decode(data []byte, pcm []int16) (int, error)
+}
+
+// Codec creates Opus encoders and decoders.
+type Codec interface {
+	NewEncoder(sampleRate, channels int) (Encoder, error)
+	NewDecoder(sampleRate, channels int) (Decoder, error)
+}
+
+// EncoderFunc is a function that implements Encoder.
+type EncoderFunc func(pcm []int16, data []byte) (int, error)
+
+// Encode implements Encoder.
+func (f EncoderFunc) Encode(pcm []int16, data []byte) (int, error) { return f(pcm, data) }
+
+// DecoderFunc is a function that implements Decoder.
+type DecoderFunc func(data []byte, pcm []int16) (int, error)
+
+// Decode implements Decoder.
+func (f DecoderFunc) Decode(data []byte, pcm []int16) (int, error) { return f(data, pcm) }
+
+// Funcs is a Codec that creates encoders and decoders using the given
+// functions. It is mostly used to adapt pure Go Opus implementations. A nil
+// function makes the respective method return ErrUnsupported.
+//
+// For example, a decode-only pure Go implementation can be adapted like so:
+//
+//	codec := opus.Funcs{
+//		Decoder: func(sampleRate, channels int) (opus.Decoder, error) {
+//			dec := pureopus.NewDecoder()
+//			return opus.DecoderFunc(func(data []byte, pcm []int16) (int, error) {
+//				return dec.DecodeInt16(data, pcm)
+//			}), nil
+//		},
+//	}
+type Funcs struct {
+	Encoder func(sampleRate, channels int) (Encoder, error)
+	Decoder func(sampleRate, channels int) (Decoder, error)
+}
+
+var _ Codec = Funcs{}
+
+// NewEncoder implements Codec.
+func (f Funcs) NewEncoder(sampleRate, channels int) (Encoder, error) {
+	if f.Encoder == nil {
+		return nil, ErrUnsupported
+	}
+	return f.Encoder(sampleRate, channels)
+}
+
+// NewDecoder implements Codec.
+func (f Funcs) NewDecoder(sampleRate, channels int) (Decoder, error) {
+	if f.Decoder == nil {
+		return nil, ErrUnsupported
+	}
+	return f.Decoder(sampleRate, channels)
+}
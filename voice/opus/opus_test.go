@@ -0,0 +1,210 @@
+package opus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+type packetRecorder struct {
+	packets [][]byte
+}
+
+func (r *packetRecorder) Write(b []byte) (int, error) {
+	r.packets = append(r.packets, append([]byte(nil), b...))
+	return len(b), nil
+}
+
+func TestWriter(t *testing.T) {
+	// edgeEncoder encodes a frame as its first and last samples.
+	var frameLens []int
+	edgeEncoder := EncoderFunc(func(pcm []int16, data []byte) (int, error) {
+		frameLens = append(frameLens, len(pcm))
+		data[0] = byte(pcm[0])
+		data[1] = byte(pcm[len(pcm)-1])
+		return 2, nil
+	})
+
+	rec := &packetRecorder{}
+	w := NewWriter(rec, edgeEncoder, Channels)
+
+	pcm := make([]int16, FrameSize*Channels+10)
+	for i := range pcm {
+		pcm[i] = 1
+	}
+
+	// Write the samples in uneven chunks.
+	if err := w.WritePCM(pcm[:100]); err != nil {
+		t.Fatal("failed to write:", err)
+	}
+	if err := w.WritePCM(pcm[100:]); err != nil {
+		t.Fatal("failed to write:", err)
+	}
+
+	if len(rec.packets) != 1 {
+		t.Fatalf("expected 1 packet before flush, got %d", len(rec.packets))
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal("failed to flush:", err)
+	}
+
+	expect := [][]byte{{1, 1}, {1, 0}}
+	if len(rec.packets) != len(expect) {
+		t.Fatalf("expected %d packets, got %d", len(expect), len(rec.packets))
+	}
+
+	for i, packet := range rec.packets {
+		if !bytes.Equal(packet, expect[i]) {
+			t.Errorf("packet %d: expected %v, got %v", i, expect[i], packet)
+		}
+		if frameLens[i] != FrameSize*Channels {
+			t.Errorf("frame %d: unexpected length %d", i, frameLens[i])
+		}
+	}
+
+	// Flushing without buffered samples is a no-op.
+	if err := w.Flush(); err != nil || len(rec.packets) != 2 {
+		t.Fatal("unexpected flush:", err)
+	}
+}
+
+func TestFuncsUnsupported(t *testing.T) {
+	if _, err := (Funcs{}).NewEncoder(SampleRate, Channels); !errors.Is(err, ErrUnsupported) {
+		t.Fatal("expected ErrUnsupported, got", err)
+	}
+	if _, err := (Funcs{}).NewDecoder(SampleRate, Channels); !errors.Is(err, ErrUnsupported) {
+		t.Fatal("expected ErrUnsupported, got", err)
+	}
+}
+
+func TestPacketSamples(t *testing.T) {
+	tests := []struct {
+		packet []byte
+		expect int
+	}{
+		{SilenceFrame, 960},           // CELT FB 20ms
+		{[]byte{0x78}, 960},           // Hybrid FB 20ms
+		{[]byte{0x08}, 960},           // SILK NB 20ms
+		{[]byte{0xFD, 0x00}, 1920},    // CELT FB 20ms, 2 frames
+		{[]byte{0xFB, 0x03}, 3 * 960}, // CELT FB 20ms, 3 frames
+		{nil, 0},
+	}
+
+	for _, test := range tests {
+		if got := PacketSamples(test.packet); got != test.expect {
+			t.Errorf("PacketSamples(%x) = %d, expected %d", test.packet, got, test.expect)
+		}
+	}
+}
+
+func TestOggWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewOggWriter(&buf, Channels, "TITLE=test")
+	if err != nil {
+		t.Fatal("failed to create writer:", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := w.WritePacket(SilenceFrame); err != nil {
+			t.Fatal("failed to write packet:", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal("failed to close:", err)
+	}
+
+	if w.Granule() != uint64(3*FrameSize) {
+		t.Fatalf("expected granule %d, got %d", 3*FrameSize, w.Granule())
+	}
+
+	var pages [][]byte
+	b := buf.Bytes()
+	for len(b) > 0 {
+		if !bytes.HasPrefix(b, []byte("OggS")) {
+			t.Fatal("missing page capture pattern")
+		}
+
+		nsegs := int(b[26])
+		size := 27 + nsegs
+		for _, seg := range b[27 : 27+nsegs] {
+			size += int(seg)
+		}
+
+		page := append([]byte(nil), b[:size]...)
+		checksum := binary.LittleEndian.Uint32(page[22:])
+		binary.LittleEndian.PutUint32(page[22:], 0)
+		if oggChecksum(page) != checksum {
+			t.Fatalf("page %d has an invalid checksum", len(pages))
+		}
+
+		pages = append(pages, page)
+		b = b[size:]
+	}
+
+	// OpusHead, OpusTags and 3 audio pages.
+	if len(pages) != 5 {
+		t.Fatalf("expected 5 pages, got %d", len(pages))
+	}
+
+	if pages[0][5] != 0x02 || pages[4][5] != 0x04 {
+		t.Fatal("missing beginning or end of stream flags")
+	}
+
+	if granule := binary.LittleEndian.Uint64(pages[4][6:]); granule != uint64(3*FrameSize) {
+		t.Fatalf("expected last granule %d, got %d", 3*FrameSize, granule)
+	}
+}
+
+func TestOggReader(t *testing.T) {
+	large := append([]byte{0xFC}, bytes.Repeat([]byte{0xAA}, 600)...)
+	packets := [][]byte{SilenceFrame, large, SilenceFrame}
+
+	var buf bytes.Buffer
+
+	w, err := NewOggWriter(&buf, Channels)
+	if err != nil {
+		t.Fatal("failed to create writer:", err)
+	}
+
+	for _, packet := range packets {
+		if err := w.WritePacket(packet); err != nil {
+			t.Fatal("failed to write packet:", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal("failed to close:", err)
+	}
+
+	r, err := NewOggReader(&buf)
+	if err != nil {
+		t.Fatal("failed to create reader:", err)
+	}
+
+	if r.Channels != Channels {
+		t.Fatalf("expected %d channels, got %d", Channels, r.Channels)
+	}
+
+	for i, expect := range packets {
+		packet, err := r.ReadPacket()
+		if err != nil {
+			t.Fatalf("failed to read packet %d: %v", i, err)
+		}
+		if !bytes.Equal(packet, expect) {
+			t.Fatalf("packet %d mismatch: expected %d bytes, got %d", i, len(expect), len(packet))
+		}
+	}
+
+	if _, err := r.ReadPacket(); err != io.EOF {
+		t.Fatal("expected io.EOF, got", err)
+	}
+
+	if r.Granule() != w.Granule() {
+		t.Fatalf("expected granule %d, got %d", w.Granule(), r.Granule())
+	}
+}
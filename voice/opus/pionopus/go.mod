@@ -0,0 +1,18 @@
+module github.com/diamondburned/arikawa/v3/voice/opus/pionopus
+
+go 1.24.0
+
+require (
+	github.com/diamondburned/arikawa/v3 v3.6.0
+	github.com/pion/opus v0.1.0
+)
+
+require (
+	github.com/gorilla/websocket v1.5.1 // indirect
+	golang.org/x/crypto v0.23.0 // indirect
+	golang.org/x/net v0.24.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
+	golang.org/x/time v0.5.0 // indirect
+)
+
+replace github.com/diamondburned/arikawa/v3 => ../../..
@@ -0,0 +1,76 @@
+// Package pionopus implements opus.Codec in pure Go using github.com/pion/opus,
+// so that voice can be received in CGO_ENABLED=0 builds. It lives in its own
+// module, so that programs that don't use it don't depend on pion/opus.
+//
+// pion/opus only implements decoding, so NewEncoder always returns
+// opus.ErrUnsupported. Bots that also send audio should use package
+// voice/opus/libopus for encoding:
+//
+//	r := opus.NewReader(session, pionopus.Codec{})
+package pionopus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/voice/opus"
+	pion "github.com/pion/opus"
+)
+
+// Codec is an opus.Codec that decodes using pion/opus. It cannot encode.
+type Codec struct{}
+
+var _ opus.Codec = Codec{}
+
+// NewEncoder implements opus.Codec. It always returns opus.ErrUnsupported.
+func (Codec) NewEncoder(sampleRate, channels int) (opus.Encoder, error) {
+	return nil, opus.ErrUnsupported
+}
+
+// NewDecoder implements opus.Codec.
+func (Codec) NewDecoder(sampleRate, channels int) (opus.Decoder, error) {
+	dec, err := pion.NewDecoderWithOutput(sampleRate, channels)
+	if err != nil {
+		return nil, fmt.Errorf("pionopus: %w", err)
+	}
+
+	return &Decoder{
+		dec:        dec,
+		sampleRate: sampleRate,
+		channels:   channels,
+	}, nil
+}
+
+// Decoder is an opus.Decoder that uses pion/opus.
+type Decoder struct {
+	dec        pion.Decoder
+	sampleRate int
+	channels   int
+}
+
+var _ opus.Decoder = (*Decoder)(nil)
+
+// Decode implements opus.Decoder. pion/opus doesn't implement packet loss
+// concealment, so an empty packet is decoded as a frame of silence.
+func (d *Decoder) Decode(data []byte, pcm []int16) (int, error) {
+	if len(data) == 0 {
+		n := d.sampleRate / int(time.Second/opus.FrameDuration)
+		if size := len(pcm) / d.channels; n > size {
+			n = size
+		}
+
+		silence := pcm[:n*d.channels]
+		for i := range silence {
+			silence[i] = 0
+		}
+
+		return n, nil
+	}
+
+	n, err := d.dec.DecodeToInt16(data, pcm)
+	if err != nil {
+		return 0, fmt.Errorf("pionopus: %w", err)
+	}
+
+	return n, nil
+}
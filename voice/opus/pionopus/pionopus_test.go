@@ -0,0 +1,92 @@
+package pionopus
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/voice/opus"
+)
+
+func TestEncodeUnsupported(t *testing.T) {
+	if _, err := (Codec{}).NewEncoder(opus.SampleRate, opus.Channels); !errors.Is(err, opus.ErrUnsupported) {
+		t.Fatal("expected ErrUnsupported, got", err)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	dec, err := (Codec{}).NewDecoder(opus.SampleRate, opus.Channels)
+	if err != nil {
+		t.Fatal("failed to create decoder:", err)
+	}
+
+	pcm := make([]int16, opus.FrameSize*opus.Channels)
+
+	t.Run("silence", func(t *testing.T) {
+		for _, packet := range [][]byte{opus.SilenceFrame, nil} {
+			n, err := dec.Decode(packet, pcm)
+			if err != nil {
+				t.Fatal("failed to decode:", err)
+			}
+			if n != opus.FrameSize {
+				t.Fatalf("expected %d samples, got %d", opus.FrameSize, n)
+			}
+			for i, sample := range pcm {
+				if sample != 0 {
+					t.Fatalf("expected silence, got %d at %d", sample, i)
+				}
+			}
+		}
+	})
+
+	t.Run("ogg", func(t *testing.T) {
+		f, err := os.Open("testdata/tiny.ogg")
+		if err != nil {
+			t.Fatal("failed to open:", err)
+		}
+		defer f.Close()
+
+		r, err := opus.NewOggReader(f)
+		if err != nil {
+			t.Fatal("failed to read ogg:", err)
+		}
+
+		var packets, samples int
+		var audible bool
+
+		for {
+			packet, err := r.ReadPacket()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal("failed to read packet:", err)
+			}
+
+			n, err := dec.Decode(packet, pcm)
+			if err != nil {
+				t.Fatalf("failed to decode packet %d: %v", packets, err)
+			}
+			if expect := opus.PacketSamples(packet); n != expect {
+				t.Fatalf("packet %d: expected %d samples, got %d", packets, expect, n)
+			}
+
+			for _, sample := range pcm[:n*opus.Channels] {
+				if sample != 0 {
+					audible = true
+				}
+			}
+
+			packets++
+			samples += n
+		}
+
+		if packets == 0 || samples == 0 {
+			t.Fatal("no audio was decoded")
+		}
+		if !audible {
+			t.Fatal("decoded audio is silent")
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package opus
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/diamondburned/arikawa/v3/voice/udp"
+)
+
+// Writer encodes PCM audio and writes each frame as a single Opus packet into
+// the underlying writer, which is usually a voice Session. It buffers partial
+// frames until enough samples are written.
+//
+// A Writer is not thread-safe.
+type Writer struct {
+	w        io.Writer
+	enc      Encoder
+	channels int
+
+	pcm  []int16
+	npcm int
+	data []byte
+}
+
+// NewWriter creates a new Writer that writes 20ms frames with the given
+// number of channels.
+func NewWriter(w io.Writer, enc Encoder, channels int) *Writer {
+	return &Writer{
+		w:        w,
+		enc:      enc,
+		channels: channels,
+		pcm:      make([]int16, FrameSize*channels),
+		data:     make([]byte, MaxPacketSize),
+	}
+}
+
+// WritePCM writes interleaved PCM samples. Complete frames are encoded and
+// written immediately, while the remaining samples are buffered.
+func (w *Writer) WritePCM(pcm []int16) error {
+	for len(pcm) > 0 {
+		n := copy(w.pcm[w.npcm:], pcm)
+		w.npcm += n
+		pcm = pcm[n:]
+
+		if w.npcm == len(w.pcm) {
+			if err := w.writeFrame(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Flush pads the buffered samples with silence and writes them as a frame. It
+// does nothing if there are no buffered samples.
+func (w *Writer) Flush() error {
+	if w.npcm == 0 {
+		return nil
+	}
+
+	for i := w.npcm; i < len(w.pcm); i++ {
+		w.pcm[i] = 0
+	}
+
+	return w.writeFrame()
+}
+
+func (w *Writer) writeFrame() error {
+	w.npcm = 0
+
+	n, err := w.enc.Encode(w.pcm, w.data)
+	if err != nil {
+		return fmt.Errorf("failed to encode frame: %w", err)
+	}
+
+	if _, err := w.w.Write(w.data[:n]); err != nil {
+		return fmt.Errorf("failed to write frame: %w", err)
+	}
+
+	return nil
+}
+
+// PacketReader reads voice packets. It is implemented by the voice Session.
+type PacketReader interface {
+	ReadPacket() (*udp.Packet, error)
+}
+
+// Frame is a decoded frame of PCM audio from a single speaker.
+type Frame struct {
+	// SSRC is the synchronization source of the speaker. It can be mapped to
+	// a user ID using the voice gateway's Speaking events.
+	SSRC uint32
+	// PCM is the decoded interleaved PCM samples. Its backing array is reused
+	// by the next call to ReadFrame.
+	PCM []int16
+}
+
+// Reader reads voice packets and decodes them into PCM audio. Since each
+// speaker is a separate Opus stream, Reader keeps a Decoder for each SSRC.
+//
+// A Reader is not thread-safe.
+type Reader struct {
+	r        PacketReader
+	codec    Codec
+	channels int
+
+	decoders map[uint32]Decoder
+	pcm      []int16
+}
+
+// NewReader creates a new Reader that decodes 48kHz stereo audio using
+// decoders created by the given codec.
+func NewReader(r PacketReader, codec Codec) *Reader {
+	return &Reader{
+		r:        r,
+		codec:    codec,
+		channels: Channels,
+		decoders: make(map[uint32]Decoder),
+		// Opus packets can contain up to 120ms of audio.
+		pcm: make([]int16, 6*FrameSize*Channels),
+	}
+}
+
+// ReadFrame reads a single packet and decodes it.
+func (r *Reader) ReadFrame() (*Frame, error) {
+	p, err := r.r.ReadPacket()
+	if err != nil {
+		return nil, err
+	}
+
+	dec, ok := r.decoders[p.SSRC()]
+	if !ok {
+		dec, err = r.codec.NewDecoder(SampleRate, r.channels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create decoder: %w", err)
+		}
+		r.decoders[p.SSRC()] = dec
+	}
+
+	n, err := dec.Decode(p.Opus, r.pcm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode packet from SSRC %d: %w", p.SSRC(), err)
+	}
+
+	return &Frame{
+		SSRC: p.SSRC(),
+		PCM:  r.pcm[:n*r.channels],
+	}, nil
+}
+
+// Forget removes the decoder of the given SSRC. It should be called once a
+// speaker leaves, so that the decoder can be freed. If the decoder implements
+// io.Closer, then it is closed.
+func (r *Reader) Forget(ssrc uint32) {
+	dec, ok := r.decoders[ssrc]
+	if !ok {
+		return
+	}
+
+	delete(r.decoders, ssrc)
+
+	if closer, ok := dec.(io.Closer); ok {
+		closer.Close()
+	}
+}
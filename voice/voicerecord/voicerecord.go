@@ -0,0 +1,341 @@
+// Package voicerecord records the audio received over a voice connection into
+// a separate file for each user.
+//
+// The Opus packets sent by Discord are written into the files as-is, so no
+// Opus codec is needed. Gaps in a user's audio, such as when they stop
+// speaking, are filled with silence, so each file plays back in real time.
+//
+// A Recorder maps each audio stream to its user using the voice gateway's
+// Speaking events, so its HandleSpeaking method must be added as a handler
+// before the channel is joined:
+//
+//	rec := voicerecord.NewRecorder("recordings")
+//	v.AddHandler(rec.HandleSpeaking)
+//
+//	if err := v.JoinChannel(ctx, channelID, false, false); err != nil {
+//		return err
+//	}
+//
+//	err := rec.Record(v) // blocks until the voice session is closed
+//	rec.Close()
+package voicerecord
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/voice/opus"
+	"github.com/diamondburned/arikawa/v3/voice/udp"
+	"github.com/diamondburned/arikawa/v3/voice/voicegateway"
+)
+
+// Format is the container format that recordings are written in.
+type Format uint8
+
+const (
+	// OggFormat writes Ogg Opus files, which most audio software can play and
+	// import.
+	OggFormat Format = iota
+	// DCAFormat writes DCA0 files, which contain each Opus packet prefixed
+	// with its length as a little-endian int16. It is commonly used by Discord
+	// bots to play back audio.
+	DCAFormat
+)
+
+// Extension returns the file extension of the format, including the dot.
+func (f Format) Extension() string {
+	switch f {
+	case OggFormat:
+		return ".ogg"
+	case DCAFormat:
+		return ".dca"
+	default:
+		return ""
+	}
+}
+
+// DefaultMaxGap is the default MaxGap of a Recorder.
+const DefaultMaxGap = 5 * time.Minute
+
+// Track describes a single user's recording.
+type Track struct {
+	// UserID is the user that the audio is from.
+	UserID discord.UserID
+	// SSRC is the synchronization source of the user's audio stream.
+	SSRC uint32
+	// Start is the time at which the track starts. If Recorder.Align is true,
+	// this is the time at which the recording started. Otherwise, it is when
+	// the first packet was received.
+	Start time.Time
+}
+
+// Recorder records the audio of each user into a separate file.
+type Recorder struct {
+	// Format is the container format of each file. It defaults to OggFormat.
+	Format Format
+	// Create creates the file for the given track. By default, the file is
+	// created in the directory given to NewRecorder and named after the user
+	// ID and start time of the track.
+	Create func(Track) (io.WriteCloser, error)
+	// MaxGap is the longest gap that is filled with silence. Longer gaps are
+	// shortened to MaxGap, which avoids writing hours of silence when a user
+	// stays quiet. It defaults to DefaultMaxGap.
+	MaxGap time.Duration
+	// Align, if true, pads the start of each track with silence, so that all
+	// tracks start when the recording started and line up when imported
+	// together. MaxGap does not apply to this padding.
+	Align bool
+
+	mut    sync.Mutex
+	start  time.Time
+	users  map[uint32]discord.UserID
+	tracks map[uint32]*track
+	closed bool
+}
+
+type track struct {
+	info   Track
+	file   io.WriteCloser
+	writer packetWriter
+
+	lastTS      uint32
+	lastSamples int
+}
+
+type packetWriter interface {
+	WritePacket(packet []byte) error
+	Close() error
+}
+
+// NewRecorder creates a new Recorder that creates its files in the given
+// directory. The recording starts once NewRecorder is called.
+func NewRecorder(dir string) *Recorder {
+	r := &Recorder{
+		MaxGap: DefaultMaxGap,
+		start:  time.Now(),
+		users:  make(map[uint32]discord.UserID),
+		tracks: make(map[uint32]*track),
+	}
+
+	r.Create = func(t Track) (io.WriteCloser, error) {
+		name := fmt.Sprintf("%s-%d%s", t.UserID, t.Start.Unix(), r.Format.Extension())
+		return os.Create(filepath.Join(dir, name))
+	}
+
+	return r
+}
+
+// HandleSpeaking maps the SSRC in the given event to its user. It should be
+// added as a handler to the voice session.
+func (r *Recorder) HandleSpeaking(ev *voicegateway.SpeakingEvent) {
+	if !ev.UserID.IsValid() {
+		return
+	}
+
+	r.mut.Lock()
+	r.users[ev.SSRC] = ev.UserID
+	r.mut.Unlock()
+}
+
+// Tracks returns the tracks that have been created so far.
+func (r *Recorder) Tracks() []Track {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	tracks := make([]Track, 0, len(r.tracks))
+	for _, t := range r.tracks {
+		tracks = append(tracks, t.info)
+	}
+	return tracks
+}
+
+// Record reads packets from src and writes them using WritePacket until src
+// returns an error, which is then returned. The voice Session implements
+// opus.PacketReader.
+func (r *Recorder) Record(src opus.PacketReader) error {
+	for {
+		p, err := src.ReadPacket()
+		if err != nil {
+			return err
+		}
+
+		if err := r.WritePacket(p); err != nil {
+			return err
+		}
+	}
+}
+
+// WritePacket writes the given packet into its user's track. Packets from
+// streams that have not been mapped to a user by HandleSpeaking yet are
+// dropped.
+func (r *Recorder) WritePacket(p *udp.Packet) error {
+	return r.writePacket(p.SSRC(), p.Timestamp(), p.Opus)
+}
+
+func (r *Recorder) writePacket(ssrc, timestamp uint32, packet []byte) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if r.closed {
+		return errors.New("recorder is closed")
+	}
+
+	t, ok := r.tracks[ssrc]
+	if !ok {
+		userID, ok := r.users[ssrc]
+		if !ok {
+			return nil
+		}
+
+		var err error
+		t, err = r.newTrack(userID, ssrc)
+		if err != nil {
+			return err
+		}
+
+		t.lastTS = timestamp
+		r.tracks[ssrc] = t
+
+		if r.Align {
+			silence := int(time.Since(r.start) / opus.FrameDuration)
+			if err := t.writeSilence(silence); err != nil {
+				return err
+			}
+		}
+
+		return t.write(packet)
+	}
+
+	// Compute the gap between the end of the last packet and this one using
+	// the RTP timestamps, which count samples at 48kHz.
+	gap := int32(timestamp - (t.lastTS + uint32(t.lastSamples)))
+	if gap < 0 {
+		// Duplicate or out-of-order packet.
+		return nil
+	}
+
+	t.lastTS = timestamp
+
+	if gap >= int32(opus.FrameSize) {
+		frames := int(gap) / opus.FrameSize
+		if max := int(r.MaxGap / opus.FrameDuration); r.MaxGap > 0 && frames > max {
+			frames = max
+		}
+
+		if err := t.writeSilence(frames); err != nil {
+			return err
+		}
+	}
+
+	return t.write(packet)
+}
+
+func (r *Recorder) newTrack(userID discord.UserID, ssrc uint32) (*track, error) {
+	info := Track{
+		UserID: userID,
+		SSRC:   ssrc,
+		Start:  time.Now(),
+	}
+	if r.Align {
+		info.Start = r.start
+	}
+
+	f, err := r.Create(info)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create track for user %v: %w", userID, err)
+	}
+
+	t := &track{info: info, file: f}
+
+	switch r.Format {
+	case OggFormat:
+		t.writer, err = opus.NewOggWriter(f, opus.Channels,
+			"DATE="+info.Start.UTC().Format(time.RFC3339Nano),
+			"DISCORD_USER_ID="+userID.String(),
+			fmt.Sprintf("DISCORD_SSRC=%d", ssrc),
+		)
+	case DCAFormat:
+		t.writer = &dcaWriter{w: f}
+	default:
+		err = fmt.Errorf("unknown format %d", r.Format)
+	}
+
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("cannot write track header for user %v: %w", userID, err)
+	}
+
+	return t, nil
+}
+
+func (t *track) write(packet []byte) error {
+	t.lastSamples = opus.PacketSamples(packet)
+
+	if err := t.writer.WritePacket(packet); err != nil {
+		return fmt.Errorf("cannot write track for user %v: %w", t.info.UserID, err)
+	}
+
+	return nil
+}
+
+func (t *track) writeSilence(frames int) error {
+	for i := 0; i < frames; i++ {
+		if err := t.writer.WritePacket(opus.SilenceFrame); err != nil {
+			return fmt.Errorf("cannot write track for user %v: %w", t.info.UserID, err)
+		}
+	}
+	return nil
+}
+
+func (t *track) close() error {
+	err := t.writer.Close()
+	if closeErr := t.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Close finishes and closes all tracks. The first error encountered is
+// returned.
+func (r *Recorder) Close() error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	var firstErr error
+	for _, t := range r.tracks {
+		if err := t.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+type dcaWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+func (w *dcaWriter) WritePacket(packet []byte) error {
+	w.buf = w.buf[:0]
+	w.buf = append(w.buf, 0, 0)
+	binary.LittleEndian.PutUint16(w.buf, uint16(len(packet)))
+	w.buf = append(w.buf, packet...)
+
+	_, err := w.w.Write(w.buf)
+	return err
+}
+
+func (w *dcaWriter) Close() error { return nil }
@@ -0,0 +1,84 @@
+package voicerecord
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/voice/opus"
+	"github.com/diamondburned/arikawa/v3/voice/voicegateway"
+)
+
+type nopCloser struct{ *bytes.Buffer }
+
+func (nopCloser) Close() error { return nil }
+
+func TestRecorderGaps(t *testing.T) {
+	files := map[discord.UserID]*bytes.Buffer{}
+
+	r := NewRecorder("")
+	r.Format = DCAFormat
+	r.MaxGap = 10 * opus.FrameDuration
+	r.Create = func(track Track) (io.WriteCloser, error) {
+		buf := &bytes.Buffer{}
+		files[track.UserID] = buf
+		return nopCloser{buf}, nil
+	}
+
+	// A 20ms CELT frame.
+	frame := []byte{0xFC, 0x01, 0x02}
+
+	// Unknown SSRCs are dropped.
+	if err := r.writePacket(1, 0, frame); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	r.HandleSpeaking(&voicegateway.SpeakingEvent{SSRC: 1, UserID: 42})
+
+	packets := []uint32{
+		0,
+		960,
+		960,              // duplicate
+		960 * 5,          // 3 frames of silence
+		960 * 5,          // duplicate
+		960*5 + 960*1000, // capped at 10 frames of silence
+	}
+
+	for _, ts := range packets {
+		if err := r.writePacket(1, ts, frame); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatal("failed to close:", err)
+	}
+
+	buf, ok := files[42]
+	if !ok {
+		t.Fatal("no file created for user")
+	}
+
+	var got []byte
+	for buf.Len() > 0 {
+		size := binary.LittleEndian.Uint16(buf.Next(2))
+		packet := buf.Next(int(size))
+
+		if bytes.Equal(packet, opus.SilenceFrame) {
+			got = append(got, 's')
+		} else {
+			got = append(got, 'f')
+		}
+	}
+
+	const expect = "ff" + "sss" + "f" + "ssssssssss" + "f"
+	if string(got) != expect {
+		t.Fatalf("expected packets %q, got %q", expect, got)
+	}
+
+	if tracks := r.Tracks(); len(tracks) != 1 || tracks[0].SSRC != 1 {
+		t.Fatalf("unexpected tracks %+v", tracks)
+	}
+}